@@ -0,0 +1,190 @@
+// Command rage-difftest runs a corpus of Python scripts under both rage and
+// a locally installed python3, normalizes their stdout, and reports any
+// divergence, so language-parity regressions are caught systematically.
+//
+//	rage-difftest [-python python3] [-timeout 10s] [-v] <dir>
+//
+// A script counts as divergent when the interpreters disagree on success
+// versus failure, or when both succeed but print different (normalized)
+// output. Scripts are expected to print their observable results; memory
+// addresses in reprs are masked before comparing.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+)
+
+func main() {
+	python := flag.String("python", "python3", "CPython interpreter to compare against")
+	timeout := flag.Duration("timeout", 10*time.Second, "per-script timeout for each interpreter")
+	verbose := flag.Bool("v", false, "print every script, not just divergences")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: rage-difftest [-python python3] [-timeout 10s] [-v] <dir>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	pythonPath, err := exec.LookPath(*python)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s not found in PATH\n", *python)
+		os.Exit(1)
+	}
+
+	dir := flag.Arg(0)
+	var scripts []string
+	err = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && strings.HasSuffix(path, ".py") {
+			scripts = append(scripts, path)
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error scanning %s: %v\n", dir, err)
+		os.Exit(1)
+	}
+	if len(scripts) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no .py scripts found under %s\n", dir)
+		os.Exit(1)
+	}
+	sort.Strings(scripts)
+
+	divergent := 0
+	for _, script := range scripts {
+		rageOut, rageErr := runRage(script, *timeout)
+		cpyOut, cpyErr := runCPython(pythonPath, script, *timeout)
+
+		diff := compare(rageOut, rageErr, cpyOut, cpyErr)
+		if diff == "" {
+			if *verbose {
+				fmt.Printf("SAME  %s\n", script)
+			}
+			continue
+		}
+		divergent++
+		fmt.Printf("DIFF  %s\n%s", script, indent(diff))
+	}
+
+	fmt.Printf("\n%d/%d scripts diverged\n", divergent, len(scripts))
+	if divergent > 0 {
+		os.Exit(1)
+	}
+}
+
+// runRage executes a script in-process with stdout captured.
+func runRage(script string, timeout time.Duration) (string, error) {
+	source, err := os.ReadFile(script)
+	if err != nil {
+		return "", err
+	}
+
+	// print writes to os.Stdout; swap in a pipe for the duration of the run.
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	os.Stdout = w
+	captured := make(chan []byte)
+	go func() {
+		buf, _ := io.ReadAll(r)
+		captured <- buf
+	}()
+
+	state := rage.NewStateWithModules(rage.WithAllModules())
+	_, runErr := state.RunWithTimeout(string(source), timeout)
+	state.Close()
+
+	w.Close()
+	os.Stdout = old
+	out := <-captured
+	return string(out), runErr
+}
+
+// runCPython executes a script under the reference interpreter.
+func runCPython(pythonPath, script string, timeout time.Duration) (string, error) {
+	cmd := exec.Command(pythonPath, script)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	done := make(chan error, 1)
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return stdout.String(), fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return stdout.String(), nil
+	case <-time.After(timeout):
+		_ = cmd.Process.Kill()
+		<-done
+		return stdout.String(), fmt.Errorf("timed out after %v", timeout)
+	}
+}
+
+// compare returns a human-readable description of any divergence, or "".
+func compare(rageOut string, rageErr error, cpyOut string, cpyErr error) string {
+	switch {
+	case rageErr != nil && cpyErr == nil:
+		return fmt.Sprintf("rage failed but python3 succeeded:\n  %v\n", rageErr)
+	case rageErr == nil && cpyErr != nil:
+		return fmt.Sprintf("python3 failed but rage succeeded:\n  %v\n", cpyErr)
+	case rageErr != nil && cpyErr != nil:
+		// Both rejecting the script counts as agreement; the exact error
+		// text is allowed to differ.
+		return ""
+	}
+
+	rageNorm := normalize(rageOut)
+	cpyNorm := normalize(cpyOut)
+	if rageNorm == cpyNorm {
+		return ""
+	}
+	return fmt.Sprintf("stdout differs:\n--- rage\n%s\n--- python3\n%s\n", rageNorm, cpyNorm)
+}
+
+var addrPattern = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+
+// normalize strips formatting noise that is not meaningful for parity:
+// trailing whitespace, trailing blank lines, CRLF endings, and memory
+// addresses inside object reprs.
+func normalize(out string) string {
+	out = strings.ReplaceAll(out, "\r\n", "\n")
+	out = addrPattern.ReplaceAllString(out, "0xADDR")
+	lines := strings.Split(out, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+func indent(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "      " + line
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/model"
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// cmdCheck compiles scripts and runs static checks without executing them,
+// so script changes can be gated in CI. Beyond compile errors it flags
+// imports that resolve to neither a registered stdlib module nor a local
+// .py file next to the script.
+func cmdCheck(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: rage check <script.py> [...]")
+		return 1
+	}
+
+	knownModules := make(map[string]bool)
+	for _, name := range runtime.RegisteredModules() {
+		knownModules[name] = true
+	}
+
+	failed := false
+	for _, filename := range args {
+		if !checkFile(filename, knownModules) {
+			failed = true
+		}
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// checkFile reports all problems in one script and returns whether it passed.
+func checkFile(filename string, knownModules map[string]bool) bool {
+	source, err := os.ReadFile(filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", filename, err)
+		return false
+	}
+
+	ok := true
+	if _, errs := compiler.CompileSource(string(source), filename); len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Fprintf(os.Stderr, "%s\n", e)
+		}
+		ok = false
+	}
+
+	// Compile already reports parse errors; re-parse here only to walk the
+	// AST for import checks, ignoring any duplicate errors.
+	parser := compiler.NewParserWithFilename(string(source), filename)
+	mod, parseErrs := parser.Parse()
+	if len(parseErrs) == 0 {
+		for _, imp := range collectImports(mod.Body) {
+			if importResolves(imp.name, filename, knownModules) {
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "%s: import '%s' resolves to neither a stdlib module nor a local file\n",
+				imp.pos, imp.name)
+			ok = false
+		}
+	}
+
+	if ok {
+		fmt.Printf("%s: OK\n", filename)
+	}
+	return ok
+}
+
+// scriptImport is one imported module name and where it appears.
+type scriptImport struct {
+	name string
+	pos  model.Position
+}
+
+// collectImports walks statements (including nested bodies) and returns
+// every absolute module name that is imported.
+func collectImports(stmts []model.Stmt) []scriptImport {
+	var imports []scriptImport
+	var walk func([]model.Stmt)
+	walk = func(body []model.Stmt) {
+		for _, stmt := range body {
+			switch s := stmt.(type) {
+			case *model.Import:
+				for _, alias := range s.Names {
+					imports = append(imports, scriptImport{name: alias.Name.Name, pos: s.StartPos})
+				}
+			case *model.ImportFrom:
+				if s.Level == 0 && s.Module != nil {
+					imports = append(imports, scriptImport{name: s.Module.Name, pos: s.StartPos})
+				}
+			case *model.If:
+				walk(s.Body)
+				walk(s.OrElse)
+			case *model.While:
+				walk(s.Body)
+				walk(s.OrElse)
+			case *model.For:
+				walk(s.Body)
+				walk(s.OrElse)
+			case *model.With:
+				walk(s.Body)
+			case *model.Try:
+				walk(s.Body)
+				for _, handler := range s.Handlers {
+					walk(handler.Body)
+				}
+				walk(s.OrElse)
+				walk(s.FinalBody)
+			case *model.FunctionDef:
+				walk(s.Body)
+			case *model.ClassDef:
+				walk(s.Body)
+			case *model.Match:
+				for _, matchCase := range s.Cases {
+					walk(matchCase.Body)
+				}
+			}
+		}
+	}
+	walk(stmts)
+	return imports
+}
+
+// importResolves reports whether an import would succeed: either a
+// registered stdlib module or a .py file next to the script. Dotted names
+// check their top-level package directory.
+func importResolves(name, filename string, knownModules map[string]bool) bool {
+	top := name
+	if i := strings.Index(name, "."); i >= 0 {
+		top = name[:i]
+	}
+	if knownModules[top] {
+		return true
+	}
+	dir := filepath.Dir(filename)
+	if _, err := os.Stat(filepath.Join(dir, top+".py")); err == nil {
+		return true
+	}
+	if _, err := os.Stat(filepath.Join(dir, top)); err == nil {
+		return true
+	}
+	return false
+}
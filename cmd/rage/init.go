@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// cmdInit scaffolds an embedding project: a Go host file that applies a
+// sandbox profile, a config directory with example scripts, and a README
+// explaining how to wire it together.
+func cmdInit(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: rage init <dir>")
+		return 1
+	}
+	dir := args[0]
+	moduleName := filepath.Base(dir)
+
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		entries, _ := os.ReadDir(dir)
+		if len(entries) > 0 {
+			fmt.Fprintf(os.Stderr, "Error: directory '%s' already exists and is not empty\n", dir)
+			return 1
+		}
+	}
+
+	files := map[string]string{
+		"go.mod":            fmt.Sprintf(initGoMod, moduleName),
+		"main.go":           initMainGo,
+		"sandbox.json":      initSandboxJSON,
+		"scripts/config.py": initConfigPy,
+		"README.md":         fmt.Sprintf(initReadme, moduleName),
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating %s: %v\n", filepath.Dir(path), err)
+			return 1
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
+			return 1
+		}
+		fmt.Printf("  created %s\n", path)
+	}
+
+	fmt.Printf("\nProject scaffolded in %s. Next steps:\n", dir)
+	fmt.Printf("  cd %s\n", dir)
+	fmt.Println("  go mod tidy")
+	fmt.Println("  go run .")
+	return 0
+}
+
+const initGoMod = `module %s
+
+go 1.26
+
+require github.com/ATSOTECK/rage latest
+`
+
+const initMainGo = `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+)
+
+// sandboxProfile mirrors sandbox.json: which stdlib modules scripts may
+// import and how long they may run. Tighten it as your scripts settle.
+type sandboxProfile struct {
+	TimeoutSeconds int      ` + "`json:\"timeout_seconds\"`" + `
+	Modules        []string ` + "`json:\"modules\"`" + `
+}
+
+var moduleByName = map[string]rage.Module{
+	"math":        rage.ModuleMath,
+	"random":      rage.ModuleRandom,
+	"string":      rage.ModuleString,
+	"time":        rage.ModuleTime,
+	"re":          rage.ModuleRe,
+	"collections": rage.ModuleCollections,
+	"json":        rage.ModuleJSON,
+	"datetime":    rage.ModuleDatetime,
+	"itertools":   rage.ModuleItertools,
+	"functools":   rage.ModuleFunctools,
+}
+
+func main() {
+	raw, err := os.ReadFile("sandbox.json")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reading sandbox profile: %v\n", err)
+		os.Exit(1)
+	}
+	var profile sandboxProfile
+	if err := json.Unmarshal(raw, &profile); err != nil {
+		fmt.Fprintf(os.Stderr, "parsing sandbox profile: %v\n", err)
+		os.Exit(1)
+	}
+
+	var opts []rage.StateOption
+	for _, name := range profile.Modules {
+		mod, ok := moduleByName[name]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown module in sandbox profile: %s\n", name)
+			os.Exit(1)
+		}
+		opts = append(opts, rage.WithModule(mod))
+	}
+
+	state := rage.NewStateWithModules(opts...)
+	defer state.Close()
+
+	source, err := os.ReadFile("scripts/config.py")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reading script: %v\n", err)
+		os.Exit(1)
+	}
+	timeout := time.Duration(profile.TimeoutSeconds) * time.Second
+	if _, err := state.RunWithTimeout(string(source), timeout); err != nil {
+		fmt.Fprintf(os.Stderr, "script error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var cfg struct {
+		Name     string ` + "`rage:\"name\"`" + `
+		MaxUsers int64  ` + "`rage:\"max_users\"`" + `
+	}
+	if err := state.BindGlobals(&cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "config error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("loaded config: name=%s max_users=%d\n", cfg.Name, cfg.MaxUsers)
+}
+`
+
+const initSandboxJSON = `{
+  "timeout_seconds": 5,
+  "modules": ["math", "json"]
+}
+`
+
+const initConfigPy = `# Example configuration script. The Go host reads the globals this file
+# defines — run 'rage check scripts/config.py' before committing changes.
+import math
+
+name = "my-service"
+max_users = int(math.pow(2, 10))
+`
+
+const initReadme = `# %s
+
+A Go project embedding the RAGE Python runtime.
+
+- ` + "`main.go`" + ` — host program; loads the sandbox profile and runs the config script
+- ` + "`sandbox.json`" + ` — which stdlib modules scripts may import and the run timeout
+- ` + "`scripts/config.py`" + ` — example script whose globals the host reads
+
+Validate script changes without executing them:
+
+    rage check scripts/*.py
+`
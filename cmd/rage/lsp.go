@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ATSOTECK/rage/pkg/rage/docgen"
+	"github.com/ATSOTECK/rage/pkg/rage/lsp"
+)
+
+// cmdLsp runs a Language Server Protocol server over stdio, providing
+// compile diagnostics, go-to-definition, and hover for builtins. Editors
+// configured to launch `rage lsp` get script support without any other
+// tooling.
+func cmdLsp(args []string) int {
+	server := &lspServer{
+		in:   bufio.NewReader(os.Stdin),
+		out:  os.Stdout,
+		docs: make(map[string]string),
+	}
+	return server.run()
+}
+
+// lspServer handles one editor session over stdio.
+type lspServer struct {
+	in   *bufio.Reader
+	out  io.Writer
+	docs map[string]string // open documents by URI
+	api  *docgen.API       // host API metadata for hover, nil in the CLI
+}
+
+// lspMessage is a decoded JSON-RPC request or notification.
+type lspMessage struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// lspPosition and friends mirror the LSP wire types (0-based positions).
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+func (s *lspServer) run() int {
+	for {
+		msg, err := s.readMessage()
+		if err != nil {
+			if err == io.EOF {
+				return 0
+			}
+			fmt.Fprintf(os.Stderr, "rage lsp: %v\n", err)
+			return 1
+		}
+
+		switch msg.Method {
+		case "initialize":
+			s.reply(msg.ID, map[string]any{
+				"capabilities": map[string]any{
+					"textDocumentSync":   1, // full document sync
+					"hoverProvider":      true,
+					"definitionProvider": true,
+				},
+				"serverInfo": map[string]any{"name": "rage"},
+			})
+		case "initialized":
+			// Notification, nothing to do
+		case "shutdown":
+			s.reply(msg.ID, nil)
+		case "exit":
+			return 0
+		case "textDocument/didOpen":
+			var params struct {
+				TextDocument struct {
+					URI  string `json:"uri"`
+					Text string `json:"text"`
+				} `json:"textDocument"`
+			}
+			if json.Unmarshal(msg.Params, &params) == nil {
+				s.docs[params.TextDocument.URI] = params.TextDocument.Text
+				s.publishDiagnostics(params.TextDocument.URI)
+			}
+		case "textDocument/didChange":
+			var params struct {
+				TextDocument struct {
+					URI string `json:"uri"`
+				} `json:"textDocument"`
+				ContentChanges []struct {
+					Text string `json:"text"`
+				} `json:"contentChanges"`
+			}
+			if json.Unmarshal(msg.Params, &params) == nil && len(params.ContentChanges) > 0 {
+				// Full sync: the last change carries the whole document
+				s.docs[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+				s.publishDiagnostics(params.TextDocument.URI)
+			}
+		case "textDocument/didClose":
+			var params struct {
+				TextDocument struct {
+					URI string `json:"uri"`
+				} `json:"textDocument"`
+			}
+			if json.Unmarshal(msg.Params, &params) == nil {
+				delete(s.docs, params.TextDocument.URI)
+			}
+		case "textDocument/definition":
+			uri, pos, ok := s.positionParams(msg.Params)
+			if !ok {
+				s.reply(msg.ID, nil)
+				break
+			}
+			loc, found := lsp.Definition(s.docs[uri], pos.Line+1, pos.Character+1)
+			if !found {
+				s.reply(msg.ID, nil)
+				break
+			}
+			s.reply(msg.ID, lspLocation{
+				URI: uri,
+				Range: lspRange{
+					Start: lspPosition{Line: loc.Line - 1, Character: loc.Column - 1},
+					End:   lspPosition{Line: loc.Line - 1, Character: loc.Column - 1},
+				},
+			})
+		case "textDocument/hover":
+			uri, pos, ok := s.positionParams(msg.Params)
+			if !ok {
+				s.reply(msg.ID, nil)
+				break
+			}
+			text, found := lsp.Hover(s.api, s.docs[uri], pos.Line+1, pos.Character+1)
+			if !found {
+				s.reply(msg.ID, nil)
+				break
+			}
+			s.reply(msg.ID, map[string]any{
+				"contents": map[string]any{"kind": "markdown", "value": text},
+			})
+		default:
+			// Unknown requests get a null result; notifications are ignored
+			if msg.ID != nil {
+				s.reply(msg.ID, nil)
+			}
+		}
+	}
+}
+
+// positionParams decodes the common textDocument/position parameter shape.
+func (s *lspServer) positionParams(raw json.RawMessage) (string, lspPosition, bool) {
+	var params struct {
+		TextDocument struct {
+			URI string `json:"uri"`
+		} `json:"textDocument"`
+		Position lspPosition `json:"position"`
+	}
+	if json.Unmarshal(raw, &params) != nil {
+		return "", lspPosition{}, false
+	}
+	if _, ok := s.docs[params.TextDocument.URI]; !ok {
+		return "", lspPosition{}, false
+	}
+	return params.TextDocument.URI, params.Position, true
+}
+
+// publishDiagnostics compiles a document and pushes the results.
+func (s *lspServer) publishDiagnostics(uri string) {
+	diags := lsp.Diagnostics(s.docs[uri], uriToFilename(uri))
+	out := make([]lspDiagnostic, 0, len(diags))
+	for _, d := range diags {
+		pos := lspPosition{Line: d.Line - 1, Character: d.Column - 1}
+		out = append(out, lspDiagnostic{
+			Range:    lspRange{Start: pos, End: pos},
+			Severity: 1, // error
+			Source:   "rage",
+			Message:  d.Message,
+		})
+	}
+	s.notify("textDocument/publishDiagnostics", map[string]any{
+		"uri":         uri,
+		"diagnostics": out,
+	})
+}
+
+// readMessage reads one Content-Length framed JSON-RPC message.
+func (s *lspServer) readMessage() (*lspMessage, error) {
+	return readFramedMessage(s.in)
+}
+
+// readFramedMessage reads one Content-Length framed JSON-RPC message. The
+// framing is shared between the LSP and serve protocols.
+func readFramedMessage(in *bufio.Reader) (*lspMessage, error) {
+	contentLength := -1
+	for {
+		line, err := in.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(name, "Content-Length") {
+			contentLength, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("bad Content-Length: %v", err)
+			}
+		}
+	}
+	if contentLength < 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(in, body); err != nil {
+		return nil, err
+	}
+	var msg lspMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// reply sends a JSON-RPC response.
+func (s *lspServer) reply(id json.RawMessage, result any) {
+	s.write(map[string]any{"jsonrpc": "2.0", "id": id, "result": result})
+}
+
+// notify sends a JSON-RPC notification.
+func (s *lspServer) notify(method string, params any) {
+	s.write(map[string]any{"jsonrpc": "2.0", "method": method, "params": params})
+}
+
+func (s *lspServer) write(msg any) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rage lsp: %v\n", err)
+		return
+	}
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+// uriToFilename strips the file:// scheme for readable diagnostics.
+func uriToFilename(uri string) string {
+	return strings.TrimPrefix(uri, "file://")
+}
@@ -2,48 +2,252 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ATSOTECK/rage/internal/compiler"
 	"github.com/ATSOTECK/rage/internal/runtime"
 	"github.com/ATSOTECK/rage/internal/stdlib"
 )
 
+// Resource limits for script execution, set by --timeout and --max-memory.
+var (
+	execTimeout   time.Duration
+	execMaxMemory int64
+)
+
 func main() {
 	// Initialize standard library modules
 	stdlib.InitAllModules()
 
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: rage <script.py>")
+	args, err := parseResourceFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rage: %v\n", err)
+		os.Exit(2)
+	}
+
+	if len(args) < 1 {
+		usage()
 		os.Exit(1)
 	}
 
-	filename := os.Args[1]
+	switch args[0] {
+	case "init":
+		os.Exit(cmdInit(args[1:]))
+	case "check":
+		os.Exit(cmdCheck(args[1:]))
+	case "test":
+		os.Exit(cmdTest(args[1:]))
+	case "lsp":
+		os.Exit(cmdLsp(args[1:]))
+	case "serve":
+		os.Exit(cmdServe(args[1:]))
+	case "-m":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "rage: -m requires a module name")
+			os.Exit(2)
+		}
+		os.Exit(runModule(args[1]))
+	case "-c":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "rage: -c requires an argument")
+			os.Exit(2)
+		}
+		os.Exit(runInline(args[1]))
+	case "-":
+		os.Exit(runStdin())
+	}
+
+	os.Exit(runPath(args[0]))
+}
+
+func usage() {
+	fmt.Println("Usage: rage [options] <script.py | dir>")
+	fmt.Println("       rage [options] -m <module>")
+	fmt.Println("       rage [options] -c <code>")
+	fmt.Println("       rage [options] -  (read script from stdin)")
+	fmt.Println("       rage init <dir>")
+	fmt.Println("       rage check <script.py> [...]")
+	fmt.Println("       rage test [-update] <dir>")
+	fmt.Println("       rage lsp          (language server over stdio)")
+	fmt.Println("       rage serve --stdio | --tcp <addr>  (JSON-RPC execution server)")
+	fmt.Println("Options:")
+	fmt.Println("       --timeout <dur>     stop the script after a duration (e.g. 5s, 2m)")
+	fmt.Println("       --max-memory <size> cap script memory (e.g. 256M, 1G)")
+}
+
+// parseResourceFlags strips leading --timeout/--max-memory flags from args,
+// storing their values in execTimeout/execMaxMemory.
+func parseResourceFlags(args []string) ([]string, error) {
+	for len(args) > 0 {
+		arg := args[0]
+		name, value := arg, ""
+		if i := strings.IndexByte(arg, '='); i >= 0 {
+			name, value = arg[:i], arg[i+1:]
+		}
+		if name != "--timeout" && name != "--max-memory" {
+			break
+		}
+		if value == "" {
+			if len(args) < 2 {
+				return nil, fmt.Errorf("%s requires a value", name)
+			}
+			value = args[1]
+			args = args[2:]
+		} else {
+			args = args[1:]
+		}
+		switch name {
+		case "--timeout":
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("invalid --timeout value %q", value)
+			}
+			execTimeout = d
+		case "--max-memory":
+			n, err := parseMemorySize(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --max-memory value %q", value)
+			}
+			execMaxMemory = n
+		}
+	}
+	return args, nil
+}
+
+// parseMemorySize parses a size like "256M", "1G", "512K" or a plain byte
+// count. An optional trailing B is accepted (e.g. "256MB").
+func parseMemorySize(s string) (int64, error) {
+	num := strings.ToUpper(strings.TrimSpace(s))
+	num = strings.TrimSuffix(num, "B")
+	multiplier := int64(1)
+	switch {
+	case strings.HasSuffix(num, "K"):
+		multiplier = 1 << 10
+		num = num[:len(num)-1]
+	case strings.HasSuffix(num, "M"):
+		multiplier = 1 << 20
+		num = num[:len(num)-1]
+	case strings.HasSuffix(num, "G"):
+		multiplier = 1 << 30
+		num = num[:len(num)-1]
+	}
+	n, err := strconv.ParseInt(num, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n * multiplier, nil
+}
+
+// runInline executes code given on the command line (rage -c 'code'), with
+// imports resolving from the current directory.
+func runInline(code string) int {
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rage: %v\n", err)
+		return 1
+	}
+	return executeSource(code, "<string>", cwd)
+}
+
+// runStdin executes a script piped on standard input (cat x.py | rage -).
+func runStdin() int {
+	source, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rage: reading stdin: %v\n", err)
+		return 1
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rage: %v\n", err)
+		return 1
+	}
+	return executeSource(string(source), "<stdin>", cwd)
+}
+
+// runPath executes a script file, or a directory containing __main__.py with
+// sibling imports resolving inside the directory (like python <dir>).
+func runPath(path string) int {
+	filename := path
+	searchDir := ""
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		filename = filepath.Join(path, "__main__.py")
+		if _, err := os.Stat(filename); err != nil {
+			fmt.Fprintf(os.Stderr, "rage: can't find '__main__' module in %q\n", path)
+			return 1
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			searchDir = abs
+		}
+	}
 
-	// Read the script file
 	source, err := os.ReadFile(filename)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
-		os.Exit(1)
+		return 1
+	}
+	if searchDir == "" {
+		absFilename, _ := filepath.Abs(filename)
+		searchDir = filepath.Dir(absFilename)
+	}
+	return executeSource(string(source), filename, searchDir)
+}
+
+// runModule executes a module by dotted name found relative to the current
+// directory, like python -m. A package runs its __main__.py.
+func runModule(name string) int {
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rage: %v\n", err)
+		return 1
+	}
+	relPath := filepath.FromSlash(strings.ReplaceAll(name, ".", "/"))
+
+	filename := filepath.Join(cwd, relPath+".py")
+	if _, err := os.Stat(filename); err != nil {
+		filename = filepath.Join(cwd, relPath, "__main__.py")
+		if _, err := os.Stat(filename); err != nil {
+			fmt.Fprintf(os.Stderr, "rage: No module named %s\n", name)
+			return 1
+		}
+	}
+
+	source, err := os.ReadFile(filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+		return 1
 	}
+	return executeSource(string(source), filename, cwd)
+}
 
-	// Compile the source
-	code, errs := compiler.CompileSource(string(source), filename)
+// executeSource compiles and runs source as __main__ with imports resolving
+// from searchDir.
+func executeSource(source, filename, searchDir string) int {
+	code, errs := compiler.CompileSource(source, filename)
 	if len(errs) > 0 {
 		fmt.Fprintln(os.Stderr, "Compilation errors:")
 		for _, e := range errs {
 			fmt.Fprintln(os.Stderr, " ", e)
 		}
-		os.Exit(1)
+		return 1
 	}
 
-	// Execute
 	vm := runtime.NewVM()
+	vm.RegisterBuiltin("help", runtime.BuiltinHelp)
+	vm.SetGlobal("__name__", runtime.NewString("__main__"))
+	if execMaxMemory > 0 {
+		vm.SetMaxMemoryBytes(execMaxMemory)
+	}
 
-	// Set up filesystem imports so scripts can import local .py files
-	absFilename, _ := filepath.Abs(filename)
-	vm.SearchPaths = []string{filepath.Dir(absFilename)}
+	// Set up filesystem imports so scripts can import local .py files and
+	// packages
+	vm.SearchPaths = []string{searchDir}
 	vm.FileImporter = func(path string) (*runtime.CodeObject, error) {
 		src, err := os.ReadFile(path)
 		if err != nil {
@@ -56,9 +260,68 @@ func main() {
 		return code, nil
 	}
 
-	_, err = vm.Execute(code)
-	if err != nil {
+	// First Ctrl+C raises a catchable KeyboardInterrupt in the script; a
+	// second one force-exits in case the script swallows the first
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+	go func() {
+		<-sigCh
+		vm.Interrupt()
+		<-sigCh
+		fmt.Fprintln(os.Stderr, "rage: interrupted")
+		os.Exit(130)
+	}()
+
+	run := func() (runtime.Value, error) {
+		if execTimeout > 0 {
+			return vm.ExecuteWithTimeout(execTimeout, code)
+		}
+		return vm.Execute(code)
+	}
+	if _, err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Runtime error: %v\n", err)
-		os.Exit(1)
+		printSourceContext(err, source, filename)
+		if exc, ok := err.(*runtime.PyException); ok && exc.Type() == "KeyboardInterrupt" {
+			return 130
+		}
+		return 1
+	}
+	return 0
+}
+
+// printSourceContext prints the failing source line with a caret span under
+// the failing expression, mirroring the SnippetError rendering used for
+// compile errors. It needs a traceback frame with position info for the
+// executed file; other errors print nothing extra.
+func printSourceContext(err error, source, filename string) {
+	exc, ok := err.(*runtime.PyException)
+	if !ok {
+		return
+	}
+	for i := range exc.Traceback {
+		tb := &exc.Traceback[i]
+		if tb.Filename != filename || tb.Line <= 0 {
+			continue
+		}
+		lines := strings.Split(source, "\n")
+		if tb.Line > len(lines) {
+			return
+		}
+		line := strings.TrimRight(lines[tb.Line-1], "\r")
+		if strings.TrimSpace(line) == "" {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "    %s\n", line)
+		if tb.Col >= 1 && tb.Col <= len(line) {
+			width := 1
+			if tb.EndCol > tb.Col {
+				width = tb.EndCol - tb.Col
+			} else {
+				width = len(line) - tb.Col + 1
+			}
+			fmt.Fprintf(os.Stderr, "    %s%s\n", strings.Repeat(" ", tb.Col-1), strings.Repeat("^", width))
+		}
+		return
 	}
 }
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+)
+
+// cmdServe runs an execution protocol server speaking Content-Length framed
+// JSON-RPC, so non-Go applications can embed rage as a subprocess (or over a
+// socket) with a stable protocol. Methods: create-state, run, eval,
+// get-global, call-function, close-state, shutdown. The --timeout and
+// --max-memory flags apply to every state the server creates.
+func cmdServe(args []string) int {
+	stdio := false
+	tcpAddr := ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--stdio":
+			stdio = true
+		case "--tcp":
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "rage serve: --tcp requires an address")
+				return 2
+			}
+			i++
+			tcpAddr = args[i]
+		default:
+			fmt.Fprintf(os.Stderr, "rage serve: unknown argument %q\n", args[i])
+			return 2
+		}
+	}
+
+	if tcpAddr != "" {
+		return serveTCP(tcpAddr)
+	}
+	if !stdio {
+		fmt.Fprintln(os.Stderr, "rage serve: pass --stdio or --tcp <addr>")
+		return 2
+	}
+	session := newServeSession(bufio.NewReader(os.Stdin), os.Stdout)
+	defer session.close()
+	return session.run()
+}
+
+// serveTCP accepts connections and runs an independent session on each, so
+// clients never see each other's states.
+func serveTCP(addr string) int {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rage serve: %v\n", err)
+		return 1
+	}
+	defer ln.Close()
+	fmt.Fprintf(os.Stderr, "rage serve: listening on %s\n", ln.Addr())
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rage serve: %v\n", err)
+			return 1
+		}
+		go func() {
+			defer conn.Close()
+			session := newServeSession(bufio.NewReader(conn), conn)
+			defer session.close()
+			session.run()
+		}()
+	}
+}
+
+// serveSession handles one client, with its own set of interpreter states.
+type serveSession struct {
+	in     *bufio.Reader
+	out    io.Writer
+	states map[int]*serveState
+	nextID int
+}
+
+// serveState pairs an interpreter state with the buffer capturing its script
+// output. Print must not reach the session's stdout, which carries the
+// protocol frames; captured output is returned in each response instead.
+type serveState struct {
+	state  *rage.State
+	output *bytes.Buffer
+}
+
+func newServeSession(in *bufio.Reader, out io.Writer) *serveSession {
+	return &serveSession{in: in, out: out, states: make(map[int]*serveState), nextID: 1}
+}
+
+func (s *serveSession) close() {
+	for _, st := range s.states {
+		st.state.Close()
+	}
+}
+
+func (s *serveSession) run() int {
+	for {
+		msg, err := s.readMessage()
+		if err != nil {
+			if err == io.EOF {
+				return 0
+			}
+			fmt.Fprintf(os.Stderr, "rage serve: %v\n", err)
+			return 1
+		}
+		if msg.Method == "shutdown" {
+			s.reply(msg.ID, nil)
+			return 0
+		}
+		s.dispatch(msg)
+	}
+}
+
+func (s *serveSession) dispatch(msg *lspMessage) {
+	switch msg.Method {
+	case "create-state":
+		state := rage.NewState()
+		if execMaxMemory > 0 {
+			state.SetMaxMemoryBytes(execMaxMemory)
+		}
+		output := &bytes.Buffer{}
+		state.SetStdout(output)
+		id := s.nextID
+		s.nextID++
+		s.states[id] = &serveState{state: state, output: output}
+		s.reply(msg.ID, map[string]any{"state": id})
+	case "run":
+		var params struct {
+			State  int    `json:"state"`
+			Source string `json:"source"`
+		}
+		st, ok := s.stateParams(msg, &params, &params.State)
+		if !ok {
+			break
+		}
+		var value rage.Value
+		var err error
+		if execTimeout > 0 {
+			value, err = st.state.RunWithTimeout(params.Source, execTimeout)
+		} else {
+			value, err = st.state.Run(params.Source)
+		}
+		s.replyValue(msg.ID, st, value, err)
+	case "eval":
+		var params struct {
+			State int    `json:"state"`
+			Expr  string `json:"expr"`
+		}
+		st, ok := s.stateParams(msg, &params, &params.State)
+		if !ok {
+			break
+		}
+		value, err := st.state.RunExpr(params.Expr)
+		s.replyValue(msg.ID, st, value, err)
+	case "get-global":
+		var params struct {
+			State int    `json:"state"`
+			Name  string `json:"name"`
+		}
+		st, ok := s.stateParams(msg, &params, &params.State)
+		if !ok {
+			break
+		}
+		if !st.state.HasGlobal(params.Name) {
+			s.replyError(msg.ID, -32000, fmt.Sprintf("no global named %q", params.Name))
+			break
+		}
+		s.replyValue(msg.ID, st, st.state.GetGlobal(params.Name), nil)
+	case "call-function":
+		var params struct {
+			State    int    `json:"state"`
+			Function string `json:"function"`
+			Args     []any  `json:"args"`
+		}
+		st, ok := s.stateParams(msg, &params, &params.State)
+		if !ok {
+			break
+		}
+		fn := st.state.GetGlobal(params.Function)
+		if fn == nil {
+			s.replyError(msg.ID, -32000, fmt.Sprintf("no global named %q", params.Function))
+			break
+		}
+		args := make([]rage.Value, len(params.Args))
+		for i, a := range params.Args {
+			args[i] = argValue(a)
+		}
+		value, err := st.state.Call(fn, args...)
+		s.replyValue(msg.ID, st, value, err)
+	case "close-state":
+		var params struct {
+			State int `json:"state"`
+		}
+		st, ok := s.stateParams(msg, &params, &params.State)
+		if !ok {
+			break
+		}
+		st.state.Close()
+		delete(s.states, params.State)
+		s.reply(msg.ID, nil)
+	default:
+		s.replyError(msg.ID, -32601, fmt.Sprintf("unknown method %q", msg.Method))
+	}
+}
+
+// stateParams decodes params and resolves the state id they name, replying
+// with a protocol error when either fails. Numbers decode as json.Number so
+// integer arguments stay integers.
+func (s *serveSession) stateParams(msg *lspMessage, params any, id *int) (*serveState, bool) {
+	dec := json.NewDecoder(bytes.NewReader(msg.Params))
+	dec.UseNumber()
+	if err := dec.Decode(params); err != nil {
+		s.replyError(msg.ID, -32602, fmt.Sprintf("invalid params: %v", err))
+		return nil, false
+	}
+	state, ok := s.states[*id]
+	if !ok {
+		s.replyError(msg.ID, -32602, fmt.Sprintf("unknown state %d", *id))
+		return nil, false
+	}
+	return state, true
+}
+
+// argValue converts a decoded JSON argument to a Python value, mapping
+// whole json.Numbers to ints and the rest to floats.
+func argValue(a any) rage.Value {
+	switch v := a.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return rage.Int(i)
+		}
+		f, _ := v.Float64()
+		return rage.Float(f)
+	case []any:
+		items := make([]rage.Value, len(v))
+		for i, item := range v {
+			items[i] = argValue(item)
+		}
+		return rage.List(items...)
+	case map[string]any:
+		pairs := make([]any, 0, len(v)*2)
+		for key, val := range v {
+			pairs = append(pairs, key, argValue(val))
+		}
+		return rage.Dict(pairs...)
+	}
+	return rage.FromGo(a)
+}
+
+// replyValue sends an execution result, along with whatever the script
+// printed, or maps the error onto a JSON-RPC error object. The state's
+// output buffer is drained either way so output never leaks across requests.
+func (s *serveSession) replyValue(id json.RawMessage, st *serveState, value rage.Value, err error) {
+	output := st.output.String()
+	st.output.Reset()
+	if err != nil {
+		e := map[string]any{"code": -32000, "message": err.Error()}
+		if output != "" {
+			e["data"] = map[string]any{"output": output}
+		}
+		s.write(map[string]any{"jsonrpc": "2.0", "id": id, "error": e})
+		return
+	}
+	s.reply(id, map[string]any{
+		"type":   valueType(value),
+		"value":  jsonValue(value),
+		"output": output,
+	})
+}
+
+func valueType(v rage.Value) string {
+	if v == nil {
+		return "NoneType"
+	}
+	return v.Type()
+}
+
+// jsonValue converts a result to something JSON-encodable; values with no
+// JSON representation (functions, classes, userdata) fall back to their repr.
+func jsonValue(v rage.Value) any {
+	if v == nil {
+		return nil
+	}
+	gv := v.GoValue()
+	if _, err := json.Marshal(gv); err != nil {
+		return v.String()
+	}
+	return gv
+}
+
+// readMessage reads one Content-Length framed JSON-RPC message, reusing the
+// LSP wire types and framing.
+func (s *serveSession) readMessage() (*lspMessage, error) {
+	return readFramedMessage(s.in)
+}
+
+func (s *serveSession) reply(id json.RawMessage, result any) {
+	s.write(map[string]any{"jsonrpc": "2.0", "id": id, "result": result})
+}
+
+func (s *serveSession) replyError(id json.RawMessage, code int, message string) {
+	s.write(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error":   map[string]any{"code": code, "message": message},
+	})
+}
+
+func (s *serveSession) write(msg any) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rage serve: %v\n", err)
+		return
+	}
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ATSOTECK/rage/pkg/rage/ragetest"
+)
+
+// cmdTest runs the golden-file script harness over a directory.
+func cmdTest(args []string) int {
+	flags := flag.NewFlagSet("rage test", flag.ExitOnError)
+	update := flags.Bool("update", false, "write golden files instead of comparing")
+	timeout := flags.Duration("timeout", ragetest.DefaultTimeout, "per-script timeout")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: rage test [-update] [-timeout 10s] <dir>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 1
+	}
+
+	results, err := ragetest.RunDir(flags.Arg(0), ragetest.Options{
+		Update:  *update,
+		Timeout: *timeout,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	passed := 0
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			fmt.Printf("FAIL  %s (%s)\n      %v\n", r.Script, round(r.Duration), r.Err)
+		case r.Updated:
+			fmt.Printf("WROTE %s (%s)\n", r.Script, round(r.Duration))
+			passed++
+		default:
+			fmt.Printf("PASS  %s (%s)\n", r.Script, round(r.Duration))
+			passed++
+		}
+	}
+	fmt.Printf("\n%d/%d scripts passed\n", passed, len(results))
+	if ragetest.Failed(results) {
+		return 1
+	}
+	return 0
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
@@ -303,10 +303,12 @@ func registerGameClasses(state *rage.State) error {
 		Build(state)
 
 	state.SetGlobal("Vec2", vec2Class)
-	// Set ORIGIN as a class attribute using Python (demonstrates Go+Python interop).
-	if _, err := state.Run("Vec2.ORIGIN = Vec2(0, 0)"); err != nil {
-		return fmt.Errorf("setting Vec2.ORIGIN: %w", err)
-	}
+	// Set ORIGIN as a class attribute. Its value is itself a Vec2, so it can
+	// only be installed after the class exists.
+	origin := vec2Class.NewInstance()
+	origin.Set("x", rage.Float(0))
+	origin.Set("y", rage.Float(0))
+	vec2Class.SetAttr("ORIGIN", origin)
 
 	// ── Color(r, g, b) — RGB color ──────────────────────────────────
 	// Showcases: Init, Add, Mul, Eq, Str, Format, IntConv, FloatConv,
@@ -483,6 +485,9 @@ func registerGameClasses(state *rage.State) error {
 	//            Str, IAdd, Reversed, Iter, Next
 	// Methods:   keys, total_weight, drop
 	inventory := rage.NewClass("Inventory").
+		WithGoState(func() any {
+			return &inventoryData{items: make(map[string]rage.Value)}
+		}).
 		Init(func(s *rage.State, self rage.Object, args ...rage.Value) error {
 			cap := int64(16)
 			if len(args) > 0 {
@@ -490,27 +495,21 @@ func registerGameClasses(state *rage.State) error {
 					cap = c
 				}
 			}
-			self.Set("_data", rage.UserData(&inventoryData{
-				capacity: cap,
-				keys:     nil,
-				items:    make(map[string]rage.Value),
-			}))
+			inv := self.GoState().(*inventoryData)
+			inv.capacity = cap
 			return nil
 		}).
 		Str(func(s *rage.State, self rage.Object) (string, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			return fmt.Sprintf("Inventory(%d/%d)", len(inv.keys), inv.capacity), nil
 		}).
 		Len(func(s *rage.State, self rage.Object) (int64, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			return int64(len(inv.keys)), nil
 		}).
 		GetItem(func(s *rage.State, self rage.Object, key rage.Value) (rage.Value, error) {
 			name, _ := rage.AsString(key)
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			if v, ok := inv.items[name]; ok {
 				return v, nil
 			}
@@ -518,8 +517,7 @@ func registerGameClasses(state *rage.State) error {
 		}).
 		SetItem(func(s *rage.State, self rage.Object, key, val rage.Value) error {
 			name, _ := rage.AsString(key)
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			if int64(len(inv.keys)) >= inv.capacity {
 				if _, exists := inv.items[name]; !exists {
 					return rage.ValueError("inventory is full")
@@ -533,8 +531,7 @@ func registerGameClasses(state *rage.State) error {
 		}).
 		DelItem(func(s *rage.State, self rage.Object, key rage.Value) error {
 			name, _ := rage.AsString(key)
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			if _, ok := inv.items[name]; !ok {
 				return rage.KeyError(fmt.Sprintf("'%s'", name))
 			}
@@ -549,14 +546,12 @@ func registerGameClasses(state *rage.State) error {
 		}).
 		Contains(func(s *rage.State, self rage.Object, item rage.Value) (bool, error) {
 			name, _ := rage.AsString(item)
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			_, ok := inv.items[name]
 			return ok, nil
 		}).
 		Bool(func(s *rage.State, self rage.Object) (bool, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			return len(inv.keys) > 0, nil
 		}).
 		IAdd(func(s *rage.State, self rage.Object, other rage.Value) (rage.Value, error) {
@@ -564,8 +559,7 @@ func registerGameClasses(state *rage.State) error {
 			if !ok {
 				return nil, rage.TypeError("can only += a string item name")
 			}
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			if _, exists := inv.items[name]; !exists {
 				if int64(len(inv.keys)) >= inv.capacity {
 					return nil, rage.ValueError("inventory is full")
@@ -576,8 +570,7 @@ func registerGameClasses(state *rage.State) error {
 			return self, nil
 		}).
 		Reversed(func(s *rage.State, self rage.Object) (rage.Value, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			reversed := make([]rage.Value, len(inv.keys))
 			for i, k := range inv.keys {
 				reversed[len(inv.keys)-1-i] = rage.String(k)
@@ -585,14 +578,12 @@ func registerGameClasses(state *rage.State) error {
 			return rage.List(reversed...), nil
 		}).
 		Iter(func(s *rage.State, self rage.Object) (rage.Value, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			inv.iterIdx = 0
 			return self, nil
 		}).
 		Next(func(s *rage.State, self rage.Object) (rage.Value, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			if inv.iterIdx >= len(inv.keys) {
 				return nil, rage.ErrStopIteration
 			}
@@ -602,8 +593,7 @@ func registerGameClasses(state *rage.State) error {
 		}).
 		// keys() — return list of item names in insertion order.
 		Method("keys", func(s *rage.State, self rage.Object, args ...rage.Value) (rage.Value, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			items := make([]rage.Value, len(inv.keys))
 			for i, k := range inv.keys {
 				items[i] = rage.String(k)
@@ -612,8 +602,7 @@ func registerGameClasses(state *rage.State) error {
 		}).
 		// total_weight() — sum the "weight" field across all items.
 		Method("total_weight", func(s *rage.State, self rage.Object, args ...rage.Value) (rage.Value, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			total := int64(0)
 			for _, v := range inv.items {
 				if d, ok := rage.AsDict(v); ok {
@@ -627,8 +616,7 @@ func registerGameClasses(state *rage.State) error {
 		// drop(name) — remove an item and return it (or None if not found).
 		Method("drop", func(s *rage.State, self rage.Object, args ...rage.Value) (rage.Value, error) {
 			name, _ := rage.AsString(args[0])
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			inv := raw.(*inventoryData)
+			inv := self.GoState().(*inventoryData)
 			val, ok := inv.items[name]
 			if !ok {
 				return rage.None, nil
@@ -650,6 +638,7 @@ func registerGameClasses(state *rage.State) error {
 	// Showcases: Init, Enter, Exit, CallKw, GetAttr
 	// Methods:   filter, event_count
 	session := rage.NewClass("GameSession").
+		WithGoState(func() any { return &sessionData{} }).
 		Init(func(s *rage.State, self rage.Object, args ...rage.Value) error {
 			name := "unnamed"
 			if len(args) > 0 {
@@ -657,32 +646,26 @@ func registerGameClasses(state *rage.State) error {
 					name = n
 				}
 			}
-			self.Set("_data", rage.UserData(&sessionData{
-				name:   name,
-				events: nil,
-			}))
+			sd := self.GoState().(*sessionData)
+			sd.name = name
 			return nil
 		}).
 		Str(func(s *rage.State, self rage.Object) (string, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			sd := raw.(*sessionData)
+			sd := self.GoState().(*sessionData)
 			return fmt.Sprintf("GameSession(%q, %d events)", sd.name, len(sd.events)), nil
 		}).
 		Enter(func(s *rage.State, self rage.Object) (rage.Value, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			sd := raw.(*sessionData)
+			sd := self.GoState().(*sessionData)
 			fmt.Printf("  [session] %s: started\n", sd.name)
 			return self, nil
 		}).
 		Exit(func(s *rage.State, self rage.Object, excType, excVal, excTb rage.Value) (bool, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			sd := raw.(*sessionData)
+			sd := self.GoState().(*sessionData)
 			fmt.Printf("  [session] %s: closed (%d events logged)\n", sd.name, len(sd.events))
 			return false, nil // don't suppress exceptions
 		}).
 		CallKw(func(s *rage.State, self rage.Object, args []rage.Value, kwargs map[string]rage.Value) (rage.Value, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			sd := raw.(*sessionData)
+			sd := self.GoState().(*sessionData)
 			// Build a dict from the keyword arguments.
 			pairs := make([]any, 0, len(kwargs)*2)
 			for k, v := range kwargs {
@@ -692,8 +675,7 @@ func registerGameClasses(state *rage.State) error {
 			return rage.None, nil
 		}).
 		GetAttr(func(s *rage.State, self rage.Object, name string) (rage.Value, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			sd := raw.(*sessionData)
+			sd := self.GoState().(*sessionData)
 			switch name {
 			case "name":
 				return rage.String(sd.name), nil
@@ -705,8 +687,7 @@ func registerGameClasses(state *rage.State) error {
 		// filter(event_name) — return events matching the given event name.
 		Method("filter", func(s *rage.State, self rage.Object, args ...rage.Value) (rage.Value, error) {
 			name, _ := rage.AsString(args[0])
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			sd := raw.(*sessionData)
+			sd := self.GoState().(*sessionData)
 			var matched []rage.Value
 			for _, ev := range sd.events {
 				if d, ok := rage.AsDict(ev); ok {
@@ -719,8 +700,7 @@ func registerGameClasses(state *rage.State) error {
 		}).
 		// event_count() — return total number of logged events.
 		Method("event_count", func(s *rage.State, self rage.Object, args ...rage.Value) (rage.Value, error) {
-			raw, _ := rage.AsUserData(self.Get("_data"))
-			sd := raw.(*sessionData)
+			sd := self.GoState().(*sessionData)
 			return rage.Int(int64(len(sd.events))), nil
 		}).
 		Build(state)
@@ -733,27 +713,44 @@ func registerGameClasses(state *rage.State) error {
 // ── Pretty-print helpers ───────────────────────────────────────────────
 
 func printSettings(state *rage.State) {
-	s, ok := rage.AsDict(state.GetGlobal("settings"))
-	if !ok {
-		fmt.Println("(settings not found)")
+	// BindGlobals replaces the AsDict/AsInt extraction dance: declare the
+	// expected shape once and get per-field type errors if the script
+	// produced something unexpected.
+	var cfg struct {
+		Settings struct {
+			Environment    string          `rage:"environment"`
+			Host           string          `rage:"host"`
+			Port           int64           `rage:"port"`
+			Debug          bool            `rage:"debug"`
+			LogLevel       int64           `rage:"log_level"`
+			APIURL         string          `rage:"api_url"`
+			DBConnection   string          `rage:"db_connection"`
+			DBPoolSize     int64           `rage:"db_pool_size"`
+			MaxConnections int64           `rage:"max_connections"`
+			Features       map[string]bool `rage:"features,optional"`
+		} `rage:"settings"`
+	}
+	if err := state.BindGlobals(&cfg); err != nil {
+		fmt.Printf("(settings not usable: %v)\n", err)
 		return
 	}
+	s := cfg.Settings
 
 	section("Server Settings")
-	kv("Environment", s["environment"])
-	kv("Listen", fmt.Sprintf("%s:%s", s["host"], s["port"]))
-	kv("Debug", s["debug"])
-	kv("Log level", s["log_level"])
-	kv("API URL", s["api_url"])
-	kv("DB connection", s["db_connection"])
-	kv("DB pool size", s["db_pool_size"])
-	kv("Max connections", s["max_connections"])
-
-	if features, ok := rage.AsDict(s["features"]); ok {
+	kv("Environment", s.Environment)
+	kv("Listen", fmt.Sprintf("%s:%d", s.Host, s.Port))
+	kv("Debug", s.Debug)
+	kv("Log level", s.LogLevel)
+	kv("API URL", s.APIURL)
+	kv("DB connection", s.DBConnection)
+	kv("DB pool size", s.DBPoolSize)
+	kv("Max connections", s.MaxConnections)
+
+	if len(s.Features) > 0 {
 		fmt.Println()
 		fmt.Println("  Feature flags:")
-		for name, val := range features {
-			fmt.Printf("    %-20s %s\n", name, val)
+		for name, val := range s.Features {
+			fmt.Printf("    %-20s %v\n", name, val)
 		}
 	}
 	fmt.Println()
@@ -17,6 +17,14 @@ type astWalker struct {
 	// Comprehensions create their own scope, so yield detection skips them
 	// while super/__class__ detection enters them.
 	enterComprehensionElts bool
+
+	// enterNestedFunctions controls whether the walker descends into the
+	// bodies of nested FunctionDefs and Lambdas. These are separate scopes,
+	// so yield detection skips them, but super/__class__ detection enters
+	// them: a nested function using super() needs the enclosing method to
+	// capture the __class__ cell. Nested ClassDefs are never entered — their
+	// methods get their own __class__.
+	enterNestedFunctions bool
 }
 
 // walkStmts walks a slice of statements, returning true on first match.
@@ -111,7 +119,11 @@ func (w *astWalker) walkStmt(stmt model.Stmt) bool {
 				return true
 			}
 		}
-		// Note: Don't descend into nested FunctionDef or ClassDef.
+	case *model.FunctionDef:
+		if w.enterNestedFunctions {
+			return w.walkStmts(s.Body)
+		}
+		// Note: Don't descend into nested ClassDef.
 	}
 	return false
 }
@@ -222,6 +234,10 @@ func (w *astWalker) walkExpr(expr model.Expr) bool {
 				return true
 			}
 		}
+	case *model.Lambda:
+		if w.enterNestedFunctions {
+			return w.walkExpr(e.Body)
+		}
 	case *model.Starred:
 		return w.walkExpr(e.Value)
 	case *model.Await:
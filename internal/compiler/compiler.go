@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ATSOTECK/rage/internal/model"
 	"github.com/ATSOTECK/rage/internal/runtime"
@@ -19,15 +20,20 @@ func (e CompileError) Error() string {
 
 // Compiler compiles AST to bytecode
 type Compiler struct {
-	code           *runtime.CodeObject
-	symbolTable    *SymbolTable
-	errors         []CompileError
-	loopStack      []loopInfo
-	filename       string
-	optimizer      *Optimizer
-	currentLine    int // Current source line being compiled
-	lineStartOffset int // Bytecode offset where current line started
-	finallyDepth   int // Number of enclosing try/finally blocks (for continue/break through finally)
+	code            *runtime.CodeObject
+	symbolTable     *SymbolTable
+	errors          []CompileError
+	loopStack       []loopInfo
+	filename        string
+	optimizer       *Optimizer
+	currentLine     int // Current source line being compiled
+	currentCol      int // 1-based start column of the current expression (0 = unknown)
+	currentEndCol   int // Column just past the current expression (0 = unknown)
+	lineStartOffset int // Bytecode offset where current position started
+	finallyDepth    int // Number of enclosing try/finally blocks (for continue/break through finally)
+
+	source            string // Original source text (for annotation stringification)
+	futureAnnotations bool   // Set by 'from __future__ import annotations'
 }
 
 type loopInfo struct {
@@ -47,9 +53,11 @@ func (c *Compiler) newChildCompiler(name string, firstLine int, scopeType ScopeT
 			FirstLine: firstLine,
 			Flags:     flags,
 		},
-		symbolTable: NewSymbolTable(scopeType, c.symbolTable),
-		filename:    c.filename,
-		optimizer:   c.optimizer,
+		symbolTable:       NewSymbolTable(scopeType, c.symbolTable),
+		filename:          c.filename,
+		optimizer:         c.optimizer,
+		source:            c.source,
+		futureAnnotations: c.futureAnnotations,
 	}
 }
 
@@ -80,6 +88,15 @@ func NewCompiler(filename string) *Compiler {
 // Compile compiles a module to bytecode
 func (c *Compiler) Compile(module *model.Module) (*runtime.CodeObject, []CompileError) {
 	stmts := module.Body
+	c.code.Doc = docstringOf(stmts)
+
+	// Ensure __annotations__ exists if the module has annotated assignments
+	for _, stmt := range stmts {
+		if _, ok := stmt.(*model.AnnAssign); ok {
+			c.emit(runtime.OpSetupAnnotations)
+			break
+		}
+	}
 
 	for _, stmt := range stmts {
 		c.compileStmt(stmt)
@@ -98,6 +115,9 @@ func (c *Compiler) Compile(module *model.Module) (*runtime.CodeObject, []Compile
 	// Apply peephole optimizations
 	c.optimizer.PeepholeOptimize(c.code)
 
+	// Share duplicate names and constants across the module's code objects
+	internModule(c.code)
+
 	// Validate bytecode indices
 	if err := c.code.Validate(); err != nil {
 		c.errors = append(c.errors, CompileError{
@@ -197,28 +217,40 @@ func (c *Compiler) emitLoadConst(value any) {
 // setLine updates the current line number being compiled.
 // When the line changes, it records the previous line's bytecode range.
 func (c *Compiler) setLine(line int) {
+	c.setPos(line, 0, 0)
+}
+
+// setPos updates the source position being compiled, including the column
+// span of the enclosing expression. When the position changes, it records
+// the previous position's bytecode range.
+func (c *Compiler) setPos(line, col, endCol int) {
 	if line <= 0 {
 		return
 	}
-	if line != c.currentLine {
-		// Finish the previous line's entry if there was one
-		if c.currentLine > 0 {
-			currentOffset := len(c.code.Code)
-			if currentOffset > c.lineStartOffset {
-				c.code.LineNoTab = append(c.code.LineNoTab, runtime.LineEntry{
-					StartOffset: c.lineStartOffset,
-					EndOffset:   currentOffset,
-					Line:        c.currentLine,
-				})
-			}
-		}
+	if line != c.currentLine || col != c.currentCol || endCol != c.currentEndCol {
+		// Finish the previous position's entry if there was one
+		c.flushLineEntry()
 		c.currentLine = line
+		c.currentCol = col
+		c.currentEndCol = endCol
 		c.lineStartOffset = len(c.code.Code)
 	}
 }
 
-// finishLineTable finalizes the line number table with the last entry
-func (c *Compiler) finishLineTable() {
+// setExprPos records the column span of an expression so that runtime
+// errors can point a caret at the failing expression.
+func (c *Compiler) setExprPos(e model.Expr) {
+	pos := e.Pos()
+	end := e.End()
+	endCol := end.Column
+	if end.Line != pos.Line {
+		endCol = 0 // multi-line expression: span runs to end of line
+	}
+	c.setPos(pos.Line, pos.Column, endCol)
+}
+
+// flushLineEntry records the bytecode range emitted at the current position.
+func (c *Compiler) flushLineEntry() {
 	if c.currentLine > 0 {
 		currentOffset := len(c.code.Code)
 		if currentOffset > c.lineStartOffset {
@@ -226,11 +258,18 @@ func (c *Compiler) finishLineTable() {
 				StartOffset: c.lineStartOffset,
 				EndOffset:   currentOffset,
 				Line:        c.currentLine,
+				Col:         c.currentCol,
+				EndCol:      c.currentEndCol,
 			})
 		}
 	}
 }
 
+// finishLineTable finalizes the line number table with the last entry
+func (c *Compiler) finishLineTable() {
+	c.flushLineEntry()
+}
+
 func (c *Compiler) finalizeCode() {
 	// Build VarNames list for local variables
 	for name, sym := range c.symbolTable.symbols {
@@ -292,33 +331,92 @@ func (c *Compiler) estimateStackSize() int {
 	return maxStack
 }
 
-// CompileSource compiles Python source code to a code object
+// SnippetError decorates a parse or compile error with the offending source
+// line and a caret marking the column, so config authors can see exactly
+// where each problem is. Unwrap returns the underlying error.
+type SnippetError struct {
+	Err  error
+	Pos  model.Position
+	Line string // the offending source line, without its newline
+}
+
+func (e *SnippetError) Error() string {
+	if e.Line == "" {
+		return e.Err.Error()
+	}
+	col := e.Pos.Column
+	if col < 1 {
+		col = 1
+	}
+	if col > len(e.Line)+1 {
+		col = len(e.Line) + 1
+	}
+	caret := strings.Repeat(" ", col-1) + "^"
+	return fmt.Sprintf("%s\n    %s\n    %s", e.Err.Error(), e.Line, caret)
+}
+
+func (e *SnippetError) Unwrap() error { return e.Err }
+
+// withSnippets wraps each error with its source line and caret rendering.
+func withSnippets(source string, positions []model.Position, errs []error) []error {
+	lines := strings.Split(source, "\n")
+	wrapped := make([]error, len(errs))
+	for i, err := range errs {
+		pos := positions[i]
+		var line string
+		if pos.Line >= 1 && pos.Line <= len(lines) {
+			line = strings.TrimRight(lines[pos.Line-1], "\r")
+		}
+		wrapped[i] = &SnippetError{Err: err, Pos: pos, Line: line}
+	}
+	return wrapped
+}
+
+// CompileSource compiles Python source code to a code object.
+// On failure it reports every parse or compile error found — not just the
+// first — each rendered with the offending source line and a caret.
 func CompileSource(source, filename string) (*runtime.CodeObject, []error) {
-	parser := NewParser(source)
+	parser := NewParserWithFilename(source, filename)
 	module, parseErrors := parser.Parse()
 
 	if len(parseErrors) > 0 {
 		var errs []error
+		var positions []model.Position
 		for _, e := range parseErrors {
 			errs = append(errs, e)
+			positions = append(positions, e.Pos)
 		}
-		return nil, errs
+		return nil, withSnippets(source, positions, errs)
 	}
 
 	compiler := NewCompiler(filename)
+	compiler.source = source
 	code, compileErrors := compiler.Compile(module)
 
 	if len(compileErrors) > 0 {
 		var errs []error
+		var positions []model.Position
 		for _, e := range compileErrors {
 			errs = append(errs, e)
+			positions = append(positions, e.Pos)
 		}
-		return nil, errs
+		return nil, withSnippets(source, positions, errs)
 	}
 
 	return code, nil
 }
 
+// exprSource returns the source text of an expression, or "" when the
+// original source is unavailable.
+func (c *Compiler) exprSource(e model.Expr) string {
+	start := e.Pos().Offset
+	end := e.End().Offset
+	if c.source == "" || start < 0 || end > len(c.source) || start >= end {
+		return ""
+	}
+	return c.source[start:end]
+}
+
 // indexByte returns the index of the first occurrence of c in s, or -1 if not present.
 func indexByte(s string, c byte) int {
 	for i := 0; i < len(s); i++ {
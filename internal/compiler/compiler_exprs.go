@@ -80,10 +80,12 @@ func (c *Compiler) compileExpr(expr model.Expr) {
 		c.emitLoadConst("...") // Placeholder for Ellipsis object
 
 	case *model.Identifier:
+		c.setExprPos(e)
 		c.compileLoad(e.Name)
 
 	case *model.UnaryOp:
 		c.compileExpr(e.Operand)
+		c.setExprPos(e)
 		switch e.Op {
 		case model.TK_Plus:
 			c.emit(runtime.OpUnaryPositive)
@@ -98,6 +100,7 @@ func (c *Compiler) compileExpr(expr model.Expr) {
 	case *model.BinaryOp:
 		c.compileExpr(e.Left)
 		c.compileExpr(e.Right)
+		c.setExprPos(e)
 		c.emitBinaryOp(e.Op)
 
 	case *model.BoolOp:
@@ -111,12 +114,14 @@ func (c *Compiler) compileExpr(expr model.Expr) {
 
 	case *model.Attribute:
 		c.compileExpr(e.Value)
+		c.setExprPos(e)
 		nameIdx := c.addName(e.Attr.Name)
 		c.emitArg(runtime.OpLoadAttr, nameIdx)
 
 	case *model.Subscript:
 		c.compileExpr(e.Value)
 		c.compileExpr(e.Slice)
+		c.setExprPos(e)
 		c.emit(runtime.OpBinarySubscr)
 
 	case *model.Slice:
@@ -348,6 +353,7 @@ func (c *Compiler) compileCompare(e *model.Compare) {
 	if len(e.Ops) == 1 {
 		// Simple case: just one comparison
 		c.compileExpr(e.Comparators[0])
+		c.setExprPos(e)
 		c.emitCompareOp(e.Ops[0])
 		return
 	}
@@ -487,8 +493,10 @@ func (c *Compiler) compileCall(e *model.Call) {
 			}
 			// Push tuple of keyword names at the end
 			c.emitLoadConst(kwNames)
+			c.setExprPos(e)
 			c.emitArg(runtime.OpCallKw, len(e.Args)+len(e.Keywords))
 		} else {
+			c.setExprPos(e)
 			c.emitArg(runtime.OpCall, len(e.Args))
 		}
 	}
@@ -526,8 +534,8 @@ func (c *Compiler) compileFString(e *model.FStringLit) {
 
 				c.emitLoadConst(part.FormatSpec)
 				c.emitArg(runtime.OpCall, 2)
-			} else {
-				// No format spec: just apply conversion
+			} else if part.Conversion != 0 {
+				// Explicit conversion: str(expr) or repr(expr)
 				convName := "str"
 				if part.Conversion == 'r' {
 					convName = "repr"
@@ -536,6 +544,14 @@ func (c *Compiler) compileFString(e *model.FStringLit) {
 				c.emitArg(runtime.OpLoadGlobal, convIdx)
 				c.compileExpr(part.Expr)
 				c.emitArg(runtime.OpCall, 1)
+			} else {
+				// No spec and no conversion: format(value, "") so that a
+				// user-defined __format__ is consulted, like CPython
+				formatIdx := c.addName("format")
+				c.emitArg(runtime.OpLoadGlobal, formatIdx)
+				c.compileExpr(part.Expr)
+				c.emitLoadConst("")
+				c.emitArg(runtime.OpCall, 2)
 			}
 		} else {
 			// Load literal string
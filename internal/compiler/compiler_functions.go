@@ -7,6 +7,20 @@ import (
 
 // Function and class compilation
 
+// docstringOf returns the docstring of a statement body: the value of a leading
+// bare string literal, or "" if there is none.
+func docstringOf(stmts []model.Stmt) string {
+	if len(stmts) == 0 {
+		return ""
+	}
+	if exprStmt, ok := stmts[0].(*model.ExprStmt); ok {
+		if str, ok := exprStmt.Value.(*model.StringLit); ok {
+			return str.Value
+		}
+	}
+	return ""
+}
+
 func (c *Compiler) compileFunctionDef(s *model.FunctionDef) {
 	// Compile decorators
 	for _, dec := range s.Decorators {
@@ -27,12 +41,13 @@ func (c *Compiler) compileFunctionDef(s *model.FunctionDef) {
 		}
 	}
 
-	// Define parameters
+	// Define parameters. Positional-only params come first so that local slots
+	// match the order positional arguments are bound in.
 	if s.Args != nil {
-		for _, arg := range s.Args.Args {
+		for _, arg := range s.Args.PosOnlyArgs {
 			funcCompiler.symbolTable.Define(arg.Arg.Name)
 		}
-		for _, arg := range s.Args.PosOnlyArgs {
+		for _, arg := range s.Args.Args {
 			funcCompiler.symbolTable.Define(arg.Arg.Name)
 		}
 		for _, arg := range s.Args.KwOnlyArgs {
@@ -71,8 +86,10 @@ func (c *Compiler) compileFunctionDef(s *model.FunctionDef) {
 
 	// Set up code object
 	funcCode := funcCompiler.code
+	funcCode.Doc = docstringOf(s.Body)
 	if s.Args != nil {
 		funcCode.ArgCount = len(s.Args.Args) + len(s.Args.PosOnlyArgs)
+		funcCode.PosOnlyArgCount = len(s.Args.PosOnlyArgs)
 		funcCode.KwOnlyArgCount = len(s.Args.KwOnlyArgs)
 		if s.Args.VarArg != nil {
 			funcCode.Flags |= runtime.FlagVarArgs
@@ -120,6 +137,9 @@ func (c *Compiler) compileFunctionDef(s *model.FunctionDef) {
 		}
 	}
 
+	// Load annotations as a dict of parameter name (or "return") -> annotation
+	hasAnnotations := c.compileFunctionAnnotations(s)
+
 	// Load code object and make function
 	c.emitLoadConst(funcCode)
 	c.emitLoadConst(s.Name.Name)
@@ -131,6 +151,9 @@ func (c *Compiler) compileFunctionDef(s *model.FunctionDef) {
 	if hasKwDefaults {
 		flags |= 2 // Has kwonly defaults
 	}
+	if hasAnnotations {
+		flags |= 4 // Has annotations
+	}
 	c.emitArg(runtime.OpMakeFunction, flags)
 
 	// Apply decorators (in reverse order)
@@ -142,6 +165,49 @@ func (c *Compiler) compileFunctionDef(s *model.FunctionDef) {
 	c.compileStore(s.Name)
 }
 
+// compileFunctionAnnotations emits a dict of the function's parameter and
+// return annotations for OpMakeFunction. Returns false when the function has
+// no annotations (nothing is emitted).
+func (c *Compiler) compileFunctionAnnotations(s *model.FunctionDef) bool {
+	count := 0
+	emitPair := func(name string, ann model.Expr) {
+		c.emitLoadConst(name)
+		c.compileAnnotation(ann)
+		count++
+	}
+	if s.Args != nil {
+		for _, arg := range s.Args.PosOnlyArgs {
+			if arg.Annotation != nil {
+				emitPair(arg.Arg.Name, arg.Annotation)
+			}
+		}
+		for _, arg := range s.Args.Args {
+			if arg.Annotation != nil {
+				emitPair(arg.Arg.Name, arg.Annotation)
+			}
+		}
+		for _, arg := range s.Args.KwOnlyArgs {
+			if arg.Annotation != nil {
+				emitPair(arg.Arg.Name, arg.Annotation)
+			}
+		}
+		if s.Args.VarArg != nil && s.Args.VarArg.Annotation != nil {
+			emitPair(s.Args.VarArg.Arg.Name, s.Args.VarArg.Annotation)
+		}
+		if s.Args.KwArg != nil && s.Args.KwArg.Annotation != nil {
+			emitPair(s.Args.KwArg.Arg.Name, s.Args.KwArg.Annotation)
+		}
+	}
+	if s.Returns != nil {
+		emitPair("return", s.Returns)
+	}
+	if count == 0 {
+		return false
+	}
+	c.emitArg(runtime.OpBuildMap, count)
+	return true
+}
+
 func (c *Compiler) compileClassDef(s *model.ClassDef) {
 	// Compile decorators
 	for _, dec := range s.Decorators {
@@ -178,6 +244,7 @@ func (c *Compiler) compileClassDef(s *model.ClassDef) {
 	classCompiler.emit(runtime.OpLoadLocals)
 	classCompiler.emit(runtime.OpReturn)
 	c.finalizeAndOptimize(classCompiler)
+	classCompiler.code.Doc = docstringOf(s.Body)
 
 	c.emitLoadConst(classCompiler.code)
 	c.emitLoadConst(s.Name.Name)
@@ -219,6 +286,15 @@ func (c *Compiler) compileLambda(e *model.Lambda) {
 	// Create lambda function code
 	lambdaCompiler := c.newChildCompiler("<lambda>", e.StartPos.Line, ScopeFunction, 0)
 
+	// A lambda in a class context using super()/__class__ needs the class
+	// cell, just like a method body (see compileFunctionDef)
+	if classScope, isInClass := lambdaCompiler.symbolTable.IsInsideClass(); isInClass {
+		if usesSuperOrClassExpr(e.Body) {
+			classScope.MarkAsCell("__class__")
+			lambdaCompiler.symbolTable.Resolve("__class__")
+		}
+	}
+
 	// Define parameters
 	if e.Args != nil {
 		for _, arg := range e.Args.Args {
@@ -274,9 +350,39 @@ func (c *Compiler) compileComprehension(
 	eltExprs []model.Expr, // element expressions for prescan (capture detection)
 	setupBody func(cc *Compiler, stackOffset int),
 ) {
+	// An async comprehension body awaits __anext__, so the comprehension
+	// function itself must be a coroutine; it is awaited inline at the
+	// call site below
+	isAsync := false
+	for _, gen := range generators {
+		if gen.IsAsync {
+			isAsync = true
+			break
+		}
+	}
+	if isAsync {
+		flags |= runtime.FlagCoroutine
+	}
+
 	cc := c.newChildCompiler(name, firstLine, ScopeComprehension, flags)
 	cc.symbolTable.Define(".0")
 
+	// A comprehension body using super()/__class__ needs the class cell, just
+	// like a method body (the elements run in their own function scope)
+	if classScope, isInClass := cc.symbolTable.IsInsideClass(); isInClass {
+		needsClassCell := usesSuperOrClassExpr(eltExprs...)
+		for _, gen := range generators {
+			if needsClassCell {
+				break
+			}
+			needsClassCell = usesSuperOrClassExpr(gen.Ifs...)
+		}
+		if needsClassCell {
+			classScope.MarkAsCell("__class__")
+			cc.symbolTable.Resolve("__class__")
+		}
+	}
+
 	// Pre-define generator target variables so prescan can mark them as cells
 	for _, gen := range generators {
 		assigned := make(map[string]bool)
@@ -318,12 +424,21 @@ func (c *Compiler) compileComprehension(
 
 	// Make function and call with iterator
 	c.compileExpr(firstIter)
-	c.emit(runtime.OpGetIter)
+	if generators[0].IsAsync {
+		c.emit(runtime.OpGetAIter)
+	} else {
+		c.emit(runtime.OpGetIter)
+	}
 	c.emitLoadConst(cc.code)
 	c.emitLoadConst(name)
 	c.emitArg(runtime.OpMakeFunction, 0)
 	c.emit(runtime.OpRot2)
 	c.emitArg(runtime.OpCall, 1)
+	if isAsync {
+		// Await the coroutine so the comprehension evaluates to its result
+		c.emit(runtime.OpGetAwaitable)
+		c.emit(runtime.OpYieldFrom)
+	}
 }
 
 func (c *Compiler) compileListComp(e *model.ListComp) {
@@ -364,6 +479,14 @@ func (c *Compiler) compileDictComp(e *model.DictComp) {
 }
 
 func (c *Compiler) compileGeneratorExpr(e *model.GeneratorExpr) {
+	// Async generator expressions would need async generator objects,
+	// which the runtime does not have yet
+	for _, gen := range e.Generators {
+		if gen.IsAsync {
+			c.error(e.StartPos, "async generator expressions are not supported")
+			return
+		}
+	}
 	c.compileComprehension("<genexpr>", e.StartPos.Line, runtime.FlagGenerator, e.Generators, e.Generators[0].Iter,
 		[]model.Expr{e.Elt},
 		func(cc *Compiler, stackOffset int) {
@@ -391,11 +514,19 @@ func (c *Compiler) compileComprehensionGenerators(
 	// First generator uses .0 argument, others compile their iter
 	if depth == 0 {
 		comp.emitArg(runtime.OpLoadFast, 0) // .0
+	} else if gen.IsAsync {
+		comp.compileExpr(gen.Iter)
+		comp.emit(runtime.OpGetAIter)
 	} else {
 		comp.compileExpr(gen.Iter)
 		comp.emit(runtime.OpGetIter)
 	}
 
+	if gen.IsAsync {
+		c.compileAsyncComprehensionClause(comp, gen, generators, body, depth)
+		return
+	}
+
 	loopStart := comp.currentOffset()
 	exitJump := comp.emitJump(runtime.OpForIter)
 
@@ -427,6 +558,75 @@ func (c *Compiler) compileComprehensionGenerators(
 	comp.patchJump(exitJump, comp.currentOffset())
 }
 
+// compileAsyncComprehensionClause compiles one "async for" clause of a
+// comprehension. The async iterator is already on the stack; each iteration
+// awaits __anext__() and StopAsyncIteration ends the clause, mirroring
+// compileAsyncFor.
+func (c *Compiler) compileAsyncComprehensionClause(
+	comp *Compiler,
+	gen *model.Comprehension,
+	generators []*model.Comprehension,
+	body func(),
+	depth int,
+) {
+	loopStart := comp.currentOffset()
+	handlerJump := comp.emitJump(runtime.OpSetupExcept)
+
+	// Await __anext__(): stack [aiter] -> [aiter, value]
+	comp.emit(runtime.OpGetANext)
+	comp.emit(runtime.OpGetAwaitable)
+	comp.emit(runtime.OpYieldFrom)
+	comp.emit(runtime.OpPopExcept)
+
+	// Define and store target (only if not already defined by prescan)
+	switch t := gen.Target.(type) {
+	case *model.Identifier:
+		if _, exists := comp.symbolTable.symbols[t.Name]; !exists {
+			comp.symbolTable.Define(t.Name)
+		}
+	}
+	comp.compileStore(gen.Target)
+
+	// Compile if conditions
+	var ifJumps []int
+	for _, cond := range gen.Ifs {
+		comp.compileExpr(cond)
+		ifJumps = append(ifJumps, comp.emitJump(runtime.OpPopJumpIfFalse))
+	}
+
+	// Recurse to next generator or body
+	c.compileComprehensionGenerators(comp, generators, body, depth+1)
+
+	// Patch if jumps
+	for _, jump := range ifJumps {
+		comp.patchJump(jump, comp.currentOffset())
+	}
+
+	comp.emitArg(runtime.OpJump, loopStart)
+
+	// Exception handler: stack is [aiter, exc]
+	comp.patchJump(handlerJump, comp.currentOffset())
+	comp.emit(runtime.OpDup)
+	saiIdx := comp.addName("StopAsyncIteration")
+	comp.emitArg(runtime.OpLoadGlobal, saiIdx)
+	comp.emit(runtime.OpExceptionMatch)
+	reraiseJump := comp.emitJump(runtime.OpPopJumpIfFalse)
+
+	// StopAsyncIteration — the iterator is exhausted
+	comp.emit(runtime.OpClearException)
+	comp.emit(runtime.OpPop) // the dup
+	comp.emit(runtime.OpPop) // the exception
+	comp.emit(runtime.OpPopExceptHandler)
+	comp.emit(runtime.OpPop) // the exhausted iterator
+	endJump := comp.emitJump(runtime.OpJump)
+
+	// Any other exception propagates
+	comp.patchJump(reraiseJump, comp.currentOffset())
+	comp.emitArg(runtime.OpRaiseVarargs, 0)
+
+	comp.patchJump(endJump, comp.currentOffset())
+}
+
 // Match statement compilation
 
 func (c *Compiler) compileMatch(s *model.Match) {
@@ -502,7 +702,7 @@ func (c *Compiler) compilePattern(pattern model.Pattern) {
 		if p.Pattern != nil {
 			// Pattern with 'as' binding: pattern as name
 			// First match the element against the sub-pattern
-			c.compilePattern(p.Pattern) // Stack: [..., element, True/False]
+			c.compilePattern(p.Pattern)                       // Stack: [..., element, True/False]
 			matchJump := c.emitJump(runtime.OpPopJumpIfFalse) // Stack: [..., element]
 			// Pattern matched, bind name
 			if p.Name != nil {
@@ -673,7 +873,7 @@ func (c *Compiler) compileMappingPattern(p *model.MatchMapping) {
 
 	// Track failure jumps with how many values need to be popped
 	type failInfo struct {
-		jump       int
+		jump        int
 		valuesToPop int
 	}
 	var subPatternFails []failInfo
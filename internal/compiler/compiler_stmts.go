@@ -36,11 +36,11 @@ func (c *Compiler) compileStmt(stmt model.Stmt) {
 			c.compileExpr(s.Value)
 			c.compileStore(s.Target)
 		}
-		// In class scope, store annotation in __annotations__ dict
-		if c.symbolTable.scopeType == ScopeClass {
+		// In class and module scope, store annotation in __annotations__ dict
+		if c.symbolTable.scopeType == ScopeClass || c.symbolTable.scopeType == ScopeModule {
 			if ident, ok := s.Target.(*model.Identifier); ok {
 				// Stack order for STORE_SUBSCR: val, obj, index
-				c.compileExpr(s.Annotation)         // val: the annotation type
+				c.compileAnnotation(s.Annotation) // val: the annotation type
 				annIdx := c.addName("__annotations__")
 				c.emitArg(runtime.OpLoadName, annIdx) // obj: __annotations__ dict
 				c.emitLoadConst(ident.Name)           // index: field name string
@@ -137,6 +137,12 @@ func (c *Compiler) compileStmt(stmt model.Stmt) {
 		}
 
 	case *model.ImportFrom:
+		// __future__ imports are compile-time directives, not real imports
+		if s.Module != nil && s.Module.Name == "__future__" {
+			c.compileFutureImport(s)
+			return
+		}
+
 		level := s.Level
 		c.emitLoadConst(level)
 
@@ -237,7 +243,7 @@ func (c *Compiler) compileAugAssign(s *model.AugAssign) {
 		// Stack sequence: [obj, idx] -> [obj, idx, obj, idx] -> [obj, idx, value]
 		c.compileExpr(t.Value)
 		c.compileExpr(t.Slice)
-		c.emit(runtime.OpDup2) // Duplicate top two: [obj, idx, obj, idx]
+		c.emit(runtime.OpDup2)         // Duplicate top two: [obj, idx, obj, idx]
 		c.emit(runtime.OpBinarySubscr) // Get value: [obj, idx, value]
 	}
 
@@ -254,7 +260,7 @@ func (c *Compiler) compileAugAssign(s *model.AugAssign) {
 	// Store result
 	if isSubscript {
 		// For subscript: stack is [obj, idx, result], need [result, obj, idx] for StoreSubscr
-		c.emit(runtime.OpRot3)       // [obj, idx, result] -> [result, obj, idx]
+		c.emit(runtime.OpRot3)        // [obj, idx, result] -> [result, obj, idx]
 		c.emit(runtime.OpStoreSubscr) // Store and pop all three
 	} else {
 		c.compileStore(s.Target)
@@ -319,6 +325,11 @@ func (c *Compiler) compileWhile(s *model.While) {
 }
 
 func (c *Compiler) compileFor(s *model.For) {
+	if s.IsAsync {
+		c.compileAsyncFor(s)
+		return
+	}
+
 	// Compile iterator
 	c.compileExpr(s.Iter)
 	c.emit(runtime.OpGetIter)
@@ -360,6 +371,77 @@ func (c *Compiler) compileFor(s *model.For) {
 	c.loopStack = c.loopStack[:len(c.loopStack)-1]
 }
 
+// compileAsyncFor compiles "async for". There is no async FOR_ITER: each
+// iteration awaits __anext__() inside an except block, and StopAsyncIteration
+// raised by the awaitable ends the loop.
+func (c *Compiler) compileAsyncFor(s *model.For) {
+	// Compile async iterator
+	c.compileExpr(s.Iter)
+	c.emit(runtime.OpGetAIter)
+
+	loopStart := c.currentOffset()
+	c.loopStack = append(c.loopStack, loopInfo{startOffset: loopStart, isForLoop: true})
+
+	// Per-iteration handler catches StopAsyncIteration from the await
+	handlerJump := c.emitJump(runtime.OpSetupExcept)
+
+	// Await __anext__(): stack [aiter] -> [aiter, value]
+	c.emit(runtime.OpGetANext)
+	c.emit(runtime.OpGetAwaitable)
+	c.emit(runtime.OpYieldFrom)
+
+	// Got a value — leave the except block before running the body so that
+	// break/continue and user try/except see a clean block stack
+	c.emit(runtime.OpPopExcept)
+	c.compileStore(s.Target)
+
+	// Compile body
+	for _, stmt := range s.Body {
+		c.compileStmt(stmt)
+	}
+
+	c.emitArg(runtime.OpJump, loopStart)
+
+	// Exception handler: stack is [aiter, exc]
+	c.patchJump(handlerJump, c.currentOffset())
+	c.emit(runtime.OpDup)
+	saiIdx := c.addName("StopAsyncIteration")
+	c.emitArg(runtime.OpLoadGlobal, saiIdx)
+	c.emit(runtime.OpExceptionMatch)
+	reraiseJump := c.emitJump(runtime.OpPopJumpIfFalse)
+
+	// StopAsyncIteration — the iterator is exhausted
+	c.emit(runtime.OpClearException)
+	c.emit(runtime.OpPop) // the dup
+	c.emit(runtime.OpPop) // the exception
+	c.emit(runtime.OpPopExceptHandler)
+	c.emit(runtime.OpPop) // the exhausted iterator
+	endJump := c.emitJump(runtime.OpJump)
+
+	// Any other exception propagates
+	c.patchJump(reraiseJump, c.currentOffset())
+	c.emitArg(runtime.OpRaiseVarargs, 0)
+
+	c.patchJump(endJump, c.currentOffset())
+
+	// Handle else clause
+	if len(s.OrElse) > 0 {
+		for _, stmt := range s.OrElse {
+			c.compileStmt(stmt)
+		}
+	}
+
+	// Patch break and continue jumps
+	loop := c.loopStack[len(c.loopStack)-1]
+	for _, jump := range loop.breakJumps {
+		c.patchJump(jump, c.currentOffset())
+	}
+	for _, jump := range loop.continueJumps {
+		c.patchJump(jump, loopStart)
+	}
+	c.loopStack = c.loopStack[:len(c.loopStack)-1]
+}
+
 func (c *Compiler) compileTry(s *model.Try) {
 	// Check if any handler uses except* syntax
 	for _, h := range s.Handlers {
@@ -586,10 +668,10 @@ func (c *Compiler) compileTryStar(s *model.Try) {
 }
 
 func (c *Compiler) compileWith(s *model.With) {
-	c.compileWithItem(s.Items, 0, s.Body)
+	c.compileWithItem(s.Items, 0, s.Body, s.IsAsync)
 }
 
-func (c *Compiler) compileWithItem(items []*model.WithItem, idx int, body []model.Stmt) {
+func (c *Compiler) compileWithItem(items []*model.WithItem, idx int, body []model.Stmt, isAsync bool) {
 	item := items[idx]
 
 	// Compile the context expression: stack: [..., cm]
@@ -598,10 +680,18 @@ func (c *Compiler) compileWithItem(items []*model.WithItem, idx int, body []mode
 	// DUP so we have cm for both __enter__ and later __exit__
 	c.emit(runtime.OpDup) // stack: [..., cm, cm]
 
-	// Call __enter__
-	enterIdx := c.addName("__enter__")
+	// Call __enter__ (__aenter__ for async with, awaiting its result)
+	enterName := "__enter__"
+	if isAsync {
+		enterName = "__aenter__"
+	}
+	enterIdx := c.addName(enterName)
 	c.emitArg(runtime.OpLoadMethod, enterIdx)
 	c.emitArg(runtime.OpCallMethod, 0) // stack: [..., cm, enter_result]
+	if isAsync {
+		c.emit(runtime.OpGetAwaitable)
+		c.emit(runtime.OpYieldFrom)
+	}
 
 	// Store or pop the __enter__ result BEFORE setting up the block,
 	// so block.Level captures SP with only cm on the stack.
@@ -617,7 +707,7 @@ func (c *Compiler) compileWithItem(items []*model.WithItem, idx int, body []mode
 
 	// Compile body (or next nested with item)
 	if idx < len(items)-1 {
-		c.compileWithItem(items, idx+1, body)
+		c.compileWithItem(items, idx+1, body, isAsync)
 	} else {
 		for _, stmt := range body {
 			c.compileStmt(stmt)
@@ -635,21 +725,81 @@ func (c *Compiler) compileWithItem(items []*model.WithItem, idx int, body []mode
 	// stack: [..., cm, exception]
 	c.patchJump(cleanupJump, c.currentOffset())
 
-	c.emit(runtime.OpWithCleanup) // calls __exit__(exc_type, exc_val, exc_tb), may suppress
-	c.emit(runtime.OpEndFinally)  // re-raises if currentException still set
+	if isAsync {
+		c.emit(runtime.OpWithCleanupAsync) // calls and resolves __aexit__(exc_type, exc_val, exc_tb)
+	} else {
+		c.emit(runtime.OpWithCleanup) // calls __exit__(exc_type, exc_val, exc_tb), may suppress
+	}
+	c.emit(runtime.OpEndFinally) // re-raises if currentException still set
 	// If we reach here, exception was suppressed — skip normal exit
 	skipJump := c.emitJump(runtime.OpJump)
 
 	// === Normal exit path ===
 	c.patchJump(normalJump, c.currentOffset())
 	// stack: [..., cm] — call __exit__(None, None, None)
-	exitIdx := c.addName("__exit__")
+	exitName := "__exit__"
+	if isAsync {
+		exitName = "__aexit__"
+	}
+	exitIdx := c.addName(exitName)
 	c.emitArg(runtime.OpLoadMethod, exitIdx)
 	c.emitLoadConst(nil) // exc_type
 	c.emitLoadConst(nil) // exc_val
 	c.emitLoadConst(nil) // exc_tb
 	c.emitArg(runtime.OpCallMethod, 3)
+	if isAsync {
+		c.emit(runtime.OpGetAwaitable)
+		c.emit(runtime.OpYieldFrom)
+	}
 	c.emit(runtime.OpPop) // discard __exit__ return value
 
 	c.patchJump(skipJump, c.currentOffset())
 }
+
+// futureFeatures lists the feature names accepted by
+// 'from __future__ import ...'. Features that are already standard Python 3
+// behavior compile to no-ops; "annotations" switches annotation evaluation
+// to lazy (annotations are stored as their source text).
+var futureFeatures = map[string]bool{
+	"nested_scopes":    true,
+	"generators":       true,
+	"division":         true,
+	"absolute_import":  true,
+	"with_statement":   true,
+	"print_function":   true,
+	"unicode_literals": true,
+	"generator_stop":   true,
+	"annotations":      true,
+}
+
+// compileFutureImport handles 'from __future__ import ...' as a compile-time
+// directive. No import bytecode is emitted.
+func (c *Compiler) compileFutureImport(s *model.ImportFrom) {
+	for _, alias := range s.Names {
+		name := alias.Name.Name
+		if name == "*" {
+			c.error(alias.Name.Pos(), "future feature * is not defined")
+			continue
+		}
+		if !futureFeatures[name] {
+			c.error(alias.Name.Pos(), "future feature %s is not defined", name)
+			continue
+		}
+		if name == "annotations" {
+			c.futureAnnotations = true
+		}
+	}
+}
+
+// compileAnnotation emits code that leaves an annotation value on the stack.
+// Under 'from __future__ import annotations' the annotation is not evaluated;
+// its source text is loaded as a string constant instead.
+func (c *Compiler) compileAnnotation(ann model.Expr) {
+	if c.futureAnnotations {
+		if text := c.exprSource(ann); text != "" {
+			c.emitLoadConst(text)
+			return
+		}
+	}
+	c.compileExpr(ann)
+}
@@ -262,6 +262,28 @@ func usesSuperOrClass(stmts []model.Stmt) bool {
 			return false
 		},
 		enterComprehensionElts: true,
+		enterNestedFunctions:   true,
 	}
 	return w.walkStmts(stmts)
 }
+
+// usesSuperOrClassExpr is the expression-level variant of usesSuperOrClass,
+// used for comprehension elements and lambda bodies.
+func usesSuperOrClassExpr(exprs ...model.Expr) bool {
+	w := astWalker{
+		exprMatch: func(expr model.Expr) bool {
+			if id, ok := expr.(*model.Identifier); ok {
+				return id.Name == "super" || id.Name == "__class__"
+			}
+			return false
+		},
+		enterComprehensionElts: true,
+		enterNestedFunctions:   true,
+	}
+	for _, e := range exprs {
+		if e != nil && w.walkExpr(e) {
+			return true
+		}
+	}
+	return false
+}
@@ -353,6 +353,28 @@ z = 3`
 	assert.True(t, lines[3], "line 3 should be in line table")
 }
 
+func TestLineTableColumnSpans(t *testing.T) {
+	source := `value = alpha + beta`
+	code, errs := CompileSource(source, "<test>")
+	require.Empty(t, errs)
+	require.NotNil(t, code)
+
+	// The binary add should carry the span of "alpha + beta" (cols 9-20)
+	found := false
+	for _, entry := range code.LineNoTab {
+		if entry.Col == 9 && entry.EndCol == 21 {
+			found = true
+		}
+	}
+	assert.True(t, found, "expected a column span covering the binary expression, got %+v", code.LineNoTab)
+
+	// Offsets must stay consistent with the (optimized) bytecode
+	for _, entry := range code.LineNoTab {
+		assert.LessOrEqual(t, entry.EndOffset, len(code.Code))
+		assert.Less(t, entry.StartOffset, entry.EndOffset)
+	}
+}
+
 // =============================================================================
 // Bytecode Validation Tests
 // =============================================================================
@@ -372,3 +394,85 @@ z = [1, 2, 3]
 	err := code.Validate()
 	assert.NoError(t, err, "well-formed code should validate")
 }
+
+// =============================================================================
+// Multi-error reporting with source snippets
+//
+// CompileSource should report every error found, each carrying the offending
+// source line and a caret pointing at the column.
+// =============================================================================
+
+func TestCompileSourceReportsMultipleErrors(t *testing.T) {
+	source := "x = (\ny = ]\nz = 1 +\n"
+	code, errs := CompileSource(source, "config.py")
+	require.Nil(t, code)
+	require.GreaterOrEqual(t, len(errs), 2, "should keep parsing after the first error")
+}
+
+func TestCompileSourceErrorsCarrySnippets(t *testing.T) {
+	source := "a = 1\nb = 1 +\n"
+	code, errs := CompileSource(source, "config.py")
+	require.Nil(t, code)
+	require.Len(t, errs, 1)
+
+	var snip *SnippetError
+	require.ErrorAs(t, errs[0], &snip)
+	assert.Equal(t, "config.py", snip.Pos.Filename)
+	assert.Equal(t, 2, snip.Pos.Line)
+	assert.Equal(t, "b = 1 +", snip.Line)
+
+	rendered := errs[0].Error()
+	assert.Contains(t, rendered, "config.py:2:")
+	assert.Contains(t, rendered, "\n    b = 1 +\n")
+	// Caret is indented to the error column on its own line.
+	lines := strings.Split(rendered, "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "^", strings.TrimSpace(lines[2]))
+	assert.Equal(t, snip.Pos.Column-1+len("    "), strings.Index(lines[2], "^"))
+}
+
+func TestSnippetErrorUnwrap(t *testing.T) {
+	source := "b = 1 +\n"
+	_, errs := CompileSource(source, "config.py")
+	require.Len(t, errs, 1)
+
+	var parseErr ParseError
+	require.ErrorAs(t, errs[0], &parseErr, "SnippetError should unwrap to the underlying ParseError")
+	assert.Equal(t, 1, parseErr.Pos.Line)
+}
+
+// =============================================================================
+// __future__ imports
+// =============================================================================
+
+func TestFutureAnnotationsAreLazy(t *testing.T) {
+	code, errs := CompileSource(`
+from __future__ import annotations
+
+class C:
+    x: list[undefined_name]
+
+ann = C.__annotations__["x"]
+`, "<test>")
+	require.Empty(t, errs)
+
+	vm := runtime.NewVM()
+	_, err := vm.Execute(code)
+	require.NoError(t, err, "lazy annotations must not evaluate undefined names")
+	require.Equal(t, "list[undefined_name]", vm.GetGlobal("ann").(*runtime.PyString).Value)
+}
+
+func TestFutureImportMandatoryFeatureIsNoOp(t *testing.T) {
+	code, errs := CompileSource("from __future__ import division\nx = 1 / 2\n", "<test>")
+	require.Empty(t, errs)
+
+	vm := runtime.NewVM()
+	_, err := vm.Execute(code)
+	require.NoError(t, err)
+}
+
+func TestFutureImportUnknownFeature(t *testing.T) {
+	_, errs := CompileSource("from __future__ import braces\n", "<test>")
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "future feature braces is not defined")
+}
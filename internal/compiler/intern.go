@@ -0,0 +1,85 @@
+package compiler
+
+import (
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// Constant and name interning. Each code object deduplicates its own pools
+// as constants are added, but nested functions compiled from the same module
+// still carry private copies of the same strings and boxed scalars. This
+// pass walks a finished module's code-object tree and routes every string
+// and comparable constant through one shared table, so duplicates across
+// functions collapse to a single allocation. Bytecode is untouched — pool
+// indices stay per-code-object; only the backing values are shared.
+
+// interner canonicalizes strings and boxed scalar constants.
+type interner struct {
+	strings map[string]string
+	consts  map[any]any
+}
+
+func newInterner() *interner {
+	return &interner{
+		strings: make(map[string]string),
+		consts:  make(map[any]any),
+	}
+}
+
+// intern returns the canonical copy of s.
+func (in *interner) intern(s string) string {
+	if canonical, ok := in.strings[s]; ok {
+		return canonical
+	}
+	in.strings[s] = s
+	return s
+}
+
+// internConst returns the canonical copy of a constant-pool entry. Only
+// comparable scalar kinds are shared; slices and runtime values pass
+// through untouched.
+func (in *interner) internConst(v any) any {
+	switch c := v.(type) {
+	case string:
+		return in.intern(c)
+	case int64, float64, bool:
+		if canonical, ok := in.consts[v]; ok {
+			return canonical
+		}
+		in.consts[v] = v
+		return v
+	default:
+		return v
+	}
+}
+
+// internCode interns one code object and recurses into nested code objects
+// in its constant pool.
+func (in *interner) internCode(co *runtime.CodeObject) {
+	co.Name = in.intern(co.Name)
+	co.Filename = in.intern(co.Filename)
+	for i, name := range co.Names {
+		co.Names[i] = in.intern(name)
+	}
+	for i, name := range co.VarNames {
+		co.VarNames[i] = in.intern(name)
+	}
+	for i, name := range co.FreeVars {
+		co.FreeVars[i] = in.intern(name)
+	}
+	for i, name := range co.CellVars {
+		co.CellVars[i] = in.intern(name)
+	}
+	for i, c := range co.Constants {
+		if nested, ok := c.(*runtime.CodeObject); ok {
+			in.internCode(nested)
+			continue
+		}
+		co.Constants[i] = in.internConst(c)
+	}
+}
+
+// internModule deduplicates names and constants across all code objects
+// compiled from one module.
+func internModule(co *runtime.CodeObject) {
+	newInterner().internCode(co)
+}
@@ -0,0 +1,115 @@
+package compiler
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Constant / name interning across a module's code objects
+// =============================================================================
+
+// stringData exposes a string's backing pointer so tests can observe that
+// two equal strings were interned to one allocation.
+func stringData(s string) *byte {
+	return unsafe.StringData(s)
+}
+
+func findNestedCode(co *runtime.CodeObject, name string) *runtime.CodeObject {
+	for _, c := range co.Constants {
+		if nested, ok := c.(*runtime.CodeObject); ok {
+			if nested.Name == name {
+				return nested
+			}
+			if found := findNestedCode(nested, name); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+func findStringConst(co *runtime.CodeObject, want string) (string, bool) {
+	for _, c := range co.Constants {
+		if s, ok := c.(string); ok && s == want {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+func TestInternSharesStringConstantsAcrossFunctions(t *testing.T) {
+	code, errs := CompileSource(`
+def f():
+    return "shared constant value"
+
+def g():
+    return "shared constant value"
+`, "<test>")
+	require.Empty(t, errs)
+
+	fCode := findNestedCode(code, "f")
+	gCode := findNestedCode(code, "g")
+	require.NotNil(t, fCode)
+	require.NotNil(t, gCode)
+
+	fConst, ok := findStringConst(fCode, "shared constant value")
+	require.True(t, ok)
+	gConst, ok := findStringConst(gCode, "shared constant value")
+	require.True(t, ok)
+
+	require.Equal(t, stringData(fConst), stringData(gConst),
+		"equal string constants in sibling functions should share one allocation")
+}
+
+func TestInternSharesNamesAcrossCodeObjects(t *testing.T) {
+	code, errs := CompileSource(`
+def f():
+    return some_global_name
+
+def g():
+    return some_global_name
+`, "<test>")
+	require.Empty(t, errs)
+
+	fCode := findNestedCode(code, "f")
+	gCode := findNestedCode(code, "g")
+	require.NotNil(t, fCode)
+	require.NotNil(t, gCode)
+
+	var fName, gName string
+	for _, n := range fCode.Names {
+		if n == "some_global_name" {
+			fName = n
+		}
+	}
+	for _, n := range gCode.Names {
+		if n == "some_global_name" {
+			gName = n
+		}
+	}
+	require.NotEmpty(t, fName)
+	require.NotEmpty(t, gName)
+	require.Equal(t, stringData(fName), stringData(gName),
+		"equal names in sibling functions should share one allocation")
+}
+
+func TestInternPreservesBytecodeBehavior(t *testing.T) {
+	// Interning must not change what the code computes.
+	code, errs := CompileSource(`
+def f():
+    return "x" * 3
+
+result = f()
+`, "<test>")
+	require.Empty(t, errs)
+
+	vm := runtime.NewVM()
+	_, err := vm.Execute(code)
+	require.NoError(t, err)
+	require.Equal(t, "xxx", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
@@ -428,49 +428,49 @@ func lookupUnicodeName(name string) (rune, bool) {
 // This covers commonly used names. For full coverage, a comprehensive database would be needed.
 var unicodeNames = map[string]rune{
 	// ASCII control characters
-	"NULL":                            0x0000,
-	"BACKSPACE":                       0x0008,
-	"CHARACTER TABULATION":            0x0009,
-	"TAB":                             0x0009,
-	"LINE FEED":                       0x000A,
-	"CARRIAGE RETURN":                 0x000D,
-	"ESCAPE":                          0x001B,
-	"SPACE":                           0x0020,
-	"DELETE":                          0x007F,
-	"NO-BREAK SPACE":                  0x00A0,
+	"NULL":                 0x0000,
+	"BACKSPACE":            0x0008,
+	"CHARACTER TABULATION": 0x0009,
+	"TAB":                  0x0009,
+	"LINE FEED":            0x000A,
+	"CARRIAGE RETURN":      0x000D,
+	"ESCAPE":               0x001B,
+	"SPACE":                0x0020,
+	"DELETE":               0x007F,
+	"NO-BREAK SPACE":       0x00A0,
 
 	// Punctuation & symbols
-	"EXCLAMATION MARK":                     0x0021,
-	"QUOTATION MARK":                       0x0022,
-	"NUMBER SIGN":                          0x0023,
-	"PERCENT SIGN":                         0x0025,
-	"AMPERSAND":                            0x0026,
-	"APOSTROPHE":                           0x0027,
-	"LEFT PARENTHESIS":                     0x0028,
-	"RIGHT PARENTHESIS":                    0x0029,
-	"ASTERISK":                             0x002A,
-	"PLUS SIGN":                            0x002B,
-	"COMMA":                                0x002C,
-	"HYPHEN-MINUS":                         0x002D,
-	"FULL STOP":                            0x002E,
-	"SOLIDUS":                              0x002F,
-	"COLON":                                0x003A,
-	"SEMICOLON":                            0x003B,
-	"LESS-THAN SIGN":                       0x003C,
-	"EQUALS SIGN":                          0x003D,
-	"GREATER-THAN SIGN":                    0x003E,
-	"QUESTION MARK":                        0x003F,
-	"COMMERCIAL AT":                        0x0040,
-	"LEFT SQUARE BRACKET":                  0x005B,
-	"REVERSE SOLIDUS":                      0x005C,
-	"RIGHT SQUARE BRACKET":                 0x005D,
-	"CIRCUMFLEX ACCENT":                    0x005E,
-	"LOW LINE":                             0x005F,
-	"GRAVE ACCENT":                         0x0060,
-	"LEFT CURLY BRACKET":                   0x007B,
-	"VERTICAL LINE":                        0x007C,
-	"RIGHT CURLY BRACKET":                  0x007D,
-	"TILDE":                                0x007E,
+	"EXCLAMATION MARK":     0x0021,
+	"QUOTATION MARK":       0x0022,
+	"NUMBER SIGN":          0x0023,
+	"PERCENT SIGN":         0x0025,
+	"AMPERSAND":            0x0026,
+	"APOSTROPHE":           0x0027,
+	"LEFT PARENTHESIS":     0x0028,
+	"RIGHT PARENTHESIS":    0x0029,
+	"ASTERISK":             0x002A,
+	"PLUS SIGN":            0x002B,
+	"COMMA":                0x002C,
+	"HYPHEN-MINUS":         0x002D,
+	"FULL STOP":            0x002E,
+	"SOLIDUS":              0x002F,
+	"COLON":                0x003A,
+	"SEMICOLON":            0x003B,
+	"LESS-THAN SIGN":       0x003C,
+	"EQUALS SIGN":          0x003D,
+	"GREATER-THAN SIGN":    0x003E,
+	"QUESTION MARK":        0x003F,
+	"COMMERCIAL AT":        0x0040,
+	"LEFT SQUARE BRACKET":  0x005B,
+	"REVERSE SOLIDUS":      0x005C,
+	"RIGHT SQUARE BRACKET": 0x005D,
+	"CIRCUMFLEX ACCENT":    0x005E,
+	"LOW LINE":             0x005F,
+	"GRAVE ACCENT":         0x0060,
+	"LEFT CURLY BRACKET":   0x007B,
+	"VERTICAL LINE":        0x007C,
+	"RIGHT CURLY BRACKET":  0x007D,
+	"TILDE":                0x007E,
 
 	// Latin letters (uppercase)
 	"LATIN CAPITAL LETTER A": 'A', "LATIN CAPITAL LETTER B": 'B',
@@ -597,14 +597,14 @@ var unicodeNames = map[string]rune{
 	"BOX DRAWINGS LIGHT VERTICAL":   0x2502,
 
 	// Special
-	"REPLACEMENT CHARACTER":  0xFFFD,
-	"ZERO WIDTH SPACE":       0x200B,
-	"ZERO WIDTH NON-JOINER":  0x200C,
-	"ZERO WIDTH JOINER":      0x200D,
-	"LEFT-TO-RIGHT MARK":     0x200E,
-	"RIGHT-TO-LEFT MARK":     0x200F,
-	"BYTE ORDER MARK":        0xFEFF,
-	"SUPERSCRIPT ONE":        0x00B9,
+	"REPLACEMENT CHARACTER":     0xFFFD,
+	"ZERO WIDTH SPACE":          0x200B,
+	"ZERO WIDTH NON-JOINER":     0x200C,
+	"ZERO WIDTH JOINER":         0x200D,
+	"LEFT-TO-RIGHT MARK":        0x200E,
+	"RIGHT-TO-LEFT MARK":        0x200F,
+	"BYTE ORDER MARK":           0xFEFF,
+	"SUPERSCRIPT ONE":           0x00B9,
 	"INVERTED EXCLAMATION MARK": 0x00A1,
 	"INVERTED QUESTION MARK":    0x00BF,
 }
@@ -1,6 +1,8 @@
 package compiler
 
 import (
+	"sort"
+
 	"github.com/ATSOTECK/rage/internal/runtime"
 )
 
@@ -162,6 +164,30 @@ func (o *Optimizer) rebuildBytecode(code *runtime.CodeObject, instrs []*instruct
 		}
 	}
 
+	// Remap the line table to the rebuilt offsets so positions stay accurate
+	if len(code.LineNoTab) > 0 {
+		finalSize := newOffset
+		mapOffset := func(oldOff int) int {
+			idx := sort.SearchInts(oldOffsets, oldOff)
+			for idx < len(instrs) && instrs[idx].removed {
+				idx++
+			}
+			if idx < len(instrs) {
+				return newOffsets[idx]
+			}
+			return finalSize
+		}
+		remapped := code.LineNoTab[:0]
+		for _, entry := range code.LineNoTab {
+			entry.StartOffset = mapOffset(entry.StartOffset)
+			entry.EndOffset = mapOffset(entry.EndOffset)
+			if entry.EndOffset > entry.StartOffset {
+				remapped = append(remapped, entry)
+			}
+		}
+		code.LineNoTab = remapped
+	}
+
 	// Rebuild bytecode
 	newCode := make([]byte, 0, size)
 	for _, instr := range instrs {
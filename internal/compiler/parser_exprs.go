@@ -537,7 +537,7 @@ func (p *Parser) parseParenExpr() model.Expr {
 	first := p.parseExpression()
 
 	// Check for generator expression
-	if p.check(model.TK_For) {
+	if p.check(model.TK_For) || p.check(model.TK_Async) {
 		return p.parseGeneratorExpr(first, startPos)
 	}
 
@@ -589,7 +589,7 @@ func (p *Parser) parseListExpr() model.Expr {
 	first := p.parseExpression()
 
 	// List comprehension
-	if p.check(model.TK_For) {
+	if p.check(model.TK_For) || p.check(model.TK_Async) {
 		generators := p.parseComprehensionClauses()
 		endTok := p.expect(model.TK_RBracket)
 		return &model.ListComp{
@@ -643,7 +643,7 @@ func (p *Parser) parseDictOrSetExpr() model.Expr {
 		value := p.parseExpression()
 
 		// Dict comprehension
-		if p.check(model.TK_For) {
+		if p.check(model.TK_For) || p.check(model.TK_Async) {
 			generators := p.parseComprehensionClauses()
 			endTok := p.expect(model.TK_RBrace)
 			return &model.DictComp{
@@ -659,7 +659,7 @@ func (p *Parser) parseDictOrSetExpr() model.Expr {
 	}
 
 	// Set comprehension
-	if p.check(model.TK_For) {
+	if p.check(model.TK_For) || p.check(model.TK_Async) {
 		generators := p.parseComprehensionClauses()
 		endTok := p.expect(model.TK_RBrace)
 		return &model.SetComp{
@@ -783,7 +783,7 @@ func (p *Parser) parseCallExpr(fn model.Expr) model.Expr {
 		} else {
 			expr := p.parseExpression()
 			// Check for generator expression: f(x for x in ...)
-			if p.check(model.TK_For) {
+			if p.check(model.TK_For) || p.check(model.TK_Async) {
 				generators := p.parseComprehensionClauses()
 				genExpr := &model.GeneratorExpr{
 					Elt:        expr,
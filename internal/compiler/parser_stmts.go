@@ -1021,4 +1021,3 @@ func (p *Parser) parseDecorated() model.Stmt {
 		return nil
 	}
 }
-
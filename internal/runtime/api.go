@@ -3,6 +3,7 @@ package runtime
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 )
 
@@ -89,6 +90,17 @@ func NewBytes(v []byte) *PyBytes {
 	return &PyBytes{Value: v}
 }
 
+// NewFloatArray creates a Python floatarray sharing the given Go slice.
+// Element reads and writes on either side are visible to the other
+func NewFloatArray(v []float64) *PyFloatArray {
+	return &PyFloatArray{Values: v}
+}
+
+// NewIntArray creates a Python intarray sharing the given Go slice
+func NewIntArray(v []int64) *PyIntArray {
+	return &PyIntArray{Values: v}
+}
+
 // NewUserData creates a new userdata wrapping a Go value
 func NewUserData(v any) *PyUserData {
 	return &PyUserData{Value: v}
@@ -99,6 +111,78 @@ func NewGoFunction(name string, fn GoFunction) *PyGoFunc {
 	return &PyGoFunc{Name: name, Fn: fn}
 }
 
+// PyGoIterator is a lazy Python iterator backed by a Go callback. It works
+// with for-loops, comprehensions, and iterating builtins without copying the
+// underlying data into a list upfront. Like generators, it is one-shot: it is
+// its own iterator and cannot be restarted.
+type PyGoIterator struct {
+	Name string
+	Next func() (Value, bool, error) // Returns (next value, done, error)
+}
+
+func (g *PyGoIterator) Type() string   { return "iterator" }
+func (g *PyGoIterator) String() string { return fmt.Sprintf("<go iterator %s>", g.Name) }
+
+// NewGoIterator creates a lazy Python iterator from a Go next function.
+func NewGoIterator(name string, next func() (Value, bool, error)) *PyGoIterator {
+	return &PyGoIterator{Name: name, Next: next}
+}
+
+// =====================================
+// Keyword argument ordering
+// =====================================
+
+// KwArg is a single keyword argument paired with its name. It is used by
+// OrderedKwargs to expose keyword arguments without losing call-site order.
+type KwArg struct {
+	Name  string
+	Value Value
+}
+
+// KwargNames returns the names of the keyword arguments passed to the Go
+// builtin currently executing, in the order they appeared at the Python call
+// site. It returns nil outside a builtin call or when no keywords were passed.
+func (vm *VM) KwargNames() []string {
+	return vm.currentKwNames
+}
+
+// OrderedKwargs converts a kwargs map into name/value pairs in call-site
+// order. Names without a recorded position (for example when the host invokes
+// a builtin directly) are appended in sorted order, so the result is always
+// deterministic.
+func (vm *VM) OrderedKwargs(kwargs map[string]Value) []KwArg {
+	return orderKwargs(vm.currentKwNames, kwargs)
+}
+
+// orderKwargs arranges kwargs by the recorded call-site names, appending any
+// unrecorded names in sorted order.
+func orderKwargs(names []string, kwargs map[string]Value) []KwArg {
+	if len(kwargs) == 0 {
+		return nil
+	}
+	result := make([]KwArg, 0, len(kwargs))
+	seen := make(map[string]bool, len(kwargs))
+	for _, name := range names {
+		if v, ok := kwargs[name]; ok && !seen[name] {
+			result = append(result, KwArg{Name: name, Value: v})
+			seen[name] = true
+		}
+	}
+	if len(result) < len(kwargs) {
+		rest := make([]string, 0, len(kwargs)-len(result))
+		for name := range kwargs {
+			if !seen[name] {
+				rest = append(rest, name)
+			}
+		}
+		sort.Strings(rest)
+		for _, name := range rest {
+			result = append(result, KwArg{Name: name, Value: kwargs[name]})
+		}
+	}
+	return result
+}
+
 // =====================================
 // VM API Methods (gopher-lua style)
 // =====================================
@@ -323,11 +407,82 @@ func (vm *VM) GetGlobal(name string) Value {
 	return None
 }
 
+// FreezeGlobal marks a module-level global as read-only. Script attempts to
+// rebind or delete it raise a TypeError. The name does not need to exist yet;
+// freezing only guards the binding, not mutation of the bound object.
+func (vm *VM) FreezeGlobal(name string) {
+	if vm.frozenGlobals == nil {
+		vm.frozenGlobals = make(map[string]bool)
+	}
+	vm.frozenGlobals[name] = true
+}
+
+// frozenGlobalError builds the error raised when a script tries to rebind or
+// delete a frozen global. verb is "assign to" or "delete".
+func (vm *VM) frozenGlobalError(verb, name string) error {
+	return fmt.Errorf("TypeError: cannot %s read-only global '%s'", verb, name)
+}
+
+// SetLanguageLevel records the CPython version this VM should report through
+// sys.version_info, so scripts that branch on the interpreter version behave
+// predictably. It does not restrict which syntax the compiler accepts.
+func (vm *VM) SetLanguageLevel(major, minor int) {
+	vm.langMajor = major
+	vm.langMinor = minor
+}
+
+// OverrideModule installs a module that shadows the shared module cache for
+// this VM only. Imports of name in this VM return mod; other VMs are
+// unaffected.
+func (vm *VM) OverrideModule(name string, mod *PyModule) {
+	if vm.moduleOverrides == nil {
+		vm.moduleOverrides = make(map[string]*PyModule)
+	}
+	vm.moduleOverrides[name] = mod
+}
+
+// SetImportPolicy installs a host callback consulted before every import in
+// this VM, including dynamic __import__ calls. The policy sees each module
+// name being imported (for dotted imports, each partial name such as "pkg"
+// then "pkg.sub"); returning a non-nil error blocks the import with an
+// ImportError. A nil policy allows everything.
+func (vm *VM) SetImportPolicy(policy func(name string) error) {
+	vm.importPolicy = policy
+}
+
+// OnClose registers a hook to run when the host closes this VM. Hooks run in
+// last-in-first-out order, mirroring atexit semantics.
+func (vm *VM) OnClose(fn func()) {
+	vm.closeHooks = append(vm.closeHooks, fn)
+}
+
+// RunCloseHooks runs the hooks registered with OnClose in LIFO order and
+// clears them, so calling it twice is harmless.
+func (vm *VM) RunCloseHooks() {
+	hooks := vm.closeHooks
+	vm.closeHooks = nil
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+}
+
+// LanguageLevel returns the version set by SetLanguageLevel, or (0, 0) when
+// the default version should be reported.
+func (vm *VM) LanguageLevel() (major, minor int) {
+	return vm.langMajor, vm.langMinor
+}
+
 // SetBuiltin registers a builtin function
 func (vm *VM) SetBuiltin(name string, v Value) {
 	vm.builtins[name] = v
 }
 
+// RemoveBuiltin removes a builtin function by name.
+// Used to revoke opt-in builtins (e.g. eval/exec) from a sandboxed VM.
+func (vm *VM) RemoveBuiltin(name string) {
+	delete(vm.builtins, name)
+}
+
 // GetBuiltin gets a builtin
 func (vm *VM) GetBuiltin(name string) Value {
 	if v, ok := vm.builtins[name]; ok {
@@ -519,6 +674,18 @@ func (vm *VM) NewUserDataWithMeta(v any, typeName string) *PyUserData {
 	return ud
 }
 
+// callMetamethod invokes a metatable metamethod on userdata if one is
+// registered for its type. The second return reports whether the metamethod
+// was found
+func (vm *VM) callMetamethod(ud *PyUserData, name string, args ...Value) (Value, bool, error) {
+	method, err := vm.getAttr(ud, name)
+	if err != nil {
+		return nil, false, nil
+	}
+	result, err := vm.call(method, args, nil)
+	return result, true, err
+}
+
 // =====================================
 // Value Type Helpers
 // =====================================
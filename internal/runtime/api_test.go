@@ -922,8 +922,8 @@ func TestRaiseErrorWithFormat(t *testing.T) {
 
 func TestTypePredicates(t *testing.T) {
 	tests := []struct {
-		name  string
-		value Value
+		name                                                      string
+		value                                                     Value
 		isInt, isFloat, isString, isBool, isList, isDict, isTuple bool
 	}{
 		{"int", MakeInt(1), true, false, false, false, false, false, false},
@@ -163,6 +163,10 @@ func (vm *VM) getAttrList(lst *PyList, name string) (Value, error) {
 				if sortErr != nil {
 					return false
 				}
+				if err := vm.CheckInterrupt(); err != nil {
+					sortErr = err
+					return false
+				}
 				a, b := lst.Items[i], lst.Items[j]
 				if keyFn != nil {
 					var err error
@@ -212,6 +216,45 @@ func (vm *VM) getAttrList(lst *PyList, name string) (Value, error) {
 	return nil, fmt.Errorf("AttributeError: 'list' object has no attribute '%s'", name)
 }
 
+// dictUpdateFrom merges src into d with dict.update semantics: src may be a
+// dict or an iterable of key/value pairs, and existing keys are overwritten.
+// It also backs the |= operator on dicts (PEP 584).
+func (vm *VM) dictUpdateFrom(d *PyDict, src Value) error {
+	if sd, ok := src.(*PyDict); ok {
+		for _, k := range sd.Keys(vm) {
+			if v, ok := sd.DictGet(k, vm); ok {
+				if _, exists := d.DictGet(k, vm); !exists {
+					if err := vm.checkCollectionSize(int64(d.DictLen()), "dict"); err != nil {
+						return err
+					}
+				}
+				d.DictSet(k, v, vm)
+			}
+		}
+		return nil
+	}
+	items, err := vm.toList(src)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		pair, err := vm.toList(item)
+		if err != nil {
+			return err
+		}
+		if len(pair) != 2 {
+			return fmt.Errorf("ValueError: dictionary update sequence element has length %d; 2 is required", len(pair))
+		}
+		if _, exists := d.DictGet(pair[0], vm); !exists {
+			if err := vm.checkCollectionSize(int64(d.DictLen()), "dict"); err != nil {
+				return err
+			}
+		}
+		d.DictSet(pair[0], pair[1], vm)
+	}
+	return nil
+}
+
 // getAttrDict handles attribute access on *PyDict values.
 func (vm *VM) getAttrDict(d *PyDict, name string) (Value, error) {
 	switch name {
@@ -262,38 +305,8 @@ func (vm *VM) getAttrDict(d *PyDict, name string) (Value, error) {
 	case "update":
 		return &PyBuiltinFunc{Name: "dict.update", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
 			if len(args) > 0 {
-				switch src := args[0].(type) {
-				case *PyDict:
-					for _, k := range src.Keys(vm) {
-						if v, ok := src.DictGet(k, vm); ok {
-							if _, exists := d.DictGet(k, vm); !exists {
-								if err := vm.checkCollectionSize(int64(d.DictLen()), "dict"); err != nil {
-									return nil, err
-								}
-							}
-							d.DictSet(k, v, vm)
-						}
-					}
-				default:
-					items, err := vm.toList(args[0])
-					if err != nil {
-						return nil, err
-					}
-					for _, item := range items {
-						pair, err := vm.toList(item)
-						if err != nil {
-							return nil, err
-						}
-						if len(pair) != 2 {
-							return nil, fmt.Errorf("ValueError: dictionary update sequence element has length %d; 2 is required", len(pair))
-						}
-						if _, exists := d.DictGet(pair[0], vm); !exists {
-							if err := vm.checkCollectionSize(int64(d.DictLen()), "dict"); err != nil {
-								return nil, err
-							}
-						}
-						d.DictSet(pair[0], pair[1], vm)
-					}
+				if err := vm.dictUpdateFrom(d, args[0]); err != nil {
+					return nil, err
 				}
 			}
 			for k, v := range kwargs {
@@ -398,6 +411,29 @@ func (vm *VM) getAttrDict(d *PyDict, name string) (Value, error) {
 }
 
 // getAttrSet handles attribute access on *PySet values.
+// membershipSet converts a set-operation argument into a hash-backed set once,
+// so membership checks are O(1) lookups instead of linear scans with vm.equal.
+// Sets and frozensets are used as-is; other iterables are copied into a
+// temporary set.
+func (vm *VM) membershipSet(arg Value) (*PySet, error) {
+	switch v := arg.(type) {
+	case *PySet:
+		return v, nil
+	case *PyFrozenSet:
+		// Wrap the frozenset's storage; callers only read from the result
+		return &PySet{Items: v.Items, buckets: v.buckets, size: v.size}, nil
+	}
+	items, err := vm.toList(arg)
+	if err != nil {
+		return nil, err
+	}
+	tmp := &PySet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
+	for _, item := range items {
+		tmp.SetAdd(item, vm)
+	}
+	return tmp, nil
+}
+
 func (vm *VM) getAttrSet(s *PySet, name string) (Value, error) {
 	switch name {
 	case "add":
@@ -509,21 +545,18 @@ func (vm *VM) getAttrSet(s *PySet, name string) (Value, error) {
 				}
 				return result, nil
 			}
+			others := make([]*PySet, len(args))
+			for i, arg := range args {
+				other, err := vm.membershipSet(arg)
+				if err != nil {
+					return nil, err
+				}
+				others[i] = other
+			}
 			for k := range s.Items {
 				inAll := true
-				for _, arg := range args {
-					items, err := vm.toList(arg)
-					if err != nil {
-						return nil, err
-					}
-					found := false
-					for _, item := range items {
-						if vm.equal(k, item) {
-							found = true
-							break
-						}
-					}
-					if !found {
+				for _, other := range others {
+					if !other.SetContains(k, vm) {
 						inAll = false
 						break
 					}
@@ -556,24 +589,17 @@ func (vm *VM) getAttrSet(s *PySet, name string) (Value, error) {
 			if len(args) != 1 {
 				return nil, fmt.Errorf("symmetric_difference() takes exactly 1 argument")
 			}
-			other, err := vm.toList(args[0])
+			other, err := vm.membershipSet(args[0])
 			if err != nil {
 				return nil, err
 			}
 			result := &PySet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
 			for k := range s.Items {
-				found := false
-				for _, item := range other {
-					if vm.equal(k, item) {
-						found = true
-						break
-					}
-				}
-				if !found {
+				if !other.SetContains(k, vm) {
 					result.SetAdd(k, vm)
 				}
 			}
-			for _, item := range other {
+			for item := range other.Items {
 				if !s.SetContains(item, vm) {
 					result.SetAdd(item, vm)
 				}
@@ -585,19 +611,12 @@ func (vm *VM) getAttrSet(s *PySet, name string) (Value, error) {
 			if len(args) != 1 {
 				return nil, fmt.Errorf("issubset() takes exactly 1 argument")
 			}
-			other, err := vm.toList(args[0])
+			other, err := vm.membershipSet(args[0])
 			if err != nil {
 				return nil, err
 			}
 			for k := range s.Items {
-				found := false
-				for _, item := range other {
-					if vm.equal(k, item) {
-						found = true
-						break
-					}
-				}
-				if !found {
+				if !other.SetContains(k, vm) {
 					return False, nil
 				}
 			}
@@ -654,22 +673,19 @@ func (vm *VM) getAttrSet(s *PySet, name string) (Value, error) {
 				return None, nil
 			}
 			// Collect all items to keep: those in self AND in all args
+			others := make([]*PySet, len(args))
+			for i, arg := range args {
+				other, err := vm.membershipSet(arg)
+				if err != nil {
+					return nil, err
+				}
+				others[i] = other
+			}
 			keep := &PySet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
 			for k := range s.Items {
 				inAll := true
-				for _, arg := range args {
-					items, err := vm.toList(arg)
-					if err != nil {
-						return nil, err
-					}
-					found := false
-					for _, item := range items {
-						if vm.equal(k, item) {
-							found = true
-							break
-						}
-					}
-					if !found {
+				for _, other := range others {
+					if !other.SetContains(k, vm) {
 						inAll = false
 						break
 					}
@@ -689,7 +705,7 @@ func (vm *VM) getAttrSet(s *PySet, name string) (Value, error) {
 			if len(args) != 1 {
 				return nil, fmt.Errorf("symmetric_difference_update() takes exactly 1 argument")
 			}
-			other, err := vm.toList(args[0])
+			other, err := vm.membershipSet(args[0])
 			if err != nil {
 				return nil, err
 			}
@@ -699,14 +715,11 @@ func (vm *VM) getAttrSet(s *PySet, name string) (Value, error) {
 			toRemove := []Value{}
 			toAdd := []Value{}
 			for k := range s.Items {
-				for _, item := range other {
-					if vm.equal(k, item) {
-						toRemove = append(toRemove, k)
-						break
-					}
+				if other.SetContains(k, vm) {
+					toRemove = append(toRemove, k)
 				}
 			}
-			for _, item := range other {
+			for item := range other.Items {
 				if !s.SetContains(item, vm) {
 					toAdd = append(toAdd, item)
 				}
@@ -765,21 +778,18 @@ func (vm *VM) getAttrFrozenSet(fs *PyFrozenSet, name string) (Value, error) {
 				return result, nil
 			}
 			// Start with items from this frozenset that are in all other sets
+			others := make([]*PySet, len(args))
+			for i, arg := range args {
+				other, err := vm.membershipSet(arg)
+				if err != nil {
+					return nil, err
+				}
+				others[i] = other
+			}
 			for k := range fs.Items {
 				inAll := true
-				for _, arg := range args {
-					items, err := vm.toList(arg)
-					if err != nil {
-						return nil, err
-					}
-					found := false
-					for _, item := range items {
-						if vm.equal(k, item) {
-							found = true
-							break
-						}
-					}
-					if !found {
+				for _, other := range others {
+					if !other.SetContains(k, vm) {
 						inAll = false
 						break
 					}
@@ -793,36 +803,26 @@ func (vm *VM) getAttrFrozenSet(fs *PyFrozenSet, name string) (Value, error) {
 	}
 	if name == "difference" {
 		return &PyBuiltinFunc{Name: "frozenset.difference", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
-			result := &PyFrozenSet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
-			for k := range fs.Items {
-				result.FrozenSetAdd(k, vm)
-			}
-			for _, arg := range args {
-				items, err := vm.toList(arg)
+			others := make([]*PySet, len(args))
+			for i, arg := range args {
+				other, err := vm.membershipSet(arg)
 				if err != nil {
 					return nil, err
 				}
-				for _, item := range items {
-					// Remove if present
-					for rk := range result.Items {
-						if vm.equal(rk, item) {
-							delete(result.Items, rk)
-							// Also remove from buckets
-							if result.buckets != nil {
-								h := hashValue(rk)
-								entries := result.buckets[h]
-								for i, e := range entries {
-									if vm.equal(e.value, rk) {
-										result.buckets[h] = append(entries[:i], entries[i+1:]...)
-										result.size--
-										break
-									}
-								}
-							}
-							break
-						}
+				others[i] = other
+			}
+			result := &PyFrozenSet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
+			for k := range fs.Items {
+				inAny := false
+				for _, other := range others {
+					if other.SetContains(k, vm) {
+						inAny = true
+						break
 					}
 				}
+				if !inAny {
+					result.FrozenSetAdd(k, vm)
+				}
 			}
 			return result, nil
 		}}, nil
@@ -832,26 +832,19 @@ func (vm *VM) getAttrFrozenSet(fs *PyFrozenSet, name string) (Value, error) {
 			if len(args) != 1 {
 				return nil, fmt.Errorf("symmetric_difference() takes exactly 1 argument")
 			}
-			other, err := vm.toList(args[0])
+			other, err := vm.membershipSet(args[0])
 			if err != nil {
 				return nil, err
 			}
 			result := &PyFrozenSet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
 			// Add items from fs that are not in other
 			for k := range fs.Items {
-				found := false
-				for _, item := range other {
-					if vm.equal(k, item) {
-						found = true
-						break
-					}
-				}
-				if !found {
+				if !other.SetContains(k, vm) {
 					result.FrozenSetAdd(k, vm)
 				}
 			}
 			// Add items from other that are not in fs
-			for _, item := range other {
+			for item := range other.Items {
 				if !isHashable(item) {
 					return nil, fmt.Errorf("TypeError: unhashable type: '%s'", vm.typeName(item))
 				}
@@ -867,19 +860,12 @@ func (vm *VM) getAttrFrozenSet(fs *PyFrozenSet, name string) (Value, error) {
 			if len(args) != 1 {
 				return nil, fmt.Errorf("issubset() takes exactly 1 argument")
 			}
-			other, err := vm.toList(args[0])
+			other, err := vm.membershipSet(args[0])
 			if err != nil {
 				return nil, err
 			}
 			for k := range fs.Items {
-				found := false
-				for _, item := range other {
-					if vm.equal(k, item) {
-						found = true
-						break
-					}
-				}
-				if !found {
+				if !other.SetContains(k, vm) {
 					return False, nil
 				}
 			}
@@ -68,6 +68,59 @@ func (vm *VM) getAttrGenerator(gen *PyGenerator, name string) (Value, error) {
 	return nil, fmt.Errorf("AttributeError: 'generator' object has no attribute '%s'", name)
 }
 
+// getAttrAsyncGenerator handles attribute access on *PyAsyncGenerator values.
+// The async-for protocol drives these: __aiter__ returns the generator and
+// each awaited __anext__ advances it to the next yield, raising
+// StopAsyncIteration on exhaustion.
+func (vm *VM) getAttrAsyncGenerator(agen *PyAsyncGenerator, name string) (Value, error) {
+	advance := func(sendVal Value) (Value, error) {
+		val, done, err := vm.GeneratorSend(agen.Gen, sendVal)
+		if err != nil {
+			// The wrapped generator signals exhaustion with StopIteration,
+			// which the async protocol spells StopAsyncIteration
+			if pyExc, ok := err.(*PyException); ok && pyExc.Type() == "StopIteration" {
+				done = true
+			} else {
+				return nil, err
+			}
+		}
+		if done {
+			return nil, &PyException{
+				ExcType:  vm.builtinClass("StopAsyncIteration"),
+				Message:  "",
+				TypeName: "StopAsyncIteration",
+			}
+		}
+		return &PyResolvedAwaitable{Value: val}, nil
+	}
+	switch name {
+	case "__aiter__":
+		return &PyBuiltinFunc{Name: "async_generator.__aiter__", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			return agen, nil
+		}}, nil
+	case "__anext__":
+		return &PyBuiltinFunc{Name: "async_generator.__anext__", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			return advance(None)
+		}}, nil
+	case "asend":
+		return &PyBuiltinFunc{Name: "async_generator.asend", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			var sendVal Value = None
+			if len(args) > 0 {
+				sendVal = args[0]
+			}
+			return advance(sendVal)
+		}}, nil
+	case "aclose":
+		return &PyBuiltinFunc{Name: "async_generator.aclose", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := vm.GeneratorClose(agen.Gen); err != nil {
+				return nil, err
+			}
+			return &PyResolvedAwaitable{Value: None}, nil
+		}}, nil
+	}
+	return nil, fmt.Errorf("AttributeError: 'async_generator' object has no attribute '%s'", name)
+}
+
 // getAttrCoroutine handles attribute access on *PyCoroutine values.
 func (vm *VM) getAttrCoroutine(coro *PyCoroutine, name string) (Value, error) {
 	switch name {
@@ -177,6 +230,13 @@ func (vm *VM) getAttrException(o *PyException, name string) (Value, error) {
 			},
 		}, nil
 	}
+	// StopIteration.value carries a generator's return value (first arg)
+	if name == "value" && o.Type() == "StopIteration" {
+		if o.Args != nil && len(o.Args.Items) > 0 {
+			return o.Args.Items[0], nil
+		}
+		return None, nil
+	}
 	// Delegate to Instance for ExceptionGroup attributes (message, exceptions, subgroup, etc.)
 	if o.Instance != nil {
 		return vm.getAttr(o.Instance, name)
@@ -470,6 +530,10 @@ func (vm *VM) getAttrClass(o *PyClass, name string) (Value, error) {
 			return val, nil
 		}
 	}
+	// Every class has __doc__; it is None unless a docstring was defined
+	if name == "__doc__" {
+		return None, nil
+	}
 	return nil, fmt.Errorf("type object '%s' has no attribute '%s'", o.Name, name)
 }
 
@@ -479,9 +543,25 @@ func (vm *VM) getAttrFunction(o *PyFunction, name string) (Value, error) {
 	case "__name__":
 		return &PyString{Value: o.Name}, nil
 	case "__doc__":
+		// A __doc__ set on the function (e.g. by functools.wraps) wins over
+		// the compiled-in docstring
+		if o.Dict != nil {
+			if val, ok := o.Dict["__doc__"]; ok {
+				return val, nil
+			}
+		}
+		if o.Code != nil && o.Code.Doc != "" {
+			return &PyString{Value: o.Code.Doc}, nil
+		}
 		return None, nil
 	case "__isabstractmethod__":
 		return &PyBool{Value: o.IsAbstract}, nil
+	case "__annotations__":
+		// Created lazily so unannotated functions still expose an empty dict
+		if o.Annotations == nil {
+			o.Annotations = &PyDict{Items: make(map[Value]Value)}
+		}
+		return o.Annotations, nil
 	case "__wrapped__":
 		// Check if we have __wrapped__ stored in closure
 		if o.Closure != nil {
@@ -540,6 +620,24 @@ func (vm *VM) getAttrBuiltinFunc(o *PyBuiltinFunc, name string) (Value, error) {
 			return floatFromHexImpl(args)
 		}}, nil
 	}
+	if o.Name == "bytes" && name == "fromhex" {
+		return &PyBuiltinFunc{Name: "bytes.fromhex", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			data, err := bytesFromHexImpl(args)
+			if err != nil {
+				return nil, err
+			}
+			return &PyBytes{Value: data}, nil
+		}}, nil
+	}
+	if o.Name == "bytearray" && name == "fromhex" {
+		return &PyBuiltinFunc{Name: "bytearray.fromhex", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			data, err := bytesFromHexImpl(args)
+			if err != nil {
+				return nil, err
+			}
+			return &PyByteArray{Value: data}, nil
+		}}, nil
+	}
 	if o.Name == "int" && name == "from_bytes" {
 		return &PyBuiltinFunc{Name: "int.from_bytes", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
 			return intFromBytesImpl(vm, args, kwargs)
@@ -920,6 +1018,14 @@ func (vm *VM) getAttrBytes(b *PyBytes, name string) (Value, error) {
 			}
 			return False, nil
 		}}, nil
+	case "fromhex":
+		return &PyBuiltinFunc{Name: "bytes.fromhex", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			data, err := bytesFromHexImpl(args)
+			if err != nil {
+				return nil, err
+			}
+			return &PyBytes{Value: data}, nil
+		}}, nil
 	case "hex":
 		return &PyBuiltinFunc{Name: "bytes.hex", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
 			sep := ""
@@ -1500,6 +1606,189 @@ func (vm *VM) getAttrBytes(b *PyBytes, name string) (Value, error) {
 	return nil, fmt.Errorf("AttributeError: 'bytes' object has no attribute '%s'", name)
 }
 
+// getAttrByteArray handles attribute access on *PyByteArray values. The
+// mutable-sequence methods work on the bytearray in place; everything else
+// is delegated to the bytes implementation, with bytes results converted
+// back to bytearray so the receiver's type is preserved.
+func (vm *VM) getAttrByteArray(b *PyByteArray, name string) (Value, error) {
+	toByte := func(v Value) (byte, error) {
+		iv, ok := v.(*PyInt)
+		if !ok {
+			return 0, fmt.Errorf("TypeError: '%s' object cannot be interpreted as an integer", vm.typeName(v))
+		}
+		if iv.Value < 0 || iv.Value > 255 {
+			return 0, fmt.Errorf("ValueError: byte must be in range(0, 256)")
+		}
+		return byte(iv.Value), nil
+	}
+	switch name {
+	case "append":
+		return &PyBuiltinFunc{Name: "bytearray.append", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("append() takes exactly 1 argument")
+			}
+			c, err := toByte(args[0])
+			if err != nil {
+				return nil, err
+			}
+			b.Value = append(b.Value, c)
+			return None, nil
+		}}, nil
+	case "extend":
+		return &PyBuiltinFunc{Name: "bytearray.extend", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("extend() takes exactly 1 argument")
+			}
+			if data, ok := byteSliceOf(args[0]); ok {
+				b.Value = append(b.Value, data...)
+				return None, nil
+			}
+			iter, err := vm.getIter(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("TypeError: cannot convert '%s' object to bytearray", vm.typeName(args[0]))
+			}
+			for {
+				v, done, err := vm.iterNext(iter)
+				if err != nil {
+					return nil, err
+				}
+				if done {
+					break
+				}
+				c, err := toByte(v)
+				if err != nil {
+					return nil, err
+				}
+				b.Value = append(b.Value, c)
+			}
+			return None, nil
+		}}, nil
+	case "insert":
+		return &PyBuiltinFunc{Name: "bytearray.insert", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("insert() takes exactly 2 arguments")
+			}
+			c, err := toByte(args[1])
+			if err != nil {
+				return nil, err
+			}
+			idx := int(vm.toInt(args[0]))
+			if idx < 0 {
+				idx += len(b.Value)
+				if idx < 0 {
+					idx = 0
+				}
+			}
+			if idx >= len(b.Value) {
+				b.Value = append(b.Value, c)
+			} else {
+				b.Value = append(b.Value, 0)
+				copy(b.Value[idx+1:], b.Value[idx:])
+				b.Value[idx] = c
+			}
+			return None, nil
+		}}, nil
+	case "pop":
+		return &PyBuiltinFunc{Name: "bytearray.pop", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if len(b.Value) == 0 {
+				return nil, fmt.Errorf("IndexError: pop from empty bytearray")
+			}
+			idx := len(b.Value) - 1
+			if len(args) > 0 {
+				idx = int(vm.toInt(args[0]))
+				if idx < 0 {
+					idx += len(b.Value)
+				}
+			}
+			if idx < 0 || idx >= len(b.Value) {
+				return nil, fmt.Errorf("IndexError: pop index out of range")
+			}
+			c := b.Value[idx]
+			b.Value = append(b.Value[:idx], b.Value[idx+1:]...)
+			return MakeInt(int64(c)), nil
+		}}, nil
+	case "remove":
+		return &PyBuiltinFunc{Name: "bytearray.remove", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("remove() takes exactly 1 argument")
+			}
+			c, err := toByte(args[0])
+			if err != nil {
+				return nil, err
+			}
+			for i, v := range b.Value {
+				if v == c {
+					b.Value = append(b.Value[:i], b.Value[i+1:]...)
+					return None, nil
+				}
+			}
+			return nil, fmt.Errorf("ValueError: value not found in bytearray")
+		}}, nil
+	case "clear":
+		return &PyBuiltinFunc{Name: "bytearray.clear", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			b.Value = []byte{}
+			return None, nil
+		}}, nil
+	case "reverse":
+		return &PyBuiltinFunc{Name: "bytearray.reverse", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			for i, j := 0, len(b.Value)-1; i < j; i, j = i+1, j-1 {
+				b.Value[i], b.Value[j] = b.Value[j], b.Value[i]
+			}
+			return None, nil
+		}}, nil
+	case "copy":
+		return &PyBuiltinFunc{Name: "bytearray.copy", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			result := make([]byte, len(b.Value))
+			copy(result, b.Value)
+			return &PyByteArray{Value: result}, nil
+		}}, nil
+	}
+
+	// Delegate read-only methods (decode, find, hex, split, startswith, ...)
+	// to the bytes implementation. The probe validates the name; the wrapper
+	// re-resolves at call time so mutations between lookup and call are seen.
+	if _, err := vm.getAttrBytes(&PyBytes{}, name); err != nil {
+		return nil, fmt.Errorf("AttributeError: 'bytearray' object has no attribute '%s'", name)
+	}
+	return &PyBuiltinFunc{Name: "bytearray." + name, Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+		attr, err := vm.getAttrBytes(&PyBytes{Value: b.Value}, name)
+		if err != nil {
+			return nil, err
+		}
+		fn, ok := attr.(*PyBuiltinFunc)
+		if !ok {
+			return attr, nil
+		}
+		result, err := fn.Fn(args, kwargs)
+		if err != nil {
+			return nil, err
+		}
+		return byteArrayResult(result), nil
+	}}, nil
+}
+
+// byteArrayResult converts bytes values produced by a delegated bytes method
+// back to bytearray, including elements of a returned list or tuple.
+func byteArrayResult(v Value) Value {
+	switch r := v.(type) {
+	case *PyBytes:
+		return &PyByteArray{Value: r.Value}
+	case *PyList:
+		for i, item := range r.Items {
+			if bv, ok := item.(*PyBytes); ok {
+				r.Items[i] = &PyByteArray{Value: bv.Value}
+			}
+		}
+	case *PyTuple:
+		for i, item := range r.Items {
+			if bv, ok := item.(*PyBytes); ok {
+				r.Items[i] = &PyByteArray{Value: bv.Value}
+			}
+		}
+	}
+	return v
+}
+
 // bytesMaketransImpl implements bytes.maketrans(from, to).
 func bytesMaketransImpl(args []Value) (Value, error) {
 	if len(args) != 2 {
@@ -1523,6 +1812,51 @@ func bytesMaketransImpl(args []Value) (Value, error) {
 	return &PyBytes{Value: table}, nil
 }
 
+// bytesFromHexImpl implements bytes.fromhex(string) / bytearray.fromhex(string).
+// ASCII spaces may separate the two-character hex pairs
+func bytesFromHexImpl(args []Value) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("TypeError: fromhex() takes exactly one argument (%d given)", len(args))
+	}
+	s, ok := args[0].(*PyString)
+	if !ok {
+		return nil, fmt.Errorf("TypeError: fromhex() argument must be str")
+	}
+	hexDigit := func(c byte) (byte, bool) {
+		switch {
+		case c >= '0' && c <= '9':
+			return c - '0', true
+		case c >= 'a' && c <= 'f':
+			return c - 'a' + 10, true
+		case c >= 'A' && c <= 'F':
+			return c - 'A' + 10, true
+		}
+		return 0, false
+	}
+	str := s.Value
+	result := make([]byte, 0, len(str)/2)
+	for i := 0; i < len(str); {
+		if str[i] == ' ' {
+			i++
+			continue
+		}
+		hi, ok := hexDigit(str[i])
+		if !ok {
+			return nil, fmt.Errorf("ValueError: non-hexadecimal number found in fromhex() arg at position %d", i)
+		}
+		if i+1 >= len(str) {
+			return nil, fmt.Errorf("ValueError: non-hexadecimal number found in fromhex() arg at position %d", i+1)
+		}
+		lo, ok := hexDigit(str[i+1])
+		if !ok {
+			return nil, fmt.Errorf("ValueError: non-hexadecimal number found in fromhex() arg at position %d", i+1)
+		}
+		result = append(result, hi<<4|lo)
+		i += 2
+	}
+	return result, nil
+}
+
 // --- Bytes helper functions ---
 
 // bytesIndex finds the first occurrence of sub in data, returns -1 if not found
@@ -1,27 +1,36 @@
 package runtime
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"unicode"
 )
 
-// getAttrString handles attribute access on *PyString values.
-func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
-	switch name {
-	case "upper":
-		return &PyBuiltinFunc{Name: "str.upper", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
-			return &PyString{Value: strings.ToUpper(str.Value)}, nil
-		}}, nil
-	case "lower":
-		return &PyBuiltinFunc{Name: "str.lower", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
-			return &PyString{Value: strings.ToLower(str.Value)}, nil
-		}}, nil
-	case "split":
-		return &PyBuiltinFunc{Name: "str.split", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+// stringMethodFunc implements a str method against an explicit receiver.
+// Methods in stringMethods are shared across all strings instead of being
+// captured in a fresh closure on every attribute access.
+type stringMethodFunc func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error)
+
+// stringMethods is the shared table for the hottest str methods. Attribute
+// access on a string consults this table before the general switch in
+// getAttrString; methods not listed here still go through the closure path.
+// Populated in init to avoid an initialization cycle through the VM.
+var stringMethods map[string]stringMethodFunc
+
+func init() {
+	stringMethods = map[string]stringMethodFunc{
+		"upper": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
+			return &PyString{Value: strings.ToUpper(self.Value)}, nil
+		},
+		"lower": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
+			return &PyString{Value: strings.ToLower(self.Value)}, nil
+		},
+		"split": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
 			var strParts []string
 			if len(args) == 0 {
-				strParts = strings.Fields(str.Value)
+				strParts = strings.Fields(self.Value)
 			} else {
 				sep := vm.str(args[0])
 				maxSplit := -1
@@ -29,9 +38,9 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 					maxSplit = int(vm.toInt(args[1]))
 				}
 				if maxSplit < 0 {
-					strParts = strings.Split(str.Value, sep)
+					strParts = strings.Split(self.Value, sep)
 				} else {
-					strParts = strings.SplitN(str.Value, sep, maxSplit+1)
+					strParts = strings.SplitN(self.Value, sep, maxSplit+1)
 				}
 			}
 			parts := make([]Value, len(strParts))
@@ -39,11 +48,10 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 				parts[i] = &PyString{Value: s}
 			}
 			return &PyList{Items: parts}, nil
-		}}, nil
-	case "rsplit":
-		return &PyBuiltinFunc{Name: "str.rsplit", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+		},
+		"rsplit": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
 			if len(args) == 0 {
-				strParts := strings.Fields(str.Value)
+				strParts := strings.Fields(self.Value)
 				parts := make([]Value, len(strParts))
 				for i, s := range strParts {
 					parts[i] = &PyString{Value: s}
@@ -56,7 +64,7 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 				maxSplit = int(vm.toInt(args[1]))
 			}
 			if maxSplit < 0 {
-				strParts := strings.Split(str.Value, sep)
+				strParts := strings.Split(self.Value, sep)
 				parts := make([]Value, len(strParts))
 				for i, s := range strParts {
 					parts[i] = &PyString{Value: s}
@@ -64,7 +72,7 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 				return &PyList{Items: parts}, nil
 			}
 			// rsplit from right
-			s := str.Value
+			s := self.Value
 			var result []string
 			for maxSplit > 0 {
 				idx := strings.LastIndex(s, sep)
@@ -81,9 +89,8 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 				parts[i] = &PyString{Value: p}
 			}
 			return &PyList{Items: parts}, nil
-		}}, nil
-	case "join":
-		return &PyBuiltinFunc{Name: "str.join", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+		},
+		"join": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
 			if len(args) != 1 {
 				return nil, fmt.Errorf("join() takes exactly 1 argument")
 			}
@@ -99,38 +106,34 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 				}
 				parts = append(parts, s.Value)
 			}
-			return &PyString{Value: strings.Join(parts, str.Value)}, nil
-		}}, nil
-	case "strip":
-		return &PyBuiltinFunc{Name: "str.strip", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			return &PyString{Value: strings.Join(parts, self.Value)}, nil
+		},
+		"strip": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
 			if len(args) > 0 {
 				chars := vm.str(args[0])
-				return &PyString{Value: strings.Trim(str.Value, chars)}, nil
+				return &PyString{Value: strings.Trim(self.Value, chars)}, nil
 			}
-			return &PyString{Value: strings.TrimSpace(str.Value)}, nil
-		}}, nil
-	case "lstrip":
-		return &PyBuiltinFunc{Name: "str.lstrip", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			return &PyString{Value: strings.TrimSpace(self.Value)}, nil
+		},
+		"lstrip": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
 			if len(args) > 0 {
 				chars := vm.str(args[0])
-				return &PyString{Value: strings.TrimLeft(str.Value, chars)}, nil
+				return &PyString{Value: strings.TrimLeft(self.Value, chars)}, nil
 			}
-			return &PyString{Value: strings.TrimLeftFunc(str.Value, func(r rune) bool {
+			return &PyString{Value: strings.TrimLeftFunc(self.Value, func(r rune) bool {
 				return r == ' ' || r == '\t' || r == '\n' || r == '\r'
 			})}, nil
-		}}, nil
-	case "rstrip":
-		return &PyBuiltinFunc{Name: "str.rstrip", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+		},
+		"rstrip": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
 			if len(args) > 0 {
 				chars := vm.str(args[0])
-				return &PyString{Value: strings.TrimRight(str.Value, chars)}, nil
+				return &PyString{Value: strings.TrimRight(self.Value, chars)}, nil
 			}
-			return &PyString{Value: strings.TrimRightFunc(str.Value, func(r rune) bool {
+			return &PyString{Value: strings.TrimRightFunc(self.Value, func(r rune) bool {
 				return r == ' ' || r == '\t' || r == '\n' || r == '\r'
 			})}, nil
-		}}, nil
-	case "replace":
-		return &PyBuiltinFunc{Name: "str.replace", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+		},
+		"replace": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
 			if len(args) < 2 {
 				return nil, fmt.Errorf("replace() takes at least 2 arguments")
 			}
@@ -140,15 +143,14 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 			if len(args) > 2 {
 				count = int(vm.toInt(args[2]))
 			}
-			return &PyString{Value: strings.Replace(str.Value, old, newStr, count)}, nil
-		}}, nil
-	case "find":
-		return &PyBuiltinFunc{Name: "str.find", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			return &PyString{Value: strings.Replace(self.Value, old, newStr, count)}, nil
+		},
+		"find": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
 			if len(args) < 1 {
 				return nil, fmt.Errorf("find() takes at least 1 argument")
 			}
 			sub := vm.str(args[0])
-			s := str.Value
+			s := self.Value
 			start := 0
 			if len(args) > 1 {
 				start = int(vm.toInt(args[1]))
@@ -167,7 +169,79 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 				return MakeInt(-1), nil
 			}
 			return MakeInt(int64(start + idx)), nil
-		}}, nil
+		},
+		"startswith": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
+			if len(args) < 1 {
+				return nil, fmt.Errorf("startswith() takes at least 1 argument")
+			}
+			// Handle tuple of prefixes
+			if t, ok := args[0].(*PyTuple); ok {
+				for _, item := range t.Items {
+					prefix := vm.str(item)
+					if strings.HasPrefix(self.Value, prefix) {
+						return True, nil
+					}
+				}
+				return False, nil
+			}
+			prefix := vm.str(args[0])
+			if strings.HasPrefix(self.Value, prefix) {
+				return True, nil
+			}
+			return False, nil
+		},
+		"endswith": func(vm *VM, self *PyString, args []Value, kwargs map[string]Value) (Value, error) {
+			if len(args) < 1 {
+				return nil, fmt.Errorf("endswith() takes at least 1 argument")
+			}
+			if t, ok := args[0].(*PyTuple); ok {
+				for _, item := range t.Items {
+					suffix := vm.str(item)
+					if strings.HasSuffix(self.Value, suffix) {
+						return True, nil
+					}
+				}
+				return False, nil
+			}
+			suffix := vm.str(args[0])
+			if strings.HasSuffix(self.Value, suffix) {
+				return True, nil
+			}
+			return False, nil
+		},
+	}
+}
+
+// stringMethodPool recycles PyStringMethod objects across LOAD_METHOD /
+// CALL_METHOD pairs. The compiler only emits that opcode pair for immediate
+// calls, so the bound object cannot escape and is safe to reuse once the
+// call returns.
+var stringMethodPool = sync.Pool{New: func() any { return new(PyStringMethod) }}
+
+// boundStringMethod draws a bound method object from the pool for a table hit.
+func boundStringMethod(name string, self *PyString, fn stringMethodFunc) *PyStringMethod {
+	m := stringMethodPool.Get().(*PyStringMethod)
+	m.Name = name
+	m.Self = self
+	m.Fn = fn
+	return m
+}
+
+// releaseStringMethod returns a bound method object to the pool.
+func releaseStringMethod(m *PyStringMethod) {
+	m.Self = nil
+	m.Fn = nil
+	stringMethodPool.Put(m)
+}
+
+// getAttrString handles attribute access on *PyString values.
+func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
+	if fn, ok := stringMethods[name]; ok {
+		// Plain attribute access (e.g. f = s.upper) can let the bound object
+		// escape, so allocate here rather than drawing from the pool.
+		return &PyStringMethod{Name: name, Self: str, Fn: fn}, nil
+	}
+	switch name {
 	case "rfind":
 		return &PyBuiltinFunc{Name: "str.rfind", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
 			if len(args) < 1 {
@@ -201,47 +275,6 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 			}
 			return MakeInt(int64(idx)), nil
 		}}, nil
-	case "startswith":
-		return &PyBuiltinFunc{Name: "str.startswith", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
-			if len(args) < 1 {
-				return nil, fmt.Errorf("startswith() takes at least 1 argument")
-			}
-			// Handle tuple of prefixes
-			if t, ok := args[0].(*PyTuple); ok {
-				for _, item := range t.Items {
-					prefix := vm.str(item)
-					if strings.HasPrefix(str.Value, prefix) {
-						return True, nil
-					}
-				}
-				return False, nil
-			}
-			prefix := vm.str(args[0])
-			if strings.HasPrefix(str.Value, prefix) {
-				return True, nil
-			}
-			return False, nil
-		}}, nil
-	case "endswith":
-		return &PyBuiltinFunc{Name: "str.endswith", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
-			if len(args) < 1 {
-				return nil, fmt.Errorf("endswith() takes at least 1 argument")
-			}
-			if t, ok := args[0].(*PyTuple); ok {
-				for _, item := range t.Items {
-					suffix := vm.str(item)
-					if strings.HasSuffix(str.Value, suffix) {
-						return True, nil
-					}
-				}
-				return False, nil
-			}
-			suffix := vm.str(args[0])
-			if strings.HasSuffix(str.Value, suffix) {
-				return True, nil
-			}
-			return False, nil
-		}}, nil
 	case "count":
 		return &PyBuiltinFunc{Name: "str.count", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
 			if len(args) < 1 {
@@ -409,17 +442,18 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 		}}, nil
 	case "title":
 		return &PyBuiltinFunc{Name: "str.title", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
-			return &PyString{Value: strings.Title(str.Value)}, nil
+			return &PyString{Value: strTitle(str.Value)}, nil
 		}}, nil
 	case "swapcase":
 		return &PyBuiltinFunc{Name: "str.swapcase", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
 			var result strings.Builder
 			for _, ch := range str.Value {
-				if ch >= 'a' && ch <= 'z' {
-					result.WriteRune(ch - 32)
-				} else if ch >= 'A' && ch <= 'Z' {
-					result.WriteRune(ch + 32)
-				} else {
+				switch {
+				case unicode.IsUpper(ch) || unicode.IsTitle(ch):
+					result.WriteRune(unicode.ToLower(ch))
+				case unicode.IsLower(ch):
+					result.WriteRune(unicode.ToUpper(ch))
+				default:
 					result.WriteRune(ch)
 				}
 			}
@@ -442,7 +476,7 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 				return False, nil
 			}
 			for _, ch := range s {
-				if !((ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')) {
+				if !unicode.IsLetter(ch) {
 					return False, nil
 				}
 			}
@@ -455,7 +489,7 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 				return False, nil
 			}
 			for _, ch := range s {
-				if ch < '0' || ch > '9' {
+				if !unicode.IsDigit(ch) {
 					return False, nil
 				}
 			}
@@ -468,7 +502,8 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 				return False, nil
 			}
 			for _, ch := range s {
-				if !((ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')) {
+				if !unicode.IsLetter(ch) && !unicode.IsDigit(ch) &&
+					!unicode.Is(unicode.Nl, ch) && !unicode.Is(unicode.No, ch) {
 					return False, nil
 				}
 			}
@@ -481,7 +516,8 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 				return False, nil
 			}
 			for _, ch := range s {
-				if ch != ' ' && ch != '\t' && ch != '\n' && ch != '\r' && ch != '\f' && ch != '\v' {
+				// CPython also treats the ASCII separator controls as space
+				if !unicode.IsSpace(ch) && !(ch >= '\x1c' && ch <= '\x1f') {
 					return False, nil
 				}
 			}
@@ -492,10 +528,10 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 			s := str.Value
 			hasUpper := false
 			for _, ch := range s {
-				if ch >= 'a' && ch <= 'z' {
+				if unicode.IsLower(ch) || unicode.IsTitle(ch) {
 					return False, nil
 				}
-				if ch >= 'A' && ch <= 'Z' {
+				if unicode.IsUpper(ch) {
 					hasUpper = true
 				}
 			}
@@ -509,10 +545,10 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 			s := str.Value
 			hasLower := false
 			for _, ch := range s {
-				if ch >= 'A' && ch <= 'Z' {
+				if unicode.IsUpper(ch) || unicode.IsTitle(ch) {
 					return False, nil
 				}
-				if ch >= 'a' && ch <= 'z' {
+				if unicode.IsLower(ch) {
 					hasLower = true
 				}
 			}
@@ -563,20 +599,17 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 
 	case "casefold":
 		return &PyBuiltinFunc{Name: "str.casefold", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
-			// casefold is like lower() but more aggressive for caseless matching
-			// Go's strings.ToLower handles most Unicode casefolding
-			// For full CPython compatibility we'd need special cases (e.g. ss -> ss)
-			// but strings.ToLower is a good approximation
-			result := []rune{}
+			// casefold is like lower() but uses full case folding, so some
+			// characters expand (see fullCaseFolds)
+			var result strings.Builder
 			for _, r := range str.Value {
-				// Handle special casefold cases
-				if r == '\u00df' || r == '\u1e9e' {
-					result = append(result, 's', 's')
+				if folded, ok := fullCaseFolds[r]; ok {
+					result.WriteString(folded)
 				} else {
-					result = append(result, []rune(strings.ToLower(string(r)))...)
+					result.WriteRune(unicode.ToLower(r))
 				}
 			}
-			return &PyString{Value: string(result)}, nil
+			return &PyString{Value: result.String()}, nil
 		}}, nil
 
 	case "isascii":
@@ -787,28 +820,52 @@ func (vm *VM) getAttrString(str *PyString, name string) (Value, error) {
 			if len(args) != 1 {
 				return nil, fmt.Errorf("TypeError: translate() takes exactly one argument (%d given)", len(args))
 			}
-			table, ok := args[0].(*PyDict)
-			if !ok {
-				return nil, fmt.Errorf("TypeError: translate() argument must be a dict")
+
+			// A dict table is looked up directly; any other table just needs
+			// __getitem__, with the LookupError family meaning "no mapping"
+			var lookup func(r rune) (Value, bool, error)
+			if table, ok := args[0].(*PyDict); ok {
+				lookup = func(r rune) (Value, bool, error) {
+					val, found := table.DictGet(MakeInt(int64(r)), vm)
+					return val, found, nil
+				}
+			} else {
+				getitem, err := vm.GetAttr(args[0], "__getitem__")
+				if err != nil {
+					return nil, fmt.Errorf("TypeError: translate() argument must be subscriptable")
+				}
+				lookup = func(r rune) (Value, bool, error) {
+					val, err := vm.Call(getitem, []Value{MakeInt(int64(r))}, nil)
+					if err != nil {
+						if translateLookupMiss(err) {
+							vm.currentException = nil // Clear so it doesn't propagate
+							return nil, false, nil
+						}
+						return nil, false, err
+					}
+					return val, true, nil
+				}
 			}
+
 			var result strings.Builder
 			for _, r := range str.Value {
-				key := MakeInt(int64(r))
-				if val, found := table.DictGet(key, vm); found {
-					if val == None {
-						// Delete the character
-						continue
-					}
-					switch v := val.(type) {
-					case *PyString:
-						result.WriteString(v.Value)
-					case *PyInt:
-						result.WriteRune(rune(v.Value))
-					default:
-						result.WriteRune(r)
-					}
-				} else {
+				val, found, err := lookup(r)
+				if err != nil {
+					return nil, err
+				}
+				if !found {
 					result.WriteRune(r)
+					continue
+				}
+				switch v := val.(type) {
+				case *PyNone:
+					// Delete the character
+				case *PyString:
+					result.WriteString(v.Value)
+				case *PyInt:
+					result.WriteRune(rune(v.Value))
+				default:
+					return nil, fmt.Errorf("TypeError: character mapping must return integer, None or str")
 				}
 			}
 			return &PyString{Value: result.String()}, nil
@@ -885,3 +942,63 @@ func strMaketransImpl(vm *VM, args []Value) (Value, error) {
 	}
 	return result, nil
 }
+
+// strTitle implements str.title with CPython's algorithm: the first cased
+// character after an uncased one is titlecased and every other cased
+// character is lowercased, so "they're" becomes "They'Re". strings.Title is
+// both deprecated and wrong here — it never lowercases the rest of a word.
+func strTitle(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	prevCased := false
+	for _, r := range s {
+		if prevCased {
+			r = unicode.ToLower(r)
+		} else {
+			r = unicode.ToTitle(r)
+		}
+		prevCased = unicode.IsUpper(r) || unicode.IsLower(r) || unicode.IsTitle(r)
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// fullCaseFolds holds the full (multi-character) case foldings that
+// unicode.ToLower cannot express, taken from Unicode's CaseFolding.txt.
+// casefold falls back to simple lowercasing for everything else.
+var fullCaseFolds = map[rune]string{
+	'\u00df': "ss",           // ß
+	'\u1e9e': "ss",           // ẞ
+	'\u0130': "i\u0307",      // İ -> i + combining dot above
+	'\u0149': "\u02bcn",      // ŉ
+	'\u03c2': "\u03c3",       // ς -> σ
+	'\u0587': "\u0565\u0582", // և
+	'\u1e96': "h\u0331",      // ẖ
+	'\u1e97': "t\u0308",      // ẗ
+	'\u1e98': "w\u030a",      // ẘ
+	'\u1e99': "y\u030a",      // ẙ
+	'\u1e9a': "a\u02be",      // ẚ
+	'\u1f50': "\u03c5\u0313", // ὐ
+	'\ufb00': "ff",
+	'\ufb01': "fi",
+	'\ufb02': "fl",
+	'\ufb03': "ffi",
+	'\ufb04': "ffl",
+	'\ufb05': "st", // ﬅ
+	'\ufb06': "st", // ﬆ
+}
+
+// translateLookupMiss reports whether err is from the LookupError family,
+// which str.translate treats as "no mapping for this character" rather
+// than a failure.
+func translateLookupMiss(err error) bool {
+	var exc *PyException
+	if errors.As(err, &exc) {
+		switch exc.TypeName {
+		case "KeyError", "IndexError", "LookupError":
+			return true
+		}
+	}
+	msg := err.Error()
+	return strings.HasPrefix(msg, "KeyError") || strings.HasPrefix(msg, "IndexError") || strings.HasPrefix(msg, "LookupError")
+}
@@ -63,13 +63,17 @@ func (vm *VM) defaultGetAttribute(o *PyInstance, name string) (Value, error) {
 		return d, nil
 	}
 
-	// Then check instance dict or slots
+	// Then check instance slots and dict (both exist when '__dict__' is in
+	// __slots__ or a base class is not fully slotted)
 	if o.Slots != nil {
 		if val, ok := o.Slots[name]; ok {
 			return val, nil
 		}
-	} else if val, ok := o.Dict[name]; ok {
-		return val, nil
+	}
+	if o.Dict != nil {
+		if val, ok := o.Dict[name]; ok {
+			return val, nil
+		}
 	}
 
 	// Then check class MRO for methods/attributes (non-data descriptors)
@@ -260,10 +264,18 @@ func (vm *VM) getAttr(obj Value, name string) (Value, error) {
 	switch o := obj.(type) {
 	case *PyGenerator:
 		return vm.getAttrGenerator(o, name)
+	case *PyAsyncGenerator:
+		return vm.getAttrAsyncGenerator(o, name)
 	case *PyCoroutine:
 		return vm.getAttrCoroutine(o, name)
 	case *PyComplex:
 		return vm.getAttrComplex(o, name)
+	case *PyVec2:
+		return vm.getAttrVec2(o, name)
+	case *PyVec3:
+		return vm.getAttrVec3(o, name)
+	case *PyMat3:
+		return vm.getAttrMat3(o, name)
 	case *PyException:
 		return vm.getAttrException(o, name)
 	case *PyModule:
@@ -388,12 +400,39 @@ func (vm *VM) getAttr(obj Value, name string) (Value, error) {
 		return vm.getAttrString(o, name)
 	case *PyBytes:
 		return vm.getAttrBytes(o, name)
+	case *PyByteArray:
+		return vm.getAttrByteArray(o, name)
 	case *PyRange:
 		return vm.getAttrRange(o, name)
 	case *PyFunction:
 		return vm.getAttrFunction(o, name)
+	case *PyMethod:
+		switch name {
+		case "__func__":
+			return o.Func, nil
+		case "__self__":
+			return o.Instance, nil
+		}
+		// Everything else (__name__, __doc__, ...) comes from the wrapped function
+		return vm.getAttrFunction(o.Func, name)
 	case *PyBuiltinFunc:
 		return vm.getAttrBuiltinFunc(o, name)
+	case *PySlice:
+		// start/stop/step are None when omitted (e.g. s[::2] has start=None)
+		sliceComponent := func(v Value) Value {
+			if v == nil {
+				return None
+			}
+			return v
+		}
+		switch name {
+		case "start":
+			return sliceComponent(o.Start), nil
+		case "stop":
+			return sliceComponent(o.Stop), nil
+		case "step":
+			return sliceComponent(o.Step), nil
+		}
 	}
 
 	return nil, fmt.Errorf("AttributeError: '%s' object has no attribute '%s'", vm.typeName(obj), name)
@@ -433,14 +472,13 @@ func (vm *VM) setAttr(obj Value, name string, val Value) error {
 				break // Found in class dict but not a descriptor, fall through to instance assignment
 			}
 		}
-		// Not a descriptor, set on instance dict or slots
-		if o.Slots != nil {
-			if !isValidSlot(o.Class, name) {
-				return fmt.Errorf("AttributeError: '%s' object has no attribute '%s'", o.Class.Name, name)
-			}
+		// Not a descriptor, set on instance slots or dict
+		if o.Slots != nil && isValidSlot(o.Class, name) {
 			o.Slots[name] = val
-		} else {
+		} else if o.Dict != nil {
 			o.Dict[name] = val
+		} else {
+			return fmt.Errorf("AttributeError: '%s' object has no attribute '%s'", o.Class.Name, name)
 		}
 		return nil
 	case *PyClass:
@@ -524,20 +562,14 @@ func (vm *VM) delAttr(obj Value, name string) error {
 				break
 			}
 		}
-		if o.Slots != nil {
-			val, exists := o.Slots[name]
-			if !exists {
-				return fmt.Errorf("AttributeError: '%s' object has no attribute '%s'", o.Class.Name, name)
-			}
+		if val, exists := o.Slots[name]; exists {
 			vm.callDel(val)
 			delete(o.Slots, name)
-		} else {
-			val, exists := o.Dict[name]
-			if !exists {
-				return fmt.Errorf("AttributeError: '%s' object has no attribute '%s'", o.Class.Name, name)
-			}
+		} else if val, exists := o.Dict[name]; exists {
 			vm.callDel(val)
 			delete(o.Dict, name)
+		} else {
+			return fmt.Errorf("AttributeError: '%s' object has no attribute '%s'", o.Class.Name, name)
 		}
 		return nil
 	case *PyModule:
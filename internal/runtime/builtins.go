@@ -83,44 +83,105 @@ func parseComplexString(s string) (*PyComplex, error) {
 	return MakeComplex(real, 0), nil
 }
 
-// extractSlots checks classDict for __slots__ and returns the slot names.
-// Returns nil if __slots__ is not defined. Removes __slots__ from classDict.
-func extractSlots(classDict map[string]Value, bases []*PyClass) []string {
+// extractSlots checks classDict for __slots__ and returns the slot names
+// (own names followed by names inherited from slotted bases) plus whether
+// instances still get a __dict__ ('__dict__' listed in __slots__, or some base
+// is not fully slotted). Returns nil names if neither the class nor any base
+// defines __slots__. Removes __slots__ from classDict.
+func (vm *VM) extractSlots(classDict map[string]Value, bases []*PyClass) ([]string, bool, error) {
+	// Slots inherited from bases, and whether any base still gives instances a
+	// __dict__. The implicit object base contributes neither slots nor a dict.
+	var inherited []string
+	basesAllowDict := false
+	for _, base := range bases {
+		if base.Slots != nil {
+			for _, s := range base.Slots {
+				inherited = append(inherited, s)
+			}
+			if base.SlotsAllowDict {
+				basesAllowDict = true
+			}
+		} else if base.Name != "object" {
+			basesAllowDict = true
+		}
+	}
+
 	slotsVal, ok := classDict["__slots__"]
 	if !ok {
-		return nil
+		// No own __slots__: instances get a __dict__, but base slots stay valid.
+		if inherited == nil {
+			return nil, false, nil
+		}
+		return dedupSlots(inherited), true, nil
 	}
 	delete(classDict, "__slots__")
 
-	var slotNames []string
+	var ownNames []string
 	switch s := slotsVal.(type) {
+	case *PyString:
+		// __slots__ = "x" names a single slot
+		ownNames = []string{s.Value}
 	case *PyList:
 		for _, item := range s.Items {
-			if str, ok := item.(*PyString); ok {
-				slotNames = append(slotNames, str.Value)
+			str, ok := item.(*PyString)
+			if !ok {
+				return nil, false, fmt.Errorf("TypeError: __slots__ items must be strings, not '%s'", vm.typeName(item))
 			}
+			ownNames = append(ownNames, str.Value)
 		}
 	case *PyTuple:
 		for _, item := range s.Items {
-			if str, ok := item.(*PyString); ok {
-				slotNames = append(slotNames, str.Value)
+			str, ok := item.(*PyString)
+			if !ok {
+				return nil, false, fmt.Errorf("TypeError: __slots__ items must be strings, not '%s'", vm.typeName(item))
 			}
+			ownNames = append(ownNames, str.Value)
 		}
+	default:
+		return nil, false, fmt.Errorf("TypeError: __slots__ items must be strings, not '%s'", vm.typeName(slotsVal))
 	}
 
-	// Collect slots from base classes that define __slots__
-	for _, base := range bases {
-		if base.Slots != nil {
-			for _, s := range base.Slots {
-				slotNames = append(slotNames, s)
+	allowDict := basesAllowDict
+	slotNames := make([]string, 0, len(ownNames)+len(inherited))
+	for _, name := range ownNames {
+		switch name {
+		case "__dict__":
+			if allowDict {
+				return nil, false, fmt.Errorf("TypeError: __dict__ slot disallowed: we already got one")
+			}
+			allowDict = true
+			continue // __dict__ enables the dict, it is not a storage slot
+		case "__weakref__":
+			for _, s := range inherited {
+				if s == "__weakref__" {
+					return nil, false, fmt.Errorf("TypeError: __weakref__ slot disallowed: either we already got one, or __itemsize__ != 0")
+				}
+			}
+		default:
+			if _, exists := classDict[name]; exists {
+				return nil, false, fmt.Errorf("ValueError: '%s' in __slots__ conflicts with class variable", name)
 			}
 		}
+		slotNames = append(slotNames, name)
 	}
+	slotNames = append(slotNames, inherited...)
+
+	return dedupSlots(slotNames), allowDict, nil
+}
 
-	if slotNames == nil {
-		slotNames = []string{} // empty __slots__ = () should be non-nil empty slice
+// dedupSlots removes duplicate slot names (e.g. from diamond inheritance)
+// while preserving order. Always returns a non-nil slice: empty __slots__ = ()
+// must stay distinguishable from "no __slots__".
+func dedupSlots(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
 	}
-	return slotNames
+	return out
 }
 
 // isValidSlot checks whether name is in the class's allowed slots (including base class slots via MRO).
@@ -0,0 +1,46 @@
+package runtime
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Shared keyword-argument validation for builtins, in the spirit of CPython's
+// argument clinic: every builtin either honors its documented keywords or
+// raises TypeError for unexpected ones instead of silently ignoring them.
+
+// checkNoKwargs raises TypeError if any keyword arguments were passed to a
+// builtin that takes none.
+func checkNoKwargs(name string, kwargs map[string]Value) error {
+	if len(kwargs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("TypeError: %s() takes no keyword arguments", name)
+}
+
+// checkKwargs raises TypeError for any keyword argument not in the allowed
+// set. Builtins that accept keywords call this before reading them so typos
+// like sorted(xs, revers=True) fail loudly.
+func checkKwargs(name string, kwargs map[string]Value, allowed ...string) error {
+	if len(kwargs) == 0 {
+		return nil
+	}
+	var unexpected []string
+	for k := range kwargs {
+		found := false
+		for _, a := range allowed {
+			if k == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unexpected = append(unexpected, k)
+		}
+	}
+	if len(unexpected) == 0 {
+		return nil
+	}
+	sort.Strings(unexpected)
+	return fmt.Errorf("TypeError: '%s' is an invalid keyword argument for %s()", unexpected[0], name)
+}
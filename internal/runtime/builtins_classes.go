@@ -196,6 +196,11 @@ func (vm *VM) initBuiltinsClasses() {
 				return nil, fmt.Errorf("__build_class__: error executing class body: %w", err)
 			}
 
+			// Expose the class docstring captured by the compiler
+			if _, ok := classDict["__doc__"]; !ok && bodyFunc.Code.Doc != "" {
+				classDict["__doc__"] = &PyString{Value: bodyFunc.Code.Doc}
+			}
+
 			// Check for metaclass kwarg
 			typeClass := vm.builtinClass("type")
 			var metaclass *PyClass
@@ -286,9 +291,13 @@ func (vm *VM) initBuiltinsClasses() {
 					class.Metaclass = metaclass
 					// Extract __slots__ if defined
 					if class.Slots == nil {
-						slots := extractSlots(class.Dict, bases)
+						slots, allowDict, err := vm.extractSlots(class.Dict, bases)
+						if err != nil {
+							return nil, err
+						}
 						if slots != nil {
 							class.Slots = slots
+							class.SlotsAllowDict = allowDict
 						}
 					}
 
@@ -314,13 +323,17 @@ func (vm *VM) initBuiltinsClasses() {
 				}
 			} else {
 				// Standard class creation (no custom metaclass)
-				slots := extractSlots(classDict, bases)
+				slots, allowDict, err := vm.extractSlots(classDict, bases)
+				if err != nil {
+					return nil, err
+				}
 				class = &PyClass{
-					Name:      className,
-					Bases:     bases,
-					Dict:      classDict,
-					Metaclass: typeClass,
-					Slots:     slots,
+					Name:           className,
+					Bases:          bases,
+					Dict:           classDict,
+					Metaclass:      typeClass,
+					Slots:          slots,
+					SlotsAllowDict: allowDict,
 				}
 
 				// Build MRO using C3 linearization for proper multiple inheritance
@@ -461,6 +474,9 @@ func (vm *VM) initBuiltinsClasses() {
 	vm.builtins["classmethod"] = &PyBuiltinFunc{
 		Name: "classmethod",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("classmethod", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("classmethod expected 1 argument, got %d", len(args))
 			}
@@ -472,6 +488,9 @@ func (vm *VM) initBuiltinsClasses() {
 	vm.builtins["staticmethod"] = &PyBuiltinFunc{
 		Name: "staticmethod",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("staticmethod", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("staticmethod expected 1 argument, got %d", len(args))
 			}
@@ -483,37 +502,45 @@ func (vm *VM) initBuiltinsClasses() {
 	vm.builtins["super"] = &PyBuiltinFunc{
 		Name: "super",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("super", kwargs); err != nil {
+				return nil, err
+			}
 			var thisClass *PyClass
 			var instance Value
 
 			if len(args) == 0 {
 				// Zero-argument form: super()
-				// Need to find __class__ from the enclosing scope and self from first arg
-				// Look up the call stack to find the method context
-				// We need to look at the caller's frame, not the current one (which is the builtin call)
-				callerFrame := vm.frame
-				if len(vm.frames) >= 1 {
-					// The frames stack contains previous frames; vm.frame is current
-					// For builtin calls, we want the calling frame
-					callerFrame = vm.frame
-				}
-				if callerFrame != nil && callerFrame.Code != nil {
-					// Try to get __class__ from closure cells
-					for i, name := range callerFrame.Code.FreeVars {
-						if name == "__class__" && i < len(callerFrame.Cells) {
-							if cls, ok := callerFrame.Cells[i].Value.(*PyClass); ok {
-								thisClass = cls
-							}
-						}
-					}
-					// Try to get self from first local variable (slot 0)
-					if len(callerFrame.Code.VarNames) > 0 && len(callerFrame.Locals) > 0 {
-						instance = callerFrame.Locals[0]
+				// Find __class__ from a closure cell and self/cls from the first
+				// argument of the calling method. The call stack is walked from
+				// the innermost frame outward so super() also works inside
+				// comprehensions, generator expressions, lambdas, and nested
+				// functions defined in a method body (where the innermost frame
+				// is not the method frame itself).
+				frames := vm.frameChain()
+
+				// First frame (innermost outward) with a __class__ cell wins.
+				for _, f := range frames {
+					if cls := classCellFromFrame(f); cls != nil {
+						thisClass = cls
+						break
 					}
 				}
 				if thisClass == nil {
 					return nil, fmt.Errorf("super(): __class__ cell not found")
 				}
+
+				// First frame whose first argument is compatible with
+				// __class__ provides self/cls. Comprehension frames are skipped
+				// naturally because their first local is the iterator.
+				for _, f := range frames {
+					if f.Code == nil || len(f.Code.VarNames) == 0 || len(f.Locals) == 0 {
+						continue
+					}
+					if candidate := f.Locals[0]; instanceMatchesSuper(thisClass, candidate) {
+						instance = candidate
+						break
+					}
+				}
 				if instance == nil {
 					return nil, fmt.Errorf("super(): self argument not found")
 				}
@@ -644,13 +671,12 @@ func (vm *VM) initBuiltinsClasses() {
 					break
 				}
 			}
-			if inst.Slots != nil {
-				if !isValidSlot(inst.Class, name.Value) {
-					return nil, fmt.Errorf("AttributeError: '%s' object has no attribute '%s'", inst.Class.Name, name.Value)
-				}
+			if inst.Slots != nil && isValidSlot(inst.Class, name.Value) {
 				inst.Slots[name.Value] = args[2]
-			} else {
+			} else if inst.Dict != nil {
 				inst.Dict[name.Value] = args[2]
+			} else {
+				return nil, fmt.Errorf("AttributeError: '%s' object has no attribute '%s'", inst.Class.Name, name.Value)
 			}
 			return None, nil
 		},
@@ -696,16 +722,12 @@ func (vm *VM) initBuiltinsClasses() {
 					break
 				}
 			}
-			if inst.Slots != nil {
-				if _, exists := inst.Slots[name.Value]; !exists {
-					return nil, fmt.Errorf("AttributeError: '%s' object has no attribute '%s'", inst.Class.Name, name.Value)
-				}
+			if _, exists := inst.Slots[name.Value]; exists {
 				delete(inst.Slots, name.Value)
-			} else {
-				if _, exists := inst.Dict[name.Value]; !exists {
-					return nil, fmt.Errorf("AttributeError: '%s' object has no attribute '%s'", inst.Class.Name, name.Value)
-				}
+			} else if _, exists := inst.Dict[name.Value]; exists {
 				delete(inst.Dict, name.Value)
+			} else {
+				return nil, fmt.Errorf("AttributeError: '%s' object has no attribute '%s'", inst.Class.Name, name.Value)
 			}
 			return None, nil
 		},
@@ -754,10 +776,14 @@ func (vm *VM) initBuiltinsClasses() {
 				return nil, fmt.Errorf("object.__new__(X): X is not a type object (%s)", vm.typeName(args[0]))
 			}
 			if cls.Slots != nil {
-				return &PyInstance{
+				inst := &PyInstance{
 					Class: cls,
 					Slots: make(map[string]Value),
-				}, nil
+				}
+				if cls.SlotsAllowDict {
+					inst.Dict = make(map[string]Value)
+				}
+				return inst, nil
 			}
 			return &PyInstance{
 				Class: cls,
@@ -786,6 +812,18 @@ func (vm *VM) initBuiltinsClasses() {
 					return v.Class, nil
 				case *PyClass:
 					return typeClass, nil
+				case *PyException:
+					// Exceptions carry their real class; resolve by name for
+					// VM-raised exceptions that only have a type name.
+					if v.ExcType != nil {
+						return v.ExcType, nil
+					}
+					if cls := vm.builtinClass(v.TypeName); cls != nil {
+						return cls, nil
+					}
+					cls := &PyClass{Name: v.TypeName}
+					cls.Mro = []*PyClass{cls}
+					return cls, nil
 				default:
 					// Return a class with the type name
 					typeName := vm.typeName(args[1])
@@ -832,13 +870,17 @@ func (vm *VM) initBuiltinsClasses() {
 					}
 				}
 
-				slots := extractSlots(classDict, bases)
+				slots, allowDict, err := vm.extractSlots(classDict, bases)
+				if err != nil {
+					return nil, err
+				}
 				cls := &PyClass{
-					Name:      nameStr.Value,
-					Bases:     bases,
-					Dict:      classDict,
-					Metaclass: mcs,
-					Slots:     slots,
+					Name:           nameStr.Value,
+					Bases:          bases,
+					Dict:           classDict,
+					Metaclass:      mcs,
+					Slots:          slots,
+					SlotsAllowDict: allowDict,
 				}
 
 				// Compute C3 MRO
@@ -1111,3 +1153,67 @@ func (vm *VM) callInitSubclass(class *PyClass, kwargs map[string]Value) error {
 	}
 	return nil
 }
+
+// frameChain returns the live Python frames from innermost to outermost,
+// starting with the current frame. Used by zero-argument super() to locate
+// the enclosing method context.
+func (vm *VM) frameChain() []*Frame {
+	frames := make([]*Frame, 0, len(vm.frames)+1)
+	if vm.frame != nil {
+		frames = append(frames, vm.frame)
+	}
+	for i := len(vm.frames) - 1; i >= 0; i-- {
+		if vm.frames[i] != vm.frame {
+			frames = append(frames, vm.frames[i])
+		}
+	}
+	return frames
+}
+
+// classCellFromFrame returns the class stored in a frame's __class__ cell,
+// checking both cell and free variable slots, or nil if the frame has none.
+func classCellFromFrame(f *Frame) *PyClass {
+	if f == nil || f.Code == nil {
+		return nil
+	}
+	for i, cell := range f.Cells {
+		if cell == nil || cell.Value == nil {
+			continue
+		}
+		if f.Code.CellOrFreeName(i) != "__class__" {
+			continue
+		}
+		if cls, ok := cell.Value.(*PyClass); ok {
+			return cls
+		}
+	}
+	return nil
+}
+
+// instanceMatchesSuper reports whether v can serve as the object argument for
+// super() bound to thisClass: an instance of (a subclass of) thisClass, or a
+// class whose MRO or metaclass MRO contains thisClass.
+func instanceMatchesSuper(thisClass *PyClass, v Value) bool {
+	switch inst := v.(type) {
+	case *PyInstance:
+		for _, c := range inst.Class.Mro {
+			if c == thisClass {
+				return true
+			}
+		}
+	case *PyClass:
+		for _, c := range inst.Mro {
+			if c == thisClass {
+				return true
+			}
+		}
+		if inst.Metaclass != nil {
+			for _, c := range inst.Metaclass.Mro {
+				if c == thisClass {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
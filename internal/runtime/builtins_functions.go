@@ -15,6 +15,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["print"] = &PyBuiltinFunc{
 		Name: "print",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("print", kwargs, "sep", "end", "file", "flush"); err != nil {
+				return nil, err
+			}
 			sep := " "
 			end := "\n"
 			if v, ok := kwargs["sep"]; ok {
@@ -72,13 +75,14 @@ func (vm *VM) initBuiltinsFunctions() {
 				}
 			} else {
 				// Write to stdout (default)
+				out := vm.Stdout()
 				for i, arg := range args {
 					if i > 0 {
-						fmt.Print(sep)
+						fmt.Fprint(out, sep)
 					}
-					fmt.Print(vm.str(arg))
+					fmt.Fprint(out, vm.str(arg))
 				}
-				fmt.Print(end)
+				fmt.Fprint(out, end)
 			}
 			return None, nil
 		},
@@ -87,13 +91,21 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["range"] = &PyBuiltinFunc{
 		Name: "range",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("range", kwargs); err != nil {
+				return nil, err
+			}
 			var start int64
 			var stop int64
 			var step int64 = 1
 			// Validate all arguments are integers (not floats)
 			for i, arg := range args {
-				switch arg.(type) {
-				case *PyInt, *PyBool:
+				switch v := arg.(type) {
+				case *PyInt:
+					if v.BigValue != nil {
+						// Bounds wider than int64 would silently truncate
+						return nil, fmt.Errorf("OverflowError: Python int too large to convert to C ssize_t")
+					}
+				case *PyBool:
 					// ok
 				default:
 					_ = i
@@ -123,6 +135,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["repr"] = &PyBuiltinFunc{
 		Name: "repr",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("repr", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("repr() takes exactly 1 argument (%d given)", len(args))
 			}
@@ -133,6 +148,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["ascii"] = &PyBuiltinFunc{
 		Name: "ascii",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("ascii", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("ascii() takes exactly 1 argument (%d given)", len(args))
 			}
@@ -143,6 +161,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["format"] = &PyBuiltinFunc{
 		Name: "format",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("format", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) < 1 || len(args) > 2 {
 				return nil, fmt.Errorf("TypeError: format() takes 1 or 2 arguments (%d given)", len(args))
 			}
@@ -165,6 +186,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["isinstance"] = &PyBuiltinFunc{
 		Name: "isinstance",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("isinstance", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 2 {
 				return nil, fmt.Errorf("isinstance() takes exactly 2 arguments")
 			}
@@ -228,6 +252,8 @@ func (vm *VM) initBuiltinsFunctions() {
 					return typeName == "frozenset" || typeName == "object"
 				case *PyBytes:
 					return typeName == "bytes" || typeName == "object"
+				case *PyByteArray:
+					return typeName == "bytearray" || typeName == "object"
 				case *PyNone:
 					return typeName == "NoneType" || typeName == "object"
 				case *PyInstance:
@@ -293,6 +319,8 @@ func (vm *VM) initBuiltinsFunctions() {
 						}
 					}
 					return false, nil
+				case *GenericAlias:
+					return false, fmt.Errorf("TypeError: isinstance() argument 2 cannot be a parameterized generic")
 				default:
 					return false, fmt.Errorf("TypeError: isinstance() arg 2 must be a type, a tuple of types, or a union")
 				}
@@ -312,6 +340,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["abs"] = &PyBuiltinFunc{
 		Name: "abs",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("abs", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("abs() takes exactly one argument")
 			}
@@ -340,6 +371,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["hash"] = &PyBuiltinFunc{
 		Name: "hash",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("hash", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("hash() takes exactly one argument (%d given)", len(args))
 			}
@@ -353,11 +387,17 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["min"] = &PyBuiltinFunc{
 		Name: "min",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("min", kwargs, "key", "default"); err != nil {
+				return nil, err
+			}
 			if len(args) == 0 {
 				return nil, fmt.Errorf("min expected at least 1 argument")
 			}
 			keyFn, hasKey := kwargs["key"]
 			defaultVal, hasDefault := kwargs["default"]
+			if hasDefault && len(args) > 1 {
+				return nil, fmt.Errorf("TypeError: Cannot specify a default for min() with multiple positional arguments")
+			}
 			if len(args) == 1 {
 				items, err := vm.toList(args[0])
 				if err != nil {
@@ -396,11 +436,17 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["max"] = &PyBuiltinFunc{
 		Name: "max",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("max", kwargs, "key", "default"); err != nil {
+				return nil, err
+			}
 			if len(args) == 0 {
 				return nil, fmt.Errorf("max expected at least 1 argument")
 			}
 			keyFn, hasKey := kwargs["key"]
 			defaultVal, hasDefault := kwargs["default"]
+			if hasDefault && len(args) > 1 {
+				return nil, fmt.Errorf("TypeError: Cannot specify a default for max() with multiple positional arguments")
+			}
 			if len(args) == 1 {
 				items, err := vm.toList(args[0])
 				if err != nil {
@@ -439,6 +485,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["sum"] = &PyBuiltinFunc{
 		Name: "sum",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("sum", kwargs, "start"); err != nil {
+				return nil, err
+			}
 			if len(args) == 0 {
 				return nil, fmt.Errorf("sum expected at least 1 argument")
 			}
@@ -450,6 +499,12 @@ func (vm *VM) initBuiltinsFunctions() {
 			if len(args) > 1 {
 				start = args[1]
 			}
+			if v, ok := kwargs["start"]; ok {
+				if len(args) > 1 {
+					return nil, fmt.Errorf("TypeError: sum() got multiple values for argument 'start'")
+				}
+				start = v
+			}
 			result := start
 			for _, item := range items {
 				result, err = vm.binaryOp(OpBinaryAdd, result, item)
@@ -464,8 +519,11 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["input"] = &PyBuiltinFunc{
 		Name: "input",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("input", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) > 0 {
-				fmt.Print(vm.str(args[0]))
+				fmt.Fprint(vm.Stdout(), vm.str(args[0]))
 			}
 			var line string
 			if _, err := fmt.Scanln(&line); err != nil {
@@ -478,6 +536,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["ord"] = &PyBuiltinFunc{
 		Name: "ord",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("ord", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("TypeError: ord() takes exactly one argument")
 			}
@@ -496,6 +557,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["chr"] = &PyBuiltinFunc{
 		Name: "chr",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("chr", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("chr() takes exactly one argument")
 			}
@@ -508,10 +572,13 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["enumerate"] = &PyBuiltinFunc{
 		Name: "enumerate",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("enumerate", kwargs, "start"); err != nil {
+				return nil, err
+			}
 			if len(args) < 1 || len(args) > 2 {
 				return nil, fmt.Errorf("enumerate expected 1 to 2 arguments, got %d", len(args))
 			}
-			items, err := vm.toList(args[0])
+			it, err := vm.getIter(args[0])
 			if err != nil {
 				return nil, err
 			}
@@ -522,18 +589,35 @@ func (vm *VM) initBuiltinsFunctions() {
 			if s, ok := kwargs["start"]; ok {
 				start = vm.toInt(s)
 			}
-			result := make([]Value, len(items))
-			for i, item := range items {
-				result[i] = &PyTuple{Items: []Value{MakeInt(start + int64(i)), item}}
-			}
-			return &PyIterator{Items: result, Index: 0}, nil
+			// Lazily pull from the underlying iterator so infinite or
+			// side-effecting iterables only advance on demand
+			index := start
+			return &PyGoIterator{
+				Name: "enumerate",
+				Next: func() (Value, bool, error) {
+					val, done, err := vm.iterNext(it)
+					if err != nil || done {
+						return nil, done, err
+					}
+					pair := &PyTuple{Items: []Value{MakeInt(index), val}}
+					index++
+					return pair, false, nil
+				},
+			}, nil
 		},
 	}
 
-	// zip(*iterables) - returns iterator of tuples
+	// zip(*iterables, strict=False) - returns iterator of tuples
 	vm.builtins["zip"] = &PyBuiltinFunc{
 		Name: "zip",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("zip", kwargs, "strict"); err != nil {
+				return nil, err
+			}
+			strict := false
+			if s, ok := kwargs["strict"]; ok {
+				strict = vm.truthy(s)
+			}
 			if len(args) == 0 {
 				return &PyIterator{Items: []Value{}, Index: 0}, nil
 			}
@@ -556,6 +640,22 @@ func (vm *VM) initBuiltinsFunctions() {
 						return nil, err
 					}
 					if done {
+						if strict {
+							if j > 0 {
+								// Earlier arguments already produced a value this round
+								return nil, &PyException{TypeName: "ValueError", Message: fmt.Sprintf("zip() argument %d is shorter than argument 1", j+1)}
+							}
+							// Argument 1 is exhausted; any remaining value means it was shorter
+							for k := 1; k < len(iters); k++ {
+								_, kDone, err := vm.iterNext(iters[k])
+								if err != nil {
+									return nil, err
+								}
+								if !kDone {
+									return nil, &PyException{TypeName: "ValueError", Message: fmt.Sprintf("zip() argument %d is longer than argument 1", k+1)}
+								}
+							}
+						}
 						// This iterator is exhausted, stop
 						return &PyIterator{Items: result, Index: 0}, nil
 					}
@@ -570,6 +670,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["map"] = &PyBuiltinFunc{
 		Name: "map",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("map", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) < 2 {
 				return nil, fmt.Errorf("map() must have at least two arguments")
 			}
@@ -608,6 +711,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["filter"] = &PyBuiltinFunc{
 		Name: "filter",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("filter", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 2 {
 				return nil, fmt.Errorf("filter expected 2 arguments, got %d", len(args))
 			}
@@ -641,6 +747,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["reversed"] = &PyBuiltinFunc{
 		Name: "reversed",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("reversed", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("reversed() takes exactly one argument (%d given)", len(args))
 			}
@@ -670,6 +779,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["sorted"] = &PyBuiltinFunc{
 		Name: "sorted",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("sorted", kwargs, "key", "reverse"); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("sorted expected 1 argument, got %d", len(args))
 			}
@@ -699,6 +811,10 @@ func (vm *VM) initBuiltinsFunctions() {
 				if sortErr != nil {
 					return false
 				}
+				if err := vm.CheckInterrupt(); err != nil {
+					sortErr = err
+					return false
+				}
 				a, b := result[i], result[j]
 				// Apply key function if provided
 				if keyFn != nil {
@@ -745,6 +861,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["all"] = &PyBuiltinFunc{
 		Name: "all",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("all", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("all() takes exactly one argument (%d given)", len(args))
 			}
@@ -765,6 +884,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["any"] = &PyBuiltinFunc{
 		Name: "any",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("any", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("any() takes exactly one argument (%d given)", len(args))
 			}
@@ -785,6 +907,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["hasattr"] = &PyBuiltinFunc{
 		Name: "hasattr",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("hasattr", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 2 {
 				return nil, fmt.Errorf("hasattr() takes exactly 2 arguments (%d given)", len(args))
 			}
@@ -810,6 +935,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["dir"] = &PyBuiltinFunc{
 		Name: "dir",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("dir", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) > 1 {
 				return nil, fmt.Errorf("dir expected at most 1 argument, got %d", len(args))
 			}
@@ -883,6 +1011,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["getattr"] = &PyBuiltinFunc{
 		Name: "getattr",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("getattr", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) < 2 || len(args) > 3 {
 				return nil, fmt.Errorf("getattr expected 2 or 3 arguments, got %d", len(args))
 			}
@@ -905,6 +1036,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["setattr"] = &PyBuiltinFunc{
 		Name: "setattr",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("setattr", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 3 {
 				return nil, fmt.Errorf("setattr() takes exactly 3 arguments (%d given)", len(args))
 			}
@@ -924,6 +1058,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["delattr"] = &PyBuiltinFunc{
 		Name: "delattr",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("delattr", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 2 {
 				return nil, fmt.Errorf("delattr() takes exactly 2 arguments (%d given)", len(args))
 			}
@@ -942,6 +1079,22 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["pow"] = &PyBuiltinFunc{
 		Name: "pow",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("pow", kwargs, "base", "exp", "mod"); err != nil {
+				return nil, err
+			}
+			// Fold base/exp/mod keywords into positional slots (pow accepts
+			// keywords since Python 3.8)
+			for i, kw := range []string{"base", "exp", "mod"} {
+				if v, ok := kwargs[kw]; ok {
+					if len(args) > i {
+						return nil, fmt.Errorf("TypeError: pow() got multiple values for argument '%s'", kw)
+					}
+					if len(args) != i {
+						return nil, fmt.Errorf("TypeError: pow() missing required argument before '%s'", kw)
+					}
+					args = append(args, v)
+				}
+			}
 			if len(args) < 2 || len(args) > 3 {
 				return nil, fmt.Errorf("pow expected 2 or 3 arguments, got %d", len(args))
 			}
@@ -984,6 +1137,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["divmod"] = &PyBuiltinFunc{
 		Name: "divmod",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("divmod", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 2 {
 				return nil, fmt.Errorf("divmod expected 2 arguments, got %d", len(args))
 			}
@@ -1019,6 +1175,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["hex"] = &PyBuiltinFunc{
 		Name: "hex",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("hex", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("hex() takes exactly one argument (%d given)", len(args))
 			}
@@ -1037,6 +1196,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["oct"] = &PyBuiltinFunc{
 		Name: "oct",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("oct", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("oct() takes exactly one argument (%d given)", len(args))
 			}
@@ -1055,6 +1217,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["bin"] = &PyBuiltinFunc{
 		Name: "bin",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("bin", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("bin() takes exactly one argument (%d given)", len(args))
 			}
@@ -1073,9 +1238,24 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["round"] = &PyBuiltinFunc{
 		Name: "round",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("round", kwargs, "ndigits"); err != nil {
+				return nil, err
+			}
+			if v, ok := kwargs["ndigits"]; ok {
+				if len(args) > 1 {
+					return nil, fmt.Errorf("TypeError: round() got multiple values for argument 'ndigits'")
+				}
+				args = append(args, v)
+			}
 			if len(args) < 1 || len(args) > 2 {
 				return nil, fmt.Errorf("round() takes 1 or 2 arguments (%d given)", len(args))
 			}
+			// An explicit ndigits=None means the same as omitting it
+			if len(args) == 2 {
+				if _, isNone := args[1].(*PyNone); isNone {
+					args = args[:1]
+				}
+			}
 			// Try __round__ dunder on PyInstance first
 			if inst, ok := args[0].(*PyInstance); ok {
 				var dunderArgs []Value
@@ -1090,6 +1270,12 @@ func (vm *VM) initBuiltinsFunctions() {
 				}
 				return nil, fmt.Errorf("TypeError: type %s doesn't define __round__ method", vm.typeName(args[0]))
 			}
+			switch args[0].(type) {
+			case *PyInt, *PyFloat, *PyBool:
+				// Numeric: handled below
+			default:
+				return nil, fmt.Errorf("TypeError: type %s doesn't define __round__ method", vm.typeName(args[0]))
+			}
 			num := vm.toFloat(args[0])
 
 			if len(args) == 1 {
@@ -1098,8 +1284,11 @@ func (vm *VM) initBuiltinsFunctions() {
 				return MakeInt(int64(rounded)), nil
 			}
 
-			// Round to ndigits decimal places
-			ndigits := vm.toInt(args[1])
+			// Round to ndigits decimal places - ndigits may be any __index__-able
+			ndigits, err := vm.getIntIndex(args[1])
+			if err != nil {
+				return nil, err
+			}
 			multiplier := math.Pow(10, float64(ndigits))
 			rounded := math.RoundToEven(num*multiplier) / multiplier
 
@@ -1115,11 +1304,14 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["callable"] = &PyBuiltinFunc{
 		Name: "callable",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("callable", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("callable() takes exactly one argument (%d given)", len(args))
 			}
 			switch args[0].(type) {
-			case *PyFunction, *PyBuiltinFunc, *PyGoFunc, *PyMethod, *PyClass:
+			case *PyFunction, *PyBuiltinFunc, *PyStringMethod, *PyGoFunc, *PyMethod, *PyClass:
 				return True, nil
 			case *PyInstance:
 				// Check if instance has __call__ method
@@ -1140,6 +1332,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["next"] = &PyBuiltinFunc{
 		Name: "next",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("next", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) < 1 || len(args) > 2 {
 				return nil, fmt.Errorf("TypeError: next expected 1 or 2 arguments, got %d", len(args))
 			}
@@ -1172,6 +1367,18 @@ func (vm *VM) initBuiltinsFunctions() {
 				val := items[it.Index]
 				it.Index++
 				return val, nil
+			case *PyGoIterator:
+				val, done, err := it.Next()
+				if err != nil {
+					return nil, err
+				}
+				if done {
+					if hasDefault {
+						return args[1], nil
+					}
+					return nil, &PyException{TypeName: "StopIteration", Message: ""}
+				}
+				return val, nil
 			default:
 				// Try __next__ method
 				nextMethod, err := vm.getAttr(args[0], "__next__")
@@ -1192,12 +1399,44 @@ func (vm *VM) initBuiltinsFunctions() {
 		},
 	}
 
-	// iter(object) - get an iterator from an object
+	// iter(object) / iter(callable, sentinel) - get an iterator from an object,
+	// or call callable until it returns sentinel
 	vm.builtins["iter"] = &PyBuiltinFunc{
 		Name: "iter",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
-			if len(args) != 1 {
-				return nil, fmt.Errorf("TypeError: iter expected 1 argument, got %d", len(args))
+			if err := checkNoKwargs("iter", kwargs); err != nil {
+				return nil, err
+			}
+			if len(args) < 1 || len(args) > 2 {
+				return nil, fmt.Errorf("TypeError: iter expected at most 2 arguments, got %d", len(args))
+			}
+			if len(args) == 2 {
+				fn := args[0]
+				switch fn.(type) {
+				case *PyFunction, *PyBuiltinFunc, *PyStringMethod, *PyGoFunc, *PyMethod, *PyClass, *PyInstance:
+					// Callable (instances are checked at call time for __call__)
+				default:
+					return nil, fmt.Errorf("TypeError: iter(v, w): v must be callable")
+				}
+				sentinel := args[1]
+				done := false
+				return &PyGoIterator{
+					Name: "callable_iterator",
+					Next: func() (Value, bool, error) {
+						if done {
+							return nil, true, nil
+						}
+						val, err := vm.call(fn, nil, nil)
+						if err != nil {
+							return nil, false, err
+						}
+						if vm.equal(val, sentinel) {
+							done = true
+							return nil, true, nil
+						}
+						return val, false, nil
+					},
+				}, nil
 			}
 			return vm.getIter(args[0])
 		},
@@ -1207,6 +1446,9 @@ func (vm *VM) initBuiltinsFunctions() {
 	vm.builtins["issubclass"] = &PyBuiltinFunc{
 		Name: "issubclass",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("issubclass", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 2 {
 				return nil, fmt.Errorf("TypeError: issubclass expected 2 arguments, got %d", len(args))
 			}
@@ -1329,6 +1571,8 @@ func (vm *VM) initBuiltinsFunctions() {
 						}
 					}
 					return False, nil
+				case *GenericAlias:
+					return nil, fmt.Errorf("TypeError: issubclass() argument 2 cannot be a parameterized generic")
 				default:
 					return nil, fmt.Errorf("TypeError: issubclass() arg 2 must be a class or tuple of classes")
 				}
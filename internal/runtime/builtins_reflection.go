@@ -32,6 +32,25 @@ func BuiltinRepr(vm *VM) int {
 	return 1
 }
 
+// enterRepr marks a container as being rendered, so self-referential
+// structures print a placeholder instead of recursing forever. It returns
+// false when v is already on the rendering stack.
+func (vm *VM) enterRepr(v Value) bool {
+	if vm.reprActive == nil {
+		vm.reprActive = make(map[Value]bool)
+	}
+	if vm.reprActive[v] {
+		return false
+	}
+	vm.reprActive[v] = true
+	return true
+}
+
+// exitRepr unmarks a container after its rendering completes.
+func (vm *VM) exitRepr(v Value) {
+	delete(vm.reprActive, v)
+}
+
 // Repr returns the repr() string for a value
 func (vm *VM) Repr(v Value) string {
 	switch val := v.(type) {
@@ -51,13 +70,23 @@ func (vm *VM) Repr(v Value) string {
 		return fmt.Sprintf("'%s'", escapeString(val.Value))
 	case *PyBytes:
 		return fmt.Sprintf("b'%s'", escapeBytes(val.Value))
+	case *PyByteArray:
+		return fmt.Sprintf("bytearray(b'%s')", escapeBytes(val.Value))
 	case *PyList:
+		if !vm.enterRepr(val) {
+			return "[...]"
+		}
+		defer vm.exitRepr(val)
 		var items []string
 		for _, item := range val.Items {
 			items = append(items, vm.Repr(item))
 		}
 		return "[" + strings.Join(items, ", ") + "]"
 	case *PyTuple:
+		if !vm.enterRepr(val) {
+			return "(...)"
+		}
+		defer vm.exitRepr(val)
 		var items []string
 		for _, item := range val.Items {
 			items = append(items, vm.Repr(item))
@@ -67,6 +96,10 @@ func (vm *VM) Repr(v Value) string {
 		}
 		return "(" + strings.Join(items, ", ") + ")"
 	case *PyDict:
+		if !vm.enterRepr(val) {
+			return "{...}"
+		}
+		defer vm.exitRepr(val)
 		var items []string
 		for k, v := range val.Items {
 			items = append(items, vm.Repr(k)+": "+vm.Repr(v))
@@ -340,7 +373,10 @@ func BuiltinGlobals(vm *VM) int {
 	return 1
 }
 
-// BuiltinLocals implements locals()
+// BuiltinLocals implements locals().
+// Inside a function it returns a read-only snapshot of the frame's fast
+// locals plus any cell/free variables; at module level and in class bodies
+// it returns the namespace currently being populated, matching CPython.
 func BuiltinLocals(vm *VM) int {
 	nargs := vm.GetTop()
 	if nargs != 0 {
@@ -348,14 +384,39 @@ func BuiltinLocals(vm *VM) int {
 		return 0
 	}
 
-	locals := &PyDict{Items: make(map[Value]Value)}
-
 	// Find the caller's frame (skip the builtin's temporary frame)
 	callerFrame := vm.getCallerFrame()
+
+	// At module level locals() and globals() are the same namespace.
+	if callerFrame != nil && callerFrame.Code != nil &&
+		callerFrame.Code.Name == "<module>" && callerFrame.EnclosingGlobals == nil {
+		return BuiltinGlobals(vm)
+	}
+
+	locals := &PyDict{Items: make(map[Value]Value)}
+
 	if callerFrame != nil && callerFrame.Code != nil {
-		for i, name := range callerFrame.Code.VarNames {
-			if i < len(callerFrame.Locals) && callerFrame.Locals[i] != nil {
-				locals.Items[NewString(name)] = callerFrame.Locals[i]
+		if callerFrame.EnclosingGlobals != nil {
+			// Class body: the namespace under construction lives in the
+			// frame's Globals map.
+			for name, value := range callerFrame.Globals {
+				locals.Items[NewString(name)] = value
+			}
+		} else {
+			// Fast locals
+			for i, name := range callerFrame.Code.VarNames {
+				if i < len(callerFrame.Locals) && callerFrame.Locals[i] != nil {
+					locals.Items[NewString(name)] = callerFrame.Locals[i]
+				}
+			}
+			// Cell and free variables so closures see captured names
+			for i, cell := range callerFrame.Cells {
+				if cell == nil || cell.Value == nil {
+					continue
+				}
+				if name := callerFrame.Code.CellOrFreeName(i); name != "" {
+					locals.Items[NewString(name)] = cell.Value
+				}
 			}
 		}
 	}
@@ -845,3 +906,152 @@ func (vm *VM) EvalInNamespace(code *CodeObject, globals, locals map[string]Value
 
 	return result, nil
 }
+
+// =====================================
+// help()
+// =====================================
+
+// BuiltinHelp implements help(obj): prints a readable summary of the object's
+// signature, docstring, and (for classes and modules) contents.
+func BuiltinHelp(vm *VM) int {
+	nargs := vm.GetTop()
+	if nargs > 1 {
+		vm.RaiseError("TypeError: help() takes at most 1 argument (%d given)", nargs)
+		return 0
+	}
+
+	if nargs == 0 {
+		fmt.Println("Welcome to rage! Call help(object) for a summary of that object.")
+		vm.Push(None)
+		return 1
+	}
+
+	fmt.Print(vm.helpText(vm.Get(1)))
+	vm.Push(None)
+	return 1
+}
+
+// helpText renders the help() summary for a value.
+func (vm *VM) helpText(v Value) string {
+	var b strings.Builder
+
+	switch o := v.(type) {
+	case *PyFunction:
+		fmt.Fprintf(&b, "Help on function %s:\n\n%s%s\n", o.Name, o.Name, funcSignature(o))
+		writeIndentedDoc(&b, o.Code.Doc)
+	case *PyMethod:
+		fmt.Fprintf(&b, "Help on method %s:\n\n%s%s\n", o.Func.Name, o.Func.Name, funcSignature(o.Func))
+		writeIndentedDoc(&b, o.Func.Code.Doc)
+	case *PyBuiltinFunc:
+		fmt.Fprintf(&b, "Help on built-in function %s:\n\n%s(...)\n", o.Name, o.Name)
+	case *PyGoFunc:
+		fmt.Fprintf(&b, "Help on built-in function %s:\n\n%s(...)\n", o.Name, o.Name)
+	case *PyClass:
+		fmt.Fprintf(&b, "Help on class %s:\n\nclass %s", o.Name, o.Name)
+		if len(o.Bases) > 0 {
+			baseNames := make([]string, len(o.Bases))
+			for i, base := range o.Bases {
+				baseNames[i] = base.Name
+			}
+			fmt.Fprintf(&b, "(%s)", strings.Join(baseNames, ", "))
+		}
+		b.WriteString("\n")
+		if doc, ok := o.Dict["__doc__"]; ok {
+			if s, ok := doc.(*PyString); ok {
+				writeIndentedDoc(&b, s.Value)
+			}
+		}
+
+		// List methods defined on the class itself, sorted by name
+		var methodNames []string
+		for name, val := range o.Dict {
+			if _, ok := val.(*PyFunction); ok {
+				methodNames = append(methodNames, name)
+			}
+		}
+		if len(methodNames) > 0 {
+			sort.Strings(methodNames)
+			b.WriteString("\nMethods defined here:\n")
+			for _, name := range methodNames {
+				fn := o.Dict[name].(*PyFunction)
+				fmt.Fprintf(&b, "    %s%s\n", name, funcSignature(fn))
+				if fn.Code.Doc != "" {
+					fmt.Fprintf(&b, "        %s\n", firstLine(fn.Code.Doc))
+				}
+			}
+		}
+	case *PyModule:
+		fmt.Fprintf(&b, "Help on module %s:\n\nNAME\n    %s\n", o.Name, o.Name)
+		if o.Doc != "" {
+			b.WriteString("\nDESCRIPTION\n")
+			writeIndentedDoc(&b, o.Doc)
+		}
+		var names []string
+		for name := range o.Dict {
+			if !strings.HasPrefix(name, "__") {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			sort.Strings(names)
+			b.WriteString("\nCONTENTS\n")
+			for _, name := range names {
+				fmt.Fprintf(&b, "    %s\n", name)
+			}
+		}
+	case *PyInstance:
+		return vm.helpText(o.Class)
+	default:
+		fmt.Fprintf(&b, "Help on %s object.\n", vm.typeName(v))
+	}
+
+	return b.String()
+}
+
+// funcSignature renders a function's parameter list from its code object,
+// e.g. "(a, b, *args, **kwargs)".
+func funcSignature(fn *PyFunction) string {
+	// Locals are laid out as [positional..., keyword-only..., *args, **kwargs];
+	// display order puts *args before the keyword-only parameters.
+	co := fn.Code
+	params := make([]string, 0, co.ArgCount+co.KwOnlyArgCount+2)
+	for i := 0; i < co.ArgCount && i < len(co.VarNames); i++ {
+		params = append(params, co.VarNames[i])
+	}
+	varArgsIdx := co.ArgCount + co.KwOnlyArgCount
+	if co.Flags&FlagVarArgs != 0 && varArgsIdx < len(co.VarNames) {
+		params = append(params, "*"+co.VarNames[varArgsIdx])
+	} else if co.KwOnlyArgCount > 0 {
+		params = append(params, "*")
+	}
+	for i := co.ArgCount; i < co.ArgCount+co.KwOnlyArgCount && i < len(co.VarNames); i++ {
+		params = append(params, co.VarNames[i])
+	}
+	kwArgsIdx := varArgsIdx
+	if co.Flags&FlagVarArgs != 0 {
+		kwArgsIdx++
+	}
+	if co.Flags&FlagVarKeywords != 0 && kwArgsIdx < len(co.VarNames) {
+		params = append(params, "**"+co.VarNames[kwArgsIdx])
+	}
+	return "(" + strings.Join(params, ", ") + ")"
+}
+
+// writeIndentedDoc writes a docstring indented by four spaces, or nothing if
+// the docstring is empty.
+func writeIndentedDoc(b *strings.Builder, doc string) {
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(doc, "\n"), "\n") {
+		b.WriteString("    " + line + "\n")
+	}
+}
+
+// firstLine returns the first line of a docstring.
+func firstLine(doc string) string {
+	if i := strings.IndexByte(doc, '\n'); i >= 0 {
+		return strings.TrimSpace(doc[:i])
+	}
+	return strings.TrimSpace(doc)
+}
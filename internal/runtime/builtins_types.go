@@ -11,6 +11,9 @@ func (vm *VM) initBuiltinsTypes() {
 	vm.builtins["len"] = &PyBuiltinFunc{
 		Name: "len",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("len", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) != 1 {
 				return nil, fmt.Errorf("len() takes exactly one argument (%d given)", len(args))
 			}
@@ -29,6 +32,12 @@ func (vm *VM) initBuiltinsTypes() {
 				return MakeInt(int64(len(v.Items))), nil
 			case *PyBytes:
 				return MakeInt(int64(len(v.Value))), nil
+			case *PyByteArray:
+				return MakeInt(int64(len(v.Value))), nil
+			case *PyFloatArray:
+				return MakeInt(int64(len(v.Values))), nil
+			case *PyIntArray:
+				return MakeInt(int64(len(v.Values))), nil
 			case *PyRange:
 				return MakeInt(rangeLen(v)), nil
 			case *PyInstance:
@@ -43,6 +52,19 @@ func (vm *VM) initBuiltinsTypes() {
 					return nil, fmt.Errorf("__len__() should return an integer")
 				}
 				return nil, fmt.Errorf("object of type '%s' has no len()", vm.typeName(v))
+			case *PyUserData:
+				// Check for a __len__ metamethod in the metatable
+				if lenMethod, err := vm.getAttr(v, "__len__"); err == nil {
+					result, err := vm.call(lenMethod, nil, nil)
+					if err != nil {
+						return nil, err
+					}
+					if i, ok := result.(*PyInt); ok {
+						return i, nil
+					}
+					return nil, fmt.Errorf("__len__() should return an integer")
+				}
+				return nil, fmt.Errorf("object of type '%s' has no len()", vm.typeName(v))
 			default:
 				return nil, fmt.Errorf("object of type '%s' has no len()", vm.typeName(v))
 			}
@@ -52,6 +74,9 @@ func (vm *VM) initBuiltinsTypes() {
 	vm.builtins["slice"] = &PyBuiltinFunc{
 		Name: "slice",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("slice", kwargs); err != nil {
+				return nil, err
+			}
 			switch len(args) {
 			case 1:
 				return &PySlice{Start: None, Stop: args[0], Step: None}, nil
@@ -68,6 +93,9 @@ func (vm *VM) initBuiltinsTypes() {
 	vm.builtins["int"] = &PyBuiltinFunc{
 		Name: "int",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("int", kwargs, "base"); err != nil {
+				return nil, err
+			}
 			if len(args) == 0 {
 				if _, hasBase := kwargs["base"]; hasBase {
 					return nil, fmt.Errorf("TypeError: int() missing string argument")
@@ -107,6 +135,9 @@ func (vm *VM) initBuiltinsTypes() {
 	vm.builtins["float"] = &PyBuiltinFunc{
 		Name: "float",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("float", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) == 0 {
 				return &PyFloat{Value: 0.0}, nil
 			}
@@ -121,6 +152,34 @@ func (vm *VM) initBuiltinsTypes() {
 	vm.builtins["complex"] = &PyBuiltinFunc{
 		Name: "complex",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("complex", kwargs, "real", "imag"); err != nil {
+				return nil, err
+			}
+			// Fold real/imag keywords into positional slots; either may be
+			// given independently (complex(imag=2) == 2j).
+			if len(kwargs) > 0 {
+				realArg := Value(MakeInt(0))
+				imagArg := Value(MakeInt(0))
+				if len(args) >= 1 {
+					realArg = args[0]
+				}
+				if len(args) >= 2 {
+					imagArg = args[1]
+				}
+				if v, ok := kwargs["real"]; ok {
+					if len(args) >= 1 {
+						return nil, fmt.Errorf("TypeError: complex() got multiple values for argument 'real'")
+					}
+					realArg = v
+				}
+				if v, ok := kwargs["imag"]; ok {
+					if len(args) >= 2 {
+						return nil, fmt.Errorf("TypeError: complex() got multiple values for argument 'imag'")
+					}
+					imagArg = v
+				}
+				args = []Value{realArg, imagArg}
+			}
 			if len(args) == 0 {
 				return MakeComplex(0, 0), nil
 			}
@@ -226,6 +285,9 @@ func (vm *VM) initBuiltinsTypes() {
 	vm.builtins["str"] = &PyBuiltinFunc{
 		Name: "str",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("str", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) == 0 {
 				return &PyString{Value: ""}, nil
 			}
@@ -236,6 +298,9 @@ func (vm *VM) initBuiltinsTypes() {
 	vm.builtins["bool"] = &PyBuiltinFunc{
 		Name: "bool",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("bool", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) == 0 {
 				return False, nil
 			}
@@ -249,6 +314,9 @@ func (vm *VM) initBuiltinsTypes() {
 	vm.builtins["list"] = &PyBuiltinFunc{
 		Name: "list",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("list", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) == 0 {
 				return &PyList{Items: []Value{}}, nil
 			}
@@ -266,6 +334,9 @@ func (vm *VM) initBuiltinsTypes() {
 	vm.builtins["tuple"] = &PyBuiltinFunc{
 		Name: "tuple",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("tuple", kwargs); err != nil {
+				return nil, err
+			}
 			if len(args) == 0 {
 				return &PyTuple{Items: []Value{}}, nil
 			}
@@ -327,6 +398,9 @@ func (vm *VM) initBuiltinsTypes() {
 	vm.builtins["bytes"] = &PyBuiltinFunc{
 		Name: "bytes",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("bytes", kwargs, "encoding", "errors"); err != nil {
+				return nil, err
+			}
 			if len(args) == 0 {
 				return &PyBytes{Value: []byte{}}, nil
 			}
@@ -426,9 +500,57 @@ func (vm *VM) initBuiltinsTypes() {
 		},
 	}
 
+	vm.builtins["bytearray"] = &PyBuiltinFunc{
+		Name: "bytearray",
+		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkKwargs("bytearray", kwargs, "encoding", "errors"); err != nil {
+				return nil, err
+			}
+			if len(args) == 0 {
+				return &PyByteArray{Value: []byte{}}, nil
+			}
+			switch v := args[0].(type) {
+			case *PyInt:
+				// bytearray(3) -> bytearray(b'\x00\x00\x00')
+				if v.Value < 0 {
+					return nil, fmt.Errorf("ValueError: negative count")
+				}
+				return &PyByteArray{Value: make([]byte, v.Value)}, nil
+			case *PyString:
+				// bytearray("hello", "utf-8") - requires encoding argument
+				encoding := ""
+				if len(args) > 1 {
+					if enc, ok := args[1].(*PyString); ok {
+						encoding = enc.Value
+					}
+				}
+				if enc, ok := kwargs["encoding"]; ok {
+					if encStr, ok := enc.(*PyString); ok {
+						encoding = encStr.Value
+					}
+				}
+				if encoding == "" {
+					return nil, fmt.Errorf("TypeError: string argument without an encoding")
+				}
+				// We only support utf-8/ascii/latin-1 for now
+				return &PyByteArray{Value: []byte(v.Value)}, nil
+			default:
+				// bytes, bytearray, or any iterable of ints in range(0, 256)
+				result, err := vm.toByteSlice(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return &PyByteArray{Value: result}, nil
+			}
+		},
+	}
+
 	vm.builtins["set"] = &PyBuiltinFunc{
 		Name: "set",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("set", kwargs); err != nil {
+				return nil, err
+			}
 			s := &PySet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
 			if len(args) > 0 {
 				items, err := vm.toList(args[0])
@@ -455,6 +577,9 @@ func (vm *VM) initBuiltinsTypes() {
 	vm.builtins["frozenset"] = &PyBuiltinFunc{
 		Name: "frozenset",
 		Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if err := checkNoKwargs("frozenset", kwargs); err != nil {
+				return nil, err
+			}
 			fs := &PyFrozenSet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
 			if len(args) > 0 {
 				items, err := vm.toList(args[0])
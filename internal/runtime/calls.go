@@ -1,13 +1,21 @@
 package runtime
 
-import "fmt"
+import (
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
 
 // Function calls
 
 func (vm *VM) call(callable Value, args []Value, kwargs map[string]Value) (Value, error) {
 	switch fn := callable.(type) {
 	case *PyBuiltinFunc:
-		return fn.Fn(args, kwargs)
+		return vm.callBuiltinFunc(fn, args, kwargs)
+
+	case *PyStringMethod:
+		return fn.Fn(vm, fn.Self, args, kwargs)
 
 	case *PyGoFunc:
 		// Call Go function with gopher-lua style stack-based API
@@ -21,6 +29,11 @@ func (vm *VM) call(callable Value, args []Value, kwargs map[string]Value) (Value
 		allArgs := append([]Value{fn.Instance}, args...)
 		return vm.callFunction(fn.Func, allArgs, kwargs)
 
+	case *GenericAlias:
+		// Calling list[int](...) constructs via the origin type; the
+		// parameters are not enforced, matching CPython
+		return vm.call(fn.Origin, args, kwargs)
+
 	case *PyClass:
 		// Check for custom metaclass __call__ override
 		typeClass, _ := vm.builtins["type"].(*PyClass)
@@ -99,6 +112,37 @@ func (vm *VM) call(callable Value, args []Value, kwargs map[string]Value) (Value
 	return nil, fmt.Errorf("TypeError: '%s' object is not callable", vm.typeName(callable))
 }
 
+// callBuiltinFunc invokes a builtin-style Go function, isolating panics the
+// same way callGoFunction does for stack-based callbacks: they become a
+// RuntimeError with the Go stack attached, or are rethrown to the host if
+// the VM is configured that way.
+func (vm *VM) callBuiltinFunc(fn *PyBuiltinFunc, args []Value, kwargs map[string]Value) (result Value, err error) {
+	// Expose the call-site keyword order to the callback (see KwargNames),
+	// restoring the enclosing callback's view when nested calls return
+	savedKwNames := vm.currentKwNames
+	vm.currentKwNames = nil
+	if len(kwargs) > 0 {
+		vm.currentKwNames = vm.pendingKwNames
+	}
+	vm.pendingKwNames = nil
+	defer func() {
+		vm.currentKwNames = savedKwNames
+		if r := recover(); r != nil {
+			if pe, ok := r.(*PyPanicError); ok {
+				result = nil
+				err = fmt.Errorf("%s: %s", pe.ExcType, pe.Message)
+				return
+			}
+			if vm.panicRethrow {
+				panic(&rethrownPanic{value: r})
+			}
+			result = nil
+			err = fmt.Errorf("RuntimeError: panic in Go function '%s': %v\n%s", fn.Name, r, debug.Stack())
+		}
+	}()
+	return fn.Fn(args, kwargs)
+}
+
 // callGoFunction calls a Go function with stack-based argument passing
 func (vm *VM) callGoFunction(fn *PyGoFunc, args []Value) (Value, error) {
 	// Save current frame state
@@ -123,17 +167,27 @@ func (vm *VM) callGoFunction(fn *PyGoFunc, args []Value) (Value, error) {
 	// Call the Go function - it returns number of results
 	var nResults int
 	var panicErr *PyPanicError
+	var interruptErr error
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
 				// Check if it's a typed PyPanicError for better exception mapping
 				if pe, ok := r.(*PyPanicError); ok {
 					panicErr = pe
+				} else if ip, ok := r.(*interruptPanic); ok {
+					// Timeout/cancellation checkpoint fired inside the builtin
+					interruptErr = ip.err
+					nResults = -1
+					return
 				} else {
-					// Generic panic - convert to string
+					if vm.panicRethrow {
+						panic(&rethrownPanic{value: r})
+					}
+					// Generic panic — a bug in the callback. Attach the Go
+					// stack so the failure can be traced from Python side.
 					panicErr = &PyPanicError{
 						ExcType: "RuntimeError",
-						Message: fmt.Sprintf("%v", r),
+						Message: fmt.Sprintf("panic in Go function '%s': %v\n%s", fn.Name, r, debug.Stack()),
 					}
 				}
 				nResults = -1 // Indicate error
@@ -147,6 +201,9 @@ func (vm *VM) callGoFunction(fn *PyGoFunc, args []Value) (Value, error) {
 
 	// Handle error case
 	if nResults < 0 {
+		if interruptErr != nil {
+			return nil, interruptErr
+		}
 		return nil, fmt.Errorf("%s: %s", panicErr.ExcType, panicErr.Message)
 	}
 
@@ -165,9 +222,125 @@ func (vm *VM) callGoFunction(fn *PyGoFunc, args []Value) (Value, error) {
 	}
 }
 
+// checkFunctionArgs validates a call against the function's signature before a
+// frame is created, producing CPython-style TypeErrors for arity mismatches,
+// unknown keywords, and positional-only parameters passed by keyword.
+func (vm *VM) checkFunctionArgs(fn *PyFunction, args []Value, kwargs map[string]Value) error {
+	code := fn.Code
+	numDefaults := 0
+	if fn.Defaults != nil {
+		numDefaults = len(fn.Defaults.Items)
+	}
+
+	if len(args) > code.ArgCount && code.Flags&FlagVarArgs == 0 {
+		if numDefaults > 0 {
+			return fmt.Errorf("TypeError: %s() takes from %d to %d positional arguments but %d were given",
+				fn.Name, code.ArgCount-numDefaults, code.ArgCount, len(args))
+		}
+		plural := "s"
+		if code.ArgCount == 1 {
+			plural = ""
+		}
+		return fmt.Errorf("TypeError: %s() takes %d positional argument%s but %d were given",
+			fn.Name, code.ArgCount, plural, len(args))
+	}
+
+	totalNamed := code.ArgCount + code.KwOnlyArgCount
+	bound := make([]bool, totalNamed)
+	for i := 0; i < len(args) && i < code.ArgCount; i++ {
+		bound[i] = true
+	}
+
+	var posOnlyAsKeyword []string
+	for name := range kwargs {
+		idx := -1
+		for i := 0; i < totalNamed && i < len(code.VarNames); i++ {
+			if code.VarNames[i] == name {
+				idx = i
+				break
+			}
+		}
+		switch {
+		case idx >= 0 && idx < code.PosOnlyArgCount:
+			// Positional-only params can only be matched by keyword if the
+			// function has **kwargs, in which case they land in that dict.
+			if code.Flags&FlagVarKeywords == 0 {
+				posOnlyAsKeyword = append(posOnlyAsKeyword, name)
+			}
+		case idx >= 0:
+			if bound[idx] {
+				return fmt.Errorf("TypeError: %s() got multiple values for argument '%s'", fn.Name, name)
+			}
+			bound[idx] = true
+		default:
+			if code.Flags&FlagVarKeywords == 0 {
+				return fmt.Errorf("TypeError: %s() got an unexpected keyword argument '%s'", fn.Name, name)
+			}
+		}
+	}
+	if len(posOnlyAsKeyword) > 0 {
+		sort.Strings(posOnlyAsKeyword)
+		return fmt.Errorf("TypeError: %s() got some positional-only arguments passed as keyword arguments: %s",
+			fn.Name, joinArgNames(posOnlyAsKeyword))
+	}
+
+	var missing []string
+	for i := 0; i < code.ArgCount-numDefaults; i++ {
+		if !bound[i] && i < len(code.VarNames) {
+			missing = append(missing, code.VarNames[i])
+		}
+	}
+	if len(missing) > 0 {
+		plural := "s"
+		if len(missing) == 1 {
+			plural = ""
+		}
+		return fmt.Errorf("TypeError: %s() missing %d required positional argument%s: %s",
+			fn.Name, len(missing), plural, joinArgNames(missing))
+	}
+
+	for i := code.ArgCount; i < totalNamed && i < len(code.VarNames); i++ {
+		if bound[i] {
+			continue
+		}
+		if fn.KwDefaults != nil {
+			if _, ok := fn.KwDefaults[code.VarNames[i]]; ok {
+				continue
+			}
+		}
+		missing = append(missing, code.VarNames[i])
+	}
+	if len(missing) > 0 {
+		plural := "s"
+		if len(missing) == 1 {
+			plural = ""
+		}
+		return fmt.Errorf("TypeError: %s() missing %d required keyword-only argument%s: %s",
+			fn.Name, len(missing), plural, joinArgNames(missing))
+	}
+
+	return nil
+}
+
+// joinArgNames formats parameter names for an arity error: 'a', 'b' and 'c'.
+func joinArgNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = "'" + n + "'"
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " and " + quoted[len(quoted)-1]
+}
+
 func (vm *VM) callFunction(fn *PyFunction, args []Value, kwargs map[string]Value) (Value, error) {
 	code := fn.Code
 
+	if err := vm.checkFunctionArgs(fn, args, kwargs); err != nil {
+		return nil, err
+	}
+
 	// Check if this is a generator or coroutine - if so, create the appropriate object
 	// instead of executing immediately
 	if code.Flags&FlagGenerator != 0 {
@@ -177,8 +350,7 @@ func (vm *VM) callFunction(fn *PyFunction, args []Value, kwargs map[string]Value
 		return vm.createCoroutine(fn, args, kwargs)
 	}
 	if code.Flags&FlagAsyncGenerator != 0 {
-		// For now, treat async generators like coroutines
-		return vm.createCoroutine(fn, args, kwargs)
+		return vm.createAsyncGenerator(fn, args, kwargs)
 	}
 
 	// Check recursion depth before pushing frame
@@ -387,12 +559,13 @@ func (vm *VM) createFunctionFrame(fn *PyFunction, args []Value, kwargs map[strin
 		}
 	}
 
-	// Apply keyword arguments to the appropriate local slots
+	// Apply keyword arguments to the appropriate local slots.
+	// Positional-only params (before /) are never matched by name.
 	if kwargs != nil {
 		for name, val := range kwargs {
 			// Find the parameter index by name in positional and kw-only params
 			for i, varName := range code.VarNames {
-				if varName == name && i < code.ArgCount+code.KwOnlyArgCount {
+				if varName == name && i >= code.PosOnlyArgCount && i < code.ArgCount+code.KwOnlyArgCount {
 					frame.Locals[i] = val
 					break
 				}
@@ -411,8 +584,9 @@ func (vm *VM) createFunctionFrame(fn *PyFunction, args []Value, kwargs map[strin
 			if kwargs != nil {
 				for name, val := range kwargs {
 					// Check if this kwarg matches a named parameter
+					// (positional-only names don't count: they fall through to **kwargs)
 					isNamedParam := false
-					for i := 0; i < code.ArgCount+code.KwOnlyArgCount && i < len(code.VarNames); i++ {
+					for i := code.PosOnlyArgCount; i < code.ArgCount+code.KwOnlyArgCount && i < len(code.VarNames); i++ {
 						if code.VarNames[i] == name {
 							isNamedParam = true
 							break
@@ -488,3 +662,13 @@ func (vm *VM) createCoroutine(fn *PyFunction, args []Value, kwargs map[string]Va
 		State: GenCreated,
 	}, nil
 }
+
+// createAsyncGenerator creates an async generator from an async function
+// containing yield, backed by an ordinary generator over the same frame.
+func (vm *VM) createAsyncGenerator(fn *PyFunction, args []Value, kwargs map[string]Value) (*PyAsyncGenerator, error) {
+	gen, err := vm.createGenerator(fn, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	return &PyAsyncGenerator{Gen: gen}, nil
+}
@@ -8,8 +8,10 @@ import (
 	"strings"
 )
 
-// maxIntStringDigits is the maximum number of digits allowed when converting
-// a string to a big integer. Matches CPython 3.12+ default (sys.int_info.default_max_str_digits).
+// maxIntStringDigits is the default limit on the number of digits allowed
+// when converting a string to a big integer, matching CPython 3.12+
+// (sys.int_info.default_max_str_digits). Hosts adjust it per VM with
+// SetIntMaxStrDigits.
 const maxIntStringDigits = 4300
 
 // Type conversions
@@ -119,11 +121,14 @@ func (vm *VM) tryToIntValue(v Value) (Value, error) {
 		if s == "" {
 			return nil, fmt.Errorf("ValueError: invalid literal for int() with base 10: %q", val.Value)
 		}
-		s = strings.ReplaceAll(s, "_", "")
+		s, ok := stripIntUnderscores(s, false)
+		if !ok {
+			return nil, fmt.Errorf("ValueError: invalid literal for int() with base 10: %q", val.Value)
+		}
 		i, err := strconv.ParseInt(s, 10, 64)
 		if err != nil {
-			if len(s) > maxIntStringDigits {
-				return nil, fmt.Errorf("ValueError: Exceeds the limit (%d digits) for integer string conversion: value has %d digits", maxIntStringDigits, len(s))
+			if vm.intMaxStrDigits > 0 && len(s) > vm.intMaxStrDigits {
+				return nil, fmt.Errorf("ValueError: Exceeds the limit (%d digits) for integer string conversion: value has %d digits", vm.intMaxStrDigits, len(s))
 			}
 			// Try big.Int for overflow
 			bi := new(big.Int)
@@ -183,9 +188,6 @@ func (vm *VM) intFromStringBase(s string, base int64) (Value, error) {
 		return nil, fmt.Errorf("ValueError: int() base must be >= 2 and <= 36, or 0")
 	}
 
-	// Remove underscores
-	s = strings.ReplaceAll(s, "_", "")
-
 	// Handle sign
 	negative := false
 	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
@@ -197,21 +199,26 @@ func (vm *VM) intFromStringBase(s string, base int64) (Value, error) {
 	}
 
 	// Handle base 0 (auto-detect)
+	prefixStripped := false
 	if base == 0 {
 		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
 			base = 16
 			s = s[2:]
+			prefixStripped = true
 		} else if strings.HasPrefix(s, "0o") || strings.HasPrefix(s, "0O") {
 			base = 8
 			s = s[2:]
+			prefixStripped = true
 		} else if strings.HasPrefix(s, "0b") || strings.HasPrefix(s, "0B") {
 			base = 2
 			s = s[2:]
+			prefixStripped = true
 		} else if len(s) > 1 && s[0] == '0' {
-			// Leading zeros in base 0 are not allowed (except "0" itself)
+			// Leading zeros in base 0 are not allowed (except "0" itself,
+			// with or without underscore separators)
 			allZeros := true
 			for _, c := range s {
-				if c != '0' {
+				if c != '0' && c != '_' {
 					allZeros = false
 					break
 				}
@@ -227,21 +234,29 @@ func (vm *VM) intFromStringBase(s string, base int64) (Value, error) {
 		// Strip prefix if it matches the base
 		if base == 16 && (strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")) {
 			s = s[2:]
+			prefixStripped = true
 		} else if base == 8 && (strings.HasPrefix(s, "0o") || strings.HasPrefix(s, "0O")) {
 			s = s[2:]
+			prefixStripped = true
 		} else if base == 2 && (strings.HasPrefix(s, "0b") || strings.HasPrefix(s, "0B")) {
 			s = s[2:]
+			prefixStripped = true
 		}
 	}
 
+	s, okUnderscores := stripIntUnderscores(s, prefixStripped)
+	if !okUnderscores {
+		return nil, fmt.Errorf("ValueError: invalid literal for int() with base %d: %q", base, s)
+	}
+
 	if s == "" {
 		return nil, fmt.Errorf("ValueError: invalid literal for int() with base %d: ''", base)
 	}
 
 	i, err := strconv.ParseInt(s, int(base), 64)
 	if err != nil {
-		if len(s) > maxIntStringDigits {
-			return nil, fmt.Errorf("ValueError: Exceeds the limit (%d digits) for integer string conversion: value has %d digits", maxIntStringDigits, len(s))
+		if vm.intMaxStrDigits > 0 && len(s) > vm.intMaxStrDigits {
+			return nil, fmt.Errorf("ValueError: Exceeds the limit (%d digits) for integer string conversion: value has %d digits", vm.intMaxStrDigits, len(s))
 		}
 		// Try big.Int for overflow
 		bi := new(big.Int)
@@ -351,9 +366,30 @@ func (vm *VM) toList(v Value) ([]Value, error) {
 			items[i] = MakeInt(int64(b))
 		}
 		return items, nil
+	case *PyByteArray:
+		items := make([]Value, len(val.Value))
+		for i, b := range val.Value {
+			items[i] = MakeInt(int64(b))
+		}
+		return items, nil
+	case *PyFloatArray:
+		items := make([]Value, len(val.Values))
+		for i, f := range val.Values {
+			items[i] = &PyFloat{Value: f}
+		}
+		return items, nil
+	case *PyIntArray:
+		items := make([]Value, len(val.Values))
+		for i, n := range val.Values {
+			items[i] = MakeInt(n)
+		}
+		return items, nil
 	case *PyRange:
 		var items []Value
 		for i := val.Start; (val.Step > 0 && i < val.Stop) || (val.Step < 0 && i > val.Stop); i += val.Step {
+			if err := vm.CheckInterrupt(); err != nil {
+				return nil, err
+			}
 			items = append(items, MakeInt(i))
 		}
 		return items, nil
@@ -397,6 +433,21 @@ func (vm *VM) toList(v Value) ([]Value, error) {
 			items = append(items, value)
 		}
 		return items, nil
+	case *PyGoIterator:
+		var items []Value
+		for {
+			if err := vm.CheckInterrupt(); err != nil {
+				return nil, err
+			}
+			value, done, err := val.Next()
+			if err != nil {
+				return nil, err
+			}
+			if done {
+				return items, nil
+			}
+			items = append(items, value)
+		}
 	case *PyClass:
 		// Check for __iter__ in class dict or MRO
 		if iterMethod, err := vm.getAttr(val, "__iter__"); err == nil {
@@ -417,6 +468,16 @@ func (vm *VM) toList(v Value) ([]Value, error) {
 			return vm.iteratorToList(iterResult)
 		}
 		return nil, fmt.Errorf("'%s' object is not iterable", vm.typeName(v))
+	case *PyUserData:
+		// Check for an __iter__ metamethod in the metatable
+		if iterMethod, err := vm.getAttr(val, "__iter__"); err == nil {
+			iterResult, err := vm.call(iterMethod, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			return vm.iteratorToList(iterResult)
+		}
+		return nil, fmt.Errorf("'%s' object is not iterable", vm.typeName(v))
 	default:
 		return nil, fmt.Errorf("'%s' object is not iterable", vm.typeName(v))
 	}
@@ -426,6 +487,23 @@ func (vm *VM) toList(v Value) ([]Value, error) {
 func (vm *VM) iteratorToList(iterator Value) ([]Value, error) {
 	inst, ok := iterator.(*PyInstance)
 	if !ok {
+		if _, isUserData := iterator.(*PyUserData); isUserData {
+			// Userdata iterators drain through the generic __next__ protocol
+			var items []Value
+			for {
+				if err := vm.CheckInterrupt(); err != nil {
+					return nil, err
+				}
+				val, done, err := vm.iterNext(iterator)
+				if err != nil {
+					return nil, err
+				}
+				if done {
+					return items, nil
+				}
+				items = append(items, val)
+			}
+		}
 		// If __iter__ returned a known iterable type, just toList it
 		return vm.toList(iterator)
 	}
@@ -476,6 +554,12 @@ func (vm *VM) truthy(v Value) bool {
 		return rangeLen(val) > 0
 	case *PyBytes:
 		return len(val.Value) > 0
+	case *PyByteArray:
+		return len(val.Value) > 0
+	case *PyFloatArray:
+		return len(val.Values) > 0
+	case *PyIntArray:
+		return len(val.Values) > 0
 	case *PyInstance:
 		// Check __bool__ first
 		if result, found, err := vm.callDunder(val, "__bool__"); found {
@@ -528,13 +612,33 @@ func (vm *VM) str(v Value) string {
 		return val.Value
 	case *PyBytes:
 		return bytesRepr(val.Value)
+	case *PyByteArray:
+		return "bytearray(" + bytesRepr(val.Value) + ")"
+	case *PyFloatArray:
+		return floatArrayRepr(val.Values)
+	case *PyIntArray:
+		return intArrayRepr(val.Values)
+	case *PyVec2:
+		return val.String()
+	case *PyVec3:
+		return val.String()
+	case *PyMat3:
+		return val.String()
 	case *PyList:
+		if !vm.enterRepr(val) {
+			return "[...]"
+		}
+		defer vm.exitRepr(val)
 		parts := make([]string, len(val.Items))
 		for i, item := range val.Items {
 			parts[i] = vm.repr(item)
 		}
 		return "[" + strings.Join(parts, ", ") + "]"
 	case *PyTuple:
+		if !vm.enterRepr(val) {
+			return "(...)"
+		}
+		defer vm.exitRepr(val)
 		parts := make([]string, len(val.Items))
 		for i, item := range val.Items {
 			parts[i] = vm.repr(item)
@@ -544,6 +648,10 @@ func (vm *VM) str(v Value) string {
 		}
 		return "(" + strings.Join(parts, ", ") + ")"
 	case *PyDict:
+		if !vm.enterRepr(val) {
+			return "{...}"
+		}
+		defer vm.exitRepr(val)
 		orderedKeys := val.Keys(vm)
 		parts := make([]string, 0, len(orderedKeys))
 		for _, k := range orderedKeys {
@@ -683,6 +791,29 @@ func bytesRepr(data []byte) string {
 	return b.String()
 }
 
+// floatArrayRepr produces the repr for a floatarray, formatting each element
+// the way Python formats floats
+func floatArrayRepr(values []float64) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eEn") {
+			s += ".0"
+		}
+		parts[i] = s
+	}
+	return "floatarray([" + strings.Join(parts, ", ") + "])"
+}
+
+// intArrayRepr produces the repr for an intarray
+func intArrayRepr(values []int64) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.FormatInt(v, 10)
+	}
+	return "intarray([" + strings.Join(parts, ", ") + "])"
+}
+
 // asciiRepr returns a string representation with non-ASCII characters escaped.
 func asciiRepr(s string) string {
 	var b strings.Builder
@@ -774,6 +905,18 @@ func (vm *VM) typeName(v Value) string {
 		return "str"
 	case *PyBytes:
 		return "bytes"
+	case *PyByteArray:
+		return "bytearray"
+	case *PyFloatArray:
+		return "floatarray"
+	case *PyIntArray:
+		return "intarray"
+	case *PyVec2:
+		return "Vec2"
+	case *PyVec3:
+		return "Vec3"
+	case *PyMat3:
+		return "Mat3"
 	case *PyList:
 		return "list"
 	case *PyTuple:
@@ -814,6 +957,8 @@ func (vm *VM) typeName(v Value) string {
 		return "GenericAlias"
 	case *UnionType:
 		return "types.UnionType"
+	case *PyException:
+		return val.Type()
 	default:
 		return "object"
 	}
@@ -827,6 +972,18 @@ func (vm *VM) repr(v Value) string {
 		return fmt.Sprintf("'%s'", val.Value)
 	case *PyBytes:
 		return bytesRepr(val.Value)
+	case *PyByteArray:
+		return "bytearray(" + bytesRepr(val.Value) + ")"
+	case *PyFloatArray:
+		return floatArrayRepr(val.Values)
+	case *PyIntArray:
+		return intArrayRepr(val.Values)
+	case *PyVec2:
+		return val.String()
+	case *PyVec3:
+		return val.String()
+	case *PyMat3:
+		return val.String()
 	case *PyNone:
 		return "None"
 	case *PyBool:
@@ -835,12 +992,20 @@ func (vm *VM) repr(v Value) string {
 		}
 		return "False"
 	case *PyList:
+		if !vm.enterRepr(val) {
+			return "[...]"
+		}
+		defer vm.exitRepr(val)
 		parts := make([]string, len(val.Items))
 		for i, item := range val.Items {
 			parts[i] = vm.repr(item)
 		}
 		return "[" + strings.Join(parts, ", ") + "]"
 	case *PyTuple:
+		if !vm.enterRepr(val) {
+			return "(...)"
+		}
+		defer vm.exitRepr(val)
 		parts := make([]string, len(val.Items))
 		for i, item := range val.Items {
 			parts[i] = vm.repr(item)
@@ -850,6 +1015,10 @@ func (vm *VM) repr(v Value) string {
 		}
 		return "(" + strings.Join(parts, ", ") + ")"
 	case *PyDict:
+		if !vm.enterRepr(val) {
+			return "{...}"
+		}
+		defer vm.exitRepr(val)
 		orderedKeys := val.Keys(vm)
 		parts := make([]string, 0, len(orderedKeys))
 		for _, k := range orderedKeys {
@@ -882,3 +1051,27 @@ func (vm *VM) repr(v Value) string {
 		return vm.str(v)
 	}
 }
+
+// stripIntUnderscores removes underscore digit separators from a numeric
+// string, rejecting the placements CPython rejects: leading or trailing
+// underscores, doubled underscores, and an underscore right after the sign.
+// afterPrefix permits one leading underscore, which is legal immediately
+// after a base prefix ("0x_1f").
+func stripIntUnderscores(s string, afterPrefix bool) (string, bool) {
+	if !strings.Contains(s, "_") {
+		return s, true
+	}
+	if strings.Contains(s, "__") || strings.HasSuffix(s, "_") {
+		return "", false
+	}
+	if len(s) > 1 && (s[0] == '+' || s[0] == '-') && s[1] == '_' {
+		return "", false
+	}
+	if strings.HasPrefix(s, "_") {
+		if !afterPrefix {
+			return "", false
+		}
+		s = s[1:]
+	}
+	return strings.ReplaceAll(s, "_", ""), true
+}
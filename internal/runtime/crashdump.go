@@ -0,0 +1,110 @@
+package runtime
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// opRingSize is the number of recent opcodes kept for crash dumps. Must be
+// a power of two so the ring index can be masked.
+const opRingSize = 64
+
+// SetCrashSink installs a host-provided sink that receives a diagnostic
+// bundle whenever the VM recovers from an internal panic — a VM bug or
+// malformed bytecode, not a Python exception. While a sink is installed
+// the dispatch loop records the last executed opcodes so the bundle can
+// include them. Pass nil to uninstall.
+func (vm *VM) SetCrashSink(sink func(dump []byte)) {
+	vm.crashSink = sink
+	if sink != nil && vm.opRing == nil {
+		vm.opRing = make([]Opcode, opRingSize)
+	}
+}
+
+// emitCrashDump builds the diagnostic bundle for a recovered panic and
+// hands it to the sink. A panicking sink must not take down the recovery
+// path, so it runs under its own recover.
+func (vm *VM) emitCrashDump(panicValue any) {
+	if vm.crashSink == nil {
+		return
+	}
+	defer func() { _ = recover() }()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== rage VM crash dump ===\npanic: %v\n", panicValue)
+
+	if frame := vm.frame; frame != nil && frame.Code != nil {
+		code := frame.Code
+		fmt.Fprintf(&b, "\n--- frame ---\ncode: %s (%s:%d)\nip: %d of %d bytes\n",
+			code.Name, code.Filename, code.FirstLine, frame.IP, len(code.Code))
+
+		fmt.Fprintf(&b, "\n--- value stack (top first, %d entries) ---\n", frame.SP)
+		for i := frame.SP - 1; i >= 0 && i >= frame.SP-16; i-- {
+			fmt.Fprintf(&b, "%3d: %s\n", i, vm.safeDescribe(frame.Stack[i]))
+		}
+
+		fmt.Fprintf(&b, "\n--- bytecode (%d bytes) ---\n% x\n", len(code.Code), code.Code)
+	}
+
+	if vm.opRing != nil && vm.opRingPos > 0 {
+		b.WriteString("\n--- last opcodes (oldest first) ---\n")
+		start := vm.opRingPos - opRingSize
+		if start < 0 {
+			start = 0
+		}
+		for i := start; i < vm.opRingPos; i++ {
+			fmt.Fprintf(&b, "%s\n", vm.opRing[i&(opRingSize-1)])
+		}
+	}
+
+	b.WriteString("\n--- globals summary ---\n")
+	names := make([]string, 0, len(vm.Globals))
+	for name := range vm.Globals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) > 100 {
+		names = names[:100]
+	}
+	for _, name := range names {
+		b.WriteString(name)
+		if v := vm.Globals[name]; v != nil {
+			fmt.Fprintf(&b, ": %s", vm.safeTypeName(v))
+		}
+		b.WriteByte('\n')
+	}
+
+	fmt.Fprintf(&b, "\n--- go stack ---\n%s", debug.Stack())
+
+	vm.crashSink([]byte(b.String()))
+}
+
+// safeDescribe renders a stack value for the dump without risking a second
+// panic from a misbehaving String method.
+func (vm *VM) safeDescribe(v Value) (out string) {
+	defer func() {
+		if recover() != nil {
+			out = "<unprintable>"
+		}
+	}()
+	if v == nil {
+		return "<nil>"
+	}
+	s := fmt.Sprintf("%s: %v", vm.safeTypeName(v), v)
+	if len(s) > 120 {
+		s = s[:120] + "..."
+	}
+	return s
+}
+
+// safeTypeName returns a value's Python type name, guarding against panics.
+func (vm *VM) safeTypeName(v Value) (out string) {
+	defer func() {
+		if recover() != nil {
+			out = "<unknown>"
+		}
+	}()
+	return vm.typeName(v)
+}
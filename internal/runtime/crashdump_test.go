@@ -0,0 +1,89 @@
+package runtime
+
+import (
+	"strings"
+	"testing"
+)
+
+// crashingCode builds bytecode that pops an empty stack, which panics
+// inside the dispatch loop the way a VM bug would.
+func crashingCode() *CodeObject {
+	return &CodeObject{
+		Name:      "<module>",
+		Filename:  "crash.py",
+		Code:      []byte{byte(OpPop)},
+		StackSize: 4,
+	}
+}
+
+func TestCrashSinkReceivesDump(t *testing.T) {
+	vm := NewVM()
+	vm.SetGlobal("player_count", MakeInt(3))
+
+	var dump []byte
+	vm.SetCrashSink(func(d []byte) { dump = d })
+
+	_, err := vm.Execute(crashingCode())
+	if err == nil {
+		t.Fatal("expected an internal VM error")
+	}
+	if !strings.Contains(err.Error(), "internal VM error") {
+		t.Fatalf("expected internal VM error, got %v", err)
+	}
+	if dump == nil {
+		t.Fatal("expected a crash dump")
+	}
+
+	text := string(dump)
+	for _, want := range []string{
+		"rage VM crash dump",
+		"crash.py",
+		"--- last opcodes",
+		"--- globals summary",
+		"player_count: int",
+		"--- go stack",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("dump missing %q", want)
+		}
+	}
+}
+
+func TestCrashSinkNotCalledForPythonErrors(t *testing.T) {
+	vm := NewVM()
+	called := false
+	vm.SetCrashSink(func(d []byte) { called = true })
+
+	// A Python-level exception is not an internal inconsistency
+	code := &CodeObject{
+		Name:      "<module>",
+		Filename:  "err.py",
+		Code:      []byte{byte(OpLoadConst), 0, 0, byte(OpLoadConst), 1, 0, byte(OpBinaryDivide), byte(OpReturn)},
+		Constants: []any{int64(1), int64(0)},
+		StackSize: 4,
+	}
+	if _, err := vm.Execute(code); err == nil {
+		t.Fatal("expected a ZeroDivisionError")
+	}
+	if called {
+		t.Error("crash sink must not fire for ordinary Python exceptions")
+	}
+}
+
+func TestRecoveryWithoutSink(t *testing.T) {
+	vm := NewVM()
+	_, err := vm.Execute(crashingCode())
+	if err == nil || !strings.Contains(err.Error(), "internal VM error") {
+		t.Fatalf("expected internal VM error without a sink, got %v", err)
+	}
+}
+
+func TestCrashSinkPanicDoesNotEscape(t *testing.T) {
+	vm := NewVM()
+	vm.SetCrashSink(func(d []byte) { panic("sink bug") })
+
+	_, err := vm.Execute(crashingCode())
+	if err == nil || !strings.Contains(err.Error(), "internal VM error") {
+		t.Fatalf("expected internal VM error despite sink panic, got %v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package runtime
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // Exception handling helpers
 
@@ -170,10 +173,19 @@ func (vm *VM) buildTraceback() []TracebackEntry {
 	for i := len(vm.frames) - 1; i >= 0; i-- {
 		f := vm.frames[i]
 		line := f.Code.LineForOffset(f.IP)
+		// The IP has already advanced past the failing instruction, so the
+		// column lookup uses its last byte to stay inside the right entry
+		colOffset := f.IP - 1
+		if colOffset < 0 {
+			colOffset = 0
+		}
+		col, endCol := f.Code.ColumnsForOffset(colOffset)
 		tb = append(tb, TracebackEntry{
 			Filename: f.Code.Filename,
 			Line:     line,
 			Function: f.Code.Name,
+			Col:      col,
+			EndCol:   endCol,
 		})
 	}
 	return tb
@@ -186,6 +198,12 @@ func (vm *VM) handleException(exc *PyException) (Value, error) {
 	vm.currentException = exc
 	vm.lastException = exc
 
+	// Attach position info so uncaught errors can report file:line and the
+	// failing expression; explicit raises build their traceback at OpRaise
+	if exc.Traceback == nil {
+		exc.Traceback = vm.buildTraceback()
+	}
+
 	if len(vm.frames) == 0 {
 		return nil, exc
 	}
@@ -222,9 +240,9 @@ func (vm *VM) handleException(exc *PyException) (Value, error) {
 			// Must execute finally block first
 			frame.SP = block.Level
 			frame.IP = block.Handler
-			vm.push(exc)                                             // Push exception for finally to potentially re-raise
-			vm.excHandlerStack = append(vm.excHandlerStack, exc)     // Track for __context__ on new exceptions in finally
-			return nil, nil // Continue execution at finally
+			vm.push(exc)                                         // Push exception for finally to potentially re-raise
+			vm.excHandlerStack = append(vm.excHandlerStack, exc) // Track for __context__ on new exceptions in finally
+			return nil, nil                                      // Continue execution at finally
 
 		case BlockWith:
 			// With block - must run __exit__ with exception info
@@ -347,12 +365,68 @@ var exceptionPrefixes = []struct {
 	{"Warning", "Warning", ""},
 }
 
+// PyErrorer lets a Go error choose the Python exception it becomes when it
+// crosses into script code, instead of relying on message-prefix matching.
+type PyErrorer interface {
+	error
+	PyError() (excType, message string)
+}
+
+// MapError registers a sentinel error mapping: Go errors matching sentinel
+// via errors.Is are raised as the named exception type, for example
+// vm.MapError(os.ErrNotExist, "FileNotFoundError"). Mappings are checked in
+// registration order, after PyErrorer but before message-prefix matching.
+func (vm *VM) MapError(sentinel error, excName string) {
+	vm.errorMappings = append(vm.errorMappings, errorMapping{sentinel: sentinel, excName: excName})
+}
+
+// errorMapping pairs a Go sentinel error with a Python exception type name.
+type errorMapping struct {
+	sentinel error
+	excName  string
+}
+
+// namedException builds a PyException for a type name, falling back to
+// RuntimeError when the name is not a known exception class.
+func (vm *VM) namedException(excName, msg string) *PyException {
+	excClass := vm.builtinClass(excName)
+	if excClass == nil {
+		excClass = vm.builtinClass("RuntimeError")
+	}
+	return &PyException{
+		ExcType: excClass,
+		Args:    &PyTuple{Items: []Value{&PyString{Value: msg}}},
+		Message: msg,
+	}
+}
+
+// WrapError converts a Go error to the Python exception it would surface as,
+// applying the same mapping rules as errors returned from host callbacks
+// (PyErrorer, MapError sentinels, message prefixes).
+func (vm *VM) WrapError(err error) *PyException {
+	return vm.wrapGoError(err)
+}
+
 // wrapGoError converts a Go error to a Python exception
 func (vm *VM) wrapGoError(err error) *PyException {
 	if pyExc, ok := err.(*PyException); ok {
 		return pyExc
 	}
 
+	// Errors can pick their own exception type (see PyErrorer)
+	var chooser PyErrorer
+	if errors.As(err, &chooser) {
+		excType, msg := chooser.PyError()
+		return vm.namedException(excType, msg)
+	}
+
+	// Host-registered sentinel mappings (see MapError)
+	for _, m := range vm.errorMappings {
+		if errors.Is(err, m.sentinel) {
+			return vm.namedException(m.excName, err.Error())
+		}
+	}
+
 	errStr := err.Error()
 
 	// Find exception type using prefix matching (prefixes sorted by length desc)
@@ -374,8 +448,10 @@ func (vm *VM) wrapGoError(err error) *PyException {
 			break
 		}
 	}
+	defaulted := false
 	if excClass == nil {
 		// Fallback to RuntimeError with safe type assertion
+		defaulted = true
 		if re, ok := vm.builtins["RuntimeError"]; ok {
 			if cls, ok := re.(*PyClass); ok {
 				excClass = cls
@@ -392,11 +468,20 @@ func (vm *VM) wrapGoError(err error) *PyException {
 		}
 	}
 
-	return &PyException{
+	exc := &PyException{
 		ExcType: excClass,
 		Args:    &PyTuple{Items: []Value{&PyString{Value: msg}}},
 		Message: msg,
 	}
+	// For the RuntimeError default, surface a wrapped cause as __cause__ so
+	// scripts can see the error chain the host built with %w
+	if defaulted {
+		if inner := errors.Unwrap(err); inner != nil {
+			exc.Cause = vm.wrapGoError(inner)
+			exc.SuppressContext = true
+		}
+	}
+	return exc
 }
 
 // pyExceptionList is a Value wrapper for []*PyException stored in ExceptionGroup instances
@@ -0,0 +1,471 @@
+package runtime
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Native game-math values backing the gamemath stdlib module. Vec2/Vec3/Mat3
+// are runtime types rather than ClassBuilder classes so vector arithmetic in
+// hot script paths stays in Go loops instead of dunder dispatch.
+
+// PyVec2 is an immutable 2D vector
+type PyVec2 struct {
+	X, Y float64
+}
+
+func (v *PyVec2) Type() string { return "Vec2" }
+func (v *PyVec2) String() string {
+	return "Vec2(" + vecFloatStr(v.X) + ", " + vecFloatStr(v.Y) + ")"
+}
+
+// PyVec3 is an immutable 3D vector
+type PyVec3 struct {
+	X, Y, Z float64
+}
+
+func (v *PyVec3) Type() string { return "Vec3" }
+func (v *PyVec3) String() string {
+	return "Vec3(" + vecFloatStr(v.X) + ", " + vecFloatStr(v.Y) + ", " + vecFloatStr(v.Z) + ")"
+}
+
+// PyMat3 is an immutable 3x3 matrix in row-major order
+type PyMat3 struct {
+	M [9]float64
+}
+
+func (m *PyMat3) Type() string { return "Mat3" }
+func (m *PyMat3) String() string {
+	parts := make([]string, 9)
+	for i, v := range m.M {
+		parts[i] = vecFloatStr(v)
+	}
+	return "Mat3(" + strings.Join(parts, ", ") + ")"
+}
+
+// vecFloatStr formats a component the way Python formats floats
+func vecFloatStr(v float64) string {
+	s := strconv.FormatFloat(v, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eEn") {
+		s += ".0"
+	}
+	return s
+}
+
+// isGameMathValue reports whether v is one of the native game-math types
+func isGameMathValue(v Value) bool {
+	switch v.(type) {
+	case *PyVec2, *PyVec3, *PyMat3:
+		return true
+	}
+	return false
+}
+
+// gameMathScalar extracts a float from an int/float/bool operand
+func gameMathScalar(v Value) (float64, bool) {
+	switch n := v.(type) {
+	case *PyFloat:
+		return n.Value, true
+	case *PyInt:
+		return float64(n.Value), true
+	case *PyBool:
+		if n.Value {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// gameMathBinaryOp implements the operators for Vec2/Vec3/Mat3. The second
+// return value reports whether the operand combination was handled; when it
+// is false the caller falls through to normal dispatch.
+func (vm *VM) gameMathBinaryOp(op Opcode, a, b Value) (Value, bool, error) {
+	switch av := a.(type) {
+	case *PyVec2:
+		if bv, ok := b.(*PyVec2); ok {
+			switch op {
+			case OpBinaryAdd:
+				return &PyVec2{X: av.X + bv.X, Y: av.Y + bv.Y}, true, nil
+			case OpBinarySubtract:
+				return &PyVec2{X: av.X - bv.X, Y: av.Y - bv.Y}, true, nil
+			case OpBinaryMultiply:
+				// Componentwise product; use dot() for the scalar product
+				return &PyVec2{X: av.X * bv.X, Y: av.Y * bv.Y}, true, nil
+			case OpBinaryMatMul:
+				return &PyFloat{Value: av.X*bv.X + av.Y*bv.Y}, true, nil
+			}
+		}
+		if s, ok := gameMathScalar(b); ok {
+			switch op {
+			case OpBinaryMultiply:
+				return &PyVec2{X: av.X * s, Y: av.Y * s}, true, nil
+			case OpBinaryDivide:
+				if s == 0 {
+					return nil, true, fmt.Errorf("ZeroDivisionError: Vec2 division by zero")
+				}
+				return &PyVec2{X: av.X / s, Y: av.Y / s}, true, nil
+			}
+		}
+	case *PyVec3:
+		if bv, ok := b.(*PyVec3); ok {
+			switch op {
+			case OpBinaryAdd:
+				return &PyVec3{X: av.X + bv.X, Y: av.Y + bv.Y, Z: av.Z + bv.Z}, true, nil
+			case OpBinarySubtract:
+				return &PyVec3{X: av.X - bv.X, Y: av.Y - bv.Y, Z: av.Z - bv.Z}, true, nil
+			case OpBinaryMultiply:
+				return &PyVec3{X: av.X * bv.X, Y: av.Y * bv.Y, Z: av.Z * bv.Z}, true, nil
+			case OpBinaryMatMul:
+				return &PyFloat{Value: av.X*bv.X + av.Y*bv.Y + av.Z*bv.Z}, true, nil
+			}
+		}
+		if s, ok := gameMathScalar(b); ok {
+			switch op {
+			case OpBinaryMultiply:
+				return &PyVec3{X: av.X * s, Y: av.Y * s, Z: av.Z * s}, true, nil
+			case OpBinaryDivide:
+				if s == 0 {
+					return nil, true, fmt.Errorf("ZeroDivisionError: Vec3 division by zero")
+				}
+				return &PyVec3{X: av.X / s, Y: av.Y / s, Z: av.Z / s}, true, nil
+			}
+		}
+	case *PyMat3:
+		switch bv := b.(type) {
+		case *PyMat3:
+			switch op {
+			case OpBinaryAdd:
+				var m [9]float64
+				for i := range m {
+					m[i] = av.M[i] + bv.M[i]
+				}
+				return &PyMat3{M: m}, true, nil
+			case OpBinarySubtract:
+				var m [9]float64
+				for i := range m {
+					m[i] = av.M[i] - bv.M[i]
+				}
+				return &PyMat3{M: m}, true, nil
+			case OpBinaryMultiply, OpBinaryMatMul:
+				var m [9]float64
+				for r := 0; r < 3; r++ {
+					for c := 0; c < 3; c++ {
+						m[r*3+c] = av.M[r*3]*bv.M[c] + av.M[r*3+1]*bv.M[3+c] + av.M[r*3+2]*bv.M[6+c]
+					}
+				}
+				return &PyMat3{M: m}, true, nil
+			}
+		case *PyVec3:
+			if op == OpBinaryMultiply || op == OpBinaryMatMul {
+				return &PyVec3{
+					X: av.M[0]*bv.X + av.M[1]*bv.Y + av.M[2]*bv.Z,
+					Y: av.M[3]*bv.X + av.M[4]*bv.Y + av.M[5]*bv.Z,
+					Z: av.M[6]*bv.X + av.M[7]*bv.Y + av.M[8]*bv.Z,
+				}, true, nil
+			}
+		case *PyVec2:
+			// Treat a Vec2 as a point (x, y, 1) for affine transforms
+			if op == OpBinaryMultiply || op == OpBinaryMatMul {
+				return &PyVec2{
+					X: av.M[0]*bv.X + av.M[1]*bv.Y + av.M[2],
+					Y: av.M[3]*bv.X + av.M[4]*bv.Y + av.M[5],
+				}, true, nil
+			}
+		}
+		if s, ok := gameMathScalar(b); ok && op == OpBinaryMultiply {
+			var m [9]float64
+			for i := range m {
+				m[i] = av.M[i] * s
+			}
+			return &PyMat3{M: m}, true, nil
+		}
+	}
+
+	// scalar * vec and scalar * mat
+	if s, ok := gameMathScalar(a); ok && op == OpBinaryMultiply {
+		switch bv := b.(type) {
+		case *PyVec2:
+			return &PyVec2{X: s * bv.X, Y: s * bv.Y}, true, nil
+		case *PyVec3:
+			return &PyVec3{X: s * bv.X, Y: s * bv.Y, Z: s * bv.Z}, true, nil
+		case *PyMat3:
+			var m [9]float64
+			for i := range m {
+				m[i] = s * bv.M[i]
+			}
+			return &PyMat3{M: m}, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// getAttrVec2 handles attribute access on *PyVec2 values.
+func (vm *VM) getAttrVec2(v *PyVec2, name string) (Value, error) {
+	switch name {
+	case "x":
+		return &PyFloat{Value: v.X}, nil
+	case "y":
+		return &PyFloat{Value: v.Y}, nil
+
+	case "length":
+		return &PyBuiltinFunc{Name: "Vec2.length", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			return &PyFloat{Value: math.Hypot(v.X, v.Y)}, nil
+		}}, nil
+
+	case "length_squared":
+		return &PyBuiltinFunc{Name: "Vec2.length_squared", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			return &PyFloat{Value: v.X*v.X + v.Y*v.Y}, nil
+		}}, nil
+
+	case "normalize":
+		return &PyBuiltinFunc{Name: "Vec2.normalize", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			l := math.Hypot(v.X, v.Y)
+			if l == 0 {
+				return nil, fmt.Errorf("ValueError: cannot normalize a zero-length Vec2")
+			}
+			return &PyVec2{X: v.X / l, Y: v.Y / l}, nil
+		}}, nil
+
+	case "dot":
+		return &PyBuiltinFunc{Name: "Vec2.dot", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			o, err := vm.ToVec2(argOne(args))
+			if err != nil {
+				return nil, err
+			}
+			return &PyFloat{Value: v.X*o.X + v.Y*o.Y}, nil
+		}}, nil
+
+	case "cross":
+		// The 2D cross product is the scalar z-component
+		return &PyBuiltinFunc{Name: "Vec2.cross", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			o, err := vm.ToVec2(argOne(args))
+			if err != nil {
+				return nil, err
+			}
+			return &PyFloat{Value: v.X*o.Y - v.Y*o.X}, nil
+		}}, nil
+
+	case "lerp":
+		return &PyBuiltinFunc{Name: "Vec2.lerp", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("TypeError: lerp() takes exactly 2 arguments (%d given)", len(args))
+			}
+			o, err := vm.ToVec2(args[0])
+			if err != nil {
+				return nil, err
+			}
+			t, ok := gameMathScalar(args[1])
+			if !ok {
+				return nil, fmt.Errorf("TypeError: lerp() factor must be a number, not %s", vm.typeName(args[1]))
+			}
+			return &PyVec2{X: v.X + (o.X-v.X)*t, Y: v.Y + (o.Y-v.Y)*t}, nil
+		}}, nil
+
+	case "distance":
+		return &PyBuiltinFunc{Name: "Vec2.distance", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			o, err := vm.ToVec2(argOne(args))
+			if err != nil {
+				return nil, err
+			}
+			return &PyFloat{Value: math.Hypot(v.X-o.X, v.Y-o.Y)}, nil
+		}}, nil
+	}
+	return nil, fmt.Errorf("'Vec2' object has no attribute '%s'", name)
+}
+
+// getAttrVec3 handles attribute access on *PyVec3 values.
+func (vm *VM) getAttrVec3(v *PyVec3, name string) (Value, error) {
+	switch name {
+	case "x":
+		return &PyFloat{Value: v.X}, nil
+	case "y":
+		return &PyFloat{Value: v.Y}, nil
+	case "z":
+		return &PyFloat{Value: v.Z}, nil
+
+	case "length":
+		return &PyBuiltinFunc{Name: "Vec3.length", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			return &PyFloat{Value: math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)}, nil
+		}}, nil
+
+	case "length_squared":
+		return &PyBuiltinFunc{Name: "Vec3.length_squared", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			return &PyFloat{Value: v.X*v.X + v.Y*v.Y + v.Z*v.Z}, nil
+		}}, nil
+
+	case "normalize":
+		return &PyBuiltinFunc{Name: "Vec3.normalize", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			l := math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+			if l == 0 {
+				return nil, fmt.Errorf("ValueError: cannot normalize a zero-length Vec3")
+			}
+			return &PyVec3{X: v.X / l, Y: v.Y / l, Z: v.Z / l}, nil
+		}}, nil
+
+	case "dot":
+		return &PyBuiltinFunc{Name: "Vec3.dot", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			o, err := vm.ToVec3(argOne(args))
+			if err != nil {
+				return nil, err
+			}
+			return &PyFloat{Value: v.X*o.X + v.Y*o.Y + v.Z*o.Z}, nil
+		}}, nil
+
+	case "cross":
+		return &PyBuiltinFunc{Name: "Vec3.cross", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			o, err := vm.ToVec3(argOne(args))
+			if err != nil {
+				return nil, err
+			}
+			return &PyVec3{
+				X: v.Y*o.Z - v.Z*o.Y,
+				Y: v.Z*o.X - v.X*o.Z,
+				Z: v.X*o.Y - v.Y*o.X,
+			}, nil
+		}}, nil
+
+	case "lerp":
+		return &PyBuiltinFunc{Name: "Vec3.lerp", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("TypeError: lerp() takes exactly 2 arguments (%d given)", len(args))
+			}
+			o, err := vm.ToVec3(args[0])
+			if err != nil {
+				return nil, err
+			}
+			t, ok := gameMathScalar(args[1])
+			if !ok {
+				return nil, fmt.Errorf("TypeError: lerp() factor must be a number, not %s", vm.typeName(args[1]))
+			}
+			return &PyVec3{
+				X: v.X + (o.X-v.X)*t,
+				Y: v.Y + (o.Y-v.Y)*t,
+				Z: v.Z + (o.Z-v.Z)*t,
+			}, nil
+		}}, nil
+
+	case "distance":
+		return &PyBuiltinFunc{Name: "Vec3.distance", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			o, err := vm.ToVec3(argOne(args))
+			if err != nil {
+				return nil, err
+			}
+			dx, dy, dz := v.X-o.X, v.Y-o.Y, v.Z-o.Z
+			return &PyFloat{Value: math.Sqrt(dx*dx + dy*dy + dz*dz)}, nil
+		}}, nil
+	}
+	return nil, fmt.Errorf("'Vec3' object has no attribute '%s'", name)
+}
+
+// getAttrMat3 handles attribute access on *PyMat3 values.
+func (vm *VM) getAttrMat3(m *PyMat3, name string) (Value, error) {
+	switch name {
+	case "transpose":
+		return &PyBuiltinFunc{Name: "Mat3.transpose", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			var t [9]float64
+			for r := 0; r < 3; r++ {
+				for c := 0; c < 3; c++ {
+					t[c*3+r] = m.M[r*3+c]
+				}
+			}
+			return &PyMat3{M: t}, nil
+		}}, nil
+
+	case "determinant":
+		return &PyBuiltinFunc{Name: "Mat3.determinant", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			a := m.M
+			det := a[0]*(a[4]*a[8]-a[5]*a[7]) - a[1]*(a[3]*a[8]-a[5]*a[6]) + a[2]*(a[3]*a[7]-a[4]*a[6])
+			return &PyFloat{Value: det}, nil
+		}}, nil
+
+	case "row":
+		return &PyBuiltinFunc{Name: "Mat3.row", Fn: func(args []Value, kwargs map[string]Value) (Value, error) {
+			i, ok := argOne(args).(*PyInt)
+			if !ok || i.Value < 0 || i.Value > 2 {
+				return nil, fmt.Errorf("IndexError: Mat3 row index must be 0, 1, or 2")
+			}
+			r := int(i.Value) * 3
+			return &PyVec3{X: m.M[r], Y: m.M[r+1], Z: m.M[r+2]}, nil
+		}}, nil
+	}
+	return nil, fmt.Errorf("'Mat3' object has no attribute '%s'", name)
+}
+
+// argOne returns the sole positional argument, or None so the vector
+// conversion below produces the TypeError
+func argOne(args []Value) Value {
+	if len(args) != 1 {
+		return None
+	}
+	return args[0]
+}
+
+// ToVec2 converts a value to a *PyVec2. It accepts Vec2, a 2-sequence of
+// numbers, or any object exposing numeric x/y attributes — the hook that lets
+// user-registered classes interoperate with gamemath.
+func (vm *VM) ToVec2(v Value) (*PyVec2, error) {
+	if vec, ok := v.(*PyVec2); ok {
+		return vec, nil
+	}
+	if xs, ok := vecComponents(vm, v, 2); ok {
+		return &PyVec2{X: xs[0], Y: xs[1]}, nil
+	}
+	return nil, fmt.Errorf("TypeError: cannot convert %s to Vec2", vm.typeName(v))
+}
+
+// ToVec3 converts a value to a *PyVec3. It accepts Vec3, a 3-sequence of
+// numbers, or any object exposing numeric x/y/z attributes.
+func (vm *VM) ToVec3(v Value) (*PyVec3, error) {
+	if vec, ok := v.(*PyVec3); ok {
+		return vec, nil
+	}
+	if xs, ok := vecComponents(vm, v, 3); ok {
+		return &PyVec3{X: xs[0], Y: xs[1], Z: xs[2]}, nil
+	}
+	return nil, fmt.Errorf("TypeError: cannot convert %s to Vec3", vm.typeName(v))
+}
+
+// vecComponents extracts n numeric components from a list/tuple or from
+// x/y/z attributes of an arbitrary object
+func vecComponents(vm *VM, v Value, n int) ([]float64, bool) {
+	var items []Value
+	switch seq := v.(type) {
+	case *PyList:
+		items = seq.Items
+	case *PyTuple:
+		items = seq.Items
+	}
+	if items != nil {
+		if len(items) != n {
+			return nil, false
+		}
+		xs := make([]float64, n)
+		for i, item := range items {
+			f, ok := gameMathScalar(item)
+			if !ok {
+				return nil, false
+			}
+			xs[i] = f
+		}
+		return xs, true
+	}
+
+	names := []string{"x", "y", "z"}[:n]
+	xs := make([]float64, n)
+	for i, name := range names {
+		attr, err := vm.getAttr(v, name)
+		if err != nil {
+			return nil, false
+		}
+		f, ok := gameMathScalar(attr)
+		if !ok {
+			return nil, false
+		}
+		xs[i] = f
+	}
+	return xs, true
+}
@@ -1,7 +1,6 @@
 package runtime
 
 import (
-	"context"
 	"fmt"
 )
 
@@ -144,6 +143,43 @@ func (vm *VM) GeneratorThrow(gen *PyGenerator, excType, excValue Value) (Value,
 		}
 	}
 
+	// PEP 380: when suspended inside a yield from, the thrown exception goes
+	// to the delegated sub-generator first.
+	if sub, ok := delegatedSubGenerator(gen); ok {
+		if isGeneratorExit(excType) {
+			// close() semantics: close the sub-generator (running its finally
+			// blocks), then deliver GeneratorExit at the outer yield from site.
+			if err := vm.GeneratorClose(sub); err != nil {
+				gen.State = GenClosed
+				return nil, true, err
+			}
+		} else {
+			val, done, err := vm.GeneratorThrow(sub, excType, excValue)
+			if err == nil && !done {
+				// Sub-generator handled the exception and yielded a new value;
+				// the outer generator stays suspended at the yield from.
+				return val, false, nil
+			}
+			if pyErr, ok := err.(*PyException); ok && pyErr.Type() == "StopIteration" {
+				// Sub-generator finished: its return value becomes the value
+				// of the yield from expression.
+				var ret Value = None
+				if pyErr.Args != nil && len(pyErr.Args.Items) > 0 {
+					ret = pyErr.Args.Items[0]
+				}
+				return vm.resumeAfterYieldFrom(gen, ret)
+			}
+			if err != nil {
+				// The exception escaped the sub-generator: deliver it at the
+				// outer yield from site so outer handlers get a chance.
+				if pyExc, ok := err.(*PyException); ok {
+					excType = pyExc
+					excValue = None
+				}
+			}
+		}
+	}
+
 	// Generator is suspended at a yield point - throw the exception there
 	var exc *PyException
 
@@ -239,6 +275,51 @@ func (vm *VM) GeneratorThrow(gen *PyGenerator, excType, excValue Value) (Value,
 	}
 }
 
+// delegatedSubGenerator returns the sub-generator a suspended generator is
+// delegating to via yield from, if any. The suspension convention for
+// OpYieldFrom backs the IP up to the opcode itself and leaves the delegated
+// iterator on top of the stack.
+func delegatedSubGenerator(gen *PyGenerator) (*PyGenerator, bool) {
+	frame := gen.Frame
+	if gen.State != GenSuspended || frame == nil || frame.IP >= len(frame.Code.Code) {
+		return nil, false
+	}
+	if Opcode(frame.Code.Code[frame.IP]) != OpYieldFrom {
+		return nil, false
+	}
+	if frame.SP <= 0 {
+		return nil, false
+	}
+	sub, ok := frame.Stack[frame.SP-1].(*PyGenerator)
+	return sub, ok
+}
+
+// isGeneratorExit reports whether the thrown exception is GeneratorExit.
+func isGeneratorExit(excType Value) bool {
+	switch v := excType.(type) {
+	case *PyException:
+		return v.Type() == "GeneratorExit"
+	case *PyClass:
+		return v.Name == "GeneratorExit"
+	}
+	return false
+}
+
+// resumeAfterYieldFrom resumes a generator whose delegated sub-generator has
+// finished: the iterator is dropped and the sub-generator's return value
+// becomes the value of the yield from expression.
+func (vm *VM) resumeAfterYieldFrom(gen *PyGenerator, ret Value) (Value, bool, error) {
+	frame := gen.Frame
+	frame.SP-- // drop the delegated iterator
+	frame.IP++ // step over OpYieldFrom
+	if OpYieldFrom.HasArg() {
+		frame.IP += 2
+	}
+	// GeneratorSend pushes ret as the resume value, which lands exactly where
+	// the yield from expression result belongs.
+	return vm.GeneratorSend(gen, ret)
+}
+
 // GeneratorClose closes a generator
 func (vm *VM) GeneratorClose(gen *PyGenerator) error {
 	if gen.State == GenClosed {
@@ -383,6 +464,42 @@ func (vm *VM) CoroutineSend(coro *PyCoroutine, value Value) (Value, bool, error)
 	}
 }
 
+// completeCoroutine drives a coroutine to completion synchronously and returns
+// its result. Used for awaitables that must resolve within a single opcode,
+// like __aexit__ on the exception path of an async with.
+func (vm *VM) completeCoroutine(coro *PyCoroutine) (Value, error) {
+	for {
+		val, done, err := vm.CoroutineSend(coro, None)
+		if err != nil {
+			if pyErr, ok := err.(*PyException); ok && pyErr.Type() == "StopIteration" {
+				if pyErr.Args != nil && len(pyErr.Args.Items) > 0 {
+					return pyErr.Args.Items[0], nil
+				}
+				return None, nil
+			}
+			return nil, err
+		}
+		if done {
+			return val, nil
+		}
+	}
+}
+
+// handleYieldFromError routes an error raised by a delegated iterator through
+// the suspended frame's own exception handlers, so that try/except around a
+// yield from (or await) sees the exception. Returns nil if a handler was
+// found; execution should resume at the handler address.
+func (vm *VM) handleYieldFromError(err error) error {
+	var pyExc *PyException
+	if pe, ok := err.(*PyException); ok {
+		pyExc = pe
+	} else {
+		pyExc = vm.wrapGoError(err)
+	}
+	_, handleErr := vm.handleException(pyExc)
+	return handleErr
+}
+
 // CoroutineThrow throws an exception into a coroutine
 func (vm *VM) CoroutineThrow(coro *PyCoroutine, excType, excValue Value) (Value, bool, error) {
 	if coro.State == GenClosed {
@@ -607,25 +724,39 @@ func (vm *VM) runWithYieldSupport() (Value, bool, error) {
 			continue
 		}
 
+		// A host-requested interrupt (e.g. Ctrl+C) raises KeyboardInterrupt
+		if vm.takePendingInterrupt() {
+			if _, err := vm.handleException(vm.keyboardInterrupt()); err != nil {
+				return nil, false, err
+			}
+			frame = vm.frame
+			continue
+		}
+
 		// Check for timeout/cancellation periodically
-		if vm.ctx != nil {
-			vm.checkCounter--
-			if vm.checkCounter <= 0 {
-				vm.checkCounter = vm.checkInterval
-				select {
-				case <-vm.ctx.Done():
-					if vm.ctx.Err() == context.DeadlineExceeded {
-						return nil, false, &TimeoutError{}
-					}
-					return nil, false, &CancelledError{}
-				default:
+		if err := vm.CheckInterrupt(); err != nil {
+			return nil, false, err
+		}
+
+		// Cooperative step budgeting: park when the instruction budget is spent
+		if vm.stepLimited {
+			for vm.stepBudget <= 0 {
+				if err := vm.stepPause(); err != nil {
+					return nil, false, err
 				}
 			}
+			vm.stepBudget--
 		}
 
 		op := Opcode(frame.Code.Code[frame.IP])
 		frame.IP++
 
+		// Usage accounting (see TakeUsageCounters)
+		vm.instrCount++
+		if frame.SP > vm.peakValues {
+			vm.peakValues = frame.SP
+		}
+
 		var arg int
 		if op.HasArg() {
 			arg = int(frame.Code.Code[frame.IP]) | int(frame.Code.Code[frame.IP+1])<<8
@@ -648,7 +779,7 @@ func (vm *VM) runWithYieldSupport() (Value, bool, error) {
 			// Check if the top of stack is the sent value (not an iterator)
 			// This happens on resume: stack is [..., iterator, sent_value]
 			switch iter.(type) {
-			case *PyGenerator, *PyCoroutine, *PyIterator:
+			case *PyGenerator, *PyCoroutine, *PyIterator, *PyResolvedAwaitable:
 				// Top is already the iterator
 			default:
 				// Top is the sent value, pop it and get the iterator below
@@ -670,7 +801,11 @@ func (vm *VM) runWithYieldSupport() (Value, bool, error) {
 						}
 						continue
 					}
-					return nil, false, err
+					if handleErr := vm.handleYieldFromError(err); handleErr != nil {
+						return nil, false, handleErr
+					}
+					frame = vm.frame
+					continue
 				}
 				if done {
 					vm.pop()
@@ -696,7 +831,11 @@ func (vm *VM) runWithYieldSupport() (Value, bool, error) {
 						}
 						continue
 					}
-					return nil, false, err
+					if handleErr := vm.handleYieldFromError(err); handleErr != nil {
+						return nil, false, handleErr
+					}
+					frame = vm.frame
+					continue
 				}
 				if done {
 					vm.pop()
@@ -709,6 +848,12 @@ func (vm *VM) runWithYieldSupport() (Value, bool, error) {
 				}
 				return val, true, nil
 
+			case *PyResolvedAwaitable:
+				// Already complete: the await finishes now with the value
+				vm.pop()
+				vm.push(it.Value)
+				continue
+
 			case *PyIterator:
 				items := it.Items
 				if it.Source != nil {
@@ -731,7 +876,11 @@ func (vm *VM) runWithYieldSupport() (Value, bool, error) {
 				// Try to get next value from the iterator
 				nextVal, done, err := vm.iterNext(iter)
 				if err != nil {
-					return nil, false, err
+					if handleErr := vm.handleYieldFromError(err); handleErr != nil {
+						return nil, false, handleErr
+					}
+					frame = vm.frame
+					continue
 				}
 				if done {
 					vm.pop()
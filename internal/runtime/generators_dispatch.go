@@ -43,15 +43,18 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 			} else if val, ok := frame.Builtins[name]; ok {
 				vm.push(val)
 			} else {
-				return nil, fmt.Errorf("name '%s' is not defined", name)
+				return nil, vm.nameError(frame, name)
 			}
 		} else if val, ok := frame.Builtins[name]; ok {
 			vm.push(val)
 		} else {
-			return nil, fmt.Errorf("name '%s' is not defined", name)
+			return nil, vm.nameError(frame, name)
 		}
 	case OpStoreName:
 		name := frame.Code.Names[arg]
+		if vm.frozenGlobals[name] && frame.EnclosingGlobals == nil {
+			return nil, vm.frozenGlobalError("assign to", name)
+		}
 		frame.Globals[name] = vm.pop()
 	case OpLoadFast:
 		val := frame.Locals[arg]
@@ -73,15 +76,18 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 			} else if val, ok := frame.Builtins[name]; ok {
 				vm.push(val)
 			} else {
-				return nil, fmt.Errorf("name '%s' is not defined", name)
+				return nil, vm.nameError(frame, name)
 			}
 		} else if val, ok := frame.Builtins[name]; ok {
 			vm.push(val)
 		} else {
-			return nil, fmt.Errorf("name '%s' is not defined", name)
+			return nil, vm.nameError(frame, name)
 		}
 	case OpStoreGlobal:
 		name := frame.Code.Names[arg]
+		if vm.frozenGlobals[name] {
+			return nil, vm.frozenGlobalError("assign to", name)
+		}
 		frame.Globals[name] = vm.pop()
 
 	// Binary operations
@@ -262,15 +268,32 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 	case OpMakeFunction:
 		name := vm.pop().(*PyString)
 		code := vm.pop().(*CodeObject)
+		var annotations *PyDict
+		if arg&4 != 0 {
+			annotations, _ = vm.pop().(*PyDict)
+		}
+		var kwDefaults map[string]Value
+		if arg&2 != 0 {
+			if kwDefaultsDict, ok := vm.pop().(*PyDict); ok {
+				kwDefaults = make(map[string]Value)
+				for _, key := range kwDefaultsDict.Keys(vm) {
+					if ks, ok := key.(*PyString); ok {
+						kwDefaults[ks.Value], _ = kwDefaultsDict.DictGet(key, vm)
+					}
+				}
+			}
+		}
 		var defaults *PyTuple
 		if arg&1 != 0 {
 			defaults = vm.pop().(*PyTuple)
 		}
 		fn := &PyFunction{
-			Code:     code,
-			Globals:  frame.Globals,
-			Defaults: defaults,
-			Name:     name.Value,
+			Code:        code,
+			Globals:     frame.Globals,
+			Defaults:    defaults,
+			KwDefaults:  kwDefaults,
+			Name:        name.Value,
+			Annotations: annotations,
 		}
 		// Handle closures
 		if len(code.FreeVars) > 0 {
@@ -333,7 +356,7 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 		obj := vm.pop()
 		attr, err := vm.getAttr(obj, name)
 		if err != nil {
-			return nil, err
+			return nil, vm.withAttrSuggestion(err, obj, name)
 		}
 		vm.push(attr)
 	case OpStoreAttr:
@@ -465,19 +488,24 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 
 	case OpListAppend:
 		val := vm.pop()
-		listIdx := frame.SP - arg
-		if listIdx >= 0 && listIdx < frame.SP {
-			if list, ok := frame.Stack[listIdx].(*PyList); ok {
-				list.Items = append(list.Items, val)
-			}
-		}
+		list := vm.peek(arg).(*PyList)
+		list.Items = append(list.Items, val)
 
 	case OpLoadMethod:
 		name := frame.Code.Names[arg]
 		obj := vm.pop()
-		method, err := vm.getAttr(obj, name)
-		if err != nil {
-			return nil, err
+		var method Value
+		if s, ok := obj.(*PyString); ok {
+			if fn, ok := stringMethods[name]; ok {
+				method = boundStringMethod(name, s, fn)
+			}
+		}
+		if method == nil {
+			var err error
+			method, err = vm.getAttr(obj, name)
+			if err != nil {
+				return nil, vm.withAttrSuggestion(err, obj, name)
+			}
 		}
 		vm.push(obj)
 		vm.push(method)
@@ -494,6 +522,8 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 		alreadyBound := false
 		if _, isBound := method.(*PyMethod); isBound {
 			alreadyBound = true
+		} else if _, isBound := method.(*PyStringMethod); isBound {
+			alreadyBound = true
 		} else if bf, ok := method.(*PyBuiltinFunc); ok && bf.Bound {
 			alreadyBound = true
 		}
@@ -503,6 +533,9 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 			allArgs := append([]Value{obj}, args...)
 			result, err = vm.call(method, allArgs, nil)
 		}
+		if sm, ok := method.(*PyStringMethod); ok {
+			releaseStringMethod(sm)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -593,9 +626,9 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 		val := vm.pop()
 		if val != nil && val != None {
 			if obj, ok := val.(PyObject); ok {
-				fmt.Println(obj.String())
+				fmt.Fprintln(vm.Stdout(), obj.String())
 			} else {
-				fmt.Println(val)
+				fmt.Fprintln(vm.Stdout(), val)
 			}
 		}
 
@@ -608,12 +641,19 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 		} else if gen, ok := obj.(*PyGenerator); ok {
 			// Generators can also be awaitable
 			vm.push(gen)
+		} else if ra, ok := obj.(*PyResolvedAwaitable); ok {
+			// Already resolved (e.g. by async_generator.__anext__)
+			vm.push(ra)
+		} else if _, ok := obj.(*PyAsyncGenerator); ok {
+			return nil, fmt.Errorf("TypeError: object async_generator can't be used in 'await' expression")
 		} else {
 			// Try to get __await__ method
 			awaitable, err := vm.getAttr(obj, "__await__")
 			if err != nil {
-				// Just push the object itself for simple awaitables
-				vm.push(obj)
+				// Treat plain values as already-resolved awaitables. The
+				// wrapper keeps OpYieldFrom from mistaking the value for a
+				// sent value on its first execution
+				vm.push(&PyResolvedAwaitable{Value: obj})
 			} else {
 				// Call __await__ to get the awaitable iterator
 				result, err := vm.call(awaitable, nil, nil)
@@ -646,6 +686,21 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 		}
 		result, err := vm.call(anext, nil, nil)
 		if err != nil {
+			// StopAsyncIteration raised here (e.g. by an exhausted async
+			// generator) must reach the frame's async-for handler
+			if err == errExceptionHandledInOuterFrame {
+				return nil, nil
+			}
+			if pyExc, ok := err.(*PyException); ok {
+				_, handleErr := vm.handleException(pyExc)
+				if handleErr != nil {
+					return nil, handleErr
+				}
+				if vm.frame != frame {
+					return nil, errExceptionHandledInOuterFrame
+				}
+				return nil, nil
+			}
 			return nil, err
 		}
 		vm.push(result)
@@ -792,12 +847,12 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 			} else if val, ok := frame.Builtins[name]; ok {
 				vm.push(val)
 			} else {
-				return nil, fmt.Errorf("name '%s' is not defined", name)
+				return nil, vm.nameError(frame, name)
 			}
 		} else if val, ok := frame.Builtins[name]; ok {
 			vm.push(val)
 		} else {
-			return nil, fmt.Errorf("name '%s' is not defined", name)
+			return nil, vm.nameError(frame, name)
 		}
 		localVal := frame.Locals[localIdx]
 		if localVal == nil {
@@ -1051,6 +1106,46 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 			vm.currentException = nil
 		}
 
+	case OpWithCleanupAsync:
+		// Exception path of "async with": stack has [..., cm, exception]
+		exc := vm.pop()
+		cm := vm.pop()
+
+		exitMethod, err := vm.getAttr(cm, "__aexit__")
+		if err != nil {
+			return nil, fmt.Errorf("AttributeError: __aexit__: %w", err)
+		}
+
+		var excType, excVal, excTb Value
+		if pyExc, ok := exc.(*PyException); ok {
+			if pyExc.ExcType != nil {
+				excType = pyExc.ExcType
+			} else {
+				excType = &PyString{Value: pyExc.Type()}
+			}
+			excVal = pyExc
+			excTb = None
+		} else {
+			excType = None
+			excVal = None
+			excTb = None
+		}
+
+		result, err := vm.call(exitMethod, []Value{excType, excVal, excTb}, nil)
+		if err != nil {
+			return nil, err
+		}
+		if coro, ok := result.(*PyCoroutine); ok {
+			result, err = vm.completeCoroutine(coro)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if vm.truthy(result) {
+			vm.currentException = nil
+		}
+
 	case OpEndFinally:
 		// Pop the handler stack entry that was pushed when entering finally
 		if len(vm.excHandlerStack) > 0 {
@@ -1140,12 +1235,22 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 		b := vm.pop()
 		a := vm.pop()
 
+		// Handle dict |= (PEP 584): updates in place and, unlike |, also
+		// accepts iterables of key/value pairs
+		if d, ok := a.(*PyDict); ok && op == OpInplaceOr {
+			if err := vm.dictUpdateFrom(d, b); err != nil {
+				return nil, err
+			}
+			vm.push(d)
+			return nil, nil
+		}
+
 		// Try inplace dunder method on PyInstance first
 		var result Value
 		var err error
 		if inst, ok := a.(*PyInstance); ok {
 			var inplaceDunders = [...]string{
-				0 /* OpInplaceAdd */ : "__iadd__",
+				0 /* OpInplaceAdd */ :            "__iadd__",
 				OpInplaceSubtract - OpInplaceAdd: "__isub__",
 				OpInplaceMultiply - OpInplaceAdd: "__imul__",
 				OpInplaceDivide - OpInplaceAdd:   "__itruediv__",
@@ -1210,6 +1315,27 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 			length = int64(len(v.Items))
 		case *PyBytes:
 			length = int64(len(v.Value))
+		case *PyByteArray:
+			length = int64(len(v.Value))
+		case *PyFloatArray:
+			length = int64(len(v.Values))
+		case *PyIntArray:
+			length = int64(len(v.Values))
+		case *PyRange:
+			length = rangeLen(v)
+		case *PyUserData:
+			if result, found, err := vm.callMetamethod(v, "__len__"); found {
+				if err != nil {
+					return nil, err
+				}
+				if i, ok := result.(*PyInt); ok {
+					length = i.Value
+				} else {
+					return nil, fmt.Errorf("__len__() should return an integer")
+				}
+			} else {
+				return nil, fmt.Errorf("object of type '%s' has no len()", vm.typeName(obj))
+			}
 		case *PyInstance:
 			if result, found, err := vm.callDunder(v, "__len__"); found {
 				if err != nil {
@@ -1275,11 +1401,17 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 
 	case OpDeleteGlobal:
 		name := frame.Code.Names[arg]
+		if vm.frozenGlobals[name] {
+			return nil, vm.frozenGlobalError("delete", name)
+		}
 		vm.callDel(frame.Globals[name])
 		delete(frame.Globals, name)
 
 	case OpDeleteName:
 		name := frame.Code.Names[arg]
+		if vm.frozenGlobals[name] && frame.EnclosingGlobals == nil {
+			return nil, vm.frozenGlobalError("delete", name)
+		}
 		vm.callDel(frame.Globals[name])
 		delete(frame.Globals, name)
 
@@ -1308,8 +1440,10 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 		}
 		totalArgs := arg
 		kwargs := make(map[string]Value)
+		kwOrder := make([]string, len(kwNames.Items))
 		for i := len(kwNames.Items) - 1; i >= 0; i-- {
 			name := kwNames.Items[i].(*PyString).Value
+			kwOrder[i] = name
 			kwargs[name] = vm.pop()
 			totalArgs--
 		}
@@ -1318,6 +1452,7 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 			args[i] = vm.pop()
 		}
 		callable := vm.pop()
+		vm.pendingKwNames = kwOrder
 		result, err := vm.call(callable, args, kwargs)
 		if err != nil {
 			return nil, err
@@ -1327,6 +1462,7 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 	case OpCallEx:
 		// Call with *args/**kwargs unpacking in generator context
 		var kwargs map[string]Value
+		var kwOrder []string
 		if arg&1 != 0 {
 			kwargsVal := vm.pop()
 			if kwargsDict, ok := kwargsVal.(*PyDict); ok {
@@ -1334,6 +1470,7 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 				for _, key := range kwargsDict.Keys(vm) {
 					if ks, ok := key.(*PyString); ok {
 						val, _ := kwargsDict.DictGet(key, vm)
+						kwOrder = append(kwOrder, ks.Value)
 						kwargs[ks.Value] = val
 					}
 				}
@@ -1350,6 +1487,7 @@ func (vm *VM) executeOpcodeForGenerator(op Opcode, arg int) (Value, error) {
 		default:
 			callArgs = []Value{}
 		}
+		vm.pendingKwNames = kwOrder
 		result, err := vm.call(callable, callArgs, kwargs)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,74 @@
+package runtime
+
+import "testing"
+
+func TestHashSeedChangesStringHashes(t *testing.T) {
+	a := NewVM()
+	b := NewVM()
+	a.SetHashSeed(1)
+	b.SetHashSeed(2)
+
+	s := &PyString{Value: "hello"}
+	if a.hashValueVM(s) == b.hashValueVM(s) {
+		t.Error("different seeds should produce different string hashes")
+	}
+
+	b.SetHashSeed(1)
+	if a.hashValueVM(s) != b.hashValueVM(s) {
+		t.Error("equal seeds should produce equal string hashes")
+	}
+}
+
+func TestHashSeedLeavesNumbersAlone(t *testing.T) {
+	a := NewVM()
+	b := NewVM()
+	a.SetHashSeed(1)
+	b.SetHashSeed(2)
+
+	// Numeric hashes are seed-independent, like CPython
+	if a.hashValueVM(MakeInt(42)) != b.hashValueVM(MakeInt(42)) {
+		t.Error("int hashes should not depend on the seed")
+	}
+	if a.hashValueVM(&PyFloat{Value: 2.5}) != b.hashValueVM(&PyFloat{Value: 2.5}) {
+		t.Error("float hashes should not depend on the seed")
+	}
+}
+
+func TestSeededTupleHashConsistency(t *testing.T) {
+	vm := NewVM()
+	vm.SetHashSeed(99)
+
+	// hash((1, 'a')) == hash((1.0, 'a')) because the tuples are equal
+	t1 := &PyTuple{Items: []Value{MakeInt(1), &PyString{Value: "a"}}}
+	t2 := &PyTuple{Items: []Value{&PyFloat{Value: 1.0}, &PyString{Value: "a"}}}
+	if !vm.equal(t1, t2) {
+		t.Fatal("tuples should compare equal")
+	}
+	if vm.hashValueVM(t1) != vm.hashValueVM(t2) {
+		t.Error("equal tuples should hash equal")
+	}
+
+	// The string element must be hashed with the seed, so the tuple hash
+	// changes with it
+	other := NewVM()
+	other.SetHashSeed(100)
+	if vm.hashValueVM(t1) == other.hashValueVM(t1) {
+		t.Error("tuple hash should mix the seed through string elements")
+	}
+}
+
+func TestSeededFrozenSetHashOrderIndependent(t *testing.T) {
+	vm := NewVM()
+	vm.SetHashSeed(7)
+
+	a := &PyFrozenSet{}
+	a.FrozenSetAdd(&PyString{Value: "x"}, vm)
+	a.FrozenSetAdd(&PyString{Value: "y"}, vm)
+	b := &PyFrozenSet{}
+	b.FrozenSetAdd(&PyString{Value: "y"}, vm)
+	b.FrozenSetAdd(&PyString{Value: "x"}, vm)
+
+	if vm.hashValueVM(a) != vm.hashValueVM(b) {
+		t.Error("frozenset hash should be order-independent")
+	}
+}
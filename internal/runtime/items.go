@@ -73,6 +73,58 @@ func (vm *VM) getItem(obj Value, index Value) (Value, error) {
 			return nil, fmt.Errorf("IndexError: index out of range")
 		}
 		return MakeInt(int64(o.Value[idx])), nil
+	case *PyByteArray:
+		i, err := vm.getIntIndex(index)
+		if err != nil {
+			return nil, err
+		}
+		idx := int(i)
+		if idx < 0 {
+			idx = len(o.Value) + idx
+		}
+		if idx < 0 || idx >= len(o.Value) {
+			return nil, fmt.Errorf("IndexError: bytearray index out of range")
+		}
+		return MakeInt(int64(o.Value[idx])), nil
+	case *PyFloatArray:
+		i, err := vm.getIntIndex(index)
+		if err != nil {
+			return nil, err
+		}
+		idx := int(i)
+		if idx < 0 {
+			idx = len(o.Values) + idx
+		}
+		if idx < 0 || idx >= len(o.Values) {
+			return nil, fmt.Errorf("IndexError: floatarray index out of range")
+		}
+		return &PyFloat{Value: o.Values[idx]}, nil
+	case *PyIntArray:
+		i, err := vm.getIntIndex(index)
+		if err != nil {
+			return nil, err
+		}
+		idx := int(i)
+		if idx < 0 {
+			idx = len(o.Values) + idx
+		}
+		if idx < 0 || idx >= len(o.Values) {
+			return nil, fmt.Errorf("IndexError: intarray index out of range")
+		}
+		return MakeInt(o.Values[idx]), nil
+	case *PyRange:
+		i, err := vm.getIntIndex(index)
+		if err != nil {
+			return nil, err
+		}
+		length := rangeLen(o)
+		if i < 0 {
+			i += length
+		}
+		if i < 0 || i >= length {
+			return nil, fmt.Errorf("IndexError: range object index out of range")
+		}
+		return MakeInt(o.Start + i*o.Step), nil
 	case *PyDict:
 		// Use hash-based lookup for O(1) average case
 		if val, found := o.DictGet(index, vm); found {
@@ -302,6 +354,42 @@ func (vm *VM) sliceSequence(obj Value, slice *PySlice) (Value, error) {
 		}
 		return &PyBytes{Value: result}, nil
 
+	case *PyByteArray:
+		start, stop, step, err := computeSliceIndices(slice, len(o.Value), getInt)
+		if err != nil {
+			return nil, err
+		}
+		indices := collectSliceIndices(start, stop, step)
+		result := make([]byte, len(indices))
+		for i, idx := range indices {
+			result[i] = o.Value[idx]
+		}
+		return &PyByteArray{Value: result}, nil
+
+	case *PyFloatArray:
+		start, stop, step, err := computeSliceIndices(slice, len(o.Values), getInt)
+		if err != nil {
+			return nil, err
+		}
+		indices := collectSliceIndices(start, stop, step)
+		result := make([]float64, len(indices))
+		for i, idx := range indices {
+			result[i] = o.Values[idx]
+		}
+		return &PyFloatArray{Values: result}, nil
+
+	case *PyIntArray:
+		start, stop, step, err := computeSliceIndices(slice, len(o.Values), getInt)
+		if err != nil {
+			return nil, err
+		}
+		indices := collectSliceIndices(start, stop, step)
+		result := make([]int64, len(indices))
+		for i, idx := range indices {
+			result[i] = o.Values[idx]
+		}
+		return &PyIntArray{Values: result}, nil
+
 	case *PyString:
 		runes := []rune(o.Value)
 		start, stop, step, err := computeSliceIndices(slice, len(runes), getInt)
@@ -314,15 +402,46 @@ func (vm *VM) sliceSequence(obj Value, slice *PySlice) (Value, error) {
 			result[i] = runes[idx]
 		}
 		return &PyString{Value: string(result)}, nil
+
+	case *PyRange:
+		// Slicing a range yields a range, computed without materializing
+		length := rangeLen(o)
+		start, stop, step, err := computeSliceIndices(slice, int(length), getInt)
+		if err != nil {
+			return nil, err
+		}
+		// Count the selected indices (mirrors collectSliceIndices)
+		var n int64
+		if step > 0 {
+			if stop > start {
+				n = (int64(stop) - int64(start) + int64(step) - 1) / int64(step)
+			}
+		} else {
+			lo := int64(stop)
+			if lo < -1 {
+				lo = -1
+			}
+			if int64(start) > lo {
+				n = (int64(start)-lo-1)/int64(-step) + 1
+			}
+		}
+		newStart := o.Start + int64(start)*o.Step
+		newStep := o.Step * int64(step)
+		return &PyRange{Start: newStart, Stop: newStart + n*newStep, Step: newStep}, nil
 	}
 
 	return nil, fmt.Errorf("'%s' object is not subscriptable", vm.typeName(obj))
 }
 
 func (vm *VM) setItem(obj Value, index Value, val Value) error {
-	// Handle slice assignment
+	// Handle slice assignment for built-in types (not PyInstance, which should dispatch to __setitem__)
 	if slice, ok := index.(*PySlice); ok {
-		return vm.setSlice(obj, slice, val)
+		switch obj.(type) {
+		case *PyInstance:
+			// Fall through to PyInstance handling below, passing the slice to __setitem__
+		default:
+			return vm.setSlice(obj, slice, val)
+		}
 	}
 	switch o := obj.(type) {
 	case *PyList:
@@ -339,6 +458,76 @@ func (vm *VM) setItem(obj Value, index Value, val Value) error {
 		}
 		o.Items[idx] = val
 		return nil
+	case *PyByteArray:
+		i, err := vm.getIntIndex(index)
+		if err != nil {
+			return err
+		}
+		idx := int(i)
+		if idx < 0 {
+			idx = len(o.Value) + idx
+		}
+		if idx < 0 || idx >= len(o.Value) {
+			return fmt.Errorf("IndexError: bytearray index out of range")
+		}
+		n := vm.toInt(val)
+		if n < 0 || n > 255 {
+			return fmt.Errorf("ValueError: byte must be in range(0, 256)")
+		}
+		o.Value[idx] = byte(n)
+		return nil
+	case *PyFloatArray:
+		i, err := vm.getIntIndex(index)
+		if err != nil {
+			return err
+		}
+		idx := int(i)
+		if idx < 0 {
+			idx = len(o.Values) + idx
+		}
+		if idx < 0 || idx >= len(o.Values) {
+			return fmt.Errorf("IndexError: floatarray index out of range")
+		}
+		switch n := val.(type) {
+		case *PyFloat:
+			o.Values[idx] = n.Value
+		case *PyInt:
+			o.Values[idx] = float64(n.Value)
+		case *PyBool:
+			if n.Value {
+				o.Values[idx] = 1
+			} else {
+				o.Values[idx] = 0
+			}
+		default:
+			return fmt.Errorf("TypeError: must be real number, not %s", vm.typeName(val))
+		}
+		return nil
+	case *PyIntArray:
+		i, err := vm.getIntIndex(index)
+		if err != nil {
+			return err
+		}
+		idx := int(i)
+		if idx < 0 {
+			idx = len(o.Values) + idx
+		}
+		if idx < 0 || idx >= len(o.Values) {
+			return fmt.Errorf("IndexError: intarray index out of range")
+		}
+		switch n := val.(type) {
+		case *PyInt:
+			o.Values[idx] = n.Value
+		case *PyBool:
+			if n.Value {
+				o.Values[idx] = 1
+			} else {
+				o.Values[idx] = 0
+			}
+		default:
+			return fmt.Errorf("TypeError: integer argument expected, got %s", vm.typeName(val))
+		}
+		return nil
 	case *PyDict:
 		if !isHashable(index) {
 			return fmt.Errorf("TypeError: unhashable type: '%s'", vm.typeName(index))
@@ -357,177 +546,190 @@ func (vm *VM) setItem(obj Value, index Value, val Value) error {
 }
 
 func (vm *VM) setSlice(obj Value, slice *PySlice, val Value) error {
-	lst, ok := obj.(*PyList)
-	if !ok {
-		return fmt.Errorf("TypeError: '%s' object does not support slice assignment", vm.typeName(obj))
-	}
-	newItems, err := vm.toList(val)
-	if err != nil {
-		return err
+	getInt := func(v Value, def int) int {
+		if v == nil || v == None {
+			return def
+		}
+		if i, err := vm.getIntIndex(v); err == nil {
+			return int(i)
+		}
+		return int(vm.toInt(v))
 	}
 
-	length := len(lst.Items)
-	start := 0
-	stop := length
-	if slice.Start != nil && slice.Start != None {
-		i, err := vm.getIntIndex(slice.Start)
+	switch o := obj.(type) {
+	case *PyList:
+		newItems, err := vm.toList(val)
 		if err != nil {
 			return err
 		}
-		start = int(i)
-		if start < 0 {
-			start = length + start
+		start, stop, step, err := computeSliceIndices(slice, len(o.Items), getInt)
+		if err != nil {
+			return fmt.Errorf("ValueError: %s", err)
 		}
-		if start < 0 {
-			start = 0
+		if step == 1 {
+			// Contiguous assignment: replace o.Items[start:stop], allowing the
+			// replacement to grow or shrink the list
+			if stop < start {
+				stop = start
+			}
+			result := make([]Value, 0, start+len(newItems)+(len(o.Items)-stop))
+			result = append(result, o.Items[:start]...)
+			result = append(result, newItems...)
+			result = append(result, o.Items[stop:]...)
+			o.Items = result
+			return nil
 		}
-		if start > length {
-			start = length
+		// Extended slice: sizes must match exactly
+		indices := collectSliceIndices(start, stop, step)
+		if len(newItems) != len(indices) {
+			return fmt.Errorf("ValueError: attempt to assign sequence of size %d to extended slice of size %d", len(newItems), len(indices))
 		}
-	}
-	if slice.Stop != nil && slice.Stop != None {
-		i, err := vm.getIntIndex(slice.Stop)
+		for i, idx := range indices {
+			o.Items[idx] = newItems[i]
+		}
+		return nil
+
+	case *PyByteArray:
+		newBytes, err := vm.toByteSlice(val)
 		if err != nil {
 			return err
 		}
-		stop = int(i)
-		if stop < 0 {
-			stop = length + stop
+		start, stop, step, err := computeSliceIndices(slice, len(o.Value), getInt)
+		if err != nil {
+			return fmt.Errorf("ValueError: %s", err)
+		}
+		if step == 1 {
+			if stop < start {
+				stop = start
+			}
+			result := make([]byte, 0, start+len(newBytes)+(len(o.Value)-stop))
+			result = append(result, o.Value[:start]...)
+			result = append(result, newBytes...)
+			result = append(result, o.Value[stop:]...)
+			o.Value = result
+			return nil
 		}
-		if stop < 0 {
-			stop = 0
+		indices := collectSliceIndices(start, stop, step)
+		if len(newBytes) != len(indices) {
+			return fmt.Errorf("ValueError: attempt to assign bytes of size %d to extended slice of size %d", len(newBytes), len(indices))
 		}
-		if stop > length {
-			stop = length
+		for i, idx := range indices {
+			o.Value[idx] = newBytes[i]
 		}
+		return nil
 	}
-	if start > stop {
-		stop = start
-	}
-
-	// Replace lst.Items[start:stop] with newItems
-	result := make([]Value, 0, start+len(newItems)+(length-stop))
-	result = append(result, lst.Items[:start]...)
-	result = append(result, newItems...)
-	result = append(result, lst.Items[stop:]...)
-	lst.Items = result
-	return nil
+	return fmt.Errorf("TypeError: '%s' object does not support slice assignment", vm.typeName(obj))
 }
 
 func (vm *VM) delSlice(obj Value, slice *PySlice) error {
-	lst, ok := obj.(*PyList)
-	if !ok {
-		return fmt.Errorf("TypeError: '%s' object does not support slice deletion", vm.typeName(obj))
-	}
-
-	length := len(lst.Items)
-	step := 1
-	if slice.Step != nil && slice.Step != None {
-		i, err := vm.getIntIndex(slice.Step)
-		if err != nil {
-			return err
+	getInt := func(v Value, def int) int {
+		if v == nil || v == None {
+			return def
 		}
-		step = int(i)
-		if step == 0 {
-			return fmt.Errorf("ValueError: slice step cannot be zero")
+		if i, err := vm.getIntIndex(v); err == nil {
+			return int(i)
 		}
+		return int(vm.toInt(v))
 	}
 
-	start := 0
-	stop := length
-	if step < 0 {
-		start = length - 1
-		stop = -length - 1
-	}
-	if slice.Start != nil && slice.Start != None {
-		i, err := vm.getIntIndex(slice.Start)
+	switch o := obj.(type) {
+	case *PyList:
+		start, stop, step, err := computeSliceIndices(slice, len(o.Items), getInt)
 		if err != nil {
-			return err
+			return fmt.Errorf("ValueError: %s", err)
 		}
-		start = int(i)
-		if start < 0 {
-			start = length + start
-		}
-		if step > 0 {
-			if start < 0 {
-				start = 0
-			}
-			if start > length {
-				start = length
-			}
-		} else {
-			if start < -1 {
-				start = -1
-			}
-			if start >= length {
-				start = length - 1
+		if step == 1 {
+			// Contiguous deletion - fast path
+			if start >= stop {
+				return nil
 			}
+			result := make([]Value, 0, start+(len(o.Items)-stop))
+			result = append(result, o.Items[:start]...)
+			result = append(result, o.Items[stop:]...)
+			o.Items = result
+			return nil
 		}
-	}
-	if slice.Stop != nil && slice.Stop != None {
-		i, err := vm.getIntIndex(slice.Stop)
-		if err != nil {
-			return err
-		}
-		stop = int(i)
-		if stop < 0 {
-			stop = length + stop
+		toDelete := make(map[int]bool)
+		for _, idx := range collectSliceIndices(start, stop, step) {
+			toDelete[idx] = true
 		}
-		if step > 0 {
-			if stop < 0 {
-				stop = 0
-			}
-			if stop > length {
-				stop = length
-			}
-		} else {
-			if stop < -length-1 {
-				stop = -length - 1
-			}
-			if stop >= length {
-				stop = length - 1
+		result := make([]Value, 0, len(o.Items)-len(toDelete))
+		for i, item := range o.Items {
+			if !toDelete[i] {
+				result = append(result, item)
 			}
 		}
-	}
+		o.Items = result
+		return nil
 
-	if step == 1 {
-		// Contiguous deletion - fast path
-		if start >= stop {
+	case *PyByteArray:
+		start, stop, step, err := computeSliceIndices(slice, len(o.Value), getInt)
+		if err != nil {
+			return fmt.Errorf("ValueError: %s", err)
+		}
+		if step == 1 {
+			if start >= stop {
+				return nil
+			}
+			result := make([]byte, 0, start+(len(o.Value)-stop))
+			result = append(result, o.Value[:start]...)
+			result = append(result, o.Value[stop:]...)
+			o.Value = result
 			return nil
 		}
-		result := make([]Value, 0, start+(length-stop))
-		result = append(result, lst.Items[:start]...)
-		result = append(result, lst.Items[stop:]...)
-		lst.Items = result
-		return nil
-	}
-
-	// Step-based deletion: collect indices to delete
-	toDelete := make(map[int]bool)
-	if step > 0 {
-		for i := start; i < stop; i += step {
-			toDelete[i] = true
+		toDelete := make(map[int]bool)
+		for _, idx := range collectSliceIndices(start, stop, step) {
+			toDelete[idx] = true
 		}
-	} else {
-		for i := start; i > stop; i += step {
-			toDelete[i] = true
+		result := make([]byte, 0, len(o.Value)-len(toDelete))
+		for i, b := range o.Value {
+			if !toDelete[i] {
+				result = append(result, b)
+			}
 		}
+		o.Value = result
+		return nil
 	}
+	return fmt.Errorf("TypeError: '%s' object does not support slice deletion", vm.typeName(obj))
+}
 
-	result := make([]Value, 0, length-len(toDelete))
-	for i, item := range lst.Items {
-		if !toDelete[i] {
-			result = append(result, item)
+// toByteSlice converts an iterable of ints in range(0, 256) (or bytes/bytearray)
+// into a byte slice, for bytearray slice assignment.
+func (vm *VM) toByteSlice(val Value) ([]byte, error) {
+	switch v := val.(type) {
+	case *PyBytes:
+		cp := make([]byte, len(v.Value))
+		copy(cp, v.Value)
+		return cp, nil
+	case *PyByteArray:
+		cp := make([]byte, len(v.Value))
+		copy(cp, v.Value)
+		return cp, nil
+	}
+	items, err := vm.toList(val)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]byte, len(items))
+	for i, item := range items {
+		n := vm.toInt(item)
+		if n < 0 || n > 255 {
+			return nil, fmt.Errorf("ValueError: byte must be in range(0, 256)")
 		}
+		result[i] = byte(n)
 	}
-	lst.Items = result
-	return nil
+	return result, nil
 }
 
 func (vm *VM) delItem(obj Value, index Value) error {
-	// Handle slice deletion
+	// Handle slice deletion for built-in types (not PyInstance, which should dispatch to __delitem__)
 	if slice, ok := index.(*PySlice); ok {
-		return vm.delSlice(obj, slice)
+		switch obj.(type) {
+		case *PyInstance:
+			// Fall through to PyInstance handling below, passing the slice to __delitem__
+		default:
+			return vm.delSlice(obj, slice)
+		}
 	}
 	switch o := obj.(type) {
 	case *PyList:
@@ -544,6 +746,20 @@ func (vm *VM) delItem(obj Value, index Value) error {
 		}
 		o.Items = append(o.Items[:idx], o.Items[idx+1:]...)
 		return nil
+	case *PyByteArray:
+		i, err := vm.getIntIndex(index)
+		if err != nil {
+			return err
+		}
+		idx := int(i)
+		if idx < 0 {
+			idx = len(o.Value) + idx
+		}
+		if idx < 0 || idx >= len(o.Value) {
+			return fmt.Errorf("IndexError: bytearray index out of range")
+		}
+		o.Value = append(o.Value[:idx], o.Value[idx+1:]...)
+		return nil
 	case *PyDict:
 		// Use hash-based deletion for O(1) average case
 		if !o.DictDelete(index, vm) {
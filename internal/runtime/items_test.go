@@ -495,13 +495,13 @@ func TestComputeSliceIndices(t *testing.T) {
 	}
 
 	tests := []struct {
-		name               string
-		start, stop, step  Value
-		length             int
-		wantStart          int
-		wantStop           int
-		wantStep           int
-		wantErr            bool
+		name              string
+		start, stop, step Value
+		length            int
+		wantStart         int
+		wantStop          int
+		wantStep          int
+		wantErr           bool
 	}{
 		{"basic [1:3]", MakeInt(1), MakeInt(3), nil, 5, 1, 3, 1, false},
 		{"full [::]", nil, nil, nil, 5, 0, 5, 1, false},
@@ -546,9 +546,9 @@ func TestComputeSliceIndices(t *testing.T) {
 
 func TestCollectSliceIndices(t *testing.T) {
 	tests := []struct {
-		name               string
-		start, stop, step  int
-		want               []int
+		name              string
+		start, stop, step int
+		want              []int
 	}{
 		{"forward", 0, 5, 1, []int{0, 1, 2, 3, 4}},
 		{"step 2", 0, 5, 2, []int{0, 2, 4}},
@@ -16,6 +16,8 @@ func (vm *VM) getIter(obj Value) (Value, error) {
 		return v, nil
 	case *PyIterator:
 		return v, nil
+	case *PyGoIterator:
+		return v, nil
 	}
 
 	// For lists, create an iterator that references the list directly
@@ -24,6 +26,25 @@ func (vm *VM) getIter(obj Value) (Value, error) {
 		return &PyIterator{Source: lst, Index: 0}, nil
 	}
 
+	// Ranges iterate lazily so huge ranges are never materialized
+	if r, ok := obj.(*PyRange); ok {
+		remaining := rangeLen(r)
+		next := r.Start
+		step := r.Step
+		return &PyGoIterator{
+			Name: "range_iterator",
+			Next: func() (Value, bool, error) {
+				if remaining <= 0 {
+					return nil, true, nil
+				}
+				v := next
+				next += step
+				remaining--
+				return MakeInt(v), false, nil
+			},
+		}, nil
+	}
+
 	// Try __iter__ method first
 	if iterMethod, err := vm.getAttr(obj, "__iter__"); err == nil {
 		result, err := vm.call(iterMethod, nil, nil)
@@ -80,6 +101,16 @@ func (vm *VM) iterNext(iter Value) (Value, bool, error) {
 		}
 		return val, done, nil
 
+	case *PyGoIterator:
+		val, done, err := it.Next()
+		if err != nil {
+			return nil, false, err
+		}
+		if done {
+			return nil, true, nil
+		}
+		return val, false, nil
+
 	default:
 		// Try __next__ method
 		nextMethod, err := vm.getAttr(iter, "__next__")
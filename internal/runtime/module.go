@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 )
 
@@ -42,9 +43,9 @@ var moduleMu sync.RWMutex
 
 // moduleLoadState tracks an in-progress filesystem module load
 type moduleLoadState struct {
-	vm   *VM          // VM performing the load
+	vm   *VM           // VM performing the load
 	done chan struct{} // closed when loading completes
-	err  error        // non-nil if loading failed
+	err  error         // non-nil if loading failed
 }
 
 // moduleLoading tracks modules currently being loaded from the filesystem
@@ -58,6 +59,31 @@ func RegisterModule(name string, loader ModuleLoader) {
 	moduleMu.Unlock()
 }
 
+// perVMModules marks registered modules whose loader runs once per VM; the
+// result is cached on the importing VM instead of the shared cache.
+var perVMModules = make(map[string]bool)
+
+// RegisterPerVMModule registers a module loader whose result must not be
+// shared between VMs (e.g. atexit, which keeps per-State callbacks).
+func RegisterPerVMModule(name string, loader ModuleLoader) {
+	moduleMu.Lock()
+	moduleRegistry[name] = loader
+	perVMModules[name] = true
+	moduleMu.Unlock()
+}
+
+// RegisteredModules returns the names of all registered module loaders.
+// Used by tooling (e.g. 'rage check') to validate imports without executing.
+func RegisteredModules() []string {
+	moduleMu.RLock()
+	defer moduleMu.RUnlock()
+	names := make([]string, 0, len(moduleRegistry))
+	for name := range moduleRegistry {
+		names = append(names, name)
+	}
+	return names
+}
+
 // NewModule creates a new module with the given name
 func NewModule(name string) *PyModule {
 	return &PyModule{
@@ -150,11 +176,71 @@ func (b *ModuleBuilder) Register() *PyModule {
 // modules holds loaded modules (cached)
 var loadedModules = make(map[string]*PyModule)
 
+// frozenModules marks the registered modules as an immutable snapshot: when
+// set, importing a registered module hands the VM its own shallow copy of
+// the instance instead of sharing it through the cache, created lazily on
+// first import (see FreezeRegisteredModules)
+var frozenModules bool
+
+// FreezeRegisteredModules marks the currently registered modules as an
+// immutable shared snapshot. Imports then copy a registered module on first
+// use per VM, so scripts mutating module attributes cannot affect other
+// states and the registration work never has to be repeated. Per-VM modules
+// (see RegisterPerVMModule) are unaffected; they already get a fresh
+// instance per VM.
+func FreezeRegisteredModules() {
+	moduleMu.Lock()
+	frozenModules = true
+	moduleMu.Unlock()
+}
+
+// precompiledModules holds bytecode for modules compiled ahead of time
+// (see RegisterCompiledModule)
+var precompiledModules = make(map[string]*CodeObject)
+
+// RegisterCompiledModule registers precompiled bytecode as an importable
+// module. The code executes in a fresh namespace on first import per VM, so
+// states stay isolated while compilation happens only once per process.
+func RegisterCompiledModule(name string, code *CodeObject) {
+	moduleMu.Lock()
+	precompiledModules[name] = code
+	moduleMu.Unlock()
+}
+
+// copyModuleShallow returns a per-VM copy of a snapshotted module: the
+// namespace map is copied, the values in it are shared.
+func copyModuleShallow(mod *PyModule) *PyModule {
+	cp := *mod
+	cp.Dict = make(map[string]Value, len(mod.Dict))
+	for k, v := range mod.Dict {
+		cp.Dict[k] = v
+	}
+	return &cp
+}
+
 // ImportModule imports a module by name
 func (vm *VM) ImportModule(name string) (*PyModule, error) {
+	// Consult the host import policy before touching the cache or any locks,
+	// so cached modules are still subject to it and the callback cannot
+	// deadlock by importing
+	if vm.importPolicy != nil {
+		if err := vm.importPolicy(name); err != nil {
+			msg := err.Error()
+			if strings.HasPrefix(msg, "ImportError:") {
+				return nil, err
+			}
+			return nil, fmt.Errorf("ImportError: import of '%s' is not allowed: %s", name, msg)
+		}
+	}
+
 	moduleMu.Lock()
 	defer moduleMu.Unlock()
 
+	// Per-VM overrides shadow the shared cache
+	if mod, ok := vm.moduleOverrides[name]; ok {
+		return mod, nil
+	}
+
 	// Check if already loaded
 	if mod, ok := loadedModules[name]; ok {
 		// If another VM is still loading this module, wait for it to finish
@@ -194,50 +280,122 @@ func (vm *VM) ImportModule(name string) (*PyModule, error) {
 	// Check if there's a registered loader
 	if loader, ok := moduleRegistry[name]; ok {
 		mod := loader(vm)
-		loadedModules[name] = mod
+		switch {
+		case perVMModules[name]:
+			if vm.moduleOverrides == nil {
+				vm.moduleOverrides = make(map[string]*PyModule)
+			}
+			vm.moduleOverrides[name] = mod
+		case frozenModules:
+			// The registered instance is a frozen snapshot: give this VM
+			// its own shallow copy so script mutations stay local
+			mod = copyModuleShallow(mod)
+			if vm.moduleOverrides == nil {
+				vm.moduleOverrides = make(map[string]*PyModule)
+			}
+			vm.moduleOverrides[name] = mod
+		default:
+			loadedModules[name] = mod
+		}
 		return mod, nil
 	}
 
-	// Filesystem fallback: search SearchPaths for <name>.py
+	// Precompiled (frozen) Python modules: execute the cached bytecode in a
+	// fresh namespace, once per VM
+	if code, ok := precompiledModules[name]; ok {
+		mod := NewModule(name)
+		packageName := name
+		if lastDot := strings.LastIndexByte(name, '.'); lastDot >= 0 {
+			packageName = name[:lastDot]
+		}
+		mod.Package = packageName
+		mod.Dict["__package__"] = NewString(packageName)
+
+		if vm.moduleOverrides == nil {
+			vm.moduleOverrides = make(map[string]*PyModule)
+		}
+		// Cache before executing to handle circular imports
+		vm.moduleOverrides[name] = mod
+
+		// Unlock while executing module code (may re-enter ImportModule)
+		moduleMu.Unlock()
+		err := vm.ExecuteInModule(code, mod)
+		moduleMu.Lock()
+		if err != nil {
+			delete(vm.moduleOverrides, name)
+			return nil, fmt.Errorf("error executing '%s': %v", name, err)
+		}
+		return mod, nil
+	}
+
+	// Filesystem fallback: search SearchPaths for <name>.py or a package
+	// directory <name>/__init__.py, with dots in the module name mapping to
+	// subdirectories
 	if vm.FileImporter != nil {
+		relPath := filepath.FromSlash(strings.ReplaceAll(name, ".", "/"))
 		for _, dir := range vm.SearchPaths {
-			pyFile := filepath.Join(dir, name+".py")
-			if _, err := os.Stat(pyFile); err == nil {
-				code, err := vm.FileImporter(pyFile)
-				if err != nil {
-					return nil, fmt.Errorf("error importing '%s': %v", name, err)
+			pyFile := filepath.Join(dir, relPath+".py")
+			isPackage := false
+			if _, err := os.Stat(pyFile); err != nil {
+				initFile := filepath.Join(dir, relPath, "__init__.py")
+				if _, err := os.Stat(initFile); err != nil {
+					continue
 				}
+				pyFile = initFile
+				isPackage = true
+			}
 
-				mod := NewModule(name)
-				mod.Package = name
-				mod.Dict["__package__"] = NewString(name)
-				mod.Dict["__file__"] = NewString(pyFile)
+			code, err := vm.FileImporter(pyFile)
+			if err != nil {
+				return nil, fmt.Errorf("error importing '%s': %v", name, err)
+			}
 
-				// Track loading state so concurrent importers can wait
-				ls := &moduleLoadState{vm: vm, done: make(chan struct{})}
-				moduleLoading[name] = ls
+			// A plain module's package is its parent; a package is its own
+			mod := NewModule(name)
+			packageName := name
+			if !isPackage {
+				if lastDot := strings.LastIndexByte(name, '.'); lastDot >= 0 {
+					packageName = name[:lastDot]
+				}
+			}
+			mod.Package = packageName
+			mod.Dict["__package__"] = NewString(packageName)
+			mod.Dict["__file__"] = NewString(pyFile)
+			if isPackage {
+				mod.Dict["__path__"] = NewList([]Value{NewString(filepath.Dir(pyFile))})
+			}
 
-				// Cache before executing to handle circular imports
-				loadedModules[name] = mod
+			// Track loading state so concurrent importers can wait
+			ls := &moduleLoadState{vm: vm, done: make(chan struct{})}
+			moduleLoading[name] = ls
 
-				// Unlock while executing module code (may re-enter ImportModule)
-				moduleMu.Unlock()
-				err = vm.ExecuteInModule(code, mod)
-				moduleMu.Lock()
+			// Cache before executing to handle circular imports
+			loadedModules[name] = mod
 
-				if err != nil {
-					ls.err = err
-					delete(loadedModules, name)
-				}
-				close(ls.done)
-				delete(moduleLoading, name)
+			// Unlock while executing module code (may re-enter ImportModule)
+			moduleMu.Unlock()
+			err = vm.ExecuteInModule(code, mod)
+			moduleMu.Lock()
 
-				if err != nil {
-					return nil, fmt.Errorf("error executing '%s': %v", name, err)
-				}
+			if err != nil {
+				ls.err = err
+				delete(loadedModules, name)
+			}
+			close(ls.done)
+			delete(moduleLoading, name)
 
-				return mod, nil
+			if err != nil {
+				return nil, fmt.Errorf("error executing '%s': %v", name, err)
+			}
+
+			// Link the module as an attribute of its loaded parent package
+			if lastDot := strings.LastIndexByte(name, '.'); lastDot >= 0 {
+				if parent, ok := loadedModules[name[:lastDot]]; ok {
+					parent.Dict[name[lastDot+1:]] = mod
+				}
 			}
+
+			return mod, nil
 		}
 	}
 
@@ -358,4 +516,3 @@ func ResetModules() {
 	ResetPendingBuiltins()
 	ResetTypeMetatables()
 }
-
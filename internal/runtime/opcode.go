@@ -1,6 +1,9 @@
 package runtime
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Opcode represents a single bytecode instruction
 type Opcode byte
@@ -85,12 +88,12 @@ const (
 	OpJumpIfFalseOrPop // Jump if false, else pop (arg: offset)
 
 	// Control flow
-	OpJump             // Unconditional jump (arg: offset)
-	OpJumpIfTrue       // Jump if top is true (arg: offset)
-	OpJumpIfFalse      // Jump if top is false (arg: offset)
-	OpPopJumpIfTrue    // Pop and jump if true (arg: offset)
-	OpPopJumpIfFalse   // Pop and jump if false (arg: offset)
-	OpContinueLoop     // Continue through finally blocks (arg: loop target offset)
+	OpJump           // Unconditional jump (arg: offset)
+	OpJumpIfTrue     // Jump if top is true (arg: offset)
+	OpJumpIfFalse    // Jump if top is false (arg: offset)
+	OpPopJumpIfTrue  // Pop and jump if true (arg: offset)
+	OpPopJumpIfFalse // Pop and jump if false (arg: offset)
+	OpContinueLoop   // Continue through finally blocks (arg: loop target offset)
 
 	// Iteration
 	OpGetIter // Get iterator from iterable
@@ -130,24 +133,25 @@ const (
 	OpImportStar // Import * from module
 
 	// Exception handling
-	OpSetupExcept       // Setup exception handler (arg: handler offset)
-	OpSetupFinally      // Setup finally handler (arg: handler offset)
-	OpPopExcept         // Pop exception handler from block stack
-	OpPopBlock          // Pop top block from block stack (for normal finally entry)
-	OpEndFinally        // End finally block
-	OpRaiseVarargs      // Raise exception (arg: count 0-3)
-	OpExceptionMatch    // Check if exception matches type for except clause
-	OpClearException    // Clear current exception state (for handler entry)
-	OpPopExceptHandler  // Pop excHandlerStack entry (end of handler body)
+	OpSetupExcept      // Setup exception handler (arg: handler offset)
+	OpSetupFinally     // Setup finally handler (arg: handler offset)
+	OpPopExcept        // Pop exception handler from block stack
+	OpPopBlock         // Pop top block from block stack (for normal finally entry)
+	OpEndFinally       // End finally block
+	OpRaiseVarargs     // Raise exception (arg: count 0-3)
+	OpExceptionMatch   // Check if exception matches type for except clause
+	OpClearException   // Clear current exception state (for handler entry)
+	OpPopExceptHandler // Pop excHandlerStack entry (end of handler body)
 
 	// Exception groups (except*)
-	OpSetupExceptStar  // Setup except* handler (arg: handler offset)
-	OpExceptStarMatch  // Match exception group against type: stack [eg, type] → [eg, subgroup_or_None]
+	OpSetupExceptStar   // Setup except* handler (arg: handler offset)
+	OpExceptStarMatch   // Match exception group against type: stack [eg, type] → [eg, subgroup_or_None]
 	OpExceptStarReraise // Re-raise unmatched exceptions from except* block
 
 	// With statement
-	OpSetupWith   // Setup with statement (arg: cleanup offset)
-	OpWithCleanup // Cleanup with statement
+	OpSetupWith        // Setup with statement (arg: cleanup offset)
+	OpWithCleanup      // Cleanup with statement
+	OpWithCleanupAsync // Cleanup async with statement (calls __aexit__)
 
 	// Assertion
 	OpAssert // Assert with optional message
@@ -265,122 +269,123 @@ const (
 
 // OpcodeNames maps opcodes to their string names for debugging
 var OpcodeNames = map[Opcode]string{
-	OpPop:              "POP",
-	OpDup:              "DUP",
-	OpDup2:             "DUP_TOP_TWO",
-	OpRot2:             "ROT_TWO",
-	OpRot3:             "ROT_THREE",
-	OpLoadConst:        "LOAD_CONST",
-	OpLoadName:         "LOAD_NAME",
-	OpStoreName:        "STORE_NAME",
-	OpDeleteName:       "DELETE_NAME",
-	OpLoadFast:         "LOAD_FAST",
-	OpStoreFast:        "STORE_FAST",
-	OpDeleteFast:       "DELETE_FAST",
-	OpLoadGlobal:       "LOAD_GLOBAL",
-	OpStoreGlobal:      "STORE_GLOBAL",
-	OpDeleteGlobal:     "DELETE_GLOBAL",
-	OpLoadAttr:         "LOAD_ATTR",
-	OpStoreAttr:        "STORE_ATTR",
-	OpDeleteAttr:       "DELETE_ATTR",
-	OpBinarySubscr:     "BINARY_SUBSCR",
-	OpStoreSubscr:      "STORE_SUBSCR",
-	OpDeleteSubscr:     "DELETE_SUBSCR",
-	OpUnaryPositive:    "UNARY_POSITIVE",
-	OpUnaryNegative:    "UNARY_NEGATIVE",
-	OpUnaryNot:         "UNARY_NOT",
-	OpUnaryInvert:      "UNARY_INVERT",
-	OpBinaryAdd:        "BINARY_ADD",
-	OpBinarySubtract:   "BINARY_SUBTRACT",
-	OpBinaryMultiply:   "BINARY_MULTIPLY",
-	OpBinaryDivide:     "BINARY_TRUE_DIVIDE",
-	OpBinaryFloorDiv:   "BINARY_FLOOR_DIVIDE",
-	OpBinaryModulo:     "BINARY_MODULO",
-	OpBinaryPower:      "BINARY_POWER",
-	OpBinaryMatMul:     "BINARY_MATRIX_MULTIPLY",
-	OpBinaryLShift:     "BINARY_LSHIFT",
-	OpBinaryRShift:     "BINARY_RSHIFT",
-	OpBinaryAnd:        "BINARY_AND",
-	OpBinaryOr:         "BINARY_OR",
-	OpBinaryXor:        "BINARY_XOR",
-	OpInplaceAdd:       "INPLACE_ADD",
-	OpInplaceSubtract:  "INPLACE_SUBTRACT",
-	OpInplaceMultiply:  "INPLACE_MULTIPLY",
-	OpInplaceDivide:    "INPLACE_TRUE_DIVIDE",
-	OpInplaceFloorDiv:  "INPLACE_FLOOR_DIVIDE",
-	OpInplaceModulo:    "INPLACE_MODULO",
-	OpInplacePower:     "INPLACE_POWER",
-	OpInplaceMatMul:    "INPLACE_MATRIX_MULTIPLY",
-	OpInplaceLShift:    "INPLACE_LSHIFT",
-	OpInplaceRShift:    "INPLACE_RSHIFT",
-	OpInplaceAnd:       "INPLACE_AND",
-	OpInplaceOr:        "INPLACE_OR",
-	OpInplaceXor:       "INPLACE_XOR",
-	OpCompareEq:        "COMPARE_EQ",
-	OpCompareNe:        "COMPARE_NE",
-	OpCompareLt:        "COMPARE_LT",
-	OpCompareLe:        "COMPARE_LE",
-	OpCompareGt:        "COMPARE_GT",
-	OpCompareGe:        "COMPARE_GE",
-	OpCompareIs:        "COMPARE_IS",
-	OpCompareIsNot:     "COMPARE_IS_NOT",
-	OpCompareIn:        "COMPARE_IN",
-	OpCompareNotIn:     "COMPARE_NOT_IN",
-	OpJumpIfTrueOrPop:  "JUMP_IF_TRUE_OR_POP",
-	OpJumpIfFalseOrPop: "JUMP_IF_FALSE_OR_POP",
-	OpJump:             "JUMP",
-	OpJumpIfTrue:       "JUMP_IF_TRUE",
-	OpJumpIfFalse:      "JUMP_IF_FALSE",
-	OpPopJumpIfTrue:    "POP_JUMP_IF_TRUE",
-	OpPopJumpIfFalse:   "POP_JUMP_IF_FALSE",
-	OpContinueLoop:     "CONTINUE_LOOP",
-	OpGetIter:          "GET_ITER",
-	OpForIter:          "FOR_ITER",
-	OpMakeFunction:     "MAKE_FUNCTION",
-	OpCall:             "CALL",
-	OpCallKw:           "CALL_KW",
-	OpCallEx:           "CALL_FUNCTION_EX",
-	OpReturn:           "RETURN_VALUE",
-	OpLoadBuildClass:   "LOAD_BUILD_CLASS",
-	OpLoadMethod:       "LOAD_METHOD",
-	OpCallMethod:       "CALL_METHOD",
-	OpBuildTuple:       "BUILD_TUPLE",
-	OpBuildList:        "BUILD_LIST",
-	OpBuildSet:         "BUILD_SET",
-	OpBuildMap:         "BUILD_MAP",
-	OpBuildString:      "BUILD_STRING",
-	OpUnpackSequence:   "UNPACK_SEQUENCE",
-	OpUnpackEx:         "UNPACK_EX",
-	OpListAppend:       "LIST_APPEND",
-	OpSetAdd:           "SET_ADD",
-	OpMapAdd:           "MAP_ADD",
-	OpImportName:       "IMPORT_NAME",
-	OpImportFrom:       "IMPORT_FROM",
-	OpImportStar:       "IMPORT_STAR",
-	OpSetupExcept:      "SETUP_EXCEPT",
-	OpSetupFinally:     "SETUP_FINALLY",
-	OpPopExcept:        "POP_EXCEPT",
-	OpPopBlock:         "POP_BLOCK",
-	OpEndFinally:       "END_FINALLY",
-	OpRaiseVarargs:     "RAISE_VARARGS",
-	OpExceptionMatch:   "EXCEPTION_MATCH",
-	OpPopExceptHandler: "POP_EXCEPT_HANDLER",
+	OpPop:               "POP",
+	OpDup:               "DUP",
+	OpDup2:              "DUP_TOP_TWO",
+	OpRot2:              "ROT_TWO",
+	OpRot3:              "ROT_THREE",
+	OpLoadConst:         "LOAD_CONST",
+	OpLoadName:          "LOAD_NAME",
+	OpStoreName:         "STORE_NAME",
+	OpDeleteName:        "DELETE_NAME",
+	OpLoadFast:          "LOAD_FAST",
+	OpStoreFast:         "STORE_FAST",
+	OpDeleteFast:        "DELETE_FAST",
+	OpLoadGlobal:        "LOAD_GLOBAL",
+	OpStoreGlobal:       "STORE_GLOBAL",
+	OpDeleteGlobal:      "DELETE_GLOBAL",
+	OpLoadAttr:          "LOAD_ATTR",
+	OpStoreAttr:         "STORE_ATTR",
+	OpDeleteAttr:        "DELETE_ATTR",
+	OpBinarySubscr:      "BINARY_SUBSCR",
+	OpStoreSubscr:       "STORE_SUBSCR",
+	OpDeleteSubscr:      "DELETE_SUBSCR",
+	OpUnaryPositive:     "UNARY_POSITIVE",
+	OpUnaryNegative:     "UNARY_NEGATIVE",
+	OpUnaryNot:          "UNARY_NOT",
+	OpUnaryInvert:       "UNARY_INVERT",
+	OpBinaryAdd:         "BINARY_ADD",
+	OpBinarySubtract:    "BINARY_SUBTRACT",
+	OpBinaryMultiply:    "BINARY_MULTIPLY",
+	OpBinaryDivide:      "BINARY_TRUE_DIVIDE",
+	OpBinaryFloorDiv:    "BINARY_FLOOR_DIVIDE",
+	OpBinaryModulo:      "BINARY_MODULO",
+	OpBinaryPower:       "BINARY_POWER",
+	OpBinaryMatMul:      "BINARY_MATRIX_MULTIPLY",
+	OpBinaryLShift:      "BINARY_LSHIFT",
+	OpBinaryRShift:      "BINARY_RSHIFT",
+	OpBinaryAnd:         "BINARY_AND",
+	OpBinaryOr:          "BINARY_OR",
+	OpBinaryXor:         "BINARY_XOR",
+	OpInplaceAdd:        "INPLACE_ADD",
+	OpInplaceSubtract:   "INPLACE_SUBTRACT",
+	OpInplaceMultiply:   "INPLACE_MULTIPLY",
+	OpInplaceDivide:     "INPLACE_TRUE_DIVIDE",
+	OpInplaceFloorDiv:   "INPLACE_FLOOR_DIVIDE",
+	OpInplaceModulo:     "INPLACE_MODULO",
+	OpInplacePower:      "INPLACE_POWER",
+	OpInplaceMatMul:     "INPLACE_MATRIX_MULTIPLY",
+	OpInplaceLShift:     "INPLACE_LSHIFT",
+	OpInplaceRShift:     "INPLACE_RSHIFT",
+	OpInplaceAnd:        "INPLACE_AND",
+	OpInplaceOr:         "INPLACE_OR",
+	OpInplaceXor:        "INPLACE_XOR",
+	OpCompareEq:         "COMPARE_EQ",
+	OpCompareNe:         "COMPARE_NE",
+	OpCompareLt:         "COMPARE_LT",
+	OpCompareLe:         "COMPARE_LE",
+	OpCompareGt:         "COMPARE_GT",
+	OpCompareGe:         "COMPARE_GE",
+	OpCompareIs:         "COMPARE_IS",
+	OpCompareIsNot:      "COMPARE_IS_NOT",
+	OpCompareIn:         "COMPARE_IN",
+	OpCompareNotIn:      "COMPARE_NOT_IN",
+	OpJumpIfTrueOrPop:   "JUMP_IF_TRUE_OR_POP",
+	OpJumpIfFalseOrPop:  "JUMP_IF_FALSE_OR_POP",
+	OpJump:              "JUMP",
+	OpJumpIfTrue:        "JUMP_IF_TRUE",
+	OpJumpIfFalse:       "JUMP_IF_FALSE",
+	OpPopJumpIfTrue:     "POP_JUMP_IF_TRUE",
+	OpPopJumpIfFalse:    "POP_JUMP_IF_FALSE",
+	OpContinueLoop:      "CONTINUE_LOOP",
+	OpGetIter:           "GET_ITER",
+	OpForIter:           "FOR_ITER",
+	OpMakeFunction:      "MAKE_FUNCTION",
+	OpCall:              "CALL",
+	OpCallKw:            "CALL_KW",
+	OpCallEx:            "CALL_FUNCTION_EX",
+	OpReturn:            "RETURN_VALUE",
+	OpLoadBuildClass:    "LOAD_BUILD_CLASS",
+	OpLoadMethod:        "LOAD_METHOD",
+	OpCallMethod:        "CALL_METHOD",
+	OpBuildTuple:        "BUILD_TUPLE",
+	OpBuildList:         "BUILD_LIST",
+	OpBuildSet:          "BUILD_SET",
+	OpBuildMap:          "BUILD_MAP",
+	OpBuildString:       "BUILD_STRING",
+	OpUnpackSequence:    "UNPACK_SEQUENCE",
+	OpUnpackEx:          "UNPACK_EX",
+	OpListAppend:        "LIST_APPEND",
+	OpSetAdd:            "SET_ADD",
+	OpMapAdd:            "MAP_ADD",
+	OpImportName:        "IMPORT_NAME",
+	OpImportFrom:        "IMPORT_FROM",
+	OpImportStar:        "IMPORT_STAR",
+	OpSetupExcept:       "SETUP_EXCEPT",
+	OpSetupFinally:      "SETUP_FINALLY",
+	OpPopExcept:         "POP_EXCEPT",
+	OpPopBlock:          "POP_BLOCK",
+	OpEndFinally:        "END_FINALLY",
+	OpRaiseVarargs:      "RAISE_VARARGS",
+	OpExceptionMatch:    "EXCEPTION_MATCH",
+	OpPopExceptHandler:  "POP_EXCEPT_HANDLER",
 	OpClearException:    "CLEAR_EXCEPTION",
-	OpSetupExceptStar:  "SETUP_EXCEPT_STAR",
-	OpExceptStarMatch:  "EXCEPT_STAR_MATCH",
+	OpSetupExceptStar:   "SETUP_EXCEPT_STAR",
+	OpExceptStarMatch:   "EXCEPT_STAR_MATCH",
 	OpExceptStarReraise: "EXCEPT_STAR_RERAISE",
-	OpSetupWith:        "SETUP_WITH",
-	OpWithCleanup:      "WITH_CLEANUP",
-	OpAssert:           "ASSERT",
-	OpLoadClosure:      "LOAD_CLOSURE",
-	OpStoreClosure:     "STORE_CLOSURE",
-	OpLoadDeref:        "LOAD_DEREF",
-	OpStoreDeref:       "STORE_DEREF",
-	OpDeleteDeref:      "DELETE_DEREF",
-	OpMakeCell:         "MAKE_CELL",
-	OpNop:              "NOP",
-	OpPrintExpr:        "PRINT_EXPR",
-	OpLoadLocals:       "LOAD_LOCALS",
+	OpSetupWith:         "SETUP_WITH",
+	OpWithCleanup:       "WITH_CLEANUP",
+	OpWithCleanupAsync:  "WITH_CLEANUP_ASYNC",
+	OpAssert:            "ASSERT",
+	OpLoadClosure:       "LOAD_CLOSURE",
+	OpStoreClosure:      "STORE_CLOSURE",
+	OpLoadDeref:         "LOAD_DEREF",
+	OpStoreDeref:        "STORE_DEREF",
+	OpDeleteDeref:       "DELETE_DEREF",
+	OpMakeCell:          "MAKE_CELL",
+	OpNop:               "NOP",
+	OpPrintExpr:         "PRINT_EXPR",
+	OpLoadLocals:        "LOAD_LOCALS",
 	// Generator opcodes
 	OpYieldValue: "YIELD_VALUE",
 	OpYieldFrom:  "YIELD_FROM",
@@ -440,7 +445,7 @@ var OpcodeNames = map[Opcode]string{
 	OpCompareGeJump:      "COMPARE_GE_JUMP",
 	OpCompareEqJump:      "COMPARE_EQ_JUMP",
 	OpCompareNeJump:      "COMPARE_NE_JUMP",
-	OpCompareLtLocal: "COMPARE_LT_LOCAL",
+	OpCompareLtLocal:     "COMPARE_LT_LOCAL",
 	OpLenList:            "LEN_LIST",
 	OpLenString:          "LEN_STRING",
 	OpLenTuple:           "LEN_TUPLE",
@@ -484,7 +489,7 @@ func init() {
 		OpGetIter, OpReturn,
 		OpPopExcept, OpPopBlock, OpEndFinally, OpExceptionMatch, OpClearException, OpPopExceptHandler,
 		OpExceptStarMatch, OpExceptStarReraise,
-		OpWithCleanup,
+		OpWithCleanup, OpWithCleanupAsync,
 		OpNop, OpPrintExpr, OpLoadLocals, OpLoadBuildClass,
 		OpImportStar,
 		// Generator/coroutine opcodes (no args)
@@ -526,20 +531,22 @@ type Instruction struct {
 
 // CodeObject represents compiled Python code
 type CodeObject struct {
-	Name           string      // Function/class/module name
-	Filename       string      // Source filename
-	FirstLine      int         // First line number in source
-	Code           []byte      // Bytecode instructions
-	Constants      []any       // Constant pool
-	Names          []string    // Names used in code
-	VarNames       []string    // Local variable names
-	FreeVars       []string    // Free variables (closures)
-	CellVars       []string    // Variables captured in closures
-	ArgCount       int         // Number of positional arguments
-	KwOnlyArgCount int         // Number of keyword-only arguments
-	Flags          CodeFlags   // Code flags
-	StackSize      int         // Maximum stack size needed
-	LineNoTab      []LineEntry // Line number table
+	Name            string      // Function/class/module name
+	Filename        string      // Source filename
+	FirstLine       int         // First line number in source
+	Code            []byte      // Bytecode instructions
+	Constants       []any       // Constant pool
+	Names           []string    // Names used in code
+	VarNames        []string    // Local variable names
+	FreeVars        []string    // Free variables (closures)
+	CellVars        []string    // Variables captured in closures
+	ArgCount        int         // Number of positional arguments (including positional-only)
+	PosOnlyArgCount int         // Number of positional-only arguments (before /)
+	KwOnlyArgCount  int         // Number of keyword-only arguments
+	Flags           CodeFlags   // Code flags
+	StackSize       int         // Maximum stack size needed
+	LineNoTab       []LineEntry // Line number table
+	Doc             string      // Docstring (leading string literal), empty if none
 }
 
 // CodeFlags represents flags for code objects
@@ -563,12 +570,21 @@ type LineEntry struct {
 	StartOffset int
 	EndOffset   int
 	Line        int
+	Col         int // 1-based start column of the expression span (0 = unknown)
+	EndCol      int // Column just past the expression span (0 = unknown)
 }
 
-// Disassemble returns a human-readable disassembly of the code object
+// Disassemble returns a human-readable disassembly of the code object:
+// the opcode listing with line numbers, the constant pool, and a recursive
+// disassembly of any nested code objects (function and class bodies).
 func (co *CodeObject) Disassemble() string {
-	var result string
-	result += "Disassembly of " + co.Name + ":\n"
+	var b strings.Builder
+	co.disassembleInto(&b)
+	return b.String()
+}
+
+func (co *CodeObject) disassembleInto(b *strings.Builder) {
+	b.WriteString("Disassembly of " + co.Name + ":\n")
 
 	offset := 0
 	for offset < len(co.Code) {
@@ -578,15 +594,41 @@ func (co *CodeObject) Disassemble() string {
 		if op.HasArg() && offset+2 < len(co.Code) {
 			arg := int(co.Code[offset+1]) | int(co.Code[offset+2])<<8
 			argStr := formatArg(co, op, arg)
-			result += formatInstruction(offset, line, op, arg, argStr)
+			b.WriteString(formatInstruction(offset, line, op, arg, argStr))
 			offset += 3
 		} else {
-			result += formatInstruction(offset, line, op, -1, "")
+			b.WriteString(formatInstruction(offset, line, op, -1, ""))
 			offset += 1
 		}
 	}
 
-	return result
+	if len(co.Constants) > 0 {
+		b.WriteString("Constants:\n")
+		for i, c := range co.Constants {
+			fmt.Fprintf(b, "%4d: %s\n", i, constantRepr(c))
+		}
+	}
+
+	for _, c := range co.Constants {
+		if nested, ok := c.(*CodeObject); ok {
+			b.WriteString("\n")
+			nested.disassembleInto(b)
+		}
+	}
+}
+
+// constantRepr formats a constant-pool entry for disassembly output.
+func constantRepr(c any) string {
+	switch v := c.(type) {
+	case nil:
+		return "None"
+	case *CodeObject:
+		return fmt.Sprintf("<code object %s>", v.Name)
+	case string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
 }
 
 // LineForOffset returns the source line for a bytecode offset
@@ -599,6 +641,17 @@ func (co *CodeObject) LineForOffset(offset int) int {
 	return co.FirstLine
 }
 
+// ColumnsForOffset returns the 1-based column span recorded for a bytecode
+// offset, or zeros when the compiler recorded no span for it.
+func (co *CodeObject) ColumnsForOffset(offset int) (col, endCol int) {
+	for _, entry := range co.LineNoTab {
+		if offset >= entry.StartOffset && offset < entry.EndOffset {
+			return entry.Col, entry.EndCol
+		}
+	}
+	return 0, 0
+}
+
 // CellOrFreeName returns the variable name for a cell/free index.
 func (co *CodeObject) CellOrFreeName(idx int) string {
 	if idx < len(co.CellVars) {
@@ -805,7 +858,7 @@ func formatArg(co *CodeObject, op Opcode, arg int) string {
 	switch op {
 	case OpLoadConst:
 		if arg < len(co.Constants) {
-			return fmt.Sprintf("%v", co.Constants[arg])
+			return constantRepr(co.Constants[arg])
 		}
 	case OpLoadName, OpStoreName, OpDeleteName,
 		OpLoadGlobal, OpStoreGlobal, OpDeleteGlobal,
@@ -0,0 +1,54 @@
+package runtime
+
+import (
+	"strings"
+	"testing"
+)
+
+// =====================================
+// CodeObject.Disassemble
+// =====================================
+
+func TestDisassembleListsConstantsAndNestedCode(t *testing.T) {
+	inner := &CodeObject{
+		Name:      "inner",
+		Code:      []byte{byte(OpLoadNone), byte(OpReturn)},
+		FirstLine: 2,
+	}
+	outer := &CodeObject{
+		Name:      "outer",
+		Code:      []byte{byte(OpLoadConst), 0, 0, byte(OpReturn)},
+		Constants: []any{"hello", int64(42), inner},
+		FirstLine: 1,
+	}
+
+	out := outer.Disassemble()
+	for _, want := range []string{
+		"Disassembly of outer:",
+		"LOAD_CONST",
+		"Constants:",
+		`"hello"`,
+		"42",
+		"<code object inner>",
+		"Disassembly of inner:",
+		"RETURN_VALUE",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("disassembly missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDisassembleShowsLinesAndOffsets(t *testing.T) {
+	co := &CodeObject{
+		Name:      "mod",
+		Code:      []byte{byte(OpLoadConst), 0, 0, byte(OpReturn)},
+		Constants: []any{int64(1)},
+		FirstLine: 1,
+		LineNoTab: []LineEntry{{StartOffset: 0, EndOffset: 4, Line: 7}},
+	}
+	out := co.Disassemble()
+	if !strings.Contains(out, "   7    0 LOAD_CONST") {
+		t.Errorf("expected line 7 at offset 0 in:\n%s", out)
+	}
+}
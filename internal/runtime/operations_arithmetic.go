@@ -53,6 +53,10 @@ func (vm *VM) unaryOp(op Opcode, a Value) (Value, error) {
 			return &PyFloat{Value: -v.Value}, nil
 		case *PyComplex:
 			return MakeComplex(-v.Real, -v.Imag), nil
+		case *PyVec2:
+			return &PyVec2{X: -v.X, Y: -v.Y}, nil
+		case *PyVec3:
+			return &PyVec3{X: -v.X, Y: -v.Y, Z: -v.Z}, nil
 		}
 	case OpUnaryPositive:
 		switch v := a.(type) {
@@ -71,6 +75,68 @@ func (vm *VM) unaryOp(op Opcode, a Value) (Value, error) {
 	return nil, fmt.Errorf("TypeError: bad operand type for unary %s: '%s'", op.String(), vm.typeName(a))
 }
 
+// binaryOpSymbol maps a binary opcode to the operator symbol CPython uses
+// in its TypeError messages.
+func binaryOpSymbol(op Opcode) string {
+	switch op {
+	case OpBinaryAdd:
+		return "+"
+	case OpBinarySubtract:
+		return "-"
+	case OpBinaryMultiply:
+		return "*"
+	case OpBinaryDivide:
+		return "/"
+	case OpBinaryFloorDiv:
+		return "//"
+	case OpBinaryModulo:
+		return "%"
+	case OpBinaryPower:
+		return "** or pow()"
+	case OpBinaryMatMul:
+		return "@"
+	case OpBinaryAnd:
+		return "&"
+	case OpBinaryOr:
+		return "|"
+	case OpBinaryXor:
+		return "^"
+	case OpBinaryLShift:
+		return "<<"
+	case OpBinaryRShift:
+		return ">>"
+	}
+	return op.String()
+}
+
+// reflectedTakesPrecedence reports whether b's class is a proper subclass of
+// a's class and overrides the reflected method below a's class in its MRO.
+// CPython gives such a method priority over the left operand's forward method.
+func (vm *VM) reflectedTakesPrecedence(aInst, bInst *PyInstance, reverse string) bool {
+	if aInst == nil || bInst == nil || aInst.Class == bInst.Class {
+		return false
+	}
+	isSubclass := false
+	for _, cls := range bInst.Class.Mro {
+		if cls == aInst.Class {
+			isSubclass = true
+			break
+		}
+	}
+	if !isSubclass {
+		return false
+	}
+	for _, cls := range bInst.Class.Mro {
+		if cls == aInst.Class {
+			return false // Reached the base without finding an override
+		}
+		if _, ok := cls.Dict[reverse]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 	// Bool is a subclass of int in Python - coerce bools to ints for arithmetic
 	if ab, ok := a.(*PyBool); ok {
@@ -88,6 +154,13 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 		}
 	}
 
+	// Native game-math types implement their operators in Go
+	if isGameMathValue(a) || isGameMathValue(b) {
+		if result, handled, err := vm.gameMathBinaryOp(op, a, b); handled {
+			return result, err
+		}
+	}
+
 	// Check for dunder methods on instances first
 	dunders := map[Opcode]struct{ forward, reverse string }{
 		OpBinaryAdd:      {"__add__", "__radd__"},
@@ -106,23 +179,53 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 	}
 
 	if dunder, ok := dunders[op]; ok {
+		aInst, _ := a.(*PyInstance)
+		bInst, _ := b.(*PyInstance)
+		sameClass := aInst != nil && bInst != nil && aInst.Class == bInst.Class
+		triedReverse := false
+
+		// A subclass on the right that overrides the reflected method gets
+		// first shot, so subclasses can refine mixed-type arithmetic
+		if vm.reflectedTakesPrecedence(aInst, bInst, dunder.reverse) {
+			triedReverse = true
+			if result, found, err := vm.callDunder(bInst, dunder.reverse, a); found {
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && result != NotImplemented {
+					return result, nil
+				}
+			}
+		}
 		// Try forward method on left operand
-		if inst, ok := a.(*PyInstance); ok {
-			if result, found, err := vm.callDunder(inst, dunder.forward, b); found {
-				// Check for NotImplemented sentinel (we return nil in that case)
-				if result != nil {
-					return result, err
+		if aInst != nil {
+			if result, found, err := vm.callDunder(aInst, dunder.forward, b); found {
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && result != NotImplemented {
+					return result, nil
 				}
 			}
 		}
-		// Try reverse method on right operand
-		if inst, ok := b.(*PyInstance); ok {
-			if result, found, err := vm.callDunder(inst, dunder.reverse, a); found {
-				if result != nil {
-					return result, err
+		// Try reverse method on right operand. Skipped when both operands
+		// share a class: the forward method already saw both sides
+		if bInst != nil && !sameClass && !triedReverse {
+			if result, found, err := vm.callDunder(bInst, dunder.reverse, a); found {
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && result != NotImplemented {
+					return result, nil
 				}
 			}
 		}
+		// Every dunder declined. Builtin fallbacks below can't operate on
+		// instances, so when both sides are instances this is a TypeError
+		if aInst != nil && bInst != nil {
+			return nil, fmt.Errorf("TypeError: unsupported operand type(s) for %s: '%s' and '%s'",
+				binaryOpSymbol(op), vm.typeName(a), vm.typeName(b))
+		}
 	}
 
 	// Fast path: int op int (most common case in numeric code)
@@ -244,6 +347,24 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 				return &PyBytes{Value: result}, nil
 			}
 		}
+		// Bytearray concatenation accepts bytes or bytearray on either side;
+		// the result follows the left operand's type, as in CPython
+		if ab, ok := a.(*PyByteArray); ok {
+			if bv, ok := byteSliceOf(b); ok {
+				result := make([]byte, len(ab.Value)+len(bv))
+				copy(result, ab.Value)
+				copy(result[len(ab.Value):], bv)
+				return &PyByteArray{Value: result}, nil
+			}
+		}
+		if ab, ok := a.(*PyBytes); ok {
+			if bb, ok := b.(*PyByteArray); ok {
+				result := make([]byte, len(ab.Value)+len(bb.Value))
+				copy(result, ab.Value)
+				copy(result[len(ab.Value):], bb.Value)
+				return &PyBytes{Value: result}, nil
+			}
+		}
 	}
 
 	// String repetition - use strings.Repeat for O(n) instead of O(n²)
@@ -427,6 +548,35 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 				return &PyBytes{Value: result}, nil
 			}
 		}
+		// Bytearray repetition
+		repeatByteArray := func(data []byte, n int64) (Value, error) {
+			if n <= 0 {
+				return &PyByteArray{Value: []byte{}}, nil
+			}
+			resultSize := int64(len(data)) * n
+			if resultSize > maxBytesRepeatSize {
+				return nil, fmt.Errorf("MemoryError: bytearray repetition result too large")
+			}
+			count, err := safeIntCount(n)
+			if err != nil {
+				return nil, err
+			}
+			result := make([]byte, 0, len(data)*count)
+			for i := 0; i < count; i++ {
+				result = append(result, data...)
+			}
+			return &PyByteArray{Value: result}, nil
+		}
+		if ab, ok := a.(*PyByteArray); ok {
+			if bi, ok := b.(*PyInt); ok {
+				return repeatByteArray(ab.Value, bi.Value)
+			}
+		}
+		if ab, ok := b.(*PyByteArray); ok {
+			if ai, ok := a.(*PyInt); ok {
+				return repeatByteArray(ab.Value, ai.Value)
+			}
+		}
 	}
 
 	// String % formatting (printf-style)
@@ -434,25 +584,46 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 		if as, ok := a.(*PyString); ok {
 			return vm.stringFormat(as.Value, b)
 		}
+		if ab, ok := a.(*PyBytes); ok {
+			result, err := vm.bytesFormat(ab.Value, b)
+			if err != nil {
+				return nil, err
+			}
+			return &PyBytes{Value: result}, nil
+		}
+		if ab, ok := a.(*PyByteArray); ok {
+			result, err := vm.bytesFormat(ab.Value, b)
+			if err != nil {
+				return nil, err
+			}
+			return &PyByteArray{Value: result}, nil
+		}
 	}
 
 	// Dict merge operator: d1 | d2
 	if op == OpBinaryOr {
-		if ad, ok := a.(*PyDict); ok {
-			if bd, ok := b.(*PyDict); ok {
-				result := &PyDict{Items: make(map[Value]Value), buckets: make(map[uint64][]dictEntry)}
-				for _, k := range ad.Keys(vm) {
-					if v, ok := ad.DictGet(k, vm); ok {
-						result.DictSet(k, v, vm)
-					}
+		_, aIsDict := a.(*PyDict)
+		_, bIsDict := b.(*PyDict)
+		if aIsDict || bIsDict {
+			if !aIsDict || !bIsDict {
+				// Unlike |=, the merge operator only accepts dicts on both sides
+				return nil, fmt.Errorf("TypeError: unsupported operand type(s) for |: '%s' and '%s'",
+					vm.typeName(a), vm.typeName(b))
+			}
+			ad := a.(*PyDict)
+			bd := b.(*PyDict)
+			result := &PyDict{Items: make(map[Value]Value), buckets: make(map[uint64][]dictEntry)}
+			for _, k := range ad.Keys(vm) {
+				if v, ok := ad.DictGet(k, vm); ok {
+					result.DictSet(k, v, vm)
 				}
-				for _, k := range bd.Keys(vm) {
-					if v, ok := bd.DictGet(k, vm); ok {
-						result.DictSet(k, v, vm)
-					}
+			}
+			for _, k := range bd.Keys(vm) {
+				if v, ok := bd.DictGet(k, vm); ok {
+					result.DictSet(k, v, vm)
 				}
-				return result, nil
 			}
+			return result, nil
 		}
 	}
 
@@ -469,6 +640,17 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 			return nil, false
 		}
 
+		// Hash-based membership test so the operators below are O(n+m)
+		setContains := func(v Value, item Value) bool {
+			switch s := v.(type) {
+			case *PySet:
+				return s.SetContains(item, vm)
+			case *PyFrozenSet:
+				return s.FrozenSetContains(item, vm)
+			}
+			return false
+		}
+
 		aItems, aIsSet := getSetItems(a)
 		bItems, bIsSet := getSetItems(b)
 
@@ -503,22 +685,16 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 				if returnFrozen {
 					result := &PyFrozenSet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
 					for k := range aItems {
-						for k2 := range bItems {
-							if vm.equal(k, k2) {
-								result.FrozenSetAdd(k, vm)
-								break
-							}
+						if setContains(b, k) {
+							result.FrozenSetAdd(k, vm)
 						}
 					}
 					return result, nil
 				}
 				result := &PySet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
 				for k := range aItems {
-					for k2 := range bItems {
-						if vm.equal(k, k2) {
-							result.SetAdd(k, vm)
-							break
-						}
+					if setContains(b, k) {
+						result.SetAdd(k, vm)
 					}
 				}
 				return result, nil
@@ -527,14 +703,7 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 				if returnFrozen {
 					result := &PyFrozenSet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
 					for k := range aItems {
-						found := false
-						for k2 := range bItems {
-							if vm.equal(k, k2) {
-								found = true
-								break
-							}
-						}
-						if !found {
+						if !setContains(b, k) {
 							result.FrozenSetAdd(k, vm)
 						}
 					}
@@ -542,14 +711,7 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 				}
 				result := &PySet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
 				for k := range aItems {
-					found := false
-					for k2 := range bItems {
-						if vm.equal(k, k2) {
-							found = true
-							break
-						}
-					}
-					if !found {
+					if !setContains(b, k) {
 						result.SetAdd(k, vm)
 					}
 				}
@@ -560,27 +722,13 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 					result := &PyFrozenSet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
 					// Add items from a not in b
 					for k := range aItems {
-						found := false
-						for k2 := range bItems {
-							if vm.equal(k, k2) {
-								found = true
-								break
-							}
-						}
-						if !found {
+						if !setContains(b, k) {
 							result.FrozenSetAdd(k, vm)
 						}
 					}
 					// Add items from b not in a
 					for k := range bItems {
-						found := false
-						for k2 := range aItems {
-							if vm.equal(k, k2) {
-								found = true
-								break
-							}
-						}
-						if !found {
+						if !setContains(a, k) {
 							result.FrozenSetAdd(k, vm)
 						}
 					}
@@ -588,26 +736,12 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 				}
 				result := &PySet{Items: make(map[Value]struct{}), buckets: make(map[uint64][]setEntry)}
 				for k := range aItems {
-					found := false
-					for k2 := range bItems {
-						if vm.equal(k, k2) {
-							found = true
-							break
-						}
-					}
-					if !found {
+					if !setContains(b, k) {
 						result.SetAdd(k, vm)
 					}
 				}
 				for k := range bItems {
-					found := false
-					for k2 := range aItems {
-						if vm.equal(k, k2) {
-							found = true
-							break
-						}
-					}
-					if !found {
+					if !setContains(a, k) {
 						result.SetAdd(k, vm)
 					}
 				}
@@ -744,6 +878,6 @@ func (vm *VM) binaryOp(op Opcode, a, b Value) (Value, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("unsupported operand type(s) for %s: '%s' and '%s'",
-		op.String(), vm.typeName(a), vm.typeName(b))
+	return nil, fmt.Errorf("TypeError: unsupported operand type(s) for %s: '%s' and '%s'",
+		binaryOpSymbol(op), vm.typeName(a), vm.typeName(b))
 }
@@ -22,9 +22,11 @@ func (vm *VM) areBuiltinOrderable(a, b Value) bool {
 	case *PyTuple:
 		_, ok := b.(*PyTuple)
 		return ok
-	case *PyBytes:
-		_, ok := b.(*PyBytes)
-		return ok
+	case *PyBytes, *PyByteArray:
+		switch b.(type) {
+		case *PyBytes, *PyByteArray:
+			return true
+		}
 	case *PyBool:
 		switch b.(type) {
 		case *PyBool, *PyInt, *PyFloat:
@@ -34,7 +36,8 @@ func (vm *VM) areBuiltinOrderable(a, b Value) bool {
 	return false
 }
 
-// tryRichCompare attempts dunder-based comparison on PyInstance objects.
+// tryRichCompare attempts dunder-based comparison on PyInstance objects and
+// userdata with comparison metamethods.
 // It tries a.__dunder__(b) first, then b.__reflected__(a).
 // Returns (result, true) if a dunder was found, or (nil, false) to fall back.
 func (vm *VM) tryRichCompare(a, b Value, dunder, reflected string) (Value, bool) {
@@ -53,6 +56,20 @@ func (vm *VM) tryRichCompare(a, b Value, dunder, reflected string) (Value, bool)
 			return result, true
 		}
 	}
+	if ud, ok := a.(*PyUserData); ok {
+		result, found, err := vm.callMetamethod(ud, dunder, b)
+		if found && err != nil {
+			if pyExc, ok := err.(*PyException); ok {
+				vm.currentException = pyExc
+			} else {
+				vm.currentException = &PyException{TypeName: "RuntimeError", Message: err.Error()}
+			}
+			return nil, true
+		}
+		if found && result != NotImplemented {
+			return result, true
+		}
+	}
 	if inst, ok := b.(*PyInstance); ok {
 		result, found, err := vm.callDunder(inst, reflected, a)
 		if found && err != nil {
@@ -67,6 +84,20 @@ func (vm *VM) tryRichCompare(a, b Value, dunder, reflected string) (Value, bool)
 			return result, true
 		}
 	}
+	if ud, ok := b.(*PyUserData); ok {
+		result, found, err := vm.callMetamethod(ud, reflected, a)
+		if found && err != nil {
+			if pyExc, ok := err.(*PyException); ok {
+				vm.currentException = pyExc
+			} else {
+				vm.currentException = &PyException{TypeName: "RuntimeError", Message: err.Error()}
+			}
+			return nil, true
+		}
+		if found && result != NotImplemented {
+			return result, true
+		}
+	}
 	return nil, false
 }
 
@@ -412,17 +443,25 @@ func (vm *VM) equalWithCycleDetection(a, b Value, seen map[uintptr]map[uintptr]b
 		if bv, ok := b.(*PyString); ok {
 			return av.Value == bv.Value
 		}
+	case *PyVec2:
+		if bv, ok := b.(*PyVec2); ok {
+			return av.X == bv.X && av.Y == bv.Y
+		}
+	case *PyVec3:
+		if bv, ok := b.(*PyVec3); ok {
+			return av.X == bv.X && av.Y == bv.Y && av.Z == bv.Z
+		}
+	case *PyMat3:
+		if bv, ok := b.(*PyMat3); ok {
+			return av.M == bv.M
+		}
 	case *PyBytes:
-		if bv, ok := b.(*PyBytes); ok {
-			if len(av.Value) != len(bv.Value) {
-				return false
-			}
-			for i := range av.Value {
-				if av.Value[i] != bv.Value[i] {
-					return false
-				}
-			}
-			return true
+		if bs, ok := byteSliceOf(b); ok {
+			return bytesEqual(av.Value, bs)
+		}
+	case *PyByteArray:
+		if bs, ok := byteSliceOf(b); ok {
+			return bytesEqual(av.Value, bs)
 		}
 	case *PyList:
 		if bv, ok := b.(*PyList); ok {
@@ -451,6 +490,17 @@ func (vm *VM) equalWithCycleDetection(a, b Value, seen map[uintptr]map[uintptr]b
 			if len(av.Items) != len(bv.Items) {
 				return false
 			}
+			// Tuples can't contain themselves directly, but they can sit on
+			// a cycle through a mutable container
+			ptrA := ptrValue(a)
+			ptrB := ptrValue(b)
+			if seen[ptrA] != nil && seen[ptrA][ptrB] {
+				return true
+			}
+			if seen[ptrA] == nil {
+				seen[ptrA] = make(map[uintptr]bool)
+			}
+			seen[ptrA][ptrB] = true
 			for i := range av.Items {
 				if !vm.equalWithCycleDetection(av.Items[i], bv.Items[i], seen) {
 					return false
@@ -558,11 +608,20 @@ func (vm *VM) equalWithCycleDetection(a, b Value, seen map[uintptr]map[uintptr]b
 		}
 	case *UnionType:
 		if bv, ok := b.(*UnionType); ok {
+			// Unions compare as sets of members: int | str == str | int.
+			// Args are already deduplicated by MakeUnionType.
 			if len(av.Args) != len(bv.Args) {
 				return false
 			}
-			for i, arg := range av.Args {
-				if !vm.equalWithCycleDetection(arg, bv.Args[i], seen) {
+			for _, arg := range av.Args {
+				found := false
+				for _, other := range bv.Args {
+					if vm.equalWithCycleDetection(arg, other, seen) {
+						found = true
+						break
+					}
+				}
+				if !found {
 					return false
 				}
 			}
@@ -572,6 +631,9 @@ func (vm *VM) equalWithCycleDetection(a, b Value, seen map[uintptr]map[uintptr]b
 	case *PyClass:
 		// Classes are compared by identity
 		return a == b
+	case *GenericAlias:
+		// Parameterized generics compare structurally via unionTypeEqual
+		return unionTypeEqual(av, b)
 	case *PyInstance:
 		// Check for __eq__ method
 		if result, found, err := vm.callDunder(av, "__eq__", b); found && err == nil {
@@ -599,6 +661,23 @@ func (vm *VM) equalWithCycleDetection(a, b Value, seen map[uintptr]map[uintptr]b
 		}
 		// Fall back to identity comparison
 		return a == b
+	case *PyUserData:
+		// Check for an __eq__ metamethod in the metatable
+		if result, found, err := vm.callMetamethod(av, "__eq__", b); found && err == nil {
+			if result != NotImplemented {
+				return vm.truthy(result)
+			}
+		}
+		// Try the reflected __eq__ on b
+		if bv, ok := b.(*PyUserData); ok {
+			if result, found, err := vm.callMetamethod(bv, "__eq__", a); found && err == nil {
+				if result != NotImplemented {
+					return vm.truthy(result)
+				}
+			}
+		}
+		// Fall back to identity comparison
+		return a == b
 	}
 	// Check if b is a PyInstance with __eq__
 	if bv, ok := b.(*PyInstance); ok {
@@ -608,10 +687,23 @@ func (vm *VM) equalWithCycleDetection(a, b Value, seen map[uintptr]map[uintptr]b
 			}
 		}
 	}
+	// Check if b is userdata with an __eq__ metamethod
+	if bv, ok := b.(*PyUserData); ok {
+		if result, found, err := vm.callMetamethod(bv, "__eq__", a); found && err == nil {
+			if result != NotImplemented {
+				return vm.truthy(result)
+			}
+		}
+	}
 	// Fall back to identity comparison for unhandled types
 	return a == b
 }
 
+// maxCompareDepth bounds container ordering recursion. Mutually cyclic
+// lists can recurse forever through compare; CPython raises RecursionError
+// in the same situation.
+const maxCompareDepth = 500
+
 func (vm *VM) compare(a, b Value) int {
 	// Bool is a subclass of int - coerce for comparison
 	if ab, ok := a.(*PyBool); ok {
@@ -676,116 +768,118 @@ func (vm *VM) compare(a, b Value) int {
 			return 0
 		}
 	case *PyBytes:
-		if bv, ok := b.(*PyBytes); ok {
-			minLen := len(av.Value)
-			if len(bv.Value) < minLen {
-				minLen = len(bv.Value)
-			}
-			for i := 0; i < minLen; i++ {
-				if av.Value[i] < bv.Value[i] {
-					return -1
-				} else if av.Value[i] > bv.Value[i] {
-					return 1
-				}
-			}
-			if len(av.Value) < len(bv.Value) {
-				return -1
-			} else if len(av.Value) > len(bv.Value) {
-				return 1
-			}
-			return 0
+		if bs, ok := byteSliceOf(b); ok {
+			return bytesCompare(av.Value, bs)
+		}
+	case *PyByteArray:
+		if bs, ok := byteSliceOf(b); ok {
+			return bytesCompare(av.Value, bs)
 		}
 	case *PyList:
 		if bv, ok := b.(*PyList); ok {
-			minLen := len(av.Items)
-			if len(bv.Items) < minLen {
-				minLen = len(bv.Items)
-			}
-			for i := 0; i < minLen; i++ {
-				c := vm.compare(av.Items[i], bv.Items[i])
-				if vm.currentException != nil {
-					return 0
-				}
-				if c != 0 {
-					return c
-				}
-			}
-			if len(av.Items) < len(bv.Items) {
-				return -1
-			} else if len(av.Items) > len(bv.Items) {
-				return 1
-			}
-			return 0
+			return vm.compareItems(av.Items, bv.Items)
 		}
 	case *PyTuple:
 		if bv, ok := b.(*PyTuple); ok {
-			minLen := len(av.Items)
-			if len(bv.Items) < minLen {
-				minLen = len(bv.Items)
-			}
-			for i := 0; i < minLen; i++ {
-				c := vm.compare(av.Items[i], bv.Items[i])
-				if vm.currentException != nil {
-					return 0
-				}
-				if c != 0 {
-					return c
-				}
-			}
-			if len(av.Items) < len(bv.Items) {
-				return -1
-			} else if len(av.Items) > len(bv.Items) {
-				return 1
-			}
-			return 0
+			return vm.compareItems(av.Items, bv.Items)
 		}
 	case *PyInstance:
-		// Try __lt__ first
-		if result, found, err := vm.callDunder(av, "__lt__", b); found && err == nil {
-			if boolVal, ok := result.(*PyBool); ok && boolVal.Value {
-				return -1
-			}
+		return vm.compareInstances(a, b)
+	case *PyUserData:
+		return vm.compareInstances(a, b)
+	}
+	// Check if b is a PyInstance or userdata (a is a builtin type)
+	switch b.(type) {
+	case *PyInstance, *PyUserData:
+		return vm.compareInstances(a, b)
+	}
+	// If we reach here with different, non-orderable types, signal a TypeError
+	// (identity-equal values like None==None are fine as 0/equal)
+	if a != b && !vm.areBuiltinOrderable(a, b) {
+		vm.currentException = &PyException{
+			TypeName: "TypeError",
+			Message:  "'<' not supported between instances of '" + vm.typeName(a) + "' and '" + vm.typeName(b) + "'",
 		}
-		// Try __gt__
-		if result, found, err := vm.callDunder(av, "__gt__", b); found && err == nil {
-			if boolVal, ok := result.(*PyBool); ok && boolVal.Value {
-				return 1
-			}
+	}
+	return 0
+}
+
+// compareItems three-way compares sequence contents lexicographically. Like
+// CPython it locates the first unequal pair with cycle-safe equality before
+// ordering it, so self-referential sequences compare without recursing, and
+// it raises RecursionError once nesting exceeds maxCompareDepth.
+func (vm *VM) compareItems(aItems, bItems []Value) int {
+	if vm.compareDepth >= maxCompareDepth {
+		vm.currentException = &PyException{
+			TypeName: "RecursionError",
+			Message:  "maximum recursion depth exceeded in comparison",
 		}
-		// Try __eq__ for equality
-		if result, found, err := vm.callDunder(av, "__eq__", b); found && err == nil {
-			if boolVal, ok := result.(*PyBool); ok && boolVal.Value {
-				return 0
-			}
+		return 0
+	}
+	vm.compareDepth++
+	defer func() { vm.compareDepth-- }()
+
+	minLen := len(aItems)
+	if len(bItems) < minLen {
+		minLen = len(bItems)
+	}
+	for i := 0; i < minLen; i++ {
+		if aItems[i] == bItems[i] || vm.equal(aItems[i], bItems[i]) {
+			continue
+		}
+		c := vm.compare(aItems[i], bItems[i])
+		if vm.currentException != nil {
+			return 0
 		}
+		return c
+	}
+	if len(aItems) < len(bItems) {
+		return -1
+	} else if len(aItems) > len(bItems) {
+		return 1
+	}
+	return 0
+}
+
+// compareInstances three-way compares values where at least one side is a class
+// instance or userdata, dispatching __lt__/__gt__ with reflected fallback. When neither
+// side defines an ordering it sets a TypeError in the CPython message format.
+func (vm *VM) compareInstances(a, b Value) int {
+	if vm.equal(a, b) {
 		return 0
 	}
-	// Check if b is a PyInstance
-	if bv, ok := b.(*PyInstance); ok {
-		// Try __gt__ on b (means a < b)
-		if result, found, err := vm.callDunder(bv, "__gt__", a); found && err == nil {
-			if boolVal, ok := result.(*PyBool); ok && boolVal.Value {
-				return -1
-			}
+	if result, ok := vm.tryRichCompare(a, b, "__lt__", "__gt__"); ok {
+		if vm.currentException != nil {
+			return 0
+		}
+		if vm.truthy(result) {
+			return -1
 		}
-		// Try __lt__ on b (means a > b)
-		if result, found, err := vm.callDunder(bv, "__lt__", a); found && err == nil {
-			if boolVal, ok := result.(*PyBool); ok && boolVal.Value {
+		// Not less: confirm greater with __gt__; both false means unordered,
+		// which ranks as equal
+		if result, ok := vm.tryRichCompare(a, b, "__gt__", "__lt__"); ok {
+			if vm.currentException != nil {
+				return 0
+			}
+			if vm.truthy(result) {
 				return 1
 			}
+			return 0
 		}
+		return 1
 	}
-	// If we reach here with different, non-orderable types, signal a TypeError
-	// (identity-equal values like None==None are fine as 0/equal)
-	if a != b && !vm.areBuiltinOrderable(a, b) {
-		_, aIsInst := a.(*PyInstance)
-		_, bIsInst := b.(*PyInstance)
-		if !aIsInst && !bIsInst {
-			vm.currentException = &PyException{
-				TypeName: "TypeError",
-				Message:  "'<' not supported between instances of '" + vm.typeName(a) + "' and '" + vm.typeName(b) + "'",
-			}
+	if result, ok := vm.tryRichCompare(a, b, "__gt__", "__lt__"); ok {
+		if vm.currentException != nil {
+			return 0
+		}
+		if vm.truthy(result) {
+			return 1
 		}
+		return -1
+	}
+	vm.currentException = &PyException{
+		TypeName: "TypeError",
+		Message:  "'<' not supported between instances of '" + vm.typeName(a) + "' and '" + vm.typeName(b) + "'",
 	}
 	return 0
 }
@@ -1029,6 +1123,50 @@ func (vm *VM) contains(container, item Value) bool {
 }
 
 // bytesContains checks if sub is a subsequence of data
+// byteSliceOf extracts the underlying bytes from a bytes or bytearray value,
+// allowing the two types to compare against each other.
+func byteSliceOf(v Value) ([]byte, bool) {
+	switch bv := v.(type) {
+	case *PyBytes:
+		return bv.Value, true
+	case *PyByteArray:
+		return bv.Value, true
+	}
+	return nil, false
+}
+
+func bytesEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func bytesCompare(a, b []byte) int {
+	minLen := len(a)
+	if len(b) < minLen {
+		minLen = len(b)
+	}
+	for i := 0; i < minLen; i++ {
+		if a[i] < b[i] {
+			return -1
+		} else if a[i] > b[i] {
+			return 1
+		}
+	}
+	if len(a) < len(b) {
+		return -1
+	} else if len(a) > len(b) {
+		return 1
+	}
+	return 0
+}
+
 func bytesContains(data, sub []byte) bool {
 	if len(sub) > len(data) {
 		return false
@@ -302,3 +302,68 @@ func (vm *VM) stringFormat(format string, args Value) (Value, error) {
 
 	return &PyString{Value: result.String()}, nil
 }
+
+// bytesFormat implements Python's "bytes % args" printf-style formatting.
+// It maps the %b conversion and bytes-like arguments onto the string
+// formatter, which already handles flags, width, and precision
+func (vm *VM) bytesFormat(format []byte, args Value) ([]byte, error) {
+	// Rewrite %b conversions to %s, leaving %% escapes alone
+	f := append([]byte(nil), format...)
+	for i := 0; i < len(f); i++ {
+		if f[i] != '%' {
+			continue
+		}
+		i++
+		if i < len(f) && f[i] == '%' {
+			continue
+		}
+		// Skip the mapping key, flags, width, and precision
+		if i < len(f) && f[i] == '(' {
+			for i < len(f) && f[i] != ')' {
+				i++
+			}
+		}
+		for i < len(f) && !isFormatConversion(f[i]) {
+			i++
+		}
+		if i < len(f) && f[i] == 'b' {
+			f[i] = 's'
+		}
+	}
+	// Present bytes-like arguments as raw strings so %s splices their contents
+	asRawString := func(v Value) Value {
+		switch bv := v.(type) {
+		case *PyBytes:
+			return &PyString{Value: string(bv.Value)}
+		case *PyByteArray:
+			return &PyString{Value: string(bv.Value)}
+		}
+		return v
+	}
+	switch v := args.(type) {
+	case *PyTuple:
+		items := make([]Value, len(v.Items))
+		for i, item := range v.Items {
+			items[i] = asRawString(item)
+		}
+		args = &PyTuple{Items: items}
+	case *PyDict:
+		// Mapping values pass through untouched
+	default:
+		args = asRawString(args)
+	}
+	result, err := vm.stringFormat(string(f), args)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(result.(*PyString).Value), nil
+}
+
+// isFormatConversion reports whether c terminates a printf-style format spec
+func isFormatConversion(c byte) bool {
+	switch c {
+	case 's', 'b', 'r', 'a', 'd', 'i', 'u', 'f', 'F', 'e', 'E', 'g', 'G', 'x', 'X', 'o', 'c':
+		return true
+	}
+	return false
+}
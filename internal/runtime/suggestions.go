@@ -0,0 +1,151 @@
+package runtime
+
+import "fmt"
+
+// Did-you-mean suggestions for NameError and AttributeError, modeled on
+// CPython 3.12's traceback hints.
+
+const (
+	suggestionMoveCost = 2 // cost of an insertion, deletion, or substitution
+	suggestionCaseCost = 1 // cost of a case-only substitution
+	suggestionMaxLen   = 40
+)
+
+// editDistance returns a weighted Levenshtein distance between a and b.
+// Substitutions that only change letter case cost less than other edits,
+// so Foo is a better suggestion for foo than far is.
+func editDistance(a, b string) int {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	// Single-row dynamic programming over the shorter string
+	row := make([]int, len(a)+1)
+	for i := range row {
+		row[i] = i * suggestionMoveCost
+	}
+	for j := 1; j <= len(b); j++ {
+		prev := row[0]
+		row[0] = j * suggestionMoveCost
+		for i := 1; i <= len(a); i++ {
+			var subst int
+			switch {
+			case a[i-1] == b[j-1]:
+				subst = prev
+			case lowerByte(a[i-1]) == lowerByte(b[j-1]):
+				subst = prev + suggestionCaseCost
+			default:
+				subst = prev + suggestionMoveCost
+			}
+			insert := row[i-1] + suggestionMoveCost
+			remove := row[i] + suggestionMoveCost
+			prev = row[i]
+			row[i] = minInt3(subst, insert, remove)
+		}
+	}
+	return row[len(a)]
+}
+
+func lowerByte(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + ('a' - 'A')
+	}
+	return c
+}
+
+func minInt3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// suggestFrom returns the candidate closest to name, or "" when nothing is
+// near enough to be a plausible typo. The distance cutoff scales with the
+// lengths involved, like CPython's _suggestions module.
+func suggestFrom(name string, candidates []string) string {
+	if name == "" || len(name) > suggestionMaxLen {
+		return ""
+	}
+	best := ""
+	bestDist := 0
+	for _, cand := range candidates {
+		if cand == name || cand == "" || len(cand) > suggestionMaxLen {
+			continue
+		}
+		maxDist := (len(name) + len(cand) + 3) * suggestionMoveCost / 6
+		d := editDistance(name, cand)
+		if d > maxDist {
+			continue
+		}
+		if best == "" || d < bestDist || (d == bestDist && cand < best) {
+			best = cand
+			bestDist = d
+		}
+	}
+	return best
+}
+
+// nameError builds the NameError for an undefined name, appending a
+// did-you-mean hint when a close match exists in scope.
+func (vm *VM) nameError(frame *Frame, name string) error {
+	var candidates []string
+	for k := range frame.Globals {
+		candidates = append(candidates, k)
+	}
+	for k := range frame.EnclosingGlobals {
+		candidates = append(candidates, k)
+	}
+	for k := range frame.Builtins {
+		candidates = append(candidates, k)
+	}
+	if s := suggestFrom(name, candidates); s != "" {
+		return fmt.Errorf("NameError: name '%s' is not defined. Did you mean '%s'?", name, s)
+	}
+	return fmt.Errorf("NameError: name '%s' is not defined", name)
+}
+
+// withAttrSuggestion decorates a plain AttributeError from getAttr with a
+// did-you-mean hint based on the object's attributes. Raised *PyException
+// values (e.g. from a custom __getattr__) pass through untouched.
+func (vm *VM) withAttrSuggestion(err error, obj Value, name string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*PyException); ok {
+		return err
+	}
+	suffix := fmt.Sprintf("has no attribute '%s'", name)
+	msg := err.Error()
+	if len(msg) < len(suffix) || msg[len(msg)-len(suffix):] != suffix {
+		return err
+	}
+	var candidates []string
+	collect := func(names map[string]Value) {
+		for k := range names {
+			candidates = append(candidates, k)
+		}
+	}
+	switch o := obj.(type) {
+	case *PyInstance:
+		collect(o.Dict)
+		collect(o.Slots)
+		for _, cls := range o.Class.Mro {
+			collect(cls.Dict)
+		}
+	case *PyClass:
+		for _, cls := range o.Mro {
+			collect(cls.Dict)
+		}
+	case *PyModule:
+		collect(o.Dict)
+	default:
+		return err
+	}
+	if s := suggestFrom(name, candidates); s != "" {
+		return fmt.Errorf("%s. Did you mean '%s'?", msg, s)
+	}
+	return err
+}
@@ -0,0 +1,41 @@
+package runtime
+
+import "testing"
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "abc", 0},
+		{"abc", "abd", 2},
+		{"abc", "ab", 2},
+		{"foo", "Foo", 1},
+		{"pritn", "print", 4},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestFrom(t *testing.T) {
+	candidates := []string{"print", "range", "timeout", "Validate"}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"pritn", "print"},
+		{"timout", "timeout"},
+		{"validate", "Validate"},
+		{"zzz", ""},
+		{"print", ""}, // exact matches are never suggested
+	}
+	for _, tt := range tests {
+		if got := suggestFrom(tt.name, candidates); got != tt.want {
+			t.Errorf("suggestFrom(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -185,16 +185,23 @@ func ptrValue(v Value) uintptr {
 // In Python, mutable types (list, dict, set) are not hashable
 func isHashable(v Value) bool {
 	switch v.(type) {
-	case *PyList, *PyDict, *PySet:
+	case *PyList, *PyDict, *PySet, *PyByteArray:
 		return false
 	default:
 		return true
 	}
 }
 
-// hashValue computes a hash for a Python value
+// hashValue computes a hash for a Python value without seed mixing.
 // Only hashable types should be passed to this function
 func hashValue(v Value) uint64 {
+	return hashValueSeeded(v, 0)
+}
+
+// hashValueSeeded computes a hash for a Python value, mixing seed into
+// str and bytes hashes (the types attackers control when feeding untrusted
+// keys into a dict). Numeric hashes stay seed-independent, as in CPython.
+func hashValueSeeded(v Value, seed uint64) uint64 {
 	switch val := v.(type) {
 	case *PyNone:
 		return 0x9e3779b97f4a7c15 // FNV offset basis
@@ -243,24 +250,24 @@ func hashValue(v Value) uint64 {
 		// hash(complex(x, 0)) must equal hash(x) for real numbers
 		if val.Imag == 0 {
 			if val.Real == math.Trunc(val.Real) && !math.IsInf(val.Real, 0) && !math.IsNaN(val.Real) {
-				return hashValue(MakeInt(int64(val.Real)))
+				return hashValueSeeded(MakeInt(int64(val.Real)), seed)
 			}
-			return hashValue(&PyFloat{Value: val.Real})
+			return hashValueSeeded(&PyFloat{Value: val.Real}, seed)
 		}
 		// Combine real and imag hashes
-		hr := hashValue(&PyFloat{Value: val.Real})
-		hi := hashValue(&PyFloat{Value: val.Imag})
+		hr := hashValueSeeded(&PyFloat{Value: val.Real}, seed)
+		hi := hashValueSeeded(&PyFloat{Value: val.Imag}, seed)
 		return hr ^ (hi * 1000003)
 	case *PyString:
-		// FNV-1a hash for strings
-		h := uint64(0xcbf29ce484222325)
+		// FNV-1a hash for strings, offset by the seed
+		h := uint64(0xcbf29ce484222325) ^ seed
 		for i := 0; i < len(val.Value); i++ {
 			h ^= uint64(val.Value[i])
 			h *= 0x100000001b3
 		}
 		return h
 	case *PyBytes:
-		h := uint64(0xcbf29ce484222325)
+		h := uint64(0xcbf29ce484222325) ^ seed
 		for i := 0; i < len(val.Value); i++ {
 			h ^= uint64(val.Value[i])
 			h *= 0x100000001b3
@@ -270,7 +277,7 @@ func hashValue(v Value) uint64 {
 		// Hash tuple by combining element hashes
 		h := uint64(0xcbf29ce484222325)
 		for _, item := range val.Items {
-			itemHash := hashValue(item)
+			itemHash := hashValueSeeded(item, seed)
 			h ^= itemHash
 			h *= 0x100000001b3
 		}
@@ -290,27 +297,59 @@ func hashValue(v Value) uint64 {
 		// Hash frozenset by XORing element hashes (order-independent)
 		h := uint64(0xcbf29ce484222325)
 		for item := range val.Items {
-			itemHash := hashValue(item)
+			itemHash := hashValueSeeded(item, seed)
 			h ^= itemHash
 		}
 		return h
+	case *UnionType:
+		// Hash union by XORing member hashes so hash(int | str) == hash(str | int),
+		// matching the order-insensitive equality
+		h := uint64(0xcbf29ce484222325)
+		for _, arg := range val.Args {
+			h ^= hashValueSeeded(arg, seed)
+		}
+		return h
 	default:
 		// Default to pointer hash for other types
 		return uint64(ptrValue(v))
 	}
 }
 
-// hashValueVM computes a hash with VM context, supporting __hash__ dunder method
+// hashValueVM computes a hash with VM context, supporting the __hash__
+// dunder method and the per-VM hash seed. Tuples and frozensets recurse
+// through this method so an instance's __hash__ is honored wherever it is
+// nested, keeping hash consistent with __eq__.
 func (vm *VM) hashValueVM(v Value) uint64 {
-	if inst, ok := v.(*PyInstance); ok {
+	switch val := v.(type) {
+	case *PyInstance:
 		// Check for __hash__ method
-		if result, found, err := vm.callDunder(inst, "__hash__"); found && err == nil {
+		if result, found, err := vm.callDunder(val, "__hash__"); found && err == nil {
 			if i, ok := result.(*PyInt); ok {
 				return uint64(i.Value)
 			}
 		}
+	case *PyUserData:
+		// Check for a __hash__ metamethod in the metatable
+		if result, found, err := vm.callMetamethod(val, "__hash__"); found && err == nil {
+			if i, ok := result.(*PyInt); ok {
+				return uint64(i.Value)
+			}
+		}
+	case *PyTuple:
+		h := uint64(0xcbf29ce484222325)
+		for _, item := range val.Items {
+			h ^= vm.hashValueVM(item)
+			h *= 0x100000001b3
+		}
+		return h
+	case *PyFrozenSet:
+		h := uint64(0xcbf29ce484222325)
+		for item := range val.Items {
+			h ^= vm.hashValueVM(item)
+		}
+		return h
 	}
-	return hashValue(v)
+	return hashValueSeeded(v, vm.hashSeed)
 }
 
 // PyFloat represents a Python float
@@ -370,3 +409,29 @@ type PyBytes struct {
 
 func (b *PyBytes) Type() string   { return "bytes" }
 func (b *PyBytes) String() string { return fmt.Sprintf("b'%s'", string(b.Value)) }
+
+// PyByteArray represents Python's mutable bytearray
+type PyByteArray struct {
+	Value []byte
+}
+
+func (b *PyByteArray) Type() string   { return "bytearray" }
+func (b *PyByteArray) String() string { return fmt.Sprintf("bytearray(b'%s')", string(b.Value)) }
+
+// PyFloatArray is a mutable float64 sequence backed directly by a Go slice,
+// so host numeric data can be indexed and updated from Python without
+// converting every element upfront
+type PyFloatArray struct {
+	Values []float64
+}
+
+func (f *PyFloatArray) Type() string   { return "floatarray" }
+func (f *PyFloatArray) String() string { return floatArrayRepr(f.Values) }
+
+// PyIntArray is the int64 counterpart of PyFloatArray
+type PyIntArray struct {
+	Values []int64
+}
+
+func (f *PyIntArray) Type() string   { return "intarray" }
+func (f *PyIntArray) String() string { return intArrayRepr(f.Values) }
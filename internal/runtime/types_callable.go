@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -11,10 +12,11 @@ type PyFunction struct {
 	Globals    map[string]Value
 	Defaults   *PyTuple
 	KwDefaults map[string]Value // Keyword-only argument defaults
-	Closure    []*PyCell
-	Name       string
-	IsAbstract bool             // Set by @abstractmethod decorator
-	Dict       map[string]Value // Custom attributes (e.g. func._name)
+	Closure     []*PyCell
+	Name        string
+	IsAbstract  bool             // Set by @abstractmethod decorator
+	Dict        map[string]Value // Custom attributes (e.g. func._name)
+	Annotations *PyDict          // Parameter/return annotations, nil when absent
 }
 
 func (f *PyFunction) Type() string   { return "function" }
@@ -44,6 +46,20 @@ type PyBuiltinFunc struct {
 func (b *PyBuiltinFunc) Type() string   { return "builtin_function_or_method" }
 func (b *PyBuiltinFunc) String() string { return fmt.Sprintf("<built-in function %s>", b.Name) }
 
+// PyStringMethod is a str method bound to its receiver. The implementation is
+// shared via the stringMethods table so attribute access doesn't allocate a
+// closure; the bound object itself is pooled across LOAD_METHOD calls.
+type PyStringMethod struct {
+	Name string
+	Self *PyString
+	Fn   stringMethodFunc
+}
+
+func (m *PyStringMethod) Type() string { return "builtin_function_or_method" }
+func (m *PyStringMethod) String() string {
+	return fmt.Sprintf("<built-in method %s of str object>", m.Name)
+}
+
 // PyClass represents a Python class
 type PyClass struct {
 	Name                 string
@@ -53,7 +69,8 @@ type PyClass struct {
 	IsABC                bool       // True if class uses ABC abstract method checking
 	RegisteredSubclasses []*PyClass // Virtual subclasses registered via ABC.register()
 	Metaclass            *PyClass   // Custom metaclass (if any)
-	Slots                []string   // nil means no __slots__ (dict allowed); non-nil restricts instance attrs
+	Slots                []string   // nil means no __slots__ anywhere in the hierarchy; non-nil lists the valid slot names
+	SlotsAllowDict       bool       // true when a slotted class still gives instances a __dict__ ('__dict__' in __slots__, or a non-slotted base)
 }
 
 func (c *PyClass) Type() string   { return "type" }
@@ -62,8 +79,13 @@ func (c *PyClass) String() string { return fmt.Sprintf("<class '%s'>", c.Name) }
 // PyInstance represents an instance of a class
 type PyInstance struct {
 	Class *PyClass
-	Dict  map[string]Value   // nil when class defines __slots__
-	Slots map[string]Value   // non-nil when class defines __slots__
+	Dict  map[string]Value // nil for fully slotted classes
+	Slots map[string]Value // non-nil when __slots__ appears in the hierarchy; may coexist with Dict
+
+	// GoState holds host-defined per-instance data attached through the
+	// public API. It is invisible to Python attribute access and released
+	// with the instance by the Go garbage collector.
+	GoState any
 }
 
 func (i *PyInstance) Type() string   { return i.Class.Name }
@@ -108,16 +130,16 @@ func (s *PySuper) String() string { return "<super object>" }
 
 // PyException represents a Python exception
 type PyException struct {
-	ExcType        *PyClass         // Exception class (e.g., ValueError, TypeError)
-	TypeName       string           // Exception type name (used when ExcType is nil)
-	Args           *PyTuple         // Exception arguments
-	Message        string           // String representation
-	Cause          *PyException     // __cause__ for chained exceptions (raise X from Y)
-	Context        *PyException     // __context__ for implicit chaining
-	SuppressContext bool            // __suppress_context__ - set True when __cause__ is assigned
-	Traceback      []TracebackEntry // Traceback frames
-	Instance       *PyInstance      // non-nil for ExceptionGroup instances
-	Notes          *PyList          // __notes__ list (nil until add_note is called)
+	ExcType         *PyClass         // Exception class (e.g., ValueError, TypeError)
+	TypeName        string           // Exception type name (used when ExcType is nil)
+	Args            *PyTuple         // Exception arguments
+	Message         string           // String representation
+	Cause           *PyException     // __cause__ for chained exceptions (raise X from Y)
+	Context         *PyException     // __context__ for implicit chaining
+	SuppressContext bool             // __suppress_context__ - set True when __cause__ is assigned
+	Traceback       []TracebackEntry // Traceback frames
+	Instance        *PyInstance      // non-nil for ExceptionGroup instances
+	Notes           *PyList          // __notes__ list (nil until add_note is called)
 }
 
 // ExceptStarState tracks the remaining unmatched exceptions during except* handling
@@ -139,6 +161,28 @@ func (e *PyException) String() string {
 }
 
 func (e *PyException) Error() string {
+	return e.formatErrorChain(make(map[*PyException]bool))
+}
+
+// formatErrorChain renders the exception together with its cause/context
+// chain, CPython-style: the oldest exception prints first. The seen map
+// guards against cycles in the chain.
+func (e *PyException) formatErrorChain(seen map[*PyException]bool) string {
+	if seen[e] {
+		return e.formatError()
+	}
+	seen[e] = true
+
+	if e.Cause != nil {
+		return e.Cause.formatErrorChain(seen) +
+			"\n\nThe above exception was the direct cause of the following exception:\n\n" +
+			e.formatError()
+	}
+	if e.Context != nil && !e.SuppressContext {
+		return e.Context.formatErrorChain(seen) +
+			"\n\nDuring handling of the above exception, another exception occurred:\n\n" +
+			e.formatError()
+	}
 	return e.formatError()
 }
 
@@ -230,21 +274,23 @@ type TracebackEntry struct {
 	Filename string
 	Line     int
 	Function string
+	Col      int // 1-based start column of the failing expression (0 = unknown)
+	EndCol   int // Column just past the failing expression (0 = unknown)
 }
 
 // Frame represents a call frame
 type Frame struct {
-	Code             *CodeObject
-	IP               int              // Instruction pointer
-	Stack            []Value          // Operand stack (pre-allocated)
-	SP               int              // Stack pointer (index of next free slot)
-	Locals           []Value          // Local variables
-	Globals          map[string]Value // Global variables
-	EnclosingGlobals map[string]Value // Enclosing globals (for class bodies)
-	Builtins         map[string]Value // Built-in functions
-	Cells            []*PyCell        // Closure cells
-	BlockStack       []Block          // Block stack for try/except/finally
-	OrderedGlobalKeys []string        // Insertion-ordered global names (for class bodies)
+	Code              *CodeObject
+	IP                int              // Instruction pointer
+	Stack             []Value          // Operand stack (pre-allocated)
+	SP                int              // Stack pointer (index of next free slot)
+	Locals            []Value          // Local variables
+	Globals           map[string]Value // Global variables
+	EnclosingGlobals  map[string]Value // Enclosing globals (for class bodies)
+	Builtins          map[string]Value // Built-in functions
+	Cells             []*PyCell        // Closure cells
+	BlockStack        []Block          // Block stack for try/except/finally
+	OrderedGlobalKeys []string         // Insertion-ordered global names (for class bodies)
 }
 
 // Block represents a control flow block
@@ -295,17 +341,27 @@ func (r *PyRange) Contains(val int64) bool {
 }
 
 func rangeLen(r *PyRange) int64 {
+	// Compute in uint64 so extreme bounds (e.g. MinInt64..MaxInt64) don't
+	// overflow; clamp the result to MaxInt64
+	var width, step uint64
 	if r.Step > 0 {
 		if r.Stop <= r.Start {
 			return 0
 		}
-		return (r.Stop - r.Start + r.Step - 1) / r.Step
+		width = uint64(r.Stop) - uint64(r.Start)
+		step = uint64(r.Step)
 	} else {
 		if r.Stop >= r.Start {
 			return 0
 		}
-		return (r.Start - r.Stop - r.Step - 1) / (-r.Step)
+		width = uint64(r.Start) - uint64(r.Stop)
+		step = uint64(-r.Step)
+	}
+	n := (width-1)/step + 1
+	if n > math.MaxInt64 {
+		return math.MaxInt64
 	}
+	return int64(n)
 }
 
 // PySlice represents a slice object for slicing sequences
@@ -359,13 +415,13 @@ type PyGenerator struct {
 	YieldValue Value          // Value to send into generator on resume
 
 	// Saved VM exception state (isolated per-generator)
-	SavedCurrentException    *PyException
-	SavedLastException       *PyException
-	SavedExcHandlerStack     []*PyException
-	SavedPendingReturn       Value
-	SavedHasPendingReturn    bool
-	SavedPendingJump         int
-	SavedHasPendingJump      bool
+	SavedCurrentException *PyException
+	SavedLastException    *PyException
+	SavedExcHandlerStack  []*PyException
+	SavedPendingReturn    Value
+	SavedHasPendingReturn bool
+	SavedPendingJump      int
+	SavedHasPendingJump   bool
 }
 
 func (g *PyGenerator) Type() string   { return "generator" }
@@ -392,6 +448,28 @@ type PyCoroutine struct {
 func (c *PyCoroutine) Type() string   { return "coroutine" }
 func (c *PyCoroutine) String() string { return fmt.Sprintf("<coroutine object %s>", c.Name) }
 
+// PyAsyncGenerator represents an async generator object (async def with
+// yield). It wraps the ordinary generator machinery: awaits in this runtime
+// complete synchronously, so __anext__ advances the underlying generator
+// eagerly and wraps each yielded value in an already-resolved awaitable.
+type PyAsyncGenerator struct {
+	Gen *PyGenerator
+}
+
+func (g *PyAsyncGenerator) Type() string   { return "async_generator" }
+func (g *PyAsyncGenerator) String() string { return fmt.Sprintf("<async_generator object %s>", g.Gen.Name) }
+
+// PyResolvedAwaitable is an already-completed awaitable wrapping a plain
+// value. GetAwaitable produces it for objects that are neither coroutines
+// nor define __await__ (e.g. a Go-built __aenter__ returning its result
+// directly), so awaiting them yields the value immediately.
+type PyResolvedAwaitable struct {
+	Value Value
+}
+
+func (r *PyResolvedAwaitable) Type() string   { return "awaitable" }
+func (r *PyResolvedAwaitable) String() string { return "<resolved awaitable>" }
+
 // GenericAlias represents a parameterized type like list[int] or dict[str, int]
 type GenericAlias struct {
 	Origin Value   // The original class/builtin (e.g., list, dict, or a PyClass)
@@ -518,7 +596,16 @@ func unionTypeEqual(a, b Value) bool {
 		}
 	case *GenericAlias:
 		if bv, ok := b.(*GenericAlias); ok {
-			return av == bv
+			// list[int] == list[int]: compare origin and arguments structurally
+			if !unionTypeEqual(av.Origin, bv.Origin) || len(av.Args) != len(bv.Args) {
+				return false
+			}
+			for i := range av.Args {
+				if !unionTypeEqual(av.Args[i], bv.Args[i]) {
+					return false
+				}
+			}
+			return true
 		}
 	}
 	return a == b
@@ -35,8 +35,8 @@ type PyDict struct {
 	Items         map[Value]Value        // Legacy field for compatibility
 	buckets       map[uint64][]dictEntry // Hash buckets for O(1) lookup
 	size          int
-	orderedKeys   []Value // Insertion-ordered keys for Python 3.7+ dict ordering
-	instanceOwner *PyInstance            // if non-nil, sync mutations back to instance's Dict
+	orderedKeys   []Value     // Insertion-ordered keys for Python 3.7+ dict ordering
+	instanceOwner *PyInstance // if non-nil, sync mutations back to instance's Dict
 }
 
 func (d *PyDict) Type() string { return "dict" }
@@ -3,6 +3,10 @@ package runtime
 import (
 	"context"
 	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sync/atomic"
 	"time"
 )
 
@@ -40,13 +44,86 @@ type VM struct {
 	maxMemoryBytes    int64
 	allocatedBytes    int64
 	maxCollectionSize int64
+	intMaxStrDigits   int
+
+	// Rethrow panics from registered Go functions instead of converting
+	// them to RuntimeError (see SetPanicRethrow)
+	panicRethrow bool
+
+	// Module-level globals that scripts may not rebind or delete
+	// (see FreezeGlobal)
+	frozenGlobals map[string]bool
+
+	// CPython version this VM reports to scripts, 0 when unset
+	// (see SetLanguageLevel)
+	langMajor, langMinor int
+
+	// Modules that shadow the shared module cache for this VM only
+	// (see OverrideModule)
+	moduleOverrides map[string]*PyModule
+
+	// Containers currently being rendered by repr/str, used to detect
+	// reference cycles (see enterRepr)
+	reprActive map[Value]bool
+
+	// Nesting depth of container ordering comparisons, used to raise
+	// RecursionError on mutually cyclic structures (see compare)
+	compareDepth int
+
+	// Per-VM seed mixed into str/bytes hashes so untrusted keys can't
+	// mount algorithmic-complexity attacks on dicts and sets. Randomized
+	// at construction; see SetHashSeed
+	hashSeed uint64
+
+	// Host-registered sentinel error mappings, checked in order by
+	// wrapGoError (see MapError)
+	errorMappings []errorMapping
+
+	// Crash diagnostics: when crashSink is set the dispatch loop records
+	// recent opcodes, and recovered internal panics emit a diagnostic
+	// bundle through the sink (see SetCrashSink)
+	crashSink func(dump []byte)
+	opRing    []Opcode
+	opRingPos int
+
+	// Hooks run when the host closes this VM, in LIFO order (see OnClose)
+	closeHooks []func()
 
 	// Filesystem module imports
-	SearchPaths  []string                              // Directories to search for .py modules
+	SearchPaths  []string                                   // Directories to search for .py modules
 	FileImporter func(filename string) (*CodeObject, error) // Callback to compile a .py file (avoids circular dep)
 
+	// Host policy consulted before every import (see SetImportPolicy)
+	importPolicy func(name string) error
+
+	// Destination for print and other script output; nil means os.Stdout
+	// (see SetStdout)
+	stdout io.Writer
+
+	// Call-site order of keyword arguments: pendingKwNames is recorded by the
+	// keyword call opcodes and consumed by callBuiltinFunc, which exposes it
+	// for the duration of the Go callback (see KwargNames)
+	pendingKwNames []string
+	currentKwNames []string
+
+	// Cooperative step budgeting: when stepLimited is set the dispatch loops
+	// decrement stepBudget once per instruction and invoke stepPause whenever
+	// it is spent (see EnableStepLimit)
+	stepLimited bool
+	stepBudget  int64
+	stepPause   func() error
+
+	// Usage accounting: the dispatch loops count every executed instruction
+	// and track the deepest operand stack seen (see TakeUsageCounters)
+	instrCount int64
+	peakValues int
+
 	// Pending memory error from stack growth (checked in run loop)
 	pendingMemError bool
+
+	// Pending host-requested interrupt (see Interrupt); accessed atomically
+	// because signal handlers call Interrupt from another goroutine
+	pendingInterrupt int32
 }
 
 // TimeoutError is returned when script execution exceeds the time limit
@@ -75,13 +152,22 @@ func (e *exceptionHandledInOuterFrame) Error() string {
 
 var errExceptionHandledInOuterFrame = &exceptionHandledInOuterFrame{}
 
+// rethrownPanic wraps a panic from a Go callback when the host asked for
+// panics to be rethrown, so recoverPanic lets it pass through instead of
+// converting it to a RuntimeError
+type rethrownPanic struct {
+	value any
+}
+
 // NewVM creates a new virtual machine
 func NewVM() *VM {
 	vm := &VM{
-		Globals:       make(map[string]Value),
-		builtins:      make(map[string]Value),
-		checkInterval: 1000, // Check context every 1000 instructions by default
-		checkCounter:  1000, // Initialize counter
+		Globals:         make(map[string]Value),
+		builtins:        make(map[string]Value),
+		checkInterval:   1000, // Check context every 1000 instructions by default
+		checkCounter:    1000, // Initialize counter
+		hashSeed:        rand.Uint64(),
+		intMaxStrDigits: maxIntStringDigits,
 	}
 	vm.initBuiltins()
 
@@ -93,6 +179,33 @@ func NewVM() *VM {
 	return vm
 }
 
+// Interrupt requests that a KeyboardInterrupt be raised in the running
+// script at the next dispatch checkpoint. Unlike context cancellation the
+// resulting exception is catchable, so scripts can clean up or ignore it.
+// Safe to call from another goroutine (e.g. a signal handler).
+func (vm *VM) Interrupt() {
+	atomic.StoreInt32(&vm.pendingInterrupt, 1)
+}
+
+// takePendingInterrupt reports and clears a pending Interrupt request.
+// The fast path is a plain atomic load so the dispatch loops can afford
+// to call it every instruction.
+func (vm *VM) takePendingInterrupt() bool {
+	if atomic.LoadInt32(&vm.pendingInterrupt) == 0 {
+		return false
+	}
+	return atomic.CompareAndSwapInt32(&vm.pendingInterrupt, 1, 0)
+}
+
+// keyboardInterrupt builds the exception raised for a pending Interrupt.
+func (vm *VM) keyboardInterrupt() *PyException {
+	return &PyException{
+		ExcType:  vm.builtinClass("KeyboardInterrupt"),
+		Message:  "KeyboardInterrupt",
+		TypeName: "KeyboardInterrupt",
+	}
+}
+
 // SetCheckInterval sets how often the VM checks for timeout/cancellation.
 // Lower values are more responsive but have more overhead.
 // Default is 1000 instructions.
@@ -104,6 +217,47 @@ func (vm *VM) SetCheckInterval(n int64) {
 	vm.checkCounter = n
 }
 
+// EnableStepLimit turns on cooperative step budgeting with an empty budget.
+// The dispatch loops decrement the budget once per instruction; when it is
+// spent, pause is invoked on the executing goroutine and execution continues
+// only once the host has granted more instructions with AddSteps. If pause
+// returns an error the script is aborted with that error.
+func (vm *VM) EnableStepLimit(pause func() error) {
+	vm.stepLimited = true
+	vm.stepBudget = 0
+	vm.stepPause = pause
+}
+
+// DisableStepLimit turns off cooperative step budgeting.
+func (vm *VM) DisableStepLimit() {
+	vm.stepLimited = false
+	vm.stepPause = nil
+}
+
+// AddSteps grants n more instructions to a step-limited VM. It must only be
+// called before execution starts or while the VM is paused inside its pause
+// callback.
+func (vm *VM) AddSteps(n int64) {
+	vm.stepBudget += n
+}
+
+// TakeUsageCounters returns the number of instructions executed and the peak
+// operand-stack depth accumulated since the previous call, then resets both.
+// The embedding layer turns these into per-run usage reports.
+func (vm *VM) TakeUsageCounters() (instructions int64, peakValues int) {
+	instructions, peakValues = vm.instrCount, vm.peakValues
+	vm.instrCount, vm.peakValues = 0, 0
+	return instructions, peakValues
+}
+
+// SetHashSeed overrides the randomized str/bytes hash seed, making hash()
+// results reproducible across runs. Pass 0 to disable randomization entirely
+// (the CPython PYTHONHASHSEED=0 behavior). Must be called before any dicts
+// or sets are populated.
+func (vm *VM) SetHashSeed(seed uint64) {
+	vm.hashSeed = seed
+}
+
 // SetMaxRecursionDepth sets the maximum call stack depth. 0 means unlimited.
 func (vm *VM) SetMaxRecursionDepth(n int64) {
 	if n < 0 {
@@ -117,6 +271,16 @@ func (vm *VM) MaxRecursionDepth() int64 {
 	return vm.maxRecursionDepth
 }
 
+// SetIntMaxStrDigits sets the maximum number of digits accepted when
+// converting a string to an integer, like CPython's
+// sys.set_int_max_str_digits. 0 disables the limit.
+func (vm *VM) SetIntMaxStrDigits(n int) {
+	if n < 0 {
+		n = 0
+	}
+	vm.intMaxStrDigits = n
+}
+
 // SetMaxMemoryBytes sets the approximate memory limit in bytes. 0 means unlimited.
 func (vm *VM) SetMaxMemoryBytes(n int64) {
 	if n < 0 {
@@ -130,6 +294,33 @@ func (vm *VM) MaxMemoryBytes() int64 {
 	return vm.maxMemoryBytes
 }
 
+// SetStdout redirects script output (print, displayed expression values,
+// input prompts) to w. A nil w restores the default, os.Stdout.
+func (vm *VM) SetStdout(w io.Writer) {
+	vm.stdout = w
+}
+
+// Stdout returns the destination for script output.
+func (vm *VM) Stdout() io.Writer {
+	if vm.stdout != nil {
+		return vm.stdout
+	}
+	return os.Stdout
+}
+
+// stdoutWriter is the internal alias for Stdout used by the builtins.
+func (vm *VM) stdoutWriter() io.Writer {
+	return vm.Stdout()
+}
+
+// SetPanicRethrow controls what happens when a registered Go function panics.
+// By default the panic is recovered and converted into a Python RuntimeError
+// carrying the Go stack trace; with rethrow enabled the original panic value
+// is re-raised so the host process can handle (or crash on) it.
+func (vm *VM) SetPanicRethrow(on bool) {
+	vm.panicRethrow = on
+}
+
 // AllocatedBytes returns the approximate number of bytes currently tracked.
 func (vm *VM) AllocatedBytes() int64 {
 	return vm.allocatedBytes
@@ -171,6 +362,50 @@ func (vm *VM) checkCollectionSize(current int64, collType string) error {
 	return nil
 }
 
+// interruptError returns the error to surface when the VM's context has been
+// cancelled or timed out, or nil if execution may continue.
+func (vm *VM) interruptError() error {
+	select {
+	case <-vm.ctx.Done():
+		if vm.ctx.Err() == context.DeadlineExceeded {
+			// Extract timeout duration from context if possible
+			if deadline, ok := vm.ctx.Deadline(); ok {
+				return &TimeoutError{Timeout: time.Until(deadline) * -1}
+			}
+			return &TimeoutError{}
+		}
+		return &CancelledError{}
+	default:
+		return nil
+	}
+}
+
+// CheckInterrupt is a cancellation checkpoint for Go-native loops (sorting,
+// regex scans, large conversions) that run many iterations without passing
+// through the dispatch loop. It shares the dispatch loop's countdown counter,
+// so it is cheap enough to call once per iteration, and returns TimeoutError
+// or CancelledError once the VM's context has expired.
+func (vm *VM) CheckInterrupt() error {
+	if vm.ctx == nil {
+		return nil
+	}
+	vm.checkCounter--
+	if vm.checkCounter > 0 {
+		return nil
+	}
+	vm.checkCounter = vm.checkInterval
+	return vm.interruptError()
+}
+
+// CheckInterruptPanic is CheckInterrupt for stack-style Go functions that
+// cannot return an error. On interrupt it panics; the executor converts the
+// panic back into the original TimeoutError or CancelledError.
+func (vm *VM) CheckInterruptPanic() {
+	if err := vm.CheckInterrupt(); err != nil {
+		panic(&interruptPanic{err: err})
+	}
+}
+
 // Execute runs bytecode and returns the result
 func (vm *VM) Execute(code *CodeObject) (Value, error) {
 	return vm.ExecuteWithContext(context.Background(), code)
@@ -184,14 +419,41 @@ func (vm *VM) ExecuteWithTimeout(timeout time.Duration, code *CodeObject) (Value
 	return vm.ExecuteWithContext(ctx, code)
 }
 
+// interruptPanic carries a TimeoutError/CancelledError out of stack-style Go
+// functions that cannot return an error (see CheckInterruptPanic).
+type interruptPanic struct {
+	err error
+}
+
+// isInterrupt reports whether err is a fatal timeout/cancellation that must
+// propagate to the host instead of becoming a catchable Python exception.
+func isInterrupt(err error) bool {
+	switch err.(type) {
+	case *TimeoutError, *CancelledError:
+		return true
+	}
+	return false
+}
+
 // recoverPanic converts VM panics (e.g. stack underflow) into errors so that
 // a bug in the VM or malformed bytecode doesn't crash the host Go process.
-func recoverPanic(result *Value, err *error) {
+// Genuine internal panics additionally emit a crash dump when the host has
+// installed a sink (see SetCrashSink).
+func (vm *VM) recoverPanic(result *Value, err *error) {
 	if r := recover(); r != nil {
+		if rp, ok := r.(*rethrownPanic); ok {
+			// The host asked for Go callback panics to be rethrown
+			panic(rp.value)
+		}
 		*result = nil
+		if ip, ok := r.(*interruptPanic); ok {
+			*err = ip.err
+			return
+		}
 		if pe, ok := r.(*PyPanicError); ok {
 			*err = fmt.Errorf("%s: %s", pe.ExcType, pe.Message)
 		} else {
+			vm.emitCrashDump(r)
 			*err = fmt.Errorf("RuntimeError: internal VM error: %v", r)
 		}
 	}
@@ -201,7 +463,7 @@ func recoverPanic(result *Value, err *error) {
 // The context is checked periodically during execution (see SetCheckInterval).
 // Returns CancelledError if the context is cancelled, or TimeoutError if it times out.
 func (vm *VM) ExecuteWithContext(ctx context.Context, code *CodeObject) (result Value, err error) {
-	defer recoverPanic(&result, &err)
+	defer vm.recoverPanic(&result, &err)
 
 	if err := code.Validate(); err != nil {
 		return nil, err
@@ -217,6 +479,11 @@ func (vm *VM) ExecuteWithContext(ctx context.Context, code *CodeObject) (result
 		Builtins: vm.builtins,
 	}
 
+	// A module-level docstring becomes the __doc__ global
+	if code.Doc != "" && code.Name == "<module>" {
+		vm.Globals["__doc__"] = &PyString{Value: code.Doc}
+	}
+
 	// Track top-level frame memory
 	if vm.maxMemoryBytes > 0 {
 		frameBytes := int64(len(frame.Stack))*16 + int64(len(frame.Locals))*16
@@ -237,7 +504,7 @@ func (vm *VM) ExecuteWithContext(ctx context.Context, code *CodeObject) (result
 // This is used to populate a module's namespace when registering Python modules.
 func (vm *VM) ExecuteInModule(code *CodeObject, mod *PyModule) (err error) {
 	var result Value
-	defer recoverPanic(&result, &err)
+	defer vm.recoverPanic(&result, &err)
 
 	frame := &Frame{
 		Code:     code,
@@ -249,6 +516,11 @@ func (vm *VM) ExecuteInModule(code *CodeObject, mod *PyModule) (err error) {
 		Builtins: vm.builtins,
 	}
 
+	if code.Doc != "" {
+		mod.Doc = code.Doc
+		mod.Dict["__doc__"] = &PyString{Value: code.Doc}
+	}
+
 	// Track module frame memory
 	if vm.maxMemoryBytes > 0 {
 		frameBytes := int64(len(frame.Stack))*16 + int64(len(frame.Locals))*16
@@ -277,6 +549,10 @@ func (vm *VM) tryHandleError(err error, frame *Frame) (bool, error) {
 		}
 		return false, errExceptionHandledInOuterFrame
 	}
+	// Interrupts (timeout/cancellation) are fatal, not catchable exceptions
+	if isInterrupt(err) {
+		return false, err
+	}
 	var pyExc *PyException
 	if pe, ok := err.(*PyException); ok {
 		pyExc = pe
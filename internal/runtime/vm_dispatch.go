@@ -1,9 +1,7 @@
 package runtime
 
 import (
-	"context"
 	"fmt"
-	"time"
 	"unicode/utf8"
 )
 
@@ -27,30 +25,45 @@ func (vm *VM) run() (Value, error) {
 			continue
 		}
 
+		// A host-requested interrupt (e.g. Ctrl+C) raises KeyboardInterrupt
+		if vm.takePendingInterrupt() {
+			if _, err := vm.handleException(vm.keyboardInterrupt()); err != nil {
+				return nil, err
+			}
+			frame = vm.frame
+			continue
+		}
+
 		// Check for timeout/cancellation periodically (counter decrement is faster than modulo)
-		if vm.ctx != nil {
-			vm.checkCounter--
-			if vm.checkCounter <= 0 {
-				vm.checkCounter = vm.checkInterval
-				select {
-				case <-vm.ctx.Done():
-					if vm.ctx.Err() == context.DeadlineExceeded {
-						// Extract timeout duration from context if possible
-						if deadline, ok := vm.ctx.Deadline(); ok {
-							return nil, &TimeoutError{Timeout: time.Until(deadline) * -1}
-						}
-						return nil, &TimeoutError{}
-					}
-					return nil, &CancelledError{}
-				default:
-					// Context not done, continue execution
+		if err := vm.CheckInterrupt(); err != nil {
+			return nil, err
+		}
+
+		// Cooperative step budgeting: park when the instruction budget is spent
+		if vm.stepLimited {
+			for vm.stepBudget <= 0 {
+				if err := vm.stepPause(); err != nil {
+					return nil, err
 				}
 			}
+			vm.stepBudget--
 		}
 
 		op := Opcode(frame.Code.Code[frame.IP])
 		frame.IP++
 
+		// Usage accounting (see TakeUsageCounters)
+		vm.instrCount++
+		if frame.SP > vm.peakValues {
+			vm.peakValues = frame.SP
+		}
+
+		// Record recent opcodes for crash dumps (see SetCrashSink)
+		if vm.crashSink != nil {
+			vm.opRing[vm.opRingPos&(opRingSize-1)] = op
+			vm.opRingPos++
+		}
+
 		var arg int
 		if op.HasArg() {
 			arg = int(frame.Code.Code[frame.IP]) | int(frame.Code.Code[frame.IP+1])<<8
@@ -104,16 +117,34 @@ func (vm *VM) run() (Value, error) {
 				} else if val, ok := frame.Builtins[name]; ok {
 					vm.push(val)
 				} else {
-					return nil, fmt.Errorf("name '%s' is not defined", name)
+					err := vm.nameError(frame, name)
+					if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+						return nil, handleErr
+					} else if handled {
+						continue
+					}
 				}
 			} else if val, ok := frame.Builtins[name]; ok {
 				vm.push(val)
 			} else {
-				return nil, fmt.Errorf("name '%s' is not defined", name)
+				err := vm.nameError(frame, name)
+				if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+					return nil, handleErr
+				} else if handled {
+					continue
+				}
 			}
 
 		case OpStoreName:
 			name := frame.Code.Names[arg]
+			if vm.frozenGlobals[name] && frame.EnclosingGlobals == nil {
+				err := vm.frozenGlobalError("assign to", name)
+				if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+					return nil, handleErr
+				} else if handled {
+					continue
+				}
+			}
 			if _, exists := frame.Globals[name]; !exists && frame.OrderedGlobalKeys != nil {
 				frame.OrderedGlobalKeys = append(frame.OrderedGlobalKeys, name)
 			}
@@ -121,6 +152,14 @@ func (vm *VM) run() (Value, error) {
 
 		case OpDeleteName:
 			name := frame.Code.Names[arg]
+			if vm.frozenGlobals[name] && frame.EnclosingGlobals == nil {
+				err := vm.frozenGlobalError("delete", name)
+				if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+					return nil, handleErr
+				} else if handled {
+					continue
+				}
+			}
 			vm.callDel(frame.Globals[name])
 			delete(frame.Globals, name)
 
@@ -918,6 +957,44 @@ func (vm *VM) run() (Value, error) {
 				length = int64(len(v.Items))
 			case *PyBytes:
 				length = int64(len(v.Value))
+			case *PyByteArray:
+				length = int64(len(v.Value))
+			case *PyFloatArray:
+				length = int64(len(v.Values))
+			case *PyIntArray:
+				length = int64(len(v.Values))
+			case *PyRange:
+				length = rangeLen(v)
+			case *PyUserData:
+				if result, found, err := vm.callMetamethod(v, "__len__"); found {
+					if err != nil {
+						if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+							return nil, handleErr
+						} else if handled {
+							continue
+						}
+						return nil, err
+					}
+					if i, ok := result.(*PyInt); ok {
+						length = i.Value
+					} else {
+						lenErr := fmt.Errorf("__len__() should return an integer")
+						if handled, handleErr := vm.tryHandleError(lenErr, frame); handleErr != nil {
+							return nil, handleErr
+						} else if handled {
+							continue
+						}
+						return nil, lenErr
+					}
+				} else {
+					lenErr := fmt.Errorf("object of type '%s' has no len()", vm.typeName(obj))
+					if handled, handleErr := vm.tryHandleError(lenErr, frame); handleErr != nil {
+						return nil, handleErr
+					} else if handled {
+						continue
+					}
+					return nil, lenErr
+				}
 			case *PyInstance:
 				// Check for __len__ method
 				if result, found, err := vm.callDunder(v, "__len__"); found {
@@ -989,7 +1066,12 @@ func (vm *VM) run() (Value, error) {
 			} else if val, ok := frame.Builtins[name]; ok {
 				frame.Stack[frame.SP] = val
 			} else {
-				return nil, fmt.Errorf("name '%s' is not defined", name)
+				err := vm.nameError(frame, name)
+				if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+					return nil, handleErr
+				} else if handled {
+					continue
+				}
 			}
 			frame.SP++
 			localVal := frame.Locals[localIdx]
@@ -1009,16 +1091,34 @@ func (vm *VM) run() (Value, error) {
 				} else if val, ok := frame.Builtins[name]; ok {
 					vm.push(val)
 				} else {
-					return nil, fmt.Errorf("name '%s' is not defined", name)
+					err := vm.nameError(frame, name)
+					if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+						return nil, handleErr
+					} else if handled {
+						continue
+					}
 				}
 			} else if val, ok := frame.Builtins[name]; ok {
 				vm.push(val)
 			} else {
-				return nil, fmt.Errorf("name '%s' is not defined", name)
+				err := vm.nameError(frame, name)
+				if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+					return nil, handleErr
+				} else if handled {
+					continue
+				}
 			}
 
 		case OpStoreGlobal:
 			name := frame.Code.Names[arg]
+			if vm.frozenGlobals[name] {
+				err := vm.frozenGlobalError("assign to", name)
+				if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+					return nil, handleErr
+				} else if handled {
+					continue
+				}
+			}
 			val := vm.pop()
 			if frame.EnclosingGlobals != nil {
 				// In class body, explicit 'global' declaration writes to module globals
@@ -1029,6 +1129,14 @@ func (vm *VM) run() (Value, error) {
 
 		case OpDeleteGlobal:
 			name := frame.Code.Names[arg]
+			if vm.frozenGlobals[name] {
+				err := vm.frozenGlobalError("delete", name)
+				if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+					return nil, handleErr
+				} else if handled {
+					continue
+				}
+			}
 			if frame.EnclosingGlobals != nil {
 				vm.callDel(frame.EnclosingGlobals[name])
 				delete(frame.EnclosingGlobals, name)
@@ -1042,6 +1150,7 @@ func (vm *VM) run() (Value, error) {
 			obj := vm.pop()
 			val, err := vm.getAttr(obj, name)
 			if err != nil {
+				err = vm.withAttrSuggestion(err, obj, name)
 				if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
 					return nil, handleErr
 				} else if handled {
@@ -1319,24 +1428,38 @@ func (vm *VM) run() (Value, error) {
 				}
 			}
 
+			// Handle dict |= (PEP 584): updates in place and, unlike |, also
+			// accepts iterables of key/value pairs
+			if d, ok := a.(*PyDict); ok && op == OpInplaceOr {
+				if err := vm.dictUpdateFrom(d, b); err != nil {
+					if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+						return nil, handleErr
+					} else if handled {
+						continue
+					}
+				}
+				vm.push(d)
+				continue
+			}
+
 			// Try inplace dunder method on PyInstance first
 			var result Value
 			var err error
 			if inst, ok := a.(*PyInstance); ok {
 				var inplaceDunders = [...]string{
-					0 /* OpInplaceAdd */ : "__iadd__",
-					OpInplaceSubtract - OpInplaceAdd:  "__isub__",
-					OpInplaceMultiply - OpInplaceAdd:  "__imul__",
-					OpInplaceDivide - OpInplaceAdd:    "__itruediv__",
-					OpInplaceFloorDiv - OpInplaceAdd:  "__ifloordiv__",
-					OpInplaceModulo - OpInplaceAdd:    "__imod__",
-					OpInplacePower - OpInplaceAdd:     "__ipow__",
-					OpInplaceMatMul - OpInplaceAdd:    "__imatmul__",
-					OpInplaceLShift - OpInplaceAdd:    "__ilshift__",
-					OpInplaceRShift - OpInplaceAdd:    "__irshift__",
-					OpInplaceAnd - OpInplaceAdd:       "__iand__",
-					OpInplaceOr - OpInplaceAdd:        "__ior__",
-					OpInplaceXor - OpInplaceAdd:       "__ixor__",
+					0 /* OpInplaceAdd */ :            "__iadd__",
+					OpInplaceSubtract - OpInplaceAdd: "__isub__",
+					OpInplaceMultiply - OpInplaceAdd: "__imul__",
+					OpInplaceDivide - OpInplaceAdd:   "__itruediv__",
+					OpInplaceFloorDiv - OpInplaceAdd: "__ifloordiv__",
+					OpInplaceModulo - OpInplaceAdd:   "__imod__",
+					OpInplacePower - OpInplaceAdd:    "__ipow__",
+					OpInplaceMatMul - OpInplaceAdd:   "__imatmul__",
+					OpInplaceLShift - OpInplaceAdd:   "__ilshift__",
+					OpInplaceRShift - OpInplaceAdd:   "__irshift__",
+					OpInplaceAnd - OpInplaceAdd:      "__iand__",
+					OpInplaceOr - OpInplaceAdd:       "__ior__",
+					OpInplaceXor - OpInplaceAdd:      "__ixor__",
 				}
 				dunder := inplaceDunders[op-OpInplaceAdd]
 
@@ -1752,6 +1875,14 @@ func (vm *VM) run() (Value, error) {
 					closure[i] = cell
 				}
 			}
+			var annotations *PyDict
+			if arg&4 != 0 {
+				// Has annotations dict
+				annotations, ok = vm.pop().(*PyDict)
+				if !ok {
+					return nil, fmt.Errorf("internal error: OpMakeFunction expected dict for annotations")
+				}
+			}
 			if arg&2 != 0 {
 				// Has kwonly defaults dict
 				kwDefaultsDict, ok := vm.pop().(*PyDict)
@@ -1849,12 +1980,13 @@ func (vm *VM) run() (Value, error) {
 				fnGlobals = frame.EnclosingGlobals
 			}
 			fn := &PyFunction{
-				Code:       code,
-				Globals:    fnGlobals,
-				Defaults:   defaults,
-				KwDefaults: kwDefaults,
-				Closure:    closure,
-				Name:       name,
+				Code:        code,
+				Globals:     fnGlobals,
+				Defaults:    defaults,
+				KwDefaults:  kwDefaults,
+				Closure:     closure,
+				Name:        name,
+				Annotations: annotations,
 			}
 			vm.push(fn)
 
@@ -1890,6 +2022,10 @@ func (vm *VM) run() (Value, error) {
 					// Handler is in current frame, continue
 					continue
 				}
+				// Interrupts (timeout/cancellation) are fatal, not catchable
+				if isInterrupt(err) {
+					return nil, err
+				}
 				// Convert Go error to Python exception so try/except can catch it
 				pyExc := vm.wrapGoError(err)
 				_, handleErr := vm.handleException(pyExc)
@@ -1910,8 +2046,10 @@ func (vm *VM) run() (Value, error) {
 			}
 			totalArgs := arg
 			kwargs := make(map[string]Value)
+			kwOrder := make([]string, len(kwNames.Items))
 			for i := len(kwNames.Items) - 1; i >= 0; i-- {
 				name := kwNames.Items[i].(*PyString).Value
+				kwOrder[i] = name
 				kwargs[name] = vm.pop()
 				totalArgs--
 			}
@@ -1920,6 +2058,7 @@ func (vm *VM) run() (Value, error) {
 				args[i] = vm.pop()
 			}
 			callable := vm.pop()
+			vm.pendingKwNames = kwOrder
 			result, err := vm.call(callable, args, kwargs)
 			if err != nil {
 				// Check if exception was already handled in an outer frame
@@ -1940,6 +2079,10 @@ func (vm *VM) run() (Value, error) {
 					}
 					continue
 				}
+				// Interrupts (timeout/cancellation) are fatal, not catchable
+				if isInterrupt(err) {
+					return nil, err
+				}
 				// Convert Go error to Python exception so try/except can catch it
 				pyExc := vm.wrapGoError(err)
 				_, handleErr := vm.handleException(pyExc)
@@ -1957,6 +2100,7 @@ func (vm *VM) run() (Value, error) {
 			// Call with *args/**kwargs unpacking
 			// Stack: callable, args_tuple [, kwargs_dict if arg&1]
 			var kwargs map[string]Value
+			var kwOrder []string
 			if arg&1 != 0 {
 				kwargsVal := vm.pop()
 				if kwargsDict, ok := kwargsVal.(*PyDict); ok {
@@ -1964,6 +2108,7 @@ func (vm *VM) run() (Value, error) {
 					for _, key := range kwargsDict.Keys(vm) {
 						if ks, ok := key.(*PyString); ok {
 							val, _ := kwargsDict.DictGet(key, vm)
+							kwOrder = append(kwOrder, ks.Value)
 							kwargs[ks.Value] = val
 						}
 					}
@@ -2000,6 +2145,7 @@ func (vm *VM) run() (Value, error) {
 					args = append(args, val)
 				}
 			}
+			vm.pendingKwNames = kwOrder
 			result, err := vm.call(callable, args, kwargs)
 			if err != nil {
 				if err == errExceptionHandledInOuterFrame {
@@ -2018,6 +2164,9 @@ func (vm *VM) run() (Value, error) {
 					}
 					continue
 				}
+				if isInterrupt(err) {
+					return nil, err
+				}
 				pyExc := vm.wrapGoError(err)
 				_, handleErr := vm.handleException(pyExc)
 				if handleErr != nil {
@@ -2072,6 +2221,11 @@ func (vm *VM) run() (Value, error) {
 				// Generators are iterable but not awaitable by default
 				// Only coroutine generators (from async def) are awaitable
 				vm.push(v)
+			case *PyResolvedAwaitable:
+				// Already resolved (e.g. by async_generator.__anext__)
+				vm.push(v)
+			case *PyAsyncGenerator:
+				return nil, fmt.Errorf("TypeError: object async_generator can't be used in 'await' expression")
 			default:
 				// Try to call __await__ method
 				awaitMethod, err := vm.getAttr(obj, "__await__")
@@ -2107,7 +2261,11 @@ func (vm *VM) run() (Value, error) {
 			}
 			anext, err := vm.call(anextMethod, nil, nil)
 			if err != nil {
-				return nil, err
+				if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+					return nil, handleErr
+				} else if handled {
+					continue
+				}
 			}
 			vm.push(anext)
 
@@ -2396,12 +2554,24 @@ func (vm *VM) run() (Value, error) {
 		case OpLoadMethod:
 			name := frame.Code.Names[arg]
 			obj := vm.pop()
-			method, err := vm.getAttr(obj, name)
-			if err != nil {
-				if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
-					return nil, handleErr
-				} else if handled {
-					continue
+			// Fast path: str methods come from the shared table via the pool,
+			// skipping the closure allocation in getAttrString.
+			var method Value
+			if s, ok := obj.(*PyString); ok {
+				if fn, ok := stringMethods[name]; ok {
+					method = boundStringMethod(name, s, fn)
+				}
+			}
+			if method == nil {
+				var err error
+				method, err = vm.getAttr(obj, name)
+				if err != nil {
+					err = vm.withAttrSuggestion(err, obj, name)
+					if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
+						return nil, handleErr
+					} else if handled {
+						continue
+					}
 				}
 			}
 			// Push object and method for CALL_METHOD
@@ -2421,6 +2591,8 @@ func (vm *VM) run() (Value, error) {
 			alreadyBound := false
 			if _, isBound := method.(*PyMethod); isBound {
 				alreadyBound = true
+			} else if _, isBound := method.(*PyStringMethod); isBound {
+				alreadyBound = true
 			} else if bf, ok := method.(*PyBuiltinFunc); ok && bf.Bound {
 				alreadyBound = true
 			}
@@ -2432,6 +2604,11 @@ func (vm *VM) run() (Value, error) {
 				allArgs := append([]Value{obj}, args...)
 				result, err = vm.call(method, allArgs, nil)
 			}
+			if sm, ok := method.(*PyStringMethod); ok {
+				// The opcode pair guarantees no other reference to the bound
+				// object exists, so it can go back to the pool either way.
+				releaseStringMethod(sm)
+			}
 			if err != nil {
 				if handled, handleErr := vm.tryHandleError(err, frame); handleErr != nil {
 					return nil, handleErr
@@ -2631,6 +2808,49 @@ func (vm *VM) run() (Value, error) {
 				vm.currentException = nil
 			}
 
+		case OpWithCleanupAsync:
+			// Exception path of "async with": stack has [..., cm, exception]
+			// Call __aexit__(exc_type, exc_val, exc_tb) and resolve the awaitable
+			exc := vm.pop() // the exception
+			cm := vm.pop()  // the context manager
+
+			exitMethod, err := vm.getAttr(cm, "__aexit__")
+			if err != nil {
+				return nil, fmt.Errorf("AttributeError: __aexit__: %w", err)
+			}
+
+			// Build args: exc_type, exc_val, exc_tb
+			var excType, excVal, excTb Value
+			if pyExc, ok := exc.(*PyException); ok {
+				if pyExc.ExcType != nil {
+					excType = pyExc.ExcType
+				} else {
+					excType = &PyString{Value: pyExc.Type()}
+				}
+				excVal = pyExc
+				excTb = None // traceback not implemented as object
+			} else {
+				excType = None
+				excVal = None
+				excTb = None
+			}
+
+			result, err := vm.call(exitMethod, []Value{excType, excVal, excTb}, nil)
+			if err != nil {
+				return nil, err
+			}
+			if coro, ok := result.(*PyCoroutine); ok {
+				result, err = vm.completeCoroutine(coro)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			// If __aexit__ returns truthy, suppress the exception
+			if vm.truthy(result) {
+				vm.currentException = nil
+			}
+
 		case OpEndFinally:
 			// End finally block - re-raise exception if one was active
 			// Pop the handler stack entry that was pushed when entering finally
@@ -295,7 +295,7 @@ func TestEnsureStackNoOpWhenSufficient(t *testing.T) {
 
 func TestPendingMemErrorOnStackGrowth(t *testing.T) {
 	vm := newVMWithFrame(2)
-	vm.maxMemoryBytes = 1    // Extremely low limit
+	vm.maxMemoryBytes = 1 // Extremely low limit
 	vm.allocatedBytes = 0
 
 	// Push enough to trigger stack growth
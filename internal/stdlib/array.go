@@ -0,0 +1,335 @@
+package stdlib
+
+import (
+	"fmt"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// InitArrayModule registers the array module: typed float64/int64 arrays with
+// elementwise arithmetic and reductions implemented as Go loops, a pragmatic
+// substitute for numpy in balance/tuning scripts. Supported typecodes are
+// "d" (float64) and "q" (int64), matching CPython's array module.
+func InitArrayModule() {
+	runtime.RegisterModule("array", func(vm *runtime.VM) *runtime.PyModule {
+		mod := runtime.NewModule("array")
+
+		mod.Dict["array"] = &runtime.PyBuiltinFunc{
+			Name: "array",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				return arrayNew(vm, args, kwargs)
+			},
+		}
+
+		mod.Dict["zeros"] = &runtime.PyBuiltinFunc{
+			Name: "zeros",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				return arrayZeros(vm, args, kwargs)
+			},
+		}
+
+		for _, op := range []string{"add", "sub", "mul", "div"} {
+			op := op
+			mod.Dict[op] = &runtime.PyBuiltinFunc{
+				Name: op,
+				Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+					return arrayElementwise(vm, op, args, kwargs)
+				},
+			}
+		}
+
+		for _, red := range []string{"sum", "min", "max", "mean"} {
+			red := red
+			mod.Dict[red] = &runtime.PyBuiltinFunc{
+				Name: red,
+				Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+					return arrayReduce(vm, red, args, kwargs)
+				},
+			}
+		}
+
+		mod.Dict["tolist"] = &runtime.PyBuiltinFunc{
+			Name: "tolist",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 1 {
+					return nil, fmt.Errorf("TypeError: tolist() takes exactly one argument (%d given)", len(args))
+				}
+				items, err := vm.ToList(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return &runtime.PyList{Items: items}, nil
+			},
+		}
+
+		return mod
+	})
+}
+
+// arrayNew implements array(typecode, initializer=None)
+func arrayNew(vm *runtime.VM, args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("TypeError: array() takes 1 or 2 arguments (%d given)", len(args))
+	}
+	tc, ok := args[0].(*runtime.PyString)
+	if !ok {
+		return nil, fmt.Errorf("TypeError: array() argument 1 must be a typecode string")
+	}
+
+	var items []runtime.Value
+	if len(args) == 2 {
+		var err error
+		items, err = vm.ToList(args[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch tc.Value {
+	case "d":
+		values := make([]float64, len(items))
+		for i, item := range items {
+			f, err := arrayToFloat(vm, item)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = f
+		}
+		return runtime.NewFloatArray(values), nil
+	case "q":
+		values := make([]int64, len(items))
+		for i, item := range items {
+			n, err := arrayToInt(vm, item)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = n
+		}
+		return runtime.NewIntArray(values), nil
+	}
+	return nil, fmt.Errorf("ValueError: bad typecode (must be 'd' or 'q')")
+}
+
+// arrayZeros implements zeros(typecode, n)
+func arrayZeros(vm *runtime.VM, args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("TypeError: zeros() takes exactly 2 arguments (%d given)", len(args))
+	}
+	tc, ok := args[0].(*runtime.PyString)
+	if !ok {
+		return nil, fmt.Errorf("TypeError: zeros() argument 1 must be a typecode string")
+	}
+	n, ok := args[1].(*runtime.PyInt)
+	if !ok {
+		return nil, fmt.Errorf("TypeError: zeros() argument 2 must be an integer")
+	}
+	if n.Value < 0 {
+		return nil, fmt.Errorf("ValueError: negative array size")
+	}
+	switch tc.Value {
+	case "d":
+		return runtime.NewFloatArray(make([]float64, n.Value)), nil
+	case "q":
+		return runtime.NewIntArray(make([]int64, n.Value)), nil
+	}
+	return nil, fmt.Errorf("ValueError: bad typecode (must be 'd' or 'q')")
+}
+
+// arrayElementwise implements add/sub/mul/div(a, b) where a is a typed array
+// and b is a typed array of the same length or a scalar. The result is a new
+// array; div always yields a floatarray.
+func arrayElementwise(vm *runtime.VM, op string, args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("TypeError: %s() takes exactly 2 arguments (%d given)", op, len(args))
+	}
+
+	// Integer arrays stay integer except under division
+	if a, ok := args[0].(*runtime.PyIntArray); ok && op != "div" {
+		if scalar, ok := args[1].(*runtime.PyInt); ok {
+			result := make([]int64, len(a.Values))
+			for i, x := range a.Values {
+				result[i] = intOp(op, x, scalar.Value)
+			}
+			return runtime.NewIntArray(result), nil
+		}
+		if b, ok := args[1].(*runtime.PyIntArray); ok {
+			if len(a.Values) != len(b.Values) {
+				return nil, fmt.Errorf("ValueError: arrays have different lengths (%d and %d)", len(a.Values), len(b.Values))
+			}
+			result := make([]int64, len(a.Values))
+			for i, x := range a.Values {
+				result[i] = intOp(op, x, b.Values[i])
+			}
+			return runtime.NewIntArray(result), nil
+		}
+	}
+
+	a, err := arrayAsFloats(vm, args[0])
+	if err != nil {
+		return nil, fmt.Errorf("TypeError: %s() argument 1 must be a typed array", op)
+	}
+	result := make([]float64, len(a))
+
+	if b, err := arrayAsFloats(vm, args[1]); err == nil {
+		if len(a) != len(b) {
+			return nil, fmt.Errorf("ValueError: arrays have different lengths (%d and %d)", len(a), len(b))
+		}
+		for i, x := range a {
+			y, err := floatOp(op, x, b[i])
+			if err != nil {
+				return nil, err
+			}
+			result[i] = y
+		}
+		return runtime.NewFloatArray(result), nil
+	}
+
+	scalar, err := arrayToFloat(vm, args[1])
+	if err != nil {
+		return nil, fmt.Errorf("TypeError: %s() argument 2 must be a typed array or a number", op)
+	}
+	for i, x := range a {
+		y, err := floatOp(op, x, scalar)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = y
+	}
+	return runtime.NewFloatArray(result), nil
+}
+
+// arrayReduce implements sum/min/max/mean(a)
+func arrayReduce(vm *runtime.VM, red string, args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("TypeError: %s() takes exactly one argument (%d given)", red, len(args))
+	}
+
+	// Integer arrays reduce to ints, except mean
+	if a, ok := args[0].(*runtime.PyIntArray); ok && red != "mean" {
+		if len(a.Values) == 0 {
+			if red == "sum" {
+				return runtime.MakeInt(0), nil
+			}
+			return nil, fmt.Errorf("ValueError: %s() arg is an empty sequence", red)
+		}
+		acc := a.Values[0]
+		for _, x := range a.Values[1:] {
+			switch red {
+			case "sum":
+				acc += x
+			case "min":
+				if x < acc {
+					acc = x
+				}
+			case "max":
+				if x > acc {
+					acc = x
+				}
+			}
+		}
+		return runtime.MakeInt(acc), nil
+	}
+
+	values, err := arrayAsFloats(vm, args[0])
+	if err != nil {
+		return nil, fmt.Errorf("TypeError: %s() argument must be a typed array", red)
+	}
+	if len(values) == 0 {
+		if red == "sum" {
+			return &runtime.PyFloat{Value: 0}, nil
+		}
+		return nil, fmt.Errorf("ValueError: %s() arg is an empty sequence", red)
+	}
+
+	acc := values[0]
+	for _, x := range values[1:] {
+		switch red {
+		case "sum", "mean":
+			acc += x
+		case "min":
+			if x < acc {
+				acc = x
+			}
+		case "max":
+			if x > acc {
+				acc = x
+			}
+		}
+	}
+	if red == "mean" {
+		acc /= float64(len(values))
+	}
+	return &runtime.PyFloat{Value: acc}, nil
+}
+
+// arrayAsFloats views a typed array as float64s, converting intarrays
+func arrayAsFloats(vm *runtime.VM, v runtime.Value) ([]float64, error) {
+	switch a := v.(type) {
+	case *runtime.PyFloatArray:
+		return a.Values, nil
+	case *runtime.PyIntArray:
+		values := make([]float64, len(a.Values))
+		for i, n := range a.Values {
+			values[i] = float64(n)
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("TypeError: expected a typed array, got %s", vm.TypeNameOf(v))
+}
+
+func arrayToFloat(vm *runtime.VM, v runtime.Value) (float64, error) {
+	switch n := v.(type) {
+	case *runtime.PyFloat:
+		return n.Value, nil
+	case *runtime.PyInt:
+		return float64(n.Value), nil
+	case *runtime.PyBool:
+		if n.Value {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("TypeError: must be real number, not %s", vm.TypeNameOf(v))
+}
+
+func arrayToInt(vm *runtime.VM, v runtime.Value) (int64, error) {
+	switch n := v.(type) {
+	case *runtime.PyInt:
+		return n.Value, nil
+	case *runtime.PyBool:
+		if n.Value {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("TypeError: integer argument expected, got %s", vm.TypeNameOf(v))
+}
+
+func intOp(op string, x, y int64) int64 {
+	switch op {
+	case "add":
+		return x + y
+	case "sub":
+		return x - y
+	case "mul":
+		return x * y
+	}
+	return 0
+}
+
+func floatOp(op string, x, y float64) (float64, error) {
+	switch op {
+	case "add":
+		return x + y, nil
+	case "sub":
+		return x - y, nil
+	case "mul":
+		return x * y, nil
+	case "div":
+		if y == 0 {
+			return 0, fmt.Errorf("ZeroDivisionError: float division by zero")
+		}
+		return x / y, nil
+	}
+	return 0, fmt.Errorf("ValueError: unknown operation %q", op)
+}
@@ -0,0 +1,73 @@
+package stdlib
+
+import (
+	"fmt"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// atexitEntry is one registered cleanup callback with its bound arguments.
+type atexitEntry struct {
+	fn     runtime.Value
+	args   []runtime.Value
+	kwargs map[string]runtime.Value
+}
+
+// atexitState holds the callbacks registered in one VM.
+type atexitState struct {
+	entries []*atexitEntry
+}
+
+// InitAtexitModule registers the atexit module. Callbacks are kept per State
+// and run in last-in-first-out order when the host calls State.Close()
+// (including after a script terminates via sys.exit). A callback that raises
+// does not stop the remaining callbacks from running.
+func InitAtexitModule() {
+	runtime.RegisterPerVMModule("atexit", func(vm *runtime.VM) *runtime.PyModule {
+		mod := runtime.NewModule("atexit")
+		state := &atexitState{}
+
+		vm.OnClose(func() {
+			for i := len(state.entries) - 1; i >= 0; i-- {
+				e := state.entries[i]
+				// Errors are swallowed: cleanup must keep going
+				_, _ = vm.Call(e.fn, e.args, e.kwargs)
+			}
+			state.entries = nil
+		})
+
+		mod.Dict["register"] = &runtime.PyBuiltinFunc{
+			Name: "register",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) < 1 {
+					return nil, fmt.Errorf("TypeError: register() takes at least 1 argument (0 given)")
+				}
+				state.entries = append(state.entries, &atexitEntry{
+					fn:     args[0],
+					args:   args[1:],
+					kwargs: kwargs,
+				})
+				return args[0], nil
+			},
+		}
+
+		mod.Dict["unregister"] = &runtime.PyBuiltinFunc{
+			Name: "unregister",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 1 {
+					return nil, fmt.Errorf("TypeError: unregister() takes exactly 1 argument (%d given)", len(args))
+				}
+				kept := state.entries[:0]
+				for _, e := range state.entries {
+					if e.fn != args[0] {
+						kept = append(kept, e)
+					}
+				}
+				state.entries = kept
+				return runtime.None, nil
+			},
+		}
+
+		return mod
+	})
+}
@@ -0,0 +1,114 @@
+package stdlib
+
+import (
+	"time"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// InitCalendarModule registers the calendar module with the date arithmetic
+// scheduling scripts need: isleap, leapdays, weekday and monthrange, plus the
+// day_name/month_name tables. Weekday numbering follows CPython (Monday=0).
+func InitCalendarModule() {
+	dayNames := []runtime.Value{
+		runtime.NewString("Monday"), runtime.NewString("Tuesday"),
+		runtime.NewString("Wednesday"), runtime.NewString("Thursday"),
+		runtime.NewString("Friday"), runtime.NewString("Saturday"),
+		runtime.NewString("Sunday"),
+	}
+	// Index 0 is empty so month_name[1] == "January", as in CPython
+	monthNames := []runtime.Value{runtime.NewString("")}
+	for m := time.January; m <= time.December; m++ {
+		monthNames = append(monthNames, runtime.NewString(m.String()))
+	}
+
+	runtime.NewModuleBuilder("calendar").
+		Doc("Calendar date calculations.").
+		Const("MONDAY", runtime.NewInt(0)).
+		Const("SUNDAY", runtime.NewInt(6)).
+		Const("day_name", runtime.NewList(dayNames)).
+		Const("month_name", runtime.NewList(monthNames)).
+		Func("isleap", calendarIsleap).
+		Func("leapdays", calendarLeapdays).
+		Func("weekday", calendarWeekday).
+		Func("monthrange", calendarMonthrange).
+		Register()
+}
+
+func isLeapYear(year int64) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// leapsBefore counts leap years in [1, year).
+func leapsBefore(year int64) int64 {
+	y := year - 1
+	return y/4 - y/100 + y/400
+}
+
+// calendarIsleap reports whether year is a leap year.
+// isleap(year) -> bool
+func calendarIsleap(vm *runtime.VM) int {
+	if !vm.RequireArgs("isleap", 1) {
+		return 0
+	}
+	vm.Push(runtime.NewBool(isLeapYear(vm.CheckInt(1))))
+	return 1
+}
+
+// calendarLeapdays counts leap years in the range [y1, y2).
+// leapdays(y1, y2) -> int
+func calendarLeapdays(vm *runtime.VM) int {
+	if !vm.RequireArgs("leapdays", 2) {
+		return 0
+	}
+	y1 := vm.CheckInt(1)
+	y2 := vm.CheckInt(2)
+	vm.Push(runtime.NewInt(leapsBefore(y2) - leapsBefore(y1)))
+	return 1
+}
+
+// pyWeekday converts Go's Sunday=0 weekday to Python's Monday=0.
+func pyWeekday(t time.Time) int64 {
+	return int64((int(t.Weekday()) + 6) % 7)
+}
+
+// calendarWeekday returns the day of week (Monday=0) for a date.
+// weekday(year, month, day) -> int
+func calendarWeekday(vm *runtime.VM) int {
+	if !vm.RequireArgs("weekday", 3) {
+		return 0
+	}
+	year := vm.CheckInt(1)
+	month := vm.CheckInt(2)
+	day := vm.CheckInt(3)
+	if month < 1 || month > 12 {
+		vm.RaiseError("ValueError: month must be in 1..12")
+		return 0
+	}
+	t := time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, time.UTC)
+	vm.Push(runtime.NewInt(pyWeekday(t)))
+	return 1
+}
+
+// calendarMonthrange returns the weekday of the first day of the month and
+// the number of days in the month.
+// monthrange(year, month) -> (weekday, days)
+func calendarMonthrange(vm *runtime.VM) int {
+	if !vm.RequireArgs("monthrange", 2) {
+		return 0
+	}
+	year := vm.CheckInt(1)
+	month := vm.CheckInt(2)
+	if month < 1 || month > 12 {
+		vm.RaiseError("ValueError: month must be in 1..12")
+		return 0
+	}
+	first := time.Date(int(year), time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	// Day 0 of the next month is the last day of this one
+	days := first.AddDate(0, 1, -1).Day()
+	vm.Push(runtime.NewTuple([]runtime.Value{
+		runtime.NewInt(pyWeekday(first)),
+		runtime.NewInt(int64(days)),
+	}))
+	return 1
+}
@@ -0,0 +1,131 @@
+package stdlib
+
+import (
+	"fmt"
+	"math"
+	"os"
+	goruntime "runtime"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// InitCompatModule registers the compat module: a grab bag of CPython and
+// six-style helpers that copy-pasted library code frequently reaches for —
+// string_types, iteritems and friends, platform basics, os.linesep — behind
+// a single import, so such code runs with fewer edits.
+func InitCompatModule() {
+	runtime.RegisterModule("compat", func(vm *runtime.VM) *runtime.PyModule {
+		mod := runtime.NewModule("compat")
+		mod.Doc = "Compatibility helpers for code written against CPython or six."
+		mod.Dict["__doc__"] = runtime.NewString(mod.Doc)
+
+		// six-style flags and type aliases. The builtin constructors double
+		// as type objects for isinstance, matching how scripts use them.
+		strType := vm.GetBuiltin("str")
+		intType := vm.GetBuiltin("int")
+		mod.Dict["PY2"] = runtime.NewBool(false)
+		mod.Dict["PY3"] = runtime.NewBool(true)
+		mod.Dict["text_type"] = strType
+		mod.Dict["binary_type"] = vm.GetBuiltin("bytes")
+		mod.Dict["string_types"] = runtime.NewTuple([]runtime.Value{strType})
+		mod.Dict["integer_types"] = runtime.NewTuple([]runtime.Value{intType})
+		mod.Dict["class_types"] = runtime.NewTuple([]runtime.Value{vm.GetBuiltin("type")})
+
+		// six's dict iteration helpers: Python 3 semantics, so each simply
+		// defers to the mapping's own method
+		for pyName, method := range map[string]string{
+			"iteritems":  "items",
+			"iterkeys":   "keys",
+			"itervalues": "values",
+		} {
+			name, method := pyName, method
+			mod.Dict[name] = &runtime.PyBuiltinFunc{
+				Name: name,
+				Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+					if len(args) != 1 {
+						return nil, fmt.Errorf("TypeError: %s() takes exactly 1 argument, got %d", name, len(args))
+					}
+					bound, err := vm.GetAttr(args[0], method)
+					if err != nil {
+						return nil, err
+					}
+					return vm.Call(bound, nil, nil)
+				},
+			}
+		}
+
+		// Platform module basics
+		mod.Dict["system"] = &runtime.PyBuiltinFunc{
+			Name: "system",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				return runtime.NewString(compatSystem()), nil
+			},
+		}
+		mod.Dict["machine"] = &runtime.PyBuiltinFunc{
+			Name: "machine",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				return runtime.NewString(compatMachine()), nil
+			},
+		}
+		mod.Dict["node"] = &runtime.PyBuiltinFunc{
+			Name: "node",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				host, err := os.Hostname()
+				if err != nil {
+					host = ""
+				}
+				return runtime.NewString(host), nil
+			},
+		}
+		mod.Dict["python_version"] = &runtime.PyBuiltinFunc{
+			Name: "python_version",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				return runtime.NewString(fmt.Sprintf("%d.%d.%d", versionMajor, versionMinor, versionMicro)), nil
+			},
+		}
+
+		// Constants usually found on sys and os
+		mod.Dict["maxsize"] = runtime.NewInt(math.MaxInt64)
+		mod.Dict["linesep"] = runtime.NewString(compatLinesep())
+		mod.Dict["devnull"] = runtime.NewString(os.DevNull)
+
+		return mod
+	})
+}
+
+// compatSystem returns the platform.system() name for the host OS.
+func compatSystem() string {
+	switch goruntime.GOOS {
+	case "darwin":
+		return "Darwin"
+	case "windows":
+		return "Windows"
+	case "linux":
+		return "Linux"
+	default:
+		return goruntime.GOOS
+	}
+}
+
+// compatMachine returns the platform.machine() name for the host
+// architecture, using the uname spellings CPython reports.
+func compatMachine() string {
+	switch goruntime.GOARCH {
+	case "amd64":
+		return "x86_64"
+	case "arm64":
+		return "aarch64"
+	case "386":
+		return "i386"
+	default:
+		return goruntime.GOARCH
+	}
+}
+
+// compatLinesep returns the os.linesep string for the host OS.
+func compatLinesep() string {
+	if goruntime.GOOS == "windows" {
+		return "\r\n"
+	}
+	return "\n"
+}
@@ -222,7 +222,21 @@ func datetimeNew(vm *runtime.VM) int {
 
 // datetime.now()
 func datetimeNow(vm *runtime.VM) int {
-	dt := datetimeFromTime(time.Now())
+	now := time.Now()
+	// Optional tz argument converts to that zone's wall-clock time
+	if vm.GetTop() >= 1 && !runtime.IsNone(vm.Get(1)) {
+		ud := vm.ToUserData(1)
+		z, ok := (*PyZoneInfo)(nil), false
+		if ud != nil {
+			z, ok = ud.Value.(*PyZoneInfo)
+		}
+		if !ok {
+			vm.RaiseError("now() tz must be a zoneinfo.ZoneInfo")
+			return 0
+		}
+		now = now.In(z.Loc)
+	}
+	dt := datetimeFromTime(now)
 	vm.Push(wrapDatetime(dt))
 	return 1
 }
@@ -0,0 +1,61 @@
+package stdlib
+
+import (
+	"fmt"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// InitDisModule registers the dis module for bytecode inspection.
+func InitDisModule() {
+	runtime.RegisterModule("dis", func(vm *runtime.VM) *runtime.PyModule {
+		mod := runtime.NewModule("dis")
+
+		mod.Dict["dis"] = &runtime.PyBuiltinFunc{
+			Name: "dis",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 1 {
+					return nil, fmt.Errorf("TypeError: dis() takes exactly 1 argument (%d given)", len(args))
+				}
+				code, err := codeObjectOf(vm, args[0])
+				if err != nil {
+					return nil, err
+				}
+				fmt.Fprint(vm.Stdout(), code.Disassemble())
+				return runtime.None, nil
+			},
+		}
+
+		mod.Dict["disassembly"] = &runtime.PyBuiltinFunc{
+			Name: "disassembly",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 1 {
+					return nil, fmt.Errorf("TypeError: disassembly() takes exactly 1 argument (%d given)", len(args))
+				}
+				code, err := codeObjectOf(vm, args[0])
+				if err != nil {
+					return nil, err
+				}
+				return runtime.NewString(code.Disassemble()), nil
+			},
+		}
+
+		return mod
+	})
+}
+
+// codeObjectOf extracts the code object from a disassemblable value.
+func codeObjectOf(vm *runtime.VM, v runtime.Value) (*runtime.CodeObject, error) {
+	switch obj := v.(type) {
+	case *runtime.PyFunction:
+		return obj.Code, nil
+	case *runtime.PyMethod:
+		return obj.Func.Code, nil
+	case *runtime.PyGenerator:
+		return obj.Code, nil
+	case *runtime.PyCoroutine:
+		return obj.Code, nil
+	default:
+		return nil, fmt.Errorf("TypeError: don't know how to disassemble %s objects", vm.TypeNameOf(v))
+	}
+}
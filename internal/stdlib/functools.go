@@ -262,6 +262,14 @@ func getFunctionAttr(fn runtime.Value, attr string) runtime.Value {
 		case "__name__":
 			return runtime.NewString(f.Name)
 		case "__doc__":
+			if f.Dict != nil {
+				if val, ok := f.Dict["__doc__"]; ok {
+					return val
+				}
+			}
+			if f.Code != nil && f.Code.Doc != "" {
+				return runtime.NewString(f.Code.Doc)
+			}
 			return runtime.None
 		}
 	case *runtime.PyBuiltinFunc:
@@ -282,7 +290,11 @@ func setFunctionAttr(fn runtime.Value, attr string, val runtime.Value) {
 			if s, ok := val.(*runtime.PyString); ok {
 				f.Name = s.Value
 			}
-		// __doc__ is not currently writable as CodeObject doesn't store it
+		case "__doc__":
+			if f.Dict == nil {
+				f.Dict = make(map[string]runtime.Value)
+			}
+			f.Dict["__doc__"] = val
 		}
 	}
 }
@@ -0,0 +1,232 @@
+package stdlib
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// InitGameMathModule registers the gamemath module: Vec2/Vec3/Mat3 implemented
+// natively in Go with operator overloads (+, -, *, /, @, unary -, ==) plus
+// dot/cross/lerp/normalize helpers. Anywhere a vector is expected, a 2/3-item
+// sequence of numbers or any object with numeric x/y(/z) attributes is also
+// accepted, so user-registered classes interoperate without explicit
+// conversion.
+func InitGameMathModule() {
+	runtime.RegisterModule("gamemath", func(vm *runtime.VM) *runtime.PyModule {
+		mod := runtime.NewModule("gamemath")
+
+		mod.Dict["Vec2"] = &runtime.PyBuiltinFunc{
+			Name: "Vec2",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				switch len(args) {
+				case 0:
+					return &runtime.PyVec2{}, nil
+				case 1:
+					return vm.ToVec2(args[0])
+				case 2:
+					x, okX := gameMathNumber(args[0])
+					y, okY := gameMathNumber(args[1])
+					if !okX || !okY {
+						return nil, fmt.Errorf("TypeError: Vec2() components must be numbers")
+					}
+					return &runtime.PyVec2{X: x, Y: y}, nil
+				}
+				return nil, fmt.Errorf("TypeError: Vec2() takes 0 to 2 arguments (%d given)", len(args))
+			},
+		}
+
+		mod.Dict["Vec3"] = &runtime.PyBuiltinFunc{
+			Name: "Vec3",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				switch len(args) {
+				case 0:
+					return &runtime.PyVec3{}, nil
+				case 1:
+					return vm.ToVec3(args[0])
+				case 3:
+					x, okX := gameMathNumber(args[0])
+					y, okY := gameMathNumber(args[1])
+					z, okZ := gameMathNumber(args[2])
+					if !okX || !okY || !okZ {
+						return nil, fmt.Errorf("TypeError: Vec3() components must be numbers")
+					}
+					return &runtime.PyVec3{X: x, Y: y, Z: z}, nil
+				}
+				return nil, fmt.Errorf("TypeError: Vec3() takes 0, 1, or 3 arguments (%d given)", len(args))
+			},
+		}
+
+		mod.Dict["Mat3"] = &runtime.PyBuiltinFunc{
+			Name: "Mat3",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				switch len(args) {
+				case 0:
+					// Identity
+					return &runtime.PyMat3{M: [9]float64{1, 0, 0, 0, 1, 0, 0, 0, 1}}, nil
+				case 9:
+					var m [9]float64
+					for i, arg := range args {
+						f, ok := gameMathNumber(arg)
+						if !ok {
+							return nil, fmt.Errorf("TypeError: Mat3() components must be numbers")
+						}
+						m[i] = f
+					}
+					return &runtime.PyMat3{M: m}, nil
+				}
+				return nil, fmt.Errorf("TypeError: Mat3() takes 0 or 9 arguments (%d given)", len(args))
+			},
+		}
+
+		mod.Dict["rotation"] = &runtime.PyBuiltinFunc{
+			Name: "rotation",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 1 {
+					return nil, fmt.Errorf("TypeError: rotation() takes exactly one argument (%d given)", len(args))
+				}
+				theta, ok := gameMathNumber(args[0])
+				if !ok {
+					return nil, fmt.Errorf("TypeError: rotation() angle must be a number")
+				}
+				s, c := math.Sin(theta), math.Cos(theta)
+				return &runtime.PyMat3{M: [9]float64{c, -s, 0, s, c, 0, 0, 0, 1}}, nil
+			},
+		}
+
+		mod.Dict["translation"] = &runtime.PyBuiltinFunc{
+			Name: "translation",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 2 {
+					return nil, fmt.Errorf("TypeError: translation() takes exactly 2 arguments (%d given)", len(args))
+				}
+				x, okX := gameMathNumber(args[0])
+				y, okY := gameMathNumber(args[1])
+				if !okX || !okY {
+					return nil, fmt.Errorf("TypeError: translation() offsets must be numbers")
+				}
+				return &runtime.PyMat3{M: [9]float64{1, 0, x, 0, 1, y, 0, 0, 1}}, nil
+			},
+		}
+
+		// Free-function forms of the vector methods, for use with map() and
+		// as a natural home for mixed arguments: gamemath.dot(player.pos, v)
+		mod.Dict["dot"] = gameMathVecFunc(vm, "dot")
+		mod.Dict["cross"] = gameMathVecFunc(vm, "cross")
+		mod.Dict["distance"] = gameMathVecFunc(vm, "distance")
+
+		mod.Dict["lerp"] = &runtime.PyBuiltinFunc{
+			Name: "lerp",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 3 {
+					return nil, fmt.Errorf("TypeError: lerp() takes exactly 3 arguments (%d given)", len(args))
+				}
+				// Scalar lerp when both endpoints are numbers
+				if a, ok := gameMathNumber(args[0]); ok {
+					b, okB := gameMathNumber(args[1])
+					t, okT := gameMathNumber(args[2])
+					if !okB || !okT {
+						return nil, fmt.Errorf("TypeError: lerp() arguments must be numbers or vectors")
+					}
+					return &runtime.PyFloat{Value: a + (b-a)*t}, nil
+				}
+				method, err := vm.GetAttr(args[0], "lerp")
+				if err != nil {
+					// Not a vector either: convert and retry as Vec3, then Vec2
+					return gameMathConvertedLerp(vm, args)
+				}
+				return vm.Call(method, []runtime.Value{args[1], args[2]}, nil)
+			},
+		}
+
+		mod.Dict["normalize"] = &runtime.PyBuiltinFunc{
+			Name: "normalize",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 1 {
+					return nil, fmt.Errorf("TypeError: normalize() takes exactly one argument (%d given)", len(args))
+				}
+				v, err := gameMathAnyVec(vm, args[0])
+				if err != nil {
+					return nil, err
+				}
+				method, err := vm.GetAttr(v, "normalize")
+				if err != nil {
+					return nil, err
+				}
+				return vm.Call(method, nil, nil)
+			},
+		}
+
+		return mod
+	})
+}
+
+// gameMathVecFunc builds the free-function form of a two-vector method by
+// delegating to the method on the (converted) first argument
+func gameMathVecFunc(vm *runtime.VM, name string) *runtime.PyBuiltinFunc {
+	return &runtime.PyBuiltinFunc{
+		Name: name,
+		Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("TypeError: %s() takes exactly 2 arguments (%d given)", name, len(args))
+			}
+			v, err := gameMathAnyVec(vm, args[0])
+			if err != nil {
+				return nil, err
+			}
+			method, err := vm.GetAttr(v, name)
+			if err != nil {
+				return nil, err
+			}
+			return vm.Call(method, []runtime.Value{args[1]}, nil)
+		},
+	}
+}
+
+// gameMathConvertedLerp handles lerp(a, b, t) where a is a convertible
+// non-vector value (sequence or x/y(/z) object)
+func gameMathConvertedLerp(vm *runtime.VM, args []runtime.Value) (runtime.Value, error) {
+	v, err := gameMathAnyVec(vm, args[0])
+	if err != nil {
+		return nil, err
+	}
+	method, err := vm.GetAttr(v, "lerp")
+	if err != nil {
+		return nil, err
+	}
+	return vm.Call(method, []runtime.Value{args[1], args[2]}, nil)
+}
+
+// gameMathAnyVec converts a value to a Vec2 or Vec3, preferring its natural
+// arity: native vectors pass through, everything else goes through the
+// ToVec3/ToVec2 conversion hooks
+func gameMathAnyVec(vm *runtime.VM, v runtime.Value) (runtime.Value, error) {
+	switch v.(type) {
+	case *runtime.PyVec2, *runtime.PyVec3:
+		return v, nil
+	}
+	if vec, err := vm.ToVec3(v); err == nil {
+		return vec, nil
+	}
+	if vec, err := vm.ToVec2(v); err == nil {
+		return vec, nil
+	}
+	return nil, fmt.Errorf("TypeError: cannot convert %s to a vector", vm.TypeNameOf(v))
+}
+
+// gameMathNumber extracts a float from an int/float/bool value
+func gameMathNumber(v runtime.Value) (float64, bool) {
+	switch n := v.(type) {
+	case *runtime.PyFloat:
+		return n.Value, true
+	case *runtime.PyInt:
+		return float64(n.Value), true
+	case *runtime.PyBool:
+		if n.Value {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
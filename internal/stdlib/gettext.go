@@ -0,0 +1,169 @@
+package stdlib
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// gettextState holds the message catalog installed in one VM. Plural entries
+// are keyed by their singular msgid.
+type gettextState struct {
+	singular map[string]string
+	plural   map[string][2]string
+}
+
+// InitGettextModule registers the gettext module. Catalogs are plain dicts
+// mapping a message id to its translation (or to a [singular, plural] pair
+// for ngettext), installed from Python with install(), loaded from a JSON
+// file with load(), or supplied by the host as Go maps. Lookups fall back to
+// the untranslated message, so scripts behave sensibly with no catalog.
+func InitGettextModule() {
+	runtime.RegisterPerVMModule("gettext", func(vm *runtime.VM) *runtime.PyModule {
+		mod := runtime.NewModule("gettext")
+		state := &gettextState{
+			singular: map[string]string{},
+			plural:   map[string][2]string{},
+		}
+
+		mod.Dict["install"] = &runtime.PyBuiltinFunc{
+			Name: "install",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 1 {
+					return nil, fmt.Errorf("TypeError: install() takes exactly 1 argument, got %d", len(args))
+				}
+				d, ok := args[0].(*runtime.PyDict)
+				if !ok {
+					return nil, fmt.Errorf("TypeError: install() catalog must be a dict, not %s", jsonTypeName(args[0]))
+				}
+				return runtime.None, state.merge(d)
+			},
+		}
+
+		mod.Dict["load"] = &runtime.PyBuiltinFunc{
+			Name: "load",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 1 {
+					return nil, fmt.Errorf("TypeError: load() takes exactly 1 argument, got %d", len(args))
+				}
+				path, ok := args[0].(*runtime.PyString)
+				if !ok {
+					return nil, fmt.Errorf("TypeError: load() path must be a str, not %s", jsonTypeName(args[0]))
+				}
+				return runtime.None, state.loadFile(path.Value)
+			},
+		}
+
+		mod.Dict["gettext"] = &runtime.PyBuiltinFunc{
+			Name: "gettext",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 1 {
+					return nil, fmt.Errorf("TypeError: gettext() takes exactly 1 argument, got %d", len(args))
+				}
+				msg, ok := args[0].(*runtime.PyString)
+				if !ok {
+					return nil, fmt.Errorf("TypeError: gettext() message must be a str, not %s", jsonTypeName(args[0]))
+				}
+				return runtime.NewString(state.translate(msg.Value)), nil
+			},
+		}
+
+		mod.Dict["ngettext"] = &runtime.PyBuiltinFunc{
+			Name: "ngettext",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				if len(args) != 3 {
+					return nil, fmt.Errorf("TypeError: ngettext() takes exactly 3 arguments, got %d", len(args))
+				}
+				sing, sok := args[0].(*runtime.PyString)
+				plur, pok := args[1].(*runtime.PyString)
+				n, nok := args[2].(*runtime.PyInt)
+				if !sok || !pok || !nok {
+					return nil, fmt.Errorf("TypeError: ngettext() takes (str, str, int)")
+				}
+				return runtime.NewString(state.translateN(sing.Value, plur.Value, n.Value)), nil
+			},
+		}
+
+		return mod
+	})
+}
+
+// merge folds a Python catalog dict into the installed catalog.
+func (g *gettextState) merge(d *runtime.PyDict) error {
+	for k, v := range d.Items {
+		key, ok := k.(*runtime.PyString)
+		if !ok {
+			return fmt.Errorf("TypeError: catalog keys must be strings, not %s", jsonTypeName(k))
+		}
+		switch val := v.(type) {
+		case *runtime.PyString:
+			g.singular[key.Value] = val.Value
+		case *runtime.PyList:
+			if len(val.Items) != 2 {
+				return fmt.Errorf("ValueError: plural entry for '%s' must be [singular, plural]", key.Value)
+			}
+			s, sok := val.Items[0].(*runtime.PyString)
+			p, pok := val.Items[1].(*runtime.PyString)
+			if !sok || !pok {
+				return fmt.Errorf("ValueError: plural entry for '%s' must contain strings", key.Value)
+			}
+			g.plural[key.Value] = [2]string{s.Value, p.Value}
+		default:
+			return fmt.Errorf("TypeError: catalog values must be str or [singular, plural], not %s", jsonTypeName(v))
+		}
+	}
+	return nil
+}
+
+// loadFile merges a JSON catalog file of the same shape install() accepts.
+func (g *gettextState) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("FileNotFoundError: no catalog at '%s'", path)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("ValueError: invalid catalog file '%s': %v", path, err)
+	}
+	for key, v := range raw {
+		switch val := v.(type) {
+		case string:
+			g.singular[key] = val
+		case []any:
+			if len(val) != 2 {
+				return fmt.Errorf("ValueError: plural entry for '%s' must be [singular, plural]", key)
+			}
+			s, sok := val[0].(string)
+			p, pok := val[1].(string)
+			if !sok || !pok {
+				return fmt.Errorf("ValueError: plural entry for '%s' must contain strings", key)
+			}
+			g.plural[key] = [2]string{s, p}
+		default:
+			return fmt.Errorf("ValueError: catalog values must be strings or [singular, plural] pairs")
+		}
+	}
+	return nil
+}
+
+func (g *gettextState) translate(msg string) string {
+	if t, ok := g.singular[msg]; ok {
+		return t
+	}
+	return msg
+}
+
+func (g *gettextState) translateN(singular, plural string, n int64) string {
+	if pair, ok := g.plural[singular]; ok {
+		if n == 1 {
+			return pair[0]
+		}
+		return pair[1]
+	}
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
@@ -28,4 +28,17 @@ func InitAllModules() {
 	InitHeapqModule()
 	InitBisectModule()
 	InitContextlibModule()
+	InitDisModule()
+	InitAtexitModule()
+	InitArrayModule()
+	InitGameMathModule()
+	InitYAMLModule()
+	InitTOMLModule()
+	InitTemplateModule()
+	InitGettextModule()
+	InitCalendarModule()
+	InitZoneinfoModule()
+	InitTimeitModule()
+	InitPprintModule()
+	InitCompatModule()
 }
@@ -0,0 +1,215 @@
+package stdlib
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// InitPprintModule registers the pprint module. pformat breaks containers
+// that don't fit in width across lines, one element per line, honoring
+// indent and sort_dicts like CPython; pprint prints the result. Reference
+// cycles render as "[...]"/"{...}" placeholders.
+func InitPprintModule() {
+	runtime.RegisterModule("pprint", func(vm *runtime.VM) *runtime.PyModule {
+		mod := runtime.NewModule("pprint")
+		mod.Dict["__doc__"] = runtime.NewString("Data pretty-printer.")
+
+		mod.Dict["pformat"] = &runtime.PyBuiltinFunc{
+			Name: "pformat",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				text, err := pprintFormat(vm, "pformat", args, kwargs)
+				if err != nil {
+					return nil, err
+				}
+				return runtime.NewString(text), nil
+			},
+		}
+
+		mod.Dict["pprint"] = &runtime.PyBuiltinFunc{
+			Name: "pprint",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				text, err := pprintFormat(vm, "pprint", args, kwargs)
+				if err != nil {
+					return nil, err
+				}
+				fmt.Fprintln(vm.Stdout(), text)
+				return runtime.None, nil
+			},
+		}
+
+		return mod
+	})
+}
+
+// pprintFormat parses (object, width=80, indent=1, sort_dicts=True) and
+// renders the object.
+func pprintFormat(vm *runtime.VM, name string, args []runtime.Value, kwargs map[string]runtime.Value) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("TypeError: %s() takes exactly 1 positional argument, got %d", name, len(args))
+	}
+	pp := &pprinter{vm: vm, width: 80, indent: 1, sortDicts: true, active: map[runtime.Value]bool{}}
+
+	if v, ok := kwargs["width"]; ok {
+		n, ok := v.(*runtime.PyInt)
+		if !ok {
+			return "", fmt.Errorf("TypeError: %s() width must be an int", name)
+		}
+		pp.width = int(n.Value)
+	}
+	if v, ok := kwargs["indent"]; ok {
+		n, ok := v.(*runtime.PyInt)
+		if !ok {
+			return "", fmt.Errorf("TypeError: %s() indent must be an int", name)
+		}
+		pp.indent = int(n.Value)
+	}
+	if v, ok := kwargs["sort_dicts"]; ok {
+		b, ok := v.(*runtime.PyBool)
+		if !ok {
+			return "", fmt.Errorf("TypeError: %s() sort_dicts must be a bool", name)
+		}
+		pp.sortDicts = b.Value
+	}
+	return pp.format(args[0], 0), nil
+}
+
+// pprinter carries formatting options through the recursion. active tracks
+// the containers currently being rendered for cycle detection.
+type pprinter struct {
+	vm        *runtime.VM
+	width     int
+	indent    int
+	sortDicts bool
+	active    map[runtime.Value]bool
+}
+
+// format renders v at the given column, breaking across lines if its
+// one-line form would overflow the configured width.
+func (pp *pprinter) format(v runtime.Value, col int) string {
+	flat := pp.flat(v)
+	if col+len(flat) <= pp.width || !pp.isContainer(v) {
+		return flat
+	}
+
+	inner := col + pp.indent
+	pad := strings.Repeat(" ", inner)
+	switch val := v.(type) {
+	case *runtime.PyList:
+		if pp.active[v] || len(val.Items) == 0 {
+			return flat
+		}
+		pp.active[v] = true
+		defer delete(pp.active, v)
+		return "[" + pp.joinMultiline(val.Items, inner, pad) + "]"
+	case *runtime.PyTuple:
+		if pp.active[v] || len(val.Items) == 0 {
+			return flat
+		}
+		pp.active[v] = true
+		defer delete(pp.active, v)
+		body := pp.joinMultiline(val.Items, inner, pad)
+		if len(val.Items) == 1 {
+			body += ","
+		}
+		return "(" + body + ")"
+	case *runtime.PyDict:
+		if pp.active[v] || len(val.Items) == 0 {
+			return flat
+		}
+		pp.active[v] = true
+		defer delete(pp.active, v)
+		entries := pp.dictEntries(val)
+		parts := make([]string, len(entries))
+		for i, e := range entries {
+			parts[i] = e.key + ": " + pp.format(e.value, inner+len(e.key)+2)
+		}
+		return "{" + strings.Join(parts, ",\n"+pad) + "}"
+	}
+	return flat
+}
+
+// joinMultiline renders sequence items one per line.
+func (pp *pprinter) joinMultiline(items []runtime.Value, inner int, pad string) string {
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = pp.format(item, inner)
+	}
+	return strings.Join(parts, ",\n"+pad)
+}
+
+// flat renders v on a single line, applying sort_dicts and cycle markers.
+func (pp *pprinter) flat(v runtime.Value) string {
+	switch val := v.(type) {
+	case *runtime.PyList:
+		if pp.active[v] {
+			return "[...]"
+		}
+		pp.active[v] = true
+		defer delete(pp.active, v)
+		parts := make([]string, len(val.Items))
+		for i, item := range val.Items {
+			parts[i] = pp.flat(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case *runtime.PyTuple:
+		if pp.active[v] {
+			return "(...)"
+		}
+		pp.active[v] = true
+		defer delete(pp.active, v)
+		parts := make([]string, len(val.Items))
+		for i, item := range val.Items {
+			parts[i] = pp.flat(item)
+		}
+		if len(parts) == 1 {
+			return "(" + parts[0] + ",)"
+		}
+		return "(" + strings.Join(parts, ", ") + ")"
+	case *runtime.PyDict:
+		if pp.active[v] {
+			return "{...}"
+		}
+		pp.active[v] = true
+		defer delete(pp.active, v)
+		entries := pp.dictEntries(val)
+		parts := make([]string, len(entries))
+		for i, e := range entries {
+			parts[i] = e.key + ": " + pp.flat(e.value)
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	default:
+		return pp.vm.Repr(v)
+	}
+}
+
+type pprintEntry struct {
+	key   string
+	value runtime.Value
+}
+
+// dictEntries returns a dict's entries with rendered keys, sorted when
+// sort_dicts is set, otherwise in insertion order.
+func (pp *pprinter) dictEntries(d *runtime.PyDict) []pprintEntry {
+	keys := d.Keys(pp.vm)
+	entries := make([]pprintEntry, 0, len(keys))
+	for _, k := range keys {
+		if v, ok := d.DictGet(k, pp.vm); ok {
+			entries = append(entries, pprintEntry{key: pp.vm.Repr(k), value: v})
+		}
+	}
+	if pp.sortDicts {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	}
+	return entries
+}
+
+func (pp *pprinter) isContainer(v runtime.Value) bool {
+	switch v.(type) {
+	case *runtime.PyList, *runtime.PyTuple, *runtime.PyDict:
+		return true
+	}
+	return false
+}
@@ -300,7 +300,7 @@ func reSub(vm *runtime.VM) int {
 	if count < 0 {
 		result = compiled.Regex.ReplaceAllString(str, repl)
 	} else {
-		result = replaceN(compiled.Regex, str, repl, count)
+		result = replaceN(vm, compiled.Regex, str, repl, count)
 	}
 
 	vm.Push(runtime.NewString(result))
@@ -329,7 +329,7 @@ func reSubn(vm *runtime.VM) int {
 		count = len(matches)
 		result = compiled.Regex.ReplaceAllString(str, repl)
 	} else {
-		result, count = replaceNCount(compiled.Regex, str, repl, maxCount)
+		result, count = replaceNCount(vm, compiled.Regex, str, repl, maxCount)
 	}
 
 	vm.Push(runtime.NewTuple([]runtime.Value{
@@ -339,13 +339,14 @@ func reSubn(vm *runtime.VM) int {
 	return 1
 }
 
-func replaceN(re *regexp.Regexp, str, repl string, n int) string {
+func replaceN(vm *runtime.VM, re *regexp.Regexp, str, repl string, n int) string {
 	if n == 0 {
 		return str
 	}
 
 	result := str
 	for i := 0; (n < 0 || i < n) && re.MatchString(result); i++ {
+		vm.CheckInterruptPanic()
 		result = re.ReplaceAllStringFunc(result, func(match string) string {
 			return repl
 		})
@@ -365,7 +366,7 @@ func replaceN(re *regexp.Regexp, str, repl string, n int) string {
 	return result
 }
 
-func replaceNCount(re *regexp.Regexp, str, repl string, n int) (string, int) {
+func replaceNCount(vm *runtime.VM, re *regexp.Regexp, str, repl string, n int) (string, int) {
 	if n == 0 {
 		return str, 0
 	}
@@ -374,6 +375,7 @@ func replaceNCount(re *regexp.Regexp, str, repl string, n int) (string, int) {
 	result := str
 
 	for (n < 0 || count < n) && re.MatchString(result) {
+		vm.CheckInterruptPanic()
 		loc := re.FindStringIndex(result)
 		if loc == nil {
 			break
@@ -2,6 +2,7 @@ package stdlib
 
 import (
 	"encoding/binary"
+	"fmt"
 	"math"
 	"os"
 	goruntime "runtime"
@@ -28,6 +29,11 @@ func SetArgv(args []string) {
 
 // InitSysModule registers the sys module
 func InitSysModule() {
+	buildSysModule().Register()
+}
+
+// buildSysModule constructs the sys module without registering it.
+func buildSysModule() *gopherpy.ModuleBuilder {
 	// Build argv list
 	var argvItems []gopherpy.Value
 	if len(sysArgv) > 0 {
@@ -60,7 +66,7 @@ func InitSysModule() {
 		Const("cache_tag", gopherpy.NewString("RAGE-314")).
 		Build()
 
-	gopherpy.NewModuleBuilder("sys").
+	return gopherpy.NewModuleBuilder("sys").
 		Doc("System-specific parameters and functions.").
 		// Version info
 		Const("version", gopherpy.NewString("3.14.0 (RAGE)")).
@@ -94,8 +100,26 @@ func InitSysModule() {
 		Func("setrecursionlimit", sysSetRecursionLimit).
 		Func("getsizeof", sysGetSizeof).
 		Func("getrefcount", sysGetRefcount).
-		Func("intern", sysIntern).
-		Register()
+		Func("intern", sysIntern)
+}
+
+// SysModuleForVersion builds a sys module whose version fields report the
+// given language level instead of the default. Used with VM.OverrideModule to
+// emulate a specific CPython version for one State (see
+// State.SetLanguageLevel).
+func SysModuleForVersion(major, minor int) *gopherpy.PyModule {
+	mod := buildSysModule().Build()
+	versionInfo := gopherpy.NewTuple([]gopherpy.Value{
+		gopherpy.NewInt(int64(major)),
+		gopherpy.NewInt(int64(minor)),
+		gopherpy.NewInt(0),
+		gopherpy.NewString("final"),
+		gopherpy.NewInt(0),
+	})
+	mod.Dict["version"] = gopherpy.NewString(fmt.Sprintf("%d.%d.0 (RAGE)", major, minor))
+	mod.Dict["version_info"] = versionInfo
+	mod.Dict["hexversion"] = gopherpy.NewInt(int64(major)<<24 | int64(minor)<<16 | 0xF0)
+	return mod
 }
 
 // getPlatform returns the platform identifier
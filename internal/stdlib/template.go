@@ -0,0 +1,923 @@
+package stdlib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// InitTemplateModule registers the template module, a Jinja-flavored text
+// templating engine rendered by Go. It covers the constructs small
+// generation scripts (NPC dialogue, emails, config files) actually need:
+//
+//	{{ expr }}                        substitution with dotted/indexed access
+//	{{ name | upper }}                filters, with args: {{ xs | join(", ") }}
+//	{% if c %}...{% elif %}{% else %} conditionals
+//	{% for x in xs %}...{% endfor %}  loops, with a `loop` helper variable
+//	{# ... #}                         comments
+//
+// Undefined variables are errors unless passed through the default filter.
+func InitTemplateModule() {
+	runtime.RegisterModule("template", func(vm *runtime.VM) *runtime.PyModule {
+		mod := runtime.NewModule("template")
+		mod.Dict["__doc__"] = runtime.NewString("Jinja-flavored text templating.")
+
+		mod.Dict["render"] = &runtime.PyBuiltinFunc{
+			Name: "render",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				return templateRender(vm, args, kwargs)
+			},
+		}
+		return mod
+	})
+}
+
+// templateRender implements template.render(tpl, **vars). Variables may also
+// be given as a single positional dict: render(tpl, {"k": v}).
+func templateRender(vm *runtime.VM, args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("TypeError: render() takes 1 or 2 positional arguments, got %d", len(args))
+	}
+	tpl, ok := args[0].(*runtime.PyString)
+	if !ok {
+		return nil, fmt.Errorf("TypeError: render() template must be a str, not %s", jsonTypeName(args[0]))
+	}
+
+	vars := map[string]runtime.Value{}
+	if len(args) == 2 {
+		d, ok := args[1].(*runtime.PyDict)
+		if !ok {
+			return nil, fmt.Errorf("TypeError: render() variables must be a dict, not %s", jsonTypeName(args[1]))
+		}
+		for k, v := range d.Items {
+			if key, ok := k.(*runtime.PyString); ok {
+				vars[key.Value] = v
+			}
+		}
+	}
+	for k, v := range kwargs {
+		vars[k] = v
+	}
+
+	nodes, err := parseTemplate(tpl.Value)
+	if err != nil {
+		return nil, &runtime.PyException{TypeName: "TemplateError", Message: err.Error()}
+	}
+	r := &tmplRenderer{vm: vm, scopes: []map[string]runtime.Value{vars}}
+	var buf strings.Builder
+	if err := r.renderNodes(&buf, nodes); err != nil {
+		return nil, &runtime.PyException{TypeName: "TemplateError", Message: err.Error()}
+	}
+	return runtime.NewString(buf.String()), nil
+}
+
+// =============================================================================
+// Template parsing
+// =============================================================================
+
+type tmplNode interface{}
+
+type tmplText string
+
+type tmplOutput struct {
+	expr string
+	line int
+}
+
+type tmplIf struct {
+	conds  []string // one per if/elif branch
+	lines  []int
+	bodies [][]tmplNode
+	orElse []tmplNode
+}
+
+type tmplFor struct {
+	loopVar string
+	seqExpr string
+	line    int
+	body    []tmplNode
+}
+
+// tmplToken is one lexed chunk of the template source.
+type tmplToken struct {
+	kind    int // 0 text, 1 output, 2 tag
+	content string
+	line    int
+}
+
+// lexTemplate splits the source into text, {{ output }} and {% tag %} tokens,
+// dropping {# comments #}.
+func lexTemplate(src string) ([]tmplToken, error) {
+	var toks []tmplToken
+	line := 1
+	for len(src) > 0 {
+		open := strings.IndexByte(src, '{')
+		if open < 0 || open == len(src)-1 {
+			toks = append(toks, tmplToken{kind: 0, content: src, line: line})
+			break
+		}
+		var close_, kind int
+		switch src[open+1] {
+		case '{':
+			close_, kind = strings.Index(src[open:], "}}"), 1
+		case '%':
+			close_, kind = strings.Index(src[open:], "%}"), 2
+		case '#':
+			close_, kind = strings.Index(src[open:], "#}"), 3
+		default:
+			// A lone brace is plain text
+			toks = append(toks, tmplToken{kind: 0, content: src[:open+1], line: line})
+			line += strings.Count(src[:open+1], "\n")
+			src = src[open+1:]
+			continue
+		}
+		if close_ < 0 {
+			return nil, fmt.Errorf("unclosed '%s' on line %d", src[open:open+2], line+strings.Count(src[:open], "\n"))
+		}
+		if open > 0 {
+			toks = append(toks, tmplToken{kind: 0, content: src[:open], line: line})
+		}
+		line += strings.Count(src[:open], "\n")
+		inner := src[open+2 : open+close_]
+		if kind != 3 {
+			toks = append(toks, tmplToken{kind: kind, content: strings.TrimSpace(inner), line: line})
+		}
+		line += strings.Count(inner, "\n")
+		src = src[open+close_+2:]
+	}
+	return toks, nil
+}
+
+func parseTemplate(src string) ([]tmplNode, error) {
+	toks, err := lexTemplate(src)
+	if err != nil {
+		return nil, err
+	}
+	nodes, pos, err := parseTmplNodes(toks, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if pos != len(toks) {
+		return nil, fmt.Errorf("unexpected '{%% %s %%}' on line %d", toks[pos].content, toks[pos].line)
+	}
+	return nodes, nil
+}
+
+// parseTmplNodes parses tokens until one of stopTags (or the end) is reached.
+// The stopping tag itself is not consumed.
+func parseTmplNodes(toks []tmplToken, pos int, stopTags []string) ([]tmplNode, int, error) {
+	var nodes []tmplNode
+	for pos < len(toks) {
+		tok := toks[pos]
+		switch tok.kind {
+		case 0:
+			nodes = append(nodes, tmplText(tok.content))
+			pos++
+		case 1:
+			nodes = append(nodes, tmplOutput{expr: tok.content, line: tok.line})
+			pos++
+		case 2:
+			word := tok.content
+			if i := strings.IndexByte(word, ' '); i >= 0 {
+				word = word[:i]
+			}
+			for _, stop := range stopTags {
+				if word == stop {
+					return nodes, pos, nil
+				}
+			}
+			switch word {
+			case "if":
+				node, next, err := parseTmplIf(toks, pos)
+				if err != nil {
+					return nil, 0, err
+				}
+				nodes = append(nodes, node)
+				pos = next
+			case "for":
+				node, next, err := parseTmplFor(toks, pos)
+				if err != nil {
+					return nil, 0, err
+				}
+				nodes = append(nodes, node)
+				pos = next
+			default:
+				return nil, 0, fmt.Errorf("unknown tag '%s' on line %d", word, tok.line)
+			}
+		}
+	}
+	if len(stopTags) > 0 {
+		return nil, 0, fmt.Errorf("missing '{%% %s %%}'", stopTags[len(stopTags)-1])
+	}
+	return nodes, pos, nil
+}
+
+func parseTmplIf(toks []tmplToken, pos int) (tmplNode, int, error) {
+	node := &tmplIf{}
+	for {
+		tag := toks[pos]
+		cond := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(tag.content, "elif"), "if"))
+		if cond == "" {
+			return nil, 0, fmt.Errorf("'%s' needs a condition on line %d", tag.content, tag.line)
+		}
+		body, next, err := parseTmplNodes(toks, pos+1, []string{"elif", "else", "endif"})
+		if err != nil {
+			return nil, 0, err
+		}
+		node.conds = append(node.conds, cond)
+		node.lines = append(node.lines, tag.line)
+		node.bodies = append(node.bodies, body)
+		pos = next
+		if !strings.HasPrefix(toks[pos].content, "elif") {
+			break
+		}
+	}
+	if toks[pos].content == "else" {
+		body, next, err := parseTmplNodes(toks, pos+1, []string{"endif"})
+		if err != nil {
+			return nil, 0, err
+		}
+		node.orElse = body
+		pos = next
+	}
+	return node, pos + 1, nil // consume endif
+}
+
+func parseTmplFor(toks []tmplToken, pos int) (tmplNode, int, error) {
+	tag := toks[pos]
+	spec := strings.TrimSpace(strings.TrimPrefix(tag.content, "for"))
+	varName, seqExpr, found := strings.Cut(spec, " in ")
+	varName = strings.TrimSpace(varName)
+	if !found || varName == "" || !isTmplName(varName) {
+		return nil, 0, fmt.Errorf("malformed for tag on line %d, expected '{%% for name in expr %%}'", tag.line)
+	}
+	body, next, err := parseTmplNodes(toks, pos+1, []string{"endfor"})
+	if err != nil {
+		return nil, 0, err
+	}
+	return &tmplFor{
+		loopVar: varName,
+		seqExpr: strings.TrimSpace(seqExpr),
+		line:    tag.line,
+		body:    body,
+	}, next + 1, nil // consume endfor
+}
+
+func isTmplName(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || i > 0 && c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+// =============================================================================
+// Rendering
+// =============================================================================
+
+// tmplUndefined marks a missing variable; only the default filter and
+// truthiness tests accept it.
+type tmplUndefined struct {
+	name string
+}
+
+type tmplRenderer struct {
+	vm     *runtime.VM
+	scopes []map[string]runtime.Value
+}
+
+func (r *tmplRenderer) lookup(name string) (runtime.Value, bool) {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if v, ok := r.scopes[i][name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func (r *tmplRenderer) renderNodes(buf *strings.Builder, nodes []tmplNode) error {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case tmplText:
+			buf.WriteString(string(n))
+		case tmplOutput:
+			v, err := r.eval(n.expr, n.line)
+			if err != nil {
+				return err
+			}
+			if u, ok := v.(tmplUndefined); ok {
+				return fmt.Errorf("'%s' is undefined on line %d", u.name, n.line)
+			}
+			buf.WriteString(r.stringify(v))
+		case *tmplIf:
+			if err := r.renderIf(buf, n); err != nil {
+				return err
+			}
+		case *tmplFor:
+			if err := r.renderFor(buf, n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *tmplRenderer) renderIf(buf *strings.Builder, node *tmplIf) error {
+	for i, cond := range node.conds {
+		v, err := r.eval(cond, node.lines[i])
+		if err != nil {
+			return err
+		}
+		if r.truthy(v) {
+			return r.renderNodes(buf, node.bodies[i])
+		}
+	}
+	return r.renderNodes(buf, node.orElse)
+}
+
+func (r *tmplRenderer) renderFor(buf *strings.Builder, node *tmplFor) error {
+	seq, err := r.eval(node.seqExpr, node.line)
+	if err != nil {
+		return err
+	}
+	if u, ok := seq.(tmplUndefined); ok {
+		return fmt.Errorf("'%s' is undefined on line %d", u.name, node.line)
+	}
+	items, err := r.vm.ToList(seq)
+	if err != nil {
+		return fmt.Errorf("cannot loop over %s on line %d", jsonTypeName(seq), node.line)
+	}
+
+	scope := map[string]runtime.Value{}
+	r.scopes = append(r.scopes, scope)
+	defer func() { r.scopes = r.scopes[:len(r.scopes)-1] }()
+
+	for i, item := range items {
+		loop := runtime.NewDict()
+		loop.Items[runtime.NewString("index")] = runtime.NewInt(int64(i + 1))
+		loop.Items[runtime.NewString("index0")] = runtime.NewInt(int64(i))
+		loop.Items[runtime.NewString("first")] = runtime.NewBool(i == 0)
+		loop.Items[runtime.NewString("last")] = runtime.NewBool(i == len(items)-1)
+		scope[node.loopVar] = item
+		scope["loop"] = loop
+		if err := r.renderNodes(buf, node.body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stringify renders a value for output: strings are emitted raw, everything
+// else uses its str() form.
+func (r *tmplRenderer) stringify(v runtime.Value) string {
+	if s, ok := v.(*runtime.PyString); ok {
+		return s.Value
+	}
+	return r.vm.Repr(v)
+}
+
+func (r *tmplRenderer) truthy(v runtime.Value) bool {
+	if _, ok := v.(tmplUndefined); ok {
+		return false
+	}
+	return r.vm.Truthy(v)
+}
+
+// =============================================================================
+// Expression evaluation
+// =============================================================================
+
+// tmplExprParser evaluates one template expression directly, with no
+// intermediate AST; expressions are small and evaluated in place.
+type tmplExprParser struct {
+	r    *tmplRenderer
+	toks []tmplExprTok
+	pos  int
+	line int
+}
+
+type tmplExprTok struct {
+	kind int // 0 name, 1 number, 2 string, 3 symbol
+	text string
+}
+
+func (r *tmplRenderer) eval(expr string, line int) (runtime.Value, error) {
+	toks, err := lexTmplExpr(expr, line)
+	if err != nil {
+		return nil, err
+	}
+	p := &tmplExprParser{r: r, toks: toks, line: line}
+	v, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.toks) {
+		return nil, fmt.Errorf("unexpected '%s' in expression on line %d", p.toks[p.pos].text, line)
+	}
+	return v, nil
+}
+
+func lexTmplExpr(s string, line int) ([]tmplExprTok, error) {
+	var toks []tmplExprTok
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_':
+			start := i
+			for i < len(s) && isTmplNameByte(s[i]) {
+				i++
+			}
+			toks = append(toks, tmplExprTok{kind: 0, text: s[start:i]})
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+				i++
+			}
+			toks = append(toks, tmplExprTok{kind: 1, text: s[start:i]})
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string in expression on line %d", line)
+			}
+			toks = append(toks, tmplExprTok{kind: 2, text: s[i+1 : i+1+end]})
+			i += end + 2
+		case strings.HasPrefix(s[i:], "==") || strings.HasPrefix(s[i:], "!=") ||
+			strings.HasPrefix(s[i:], "<=") || strings.HasPrefix(s[i:], ">="):
+			toks = append(toks, tmplExprTok{kind: 3, text: s[i : i+2]})
+			i += 2
+		case strings.ContainsRune("<>|.[](),", rune(c)):
+			toks = append(toks, tmplExprTok{kind: 3, text: string(c)})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' in expression on line %d", c, line)
+		}
+	}
+	return toks, nil
+}
+
+func isTmplNameByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
+
+func (p *tmplExprParser) peekTok() (tmplExprTok, bool) {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos], true
+	}
+	return tmplExprTok{}, false
+}
+
+func (p *tmplExprParser) acceptSym(text string) bool {
+	if tok, ok := p.peekTok(); ok && tok.kind == 3 && tok.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *tmplExprParser) acceptName(text string) bool {
+	if tok, ok := p.peekTok(); ok && tok.kind == 0 && tok.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *tmplExprParser) parseOr() (runtime.Value, error) {
+	v, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptName("or") {
+		rhs, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if !p.r.truthy(v) {
+			v = rhs
+		}
+	}
+	return v, nil
+}
+
+func (p *tmplExprParser) parseAnd() (runtime.Value, error) {
+	v, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptName("and") {
+		rhs, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if p.r.truthy(v) {
+			v = rhs
+		}
+	}
+	return v, nil
+}
+
+func (p *tmplExprParser) parseNot() (runtime.Value, error) {
+	if p.acceptName("not") {
+		v, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return runtime.NewBool(!p.r.truthy(v)), nil
+	}
+	return p.parseComparison()
+}
+
+func (p *tmplExprParser) parseComparison() (runtime.Value, error) {
+	lhs, err := p.parsePiped()
+	if err != nil {
+		return nil, err
+	}
+	tok, ok := p.peekTok()
+	if !ok || tok.kind != 3 || !strings.Contains("== != < <= > >=", tok.text) {
+		return lhs, nil
+	}
+	p.pos++
+	rhs, err := p.parsePiped()
+	if err != nil {
+		return nil, err
+	}
+	result, err := tmplCompare(lhs, rhs, tok.text, p.line)
+	if err != nil {
+		return nil, err
+	}
+	return runtime.NewBool(result), nil
+}
+
+// parsePiped parses a primary value followed by any chain of | filters.
+func (p *tmplExprParser) parsePiped() (runtime.Value, error) {
+	v, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptSym("|") {
+		tok, ok := p.peekTok()
+		if !ok || tok.kind != 0 {
+			return nil, fmt.Errorf("expected a filter name after '|' on line %d", p.line)
+		}
+		p.pos++
+		var args []runtime.Value
+		if p.acceptSym("(") {
+			for !p.acceptSym(")") {
+				arg, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if !p.acceptSym(",") && !p.acceptSym(")") {
+					return nil, fmt.Errorf("expected ',' or ')' in filter arguments on line %d", p.line)
+				} else if p.toks[p.pos-1].text == ")" {
+					break
+				}
+			}
+		}
+		v, err = p.applyFilter(tok.text, v, args)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func (p *tmplExprParser) parsePrimary() (runtime.Value, error) {
+	tok, ok := p.peekTok()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of expression on line %d", p.line)
+	}
+	var v runtime.Value
+	switch tok.kind {
+	case 1: // number
+		p.pos++
+		if strings.Contains(tok.text, ".") {
+			f, err := strconv.ParseFloat(tok.text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number '%s' on line %d", tok.text, p.line)
+			}
+			v = runtime.NewFloat(f)
+		} else {
+			n, err := strconv.ParseInt(tok.text, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number '%s' on line %d", tok.text, p.line)
+			}
+			v = runtime.NewInt(n)
+		}
+	case 2: // string
+		p.pos++
+		v = runtime.NewString(tok.text)
+	case 0: // name or keyword literal
+		p.pos++
+		switch tok.text {
+		case "true", "True":
+			v = runtime.NewBool(true)
+		case "false", "False":
+			v = runtime.NewBool(false)
+		case "none", "None":
+			v = runtime.None
+		default:
+			found, ok := p.r.lookup(tok.text)
+			if !ok {
+				v = tmplUndefined{name: tok.text}
+			} else {
+				v = found
+			}
+		}
+	case 3:
+		if tok.text == "(" {
+			p.pos++
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if !p.acceptSym(")") {
+				return nil, fmt.Errorf("missing ')' on line %d", p.line)
+			}
+			v = inner
+			break
+		}
+		return nil, fmt.Errorf("unexpected '%s' in expression on line %d", tok.text, p.line)
+	}
+	return p.parseTrailers(v)
+}
+
+// parseTrailers handles dotted attribute access and [index] subscripts.
+func (p *tmplExprParser) parseTrailers(v runtime.Value) (runtime.Value, error) {
+	for {
+		switch {
+		case p.acceptSym("."):
+			tok, ok := p.peekTok()
+			if !ok || tok.kind != 0 {
+				return nil, fmt.Errorf("expected an attribute name after '.' on line %d", p.line)
+			}
+			p.pos++
+			next, err := p.attr(v, tok.text)
+			if err != nil {
+				return nil, err
+			}
+			v = next
+		case p.acceptSym("["):
+			idx, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if !p.acceptSym("]") {
+				return nil, fmt.Errorf("missing ']' on line %d", p.line)
+			}
+			next, err := p.subscript(v, idx)
+			if err != nil {
+				return nil, err
+			}
+			v = next
+		default:
+			return v, nil
+		}
+	}
+}
+
+func (p *tmplExprParser) attr(v runtime.Value, name string) (runtime.Value, error) {
+	if u, ok := v.(tmplUndefined); ok {
+		return nil, fmt.Errorf("'%s' is undefined on line %d", u.name, p.line)
+	}
+	switch val := v.(type) {
+	case *runtime.PyDict:
+		if item, ok := val.DictGet(runtime.NewString(name), p.r.vm); ok {
+			return item, nil
+		}
+	case *runtime.PyInstance:
+		if item, ok := val.Dict[name]; ok {
+			return item, nil
+		}
+	case *runtime.PyModule:
+		if item, ok := val.Dict[name]; ok {
+			return item, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no attribute '%s' on line %d", jsonTypeName(v), name, p.line)
+}
+
+func (p *tmplExprParser) subscript(v, idx runtime.Value) (runtime.Value, error) {
+	if u, ok := v.(tmplUndefined); ok {
+		return nil, fmt.Errorf("'%s' is undefined on line %d", u.name, p.line)
+	}
+	switch val := v.(type) {
+	case *runtime.PyList:
+		return tmplIndex(val.Items, idx, p.line)
+	case *runtime.PyTuple:
+		return tmplIndex(val.Items, idx, p.line)
+	case *runtime.PyDict:
+		if item, ok := val.DictGet(idx, p.r.vm); ok {
+			return item, nil
+		}
+		return nil, fmt.Errorf("key %s not found on line %d", p.r.vm.Repr(idx), p.line)
+	case *runtime.PyString:
+		runes := []rune(val.Value)
+		items := make([]runtime.Value, len(runes))
+		for i, r := range runes {
+			items[i] = runtime.NewString(string(r))
+		}
+		return tmplIndex(items, idx, p.line)
+	}
+	return nil, fmt.Errorf("%s is not subscriptable on line %d", jsonTypeName(v), p.line)
+}
+
+func tmplIndex(items []runtime.Value, idx runtime.Value, line int) (runtime.Value, error) {
+	n, ok := idx.(*runtime.PyInt)
+	if !ok {
+		return nil, fmt.Errorf("index must be an integer on line %d", line)
+	}
+	i := n.Value
+	if i < 0 {
+		i += int64(len(items))
+	}
+	if i < 0 || i >= int64(len(items)) {
+		return nil, fmt.Errorf("index %d out of range on line %d", n.Value, line)
+	}
+	return items[i], nil
+}
+
+// =============================================================================
+// Filters and comparison
+// =============================================================================
+
+func (p *tmplExprParser) applyFilter(name string, v runtime.Value, args []runtime.Value) (runtime.Value, error) {
+	// default is the one filter that accepts an undefined input
+	if name == "default" {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("filter default takes exactly 1 argument on line %d", p.line)
+		}
+		if _, ok := v.(tmplUndefined); ok {
+			return args[0], nil
+		}
+		if _, ok := v.(*runtime.PyNone); ok {
+			return args[0], nil
+		}
+		return v, nil
+	}
+	if u, ok := v.(tmplUndefined); ok {
+		return nil, fmt.Errorf("'%s' is undefined on line %d", u.name, p.line)
+	}
+
+	strFilter := func(fn func(string) string) (runtime.Value, error) {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("filter %s takes no arguments on line %d", name, p.line)
+		}
+		return runtime.NewString(fn(p.r.stringify(v))), nil
+	}
+
+	switch name {
+	case "upper":
+		return strFilter(strings.ToUpper)
+	case "lower":
+		return strFilter(strings.ToLower)
+	case "title":
+		return strFilter(tmplTitle)
+	case "capitalize":
+		return strFilter(func(s string) string {
+			if s == "" {
+				return s
+			}
+			return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+		})
+	case "trim":
+		return strFilter(strings.TrimSpace)
+	case "length":
+		items, err := p.r.vm.ToList(v)
+		if err != nil {
+			if s, ok := v.(*runtime.PyString); ok {
+				return runtime.NewInt(int64(len([]rune(s.Value)))), nil
+			}
+			return nil, fmt.Errorf("%s has no length on line %d", jsonTypeName(v), p.line)
+		}
+		return runtime.NewInt(int64(len(items))), nil
+	case "first", "last":
+		items, err := p.r.vm.ToList(v)
+		if err != nil || len(items) == 0 {
+			return nil, fmt.Errorf("filter %s needs a non-empty sequence on line %d", name, p.line)
+		}
+		if name == "first" {
+			return items[0], nil
+		}
+		return items[len(items)-1], nil
+	case "join":
+		sep := ", "
+		if len(args) == 1 {
+			s, ok := args[0].(*runtime.PyString)
+			if !ok {
+				return nil, fmt.Errorf("filter join separator must be a string on line %d", p.line)
+			}
+			sep = s.Value
+		} else if len(args) > 1 {
+			return nil, fmt.Errorf("filter join takes at most 1 argument on line %d", p.line)
+		}
+		items, err := p.r.vm.ToList(v)
+		if err != nil {
+			return nil, fmt.Errorf("filter join needs a sequence on line %d", p.line)
+		}
+		parts := make([]string, len(items))
+		for i, item := range items {
+			parts[i] = p.r.stringify(item)
+		}
+		return runtime.NewString(strings.Join(parts, sep)), nil
+	}
+	return nil, fmt.Errorf("unknown filter '%s' on line %d", name, p.line)
+}
+
+// tmplTitle uppercases the first letter of each word, like str.title().
+func tmplTitle(s string) string {
+	var buf strings.Builder
+	startOfWord := true
+	for _, r := range strings.ToLower(s) {
+		if r >= 'a' && r <= 'z' && startOfWord {
+			buf.WriteRune(r - 'a' + 'A')
+		} else {
+			buf.WriteRune(r)
+		}
+		startOfWord = !isTmplNameByte(byte(r)) && r < 128
+	}
+	return buf.String()
+}
+
+// tmplCompare evaluates a comparison operator over two values. Numbers
+// compare numerically (int and float mix), strings lexicographically.
+func tmplCompare(lhs, rhs runtime.Value, op string, line int) (bool, error) {
+	if u, ok := lhs.(tmplUndefined); ok {
+		return false, fmt.Errorf("'%s' is undefined on line %d", u.name, line)
+	}
+	if u, ok := rhs.(tmplUndefined); ok {
+		return false, fmt.Errorf("'%s' is undefined on line %d", u.name, line)
+	}
+
+	if lf, lok := tmplAsFloat(lhs); lok {
+		if rf, rok := tmplAsFloat(rhs); rok {
+			return tmplOrdered(op, lf == rf, lf < rf), nil
+		}
+	}
+	if ls, ok := lhs.(*runtime.PyString); ok {
+		if rs, ok := rhs.(*runtime.PyString); ok {
+			return tmplOrdered(op, ls.Value == rs.Value, ls.Value < rs.Value), nil
+		}
+	}
+	// Mixed or unordered types support only equality
+	switch op {
+	case "==":
+		return tmplEqual(lhs, rhs), nil
+	case "!=":
+		return !tmplEqual(lhs, rhs), nil
+	}
+	return false, fmt.Errorf("'%s' not supported between %s and %s on line %d",
+		op, jsonTypeName(lhs), jsonTypeName(rhs), line)
+}
+
+func tmplAsFloat(v runtime.Value) (float64, bool) {
+	switch val := v.(type) {
+	case *runtime.PyInt:
+		return float64(val.Value), true
+	case *runtime.PyFloat:
+		return val.Value, true
+	case *runtime.PyBool:
+		if val.Value {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func tmplEqual(lhs, rhs runtime.Value) bool {
+	if _, ok := lhs.(*runtime.PyNone); ok {
+		_, rok := rhs.(*runtime.PyNone)
+		return rok
+	}
+	if ls, ok := lhs.(*runtime.PyString); ok {
+		rs, rok := rhs.(*runtime.PyString)
+		return rok && ls.Value == rs.Value
+	}
+	return lhs == rhs
+}
+
+func tmplOrdered(op string, eq, lt bool) bool {
+	switch op {
+	case "==":
+		return eq
+	case "!=":
+		return !eq
+	case "<":
+		return lt
+	case "<=":
+		return lt || eq
+	case ">":
+		return !lt && !eq
+	case ">=":
+		return !lt
+	}
+	return false
+}
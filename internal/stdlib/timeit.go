@@ -0,0 +1,138 @@
+package stdlib
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// timeitEpoch anchors default_timer; time.Since uses Go's monotonic clock,
+// so timings are immune to wall-clock adjustments.
+var timeitEpoch = time.Now()
+
+// InitTimeitModule registers the timeit module for micro-benchmarking script
+// code. Unlike CPython, statements are callables rather than source strings,
+// and number defaults to 1000 instead of one million — rage code is
+// interpreted, and a million iterations of anything non-trivial would stall
+// the script.
+func InitTimeitModule() {
+	runtime.RegisterModule("timeit", func(vm *runtime.VM) *runtime.PyModule {
+		mod := runtime.NewModule("timeit")
+		mod.Dict["__doc__"] = runtime.NewString("Measure execution time of small code snippets.")
+
+		mod.Dict["timeit"] = &runtime.PyBuiltinFunc{
+			Name: "timeit",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				fn, number, _, err := timeitArgs("timeit", args, kwargs, false)
+				if err != nil {
+					return nil, err
+				}
+				elapsed, err := timeitRun(vm, fn, number)
+				if err != nil {
+					return nil, err
+				}
+				return runtime.NewFloat(elapsed), nil
+			},
+		}
+
+		mod.Dict["repeat"] = &runtime.PyBuiltinFunc{
+			Name: "repeat",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				fn, number, count, err := timeitArgs("repeat", args, kwargs, true)
+				if err != nil {
+					return nil, err
+				}
+				results := make([]runtime.Value, 0, count)
+				for i := int64(0); i < count; i++ {
+					elapsed, err := timeitRun(vm, fn, number)
+					if err != nil {
+						return nil, err
+					}
+					results = append(results, runtime.NewFloat(elapsed))
+				}
+				return runtime.NewList(results), nil
+			},
+		}
+
+		mod.Dict["default_timer"] = &runtime.PyBuiltinFunc{
+			Name: "default_timer",
+			Fn: func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+				return runtime.NewFloat(time.Since(timeitEpoch).Seconds()), nil
+			},
+		}
+
+		return mod
+	})
+}
+
+// timeitArgs parses (fn, number=1000) and, for repeat, the repeat=5 count.
+func timeitArgs(name string, args []runtime.Value, kwargs map[string]runtime.Value, withRepeat bool) (runtime.Value, int64, int64, error) {
+	if len(args) < 1 {
+		return nil, 0, 0, fmt.Errorf("TypeError: %s() requires a callable", name)
+	}
+	fn := args[0]
+	number := int64(1000)
+	count := int64(5)
+
+	rest := args[1:]
+	if withRepeat && len(rest) > 0 {
+		n, err := timeitIntArg(name, "repeat", rest[0])
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		count = n
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		n, err := timeitIntArg(name, "number", rest[0])
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		number = n
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		return nil, 0, 0, fmt.Errorf("TypeError: %s() got too many arguments", name)
+	}
+	if v, ok := kwargs["number"]; ok {
+		n, err := timeitIntArg(name, "number", v)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		number = n
+	}
+	if v, ok := kwargs["repeat"]; ok {
+		if !withRepeat {
+			return nil, 0, 0, fmt.Errorf("TypeError: %s() got an unexpected keyword argument 'repeat'", name)
+		}
+		n, err := timeitIntArg(name, "repeat", v)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		count = n
+	}
+	if number < 0 || count < 1 {
+		return nil, 0, 0, fmt.Errorf("ValueError: %s() counts must be positive", name)
+	}
+	return fn, number, count, nil
+}
+
+func timeitIntArg(fn, param string, v runtime.Value) (int64, error) {
+	n, ok := v.(*runtime.PyInt)
+	if !ok {
+		return 0, fmt.Errorf("TypeError: %s() %s must be an int, not %s", fn, param, jsonTypeName(v))
+	}
+	return n.Value, nil
+}
+
+// timeitRun calls fn number times and returns the elapsed seconds.
+func timeitRun(vm *runtime.VM, fn runtime.Value, number int64) (float64, error) {
+	start := time.Now()
+	for i := int64(0); i < number; i++ {
+		if _, err := vm.Call(fn, nil, nil); err != nil {
+			return 0, err
+		}
+	}
+	return time.Since(start).Seconds(), nil
+}
@@ -0,0 +1,779 @@
+package stdlib
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// InitTOMLModule registers the toml module. The parser is written in Go and
+// covers the subset config files actually use: tables, arrays of tables,
+// dotted and quoted keys, basic/literal/multiline strings, integers (with
+// underscores and 0x/0o/0b prefixes), floats, booleans, arrays and inline
+// tables. Dates and times are kept as strings since rage has no datetime
+// type.
+func InitTOMLModule() {
+	runtime.NewModuleBuilder("toml").
+		Doc("TOML parser and serializer.").
+		Func("loads", tomlLoads).
+		Func("dumps", tomlDumps).
+		Register()
+}
+
+// tomlLoads parses a TOML document into a dict.
+// loads(s) -> dict
+func tomlLoads(vm *runtime.VM) int {
+	if !vm.RequireArgs("loads", 1) {
+		return 0
+	}
+	s := vm.CheckString(1)
+
+	goVal, err := parseTOML(s)
+	if err != nil {
+		vm.RaiseError("toml.TOMLDecodeError: %s", err.Error())
+		return 0
+	}
+	result, err := goDataToPython(goVal)
+	if err != nil {
+		vm.RaiseError("toml.TOMLDecodeError: %s", err.Error())
+		return 0
+	}
+	vm.Push(result)
+	return 1
+}
+
+// tomlDumps serializes a dict to a TOML string.
+// dumps(obj) -> str
+func tomlDumps(vm *runtime.VM) int {
+	if !vm.RequireArgs("dumps", 1) {
+		return 0
+	}
+	d, ok := vm.Get(1).(*runtime.PyDict)
+	if !ok {
+		vm.RaiseError("TypeError: dumps() argument must be a dict, not %s", jsonTypeName(vm.Get(1)))
+		return 0
+	}
+	goVal, err := pythonToGoValue(d)
+	if err != nil {
+		vm.RaiseError("TypeError: %s", err.Error())
+		return 0
+	}
+	var buf strings.Builder
+	if err := writeTOMLTable(&buf, goVal.(map[string]any), nil); err != nil {
+		vm.RaiseError("TypeError: %s", err.Error())
+		return 0
+	}
+	vm.Push(runtime.NewString(buf.String()))
+	return 1
+}
+
+// =============================================================================
+// Parsing
+// =============================================================================
+
+// tomlParser is a single-pass scanner over the document.
+type tomlParser struct {
+	src  string
+	pos  int
+	line int
+}
+
+func parseTOML(src string) (map[string]any, error) {
+	p := &tomlParser{src: src, line: 1}
+	root := map[string]any{}
+	current := root
+
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.src) {
+			return root, nil
+		}
+		var err error
+		if p.peek() == '[' {
+			current, err = p.parseHeader(root)
+		} else {
+			err = p.parseKeyValue(current)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectLineEnd(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *tomlParser) peek() byte { return p.src[p.pos] }
+
+func (p *tomlParser) errf(format string, args ...any) error {
+	return fmt.Errorf(format+" (line %d)", append(args, p.line)...)
+}
+
+// skipBlank advances past whitespace, newlines and comments.
+func (p *tomlParser) skipBlank() {
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\r':
+			p.pos++
+		case '\n':
+			p.pos++
+			p.line++
+		case '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// skipSpace advances past spaces and tabs only.
+func (p *tomlParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// expectLineEnd requires the rest of the line to be blank or a comment.
+func (p *tomlParser) expectLineEnd() error {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return nil
+	}
+	switch p.src[p.pos] {
+	case '#':
+		for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+			p.pos++
+		}
+		return nil
+	case '\n':
+		return nil
+	case '\r':
+		p.pos++
+		return nil
+	}
+	return p.errf("unexpected characters after value")
+}
+
+// parseHeader handles [table] and [[array-of-tables]] lines, returning the
+// table that subsequent key/value lines populate.
+func (p *tomlParser) parseHeader(root map[string]any) (map[string]any, error) {
+	p.pos++ // '['
+	isArray := p.pos < len(p.src) && p.src[p.pos] == '['
+	if isArray {
+		p.pos++
+	}
+	path, err := p.parseKeyPath(']')
+	if err != nil {
+		return nil, err
+	}
+	if p.pos >= len(p.src) || p.src[p.pos] != ']' {
+		return nil, p.errf("expected ']' in table header")
+	}
+	p.pos++
+	if isArray {
+		if p.pos >= len(p.src) || p.src[p.pos] != ']' {
+			return nil, p.errf("expected ']]' in array-of-tables header")
+		}
+		p.pos++
+	}
+
+	parent, err := p.descend(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	if isArray {
+		arr, _ := parent[last].([]any)
+		if parent[last] != nil && arr == nil {
+			return nil, p.errf("key '%s' is not an array of tables", last)
+		}
+		table := map[string]any{}
+		parent[last] = append(arr, any(table))
+		return table, nil
+	}
+
+	switch existing := parent[last].(type) {
+	case nil:
+		table := map[string]any{}
+		parent[last] = table
+		return table, nil
+	case map[string]any:
+		return existing, nil
+	default:
+		return nil, p.errf("key '%s' is already defined", last)
+	}
+}
+
+// descend walks (creating as needed) a dotted path of tables, following the
+// last element of any array of tables along the way.
+func (p *tomlParser) descend(table map[string]any, path []string) (map[string]any, error) {
+	for _, key := range path {
+		switch next := table[key].(type) {
+		case nil:
+			child := map[string]any{}
+			table[key] = child
+			table = child
+		case map[string]any:
+			table = next
+		case []any:
+			if len(next) == 0 {
+				return nil, p.errf("key '%s' is an empty array", key)
+			}
+			child, ok := next[len(next)-1].(map[string]any)
+			if !ok {
+				return nil, p.errf("key '%s' is not a table", key)
+			}
+			table = child
+		default:
+			return nil, p.errf("key '%s' is not a table", key)
+		}
+	}
+	return table, nil
+}
+
+// parseKeyValue handles one `key = value` line, including dotted keys.
+func (p *tomlParser) parseKeyValue(table map[string]any) error {
+	path, err := p.parseKeyPath('=')
+	if err != nil {
+		return err
+	}
+	if p.pos >= len(p.src) || p.src[p.pos] != '=' {
+		return p.errf("expected '=' after key")
+	}
+	p.pos++
+	p.skipSpace()
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	target, err := p.descend(table, path[:len(path)-1])
+	if err != nil {
+		return err
+	}
+	last := path[len(path)-1]
+	if _, exists := target[last]; exists {
+		return p.errf("duplicate key '%s'", last)
+	}
+	target[last] = value
+	return nil
+}
+
+// parseKeyPath reads a possibly dotted key up to (but not consuming) stop.
+func (p *tomlParser) parseKeyPath(stop byte) ([]string, error) {
+	var path []string
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return nil, p.errf("unexpected end of input in key")
+		}
+		var part string
+		var err error
+		switch p.src[p.pos] {
+		case '"':
+			part, err = p.parseBasicString()
+		case '\'':
+			part, err = p.parseLiteralString()
+		default:
+			start := p.pos
+			for p.pos < len(p.src) && isBareKeyChar(p.src[p.pos]) {
+				p.pos++
+			}
+			part = p.src[start:p.pos]
+			if part == "" {
+				err = p.errf("invalid key")
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, part)
+		p.skipSpace()
+		if p.pos < len(p.src) && p.src[p.pos] == '.' {
+			p.pos++
+			continue
+		}
+		if p.pos < len(p.src) && (p.src[p.pos] == stop || stop == ']' && p.src[p.pos] == ']') {
+			return path, nil
+		}
+		return nil, p.errf("expected '%c' after key", stop)
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// parseValue dispatches on the first character of a value.
+func (p *tomlParser) parseValue() (any, error) {
+	if p.pos >= len(p.src) {
+		return nil, p.errf("expected a value")
+	}
+	switch p.src[p.pos] {
+	case '"':
+		if strings.HasPrefix(p.src[p.pos:], `"""`) {
+			return p.parseMultilineString(`"""`)
+		}
+		return p.parseBasicString()
+	case '\'':
+		if strings.HasPrefix(p.src[p.pos:], "'''") {
+			return p.parseMultilineString("'''")
+		}
+		return p.parseLiteralString()
+	case '[':
+		return p.parseArray()
+	case '{':
+		return p.parseInlineTable()
+	default:
+		return p.parseScalar()
+	}
+}
+
+// parseBasicString reads a "..." string with escape sequences.
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++ // opening quote
+	var buf strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch c {
+		case '"':
+			p.pos++
+			return buf.String(), nil
+		case '\n':
+			return "", p.errf("newline in basic string")
+		case '\\':
+			p.pos++
+			if p.pos >= len(p.src) {
+				return "", p.errf("unterminated escape sequence")
+			}
+			esc, err := p.parseEscape()
+			if err != nil {
+				return "", err
+			}
+			buf.WriteString(esc)
+		default:
+			buf.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.errf("unterminated string")
+}
+
+func (p *tomlParser) parseEscape() (string, error) {
+	c := p.src[p.pos]
+	p.pos++
+	switch c {
+	case 'b':
+		return "\b", nil
+	case 't':
+		return "\t", nil
+	case 'n':
+		return "\n", nil
+	case 'f':
+		return "\f", nil
+	case 'r':
+		return "\r", nil
+	case '"':
+		return `"`, nil
+	case '\\':
+		return `\`, nil
+	case 'u', 'U':
+		width := 4
+		if c == 'U' {
+			width = 8
+		}
+		if p.pos+width > len(p.src) {
+			return "", p.errf("truncated unicode escape")
+		}
+		n, err := strconv.ParseUint(p.src[p.pos:p.pos+width], 16, 32)
+		if err != nil {
+			return "", p.errf("invalid unicode escape")
+		}
+		p.pos += width
+		return string(rune(n)), nil
+	}
+	return "", p.errf("invalid escape '\\%c'", c)
+}
+
+// parseLiteralString reads a '...' string with no escapes.
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++ // opening quote
+	end := strings.IndexByte(p.src[p.pos:], '\'')
+	if end < 0 || strings.Contains(p.src[p.pos:p.pos+end], "\n") {
+		return "", p.errf("unterminated string")
+	}
+	s := p.src[p.pos : p.pos+end]
+	p.pos += end + 1
+	return s, nil
+}
+
+// parseMultilineString reads a """...""" or ”'...”' block.
+func (p *tomlParser) parseMultilineString(delim string) (string, error) {
+	p.pos += len(delim)
+	end := strings.Index(p.src[p.pos:], delim)
+	if end < 0 {
+		return "", p.errf("unterminated multiline string")
+	}
+	s := p.src[p.pos : p.pos+end]
+	p.pos += end + len(delim)
+	p.line += strings.Count(s, "\n")
+	// A newline immediately after the opening delimiter is trimmed
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "\r\n"), "\n")
+	if delim == `"""` {
+		return p.unescapeMultiline(s)
+	}
+	return s, nil
+}
+
+// unescapeMultiline applies escape sequences inside a multiline basic string,
+// including line-ending backslashes that swallow following whitespace.
+func (p *tomlParser) unescapeMultiline(s string) (string, error) {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			buf.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			return "", p.errf("unterminated escape sequence")
+		}
+		if s[i] == '\n' || s[i] == ' ' || s[i] == '\t' || s[i] == '\r' {
+			for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
+				i++
+			}
+			i--
+			continue
+		}
+		sub := &tomlParser{src: s[i:], line: p.line}
+		esc, err := sub.parseEscape()
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(esc)
+		i += sub.pos - 1
+	}
+	return buf.String(), nil
+}
+
+// parseArray reads a [ ... ] value, which may span lines.
+func (p *tomlParser) parseArray() (any, error) {
+	p.pos++ // '['
+	items := []any{}
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.src) {
+			return nil, p.errf("unterminated array")
+		}
+		if p.src[p.pos] == ']' {
+			p.pos++
+			return items, nil
+		}
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		p.skipBlank()
+		if p.pos < len(p.src) && p.src[p.pos] == ',' {
+			p.pos++
+		}
+	}
+}
+
+// parseInlineTable reads a { k = v, ... } value on a single line.
+func (p *tomlParser) parseInlineTable() (any, error) {
+	p.pos++ // '{'
+	table := map[string]any{}
+	p.skipSpace()
+	if p.pos < len(p.src) && p.src[p.pos] == '}' {
+		p.pos++
+		return table, nil
+	}
+	for {
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return nil, p.errf("unterminated inline table")
+		}
+		switch p.src[p.pos] {
+		case '}':
+			p.pos++
+			return table, nil
+		case ',':
+			p.pos++
+			p.skipSpace()
+		default:
+			return nil, p.errf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+// parseScalar reads an unquoted token: boolean, number, or date/time (which
+// rage keeps as a string).
+func (p *tomlParser) parseScalar() (any, error) {
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(" \t\r\n,]}#", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	tok := p.src[start:p.pos]
+	if tok == "" {
+		return nil, p.errf("expected a value")
+	}
+
+	switch tok {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	}
+
+	// Dates and times stay as strings: 1979-05-27, 07:32:00,
+	// 1979-05-27T07:32:00Z ...
+	if isTOMLDateTime(tok) {
+		// A local date followed by a space-separated time forms one value
+		if p.pos < len(p.src) && p.src[p.pos] == ' ' {
+			rest := p.src[p.pos+1:]
+			timeEnd := 0
+			for timeEnd < len(rest) && !strings.ContainsRune(" \t\r\n,]}#", rune(rest[timeEnd])) {
+				timeEnd++
+			}
+			if timeEnd > 0 && isTOMLDateTime(rest[:timeEnd]) {
+				p.pos += 1 + timeEnd
+				return tok + " " + rest[:timeEnd], nil
+			}
+		}
+		return tok, nil
+	}
+
+	num := strings.ReplaceAll(tok, "_", "")
+	if n, err := strconv.ParseInt(num, 0, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(num, 64); err == nil {
+		return f, nil
+	}
+	return nil, p.errf("invalid value '%s'", tok)
+}
+
+// isTOMLDateTime reports whether tok looks like a TOML date, time or
+// date-time, e.g. 1979-05-27, 07:32:00 or 1979-05-27T07:32:00Z.
+func isTOMLDateTime(tok string) bool {
+	if len(tok) >= 10 && tok[4] == '-' && tok[7] == '-' && isDigits(tok[:4]) {
+		return true
+	}
+	return len(tok) >= 8 && tok[2] == ':' && tok[5] == ':' && isDigits(tok[:2])
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+// =============================================================================
+// Serialization
+// =============================================================================
+
+// writeTOMLTable emits one table: scalar keys first, then nested tables and
+// arrays of tables as [header] sections. Keys are sorted for stable output.
+func writeTOMLTable(buf *strings.Builder, table map[string]any, path []string) error {
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var tables, tableArrays []string
+	for _, k := range keys {
+		switch v := table[k].(type) {
+		case map[string]any:
+			tables = append(tables, k)
+		case []any:
+			if isTableArray(v) {
+				tableArrays = append(tableArrays, k)
+				continue
+			}
+			if err := writeTOMLKeyValue(buf, k, v); err != nil {
+				return err
+			}
+		default:
+			if err := writeTOMLKeyValue(buf, k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, k := range tables {
+		header := append(append([]string{}, path...), k)
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		fmt.Fprintf(buf, "[%s]\n", joinTOMLKeys(header))
+		if err := writeTOMLTable(buf, table[k].(map[string]any), header); err != nil {
+			return err
+		}
+	}
+	for _, k := range tableArrays {
+		header := append(append([]string{}, path...), k)
+		for _, item := range table[k].([]any) {
+			if buf.Len() > 0 {
+				buf.WriteByte('\n')
+			}
+			fmt.Fprintf(buf, "[[%s]]\n", joinTOMLKeys(header))
+			if err := writeTOMLTable(buf, item.(map[string]any), header); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isTableArray reports whether a non-empty array holds only tables, which
+// serializes as [[name]] sections rather than an inline array.
+func isTableArray(items []any) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if _, ok := item.(map[string]any); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func writeTOMLKeyValue(buf *strings.Builder, key string, v any) error {
+	encoded, err := encodeTOMLValue(v)
+	if err != nil {
+		return fmt.Errorf("key '%s': %s", key, err.Error())
+	}
+	fmt.Fprintf(buf, "%s = %s\n", encodeTOMLKey(key), encoded)
+	return nil
+}
+
+// encodeTOMLValue serializes a scalar, array or inline table.
+func encodeTOMLValue(v any) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "", fmt.Errorf("None is not TOML serializable")
+	case bool:
+		return strconv.FormatBool(val), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case float64:
+		switch {
+		case math.IsNaN(val):
+			return "nan", nil
+		case math.IsInf(val, 1):
+			return "inf", nil
+		case math.IsInf(val, -1):
+			return "-inf", nil
+		case val == math.Trunc(val) && math.Abs(val) < 1e15:
+			// Keep floats recognizable as floats on round-trip
+			return strconv.FormatFloat(val, 'f', 1, 64), nil
+		}
+		return strconv.FormatFloat(val, 'g', -1, 64), nil
+	case string:
+		return encodeTOMLString(val), nil
+	case []any:
+		parts := make([]string, len(val))
+		for i, item := range val {
+			encoded, err := encodeTOMLValue(item)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = encoded
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			encoded, err := encodeTOMLValue(val[k])
+			if err != nil {
+				return "", err
+			}
+			parts[i] = fmt.Sprintf("%s = %s", encodeTOMLKey(k), encoded)
+		}
+		return "{" + strings.Join(parts, ", ") + "}", nil
+	default:
+		return "", fmt.Errorf("value of type %T is not TOML serializable", v)
+	}
+}
+
+// joinTOMLKeys renders a table header path, quoting parts as needed.
+func joinTOMLKeys(path []string) string {
+	parts := make([]string, len(path))
+	for i, p := range path {
+		parts[i] = encodeTOMLKey(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+func encodeTOMLKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+	for i := 0; i < len(key); i++ {
+		if !isBareKeyChar(key[i]) {
+			return encodeTOMLString(key)
+		}
+	}
+	return key
+}
+
+func encodeTOMLString(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\r':
+			buf.WriteString(`\r`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
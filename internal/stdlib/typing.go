@@ -734,8 +734,46 @@ func typingCast(vm *runtime.VM) int {
 
 // typing.get_type_hints(obj) - get type hints for an object
 func typingGetTypeHints(vm *runtime.VM) int {
-	// Return an empty dict - actual type hints require introspection
-	vm.Push(runtime.NewDict())
+	hints := runtime.NewDict()
+	if vm.GetTop() < 1 {
+		vm.Push(hints)
+		return 1
+	}
+
+	copyHints := func(src *runtime.PyDict) {
+		for _, key := range src.Keys(vm) {
+			val, _ := src.DictGet(key, vm)
+			hints.DictSet(key, val, vm)
+		}
+	}
+
+	switch obj := vm.Get(1).(type) {
+	case *runtime.PyFunction:
+		if obj.Annotations != nil {
+			copyHints(obj.Annotations)
+		}
+	case *runtime.PyMethod:
+		if obj.Func != nil && obj.Func.Annotations != nil {
+			copyHints(obj.Func.Annotations)
+		}
+	case *runtime.PyClass:
+		// Walk the MRO from base to derived so derived annotations win
+		for i := len(obj.Mro) - 1; i >= 0; i-- {
+			if ann, ok := obj.Mro[i].Dict["__annotations__"]; ok {
+				if annDict, ok := ann.(*runtime.PyDict); ok {
+					copyHints(annDict)
+				}
+			}
+		}
+	case *runtime.PyModule:
+		if ann, ok := obj.Dict["__annotations__"]; ok {
+			if annDict, ok := ann.(*runtime.PyDict); ok {
+				copyHints(annDict)
+			}
+		}
+	}
+
+	vm.Push(hints)
 	return 1
 }
 
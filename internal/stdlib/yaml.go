@@ -0,0 +1,179 @@
+package stdlib
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"gopkg.in/yaml.v3"
+)
+
+// InitYAMLModule registers the yaml module. Only the safe subset is exposed:
+// safe_load parses scalars, sequences and mappings into Python values, and
+// safe_dump serializes them back; there is no object construction, so
+// untrusted documents cannot instantiate host types.
+func InitYAMLModule() {
+	runtime.NewModuleBuilder("yaml").
+		Doc("YAML parser and emitter (safe subset).").
+		Func("safe_load", yamlSafeLoad).
+		Func("safe_dump", yamlSafeDump).
+		Register()
+}
+
+// yamlSafeLoad parses a YAML document into Python values.
+// safe_load(s) -> object
+func yamlSafeLoad(vm *runtime.VM) int {
+	if !vm.RequireArgs("safe_load", 1) {
+		return 0
+	}
+	s := vm.CheckString(1)
+
+	var goVal any
+	if err := yaml.Unmarshal([]byte(s), &goVal); err != nil {
+		vm.RaiseError("yaml.YAMLError: %s", err.Error())
+		return 0
+	}
+
+	result, err := goDataToPython(goVal)
+	if err != nil {
+		vm.RaiseError("yaml.YAMLError: %s", err.Error())
+		return 0
+	}
+	vm.Push(result)
+	return 1
+}
+
+// yamlSafeDump serializes a Python object to a YAML string.
+// safe_dump(obj) -> str
+func yamlSafeDump(vm *runtime.VM) int {
+	if !vm.RequireArgs("safe_dump", 1) {
+		return 0
+	}
+	goVal, err := pythonToGoValue(vm.Get(1))
+	if err != nil {
+		vm.RaiseError("TypeError: %s", err.Error())
+		return 0
+	}
+	out, err := yaml.Marshal(goVal)
+	if err != nil {
+		vm.RaiseError("yaml.YAMLError: %s", err.Error())
+		return 0
+	}
+	vm.Push(runtime.NewString(string(out)))
+	return 1
+}
+
+// goDataToPython converts plain Go data decoded by the YAML and TOML
+// parsers to a Python value. Unlike the JSON converter it keeps whole
+// floats as floats, since those formats distinguish 1 from 1.0.
+func goDataToPython(v any) (runtime.Value, error) {
+	switch val := v.(type) {
+	case nil:
+		return runtime.None, nil
+	case bool:
+		return runtime.NewBool(val), nil
+	case int:
+		return runtime.NewInt(int64(val)), nil
+	case int64:
+		return runtime.NewInt(val), nil
+	case uint64:
+		return runtime.NewInt(int64(val)), nil
+	case float64:
+		return runtime.NewFloat(val), nil
+	case string:
+		return runtime.NewString(val), nil
+	case time.Time:
+		// Timestamps surface as ISO 8601 strings; rage has no datetime
+		// literal in YAML position
+		return runtime.NewString(val.Format(time.RFC3339)), nil
+	case []any:
+		items := make([]runtime.Value, len(val))
+		for i, item := range val {
+			pyVal, err := goDataToPython(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = pyVal
+		}
+		return runtime.NewList(items), nil
+	case map[string]any:
+		d := runtime.NewDict()
+		for k, item := range val {
+			pyVal, err := goDataToPython(item)
+			if err != nil {
+				return nil, err
+			}
+			d.Items[runtime.NewString(k)] = pyVal
+		}
+		return d, nil
+	case map[any]any:
+		d := runtime.NewDict()
+		for k, item := range val {
+			keyStr, ok := k.(string)
+			if !ok {
+				keyStr = fmt.Sprintf("%v", k)
+			}
+			pyVal, err := goDataToPython(item)
+			if err != nil {
+				return nil, err
+			}
+			d.Items[runtime.NewString(keyStr)] = pyVal
+		}
+		return d, nil
+	default:
+		return nil, fmt.Errorf("unsupported YAML value of type %T", v)
+	}
+}
+
+// pythonToGoValue converts a Python value to plain Go data for serializers
+// (YAML, TOML). Dict keys must be strings.
+func pythonToGoValue(v runtime.Value) (any, error) {
+	switch val := v.(type) {
+	case *runtime.PyNone, nil:
+		return nil, nil
+	case *runtime.PyBool:
+		return val.Value, nil
+	case *runtime.PyInt:
+		return val.Value, nil
+	case *runtime.PyFloat:
+		return val.Value, nil
+	case *runtime.PyString:
+		return val.Value, nil
+	case *runtime.PyList:
+		items := make([]any, len(val.Items))
+		for i, item := range val.Items {
+			goVal, err := pythonToGoValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = goVal
+		}
+		return items, nil
+	case *runtime.PyTuple:
+		items := make([]any, len(val.Items))
+		for i, item := range val.Items {
+			goVal, err := pythonToGoValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = goVal
+		}
+		return items, nil
+	case *runtime.PyDict:
+		m := make(map[string]any, len(val.Items))
+		for k, item := range val.Items {
+			keyStr, ok := k.(*runtime.PyString)
+			if !ok {
+				return nil, fmt.Errorf("dict keys must be strings, not %s", jsonTypeName(k))
+			}
+			goVal, err := pythonToGoValue(item)
+			if err != nil {
+				return nil, err
+			}
+			m[keyStr.Value] = goVal
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("object of type '%s' is not serializable", jsonTypeName(v))
+	}
+}
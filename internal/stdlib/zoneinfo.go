@@ -0,0 +1,175 @@
+package stdlib
+
+import (
+	"fmt"
+	"time"
+	// Embed the IANA database so ZoneInfo resolves keys even on hosts
+	// without a system zoneinfo directory
+	_ "time/tzdata"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// PyZoneInfo wraps a Go time.Location as a datetime timezone.
+type PyZoneInfo struct {
+	Key string
+	Loc *time.Location
+}
+
+func (z *PyZoneInfo) Type() string   { return "ZoneInfo" }
+func (z *PyZoneInfo) String() string { return fmt.Sprintf("zoneinfo.ZoneInfo(key='%s')", z.Key) }
+
+// InitZoneinfoModule registers the zoneinfo module. ZoneInfo(key) looks the
+// zone up with time.LoadLocation, so anything the IANA database names
+// ("Europe/Paris", "UTC", ...) is available. Offsets are reported relative
+// to the wall-clock datetime passed in, matching CPython's zoneinfo.
+func InitZoneinfoModule() {
+	zoneinfoMT := &runtime.TypeMetatable{
+		Name: "ZoneInfo",
+		Methods: map[string]runtime.GoFunction{
+			"key":       zoneinfoKey,
+			"utcoffset": zoneinfoUtcoffset,
+			"tzname":    zoneinfoTzname,
+			"dst":       zoneinfoDst,
+		},
+	}
+	runtime.RegisterTypeMetatable("ZoneInfo", zoneinfoMT)
+
+	runtime.NewModuleBuilder("zoneinfo").
+		Doc("IANA time zone support.").
+		Func("ZoneInfo", zoneinfoNew).
+		Register()
+}
+
+func wrapZoneInfo(z *PyZoneInfo) *runtime.PyUserData {
+	ud := runtime.NewUserData(z)
+	ud.Metatable = runtime.NewDict()
+	ud.Metatable.Items[runtime.NewString("__type__")] = runtime.NewString("ZoneInfo")
+	return ud
+}
+
+// checkZoneInfo fetches argument n as a ZoneInfo, raising on mismatch.
+func checkZoneInfo(vm *runtime.VM, n int) *PyZoneInfo {
+	if ud := vm.ToUserData(n); ud != nil {
+		if z, ok := ud.Value.(*PyZoneInfo); ok {
+			return z
+		}
+	}
+	vm.RaiseError("expected ZoneInfo object")
+	return nil
+}
+
+// checkDatetimeArg fetches argument n as a datetime, raising on mismatch.
+func checkDatetimeArg(vm *runtime.VM, n int) *PyDatetime {
+	if ud := vm.ToUserData(n); ud != nil {
+		if dt, ok := ud.Value.(*PyDatetime); ok {
+			return dt
+		}
+	}
+	vm.RaiseError("expected datetime object")
+	return nil
+}
+
+// inLocation interprets dt as wall-clock time in z's location.
+func (z *PyZoneInfo) inLocation(dt *PyDatetime) time.Time {
+	return time.Date(dt.Year, time.Month(dt.Month), dt.Day,
+		dt.Hour, dt.Minute, dt.Second, dt.Microsecond*1000, z.Loc)
+}
+
+// offsetTimedelta normalizes an offset in seconds to CPython timedelta form
+// (seconds in [0, 86400), days possibly negative).
+func offsetTimedelta(seconds int) *PyTimedelta {
+	days := int64(seconds) / 86400
+	secs := int64(seconds) % 86400
+	if secs < 0 {
+		days--
+		secs += 86400
+	}
+	return &PyTimedelta{Days: days, Seconds: secs}
+}
+
+// standardOffset returns z's non-DST UTC offset for a given year, taken as
+// the smaller of the January and July offsets.
+func (z *PyZoneInfo) standardOffset(year int) int {
+	_, jan := time.Date(year, time.January, 1, 0, 0, 0, 0, z.Loc).Zone()
+	_, jul := time.Date(year, time.July, 1, 0, 0, 0, 0, z.Loc).Zone()
+	if jul < jan {
+		return jul
+	}
+	return jan
+}
+
+// zoneinfoNew constructs a zone from an IANA key.
+// ZoneInfo(key) -> ZoneInfo
+func zoneinfoNew(vm *runtime.VM) int {
+	if !vm.RequireArgs("ZoneInfo", 1) {
+		return 0
+	}
+	key := vm.CheckString(1)
+	loc, err := time.LoadLocation(key)
+	if err != nil {
+		vm.RaiseError("ZoneInfoNotFoundError: no time zone found with key '%s'", key)
+		return 0
+	}
+	vm.Push(wrapZoneInfo(&PyZoneInfo{Key: key, Loc: loc}))
+	return 1
+}
+
+// zoneinfoKey returns the IANA key the zone was created with.
+// tz.key() -> str
+func zoneinfoKey(vm *runtime.VM) int {
+	z := checkZoneInfo(vm, 1)
+	if z == nil {
+		return 0
+	}
+	vm.Push(runtime.NewString(z.Key))
+	return 1
+}
+
+// zoneinfoUtcoffset returns the UTC offset in effect at dt.
+// tz.utcoffset(dt) -> timedelta
+func zoneinfoUtcoffset(vm *runtime.VM) int {
+	z := checkZoneInfo(vm, 1)
+	if z == nil {
+		return 0
+	}
+	dt := checkDatetimeArg(vm, 2)
+	if dt == nil {
+		return 0
+	}
+	_, offset := z.inLocation(dt).Zone()
+	vm.Push(wrapTimedelta(offsetTimedelta(offset)))
+	return 1
+}
+
+// zoneinfoTzname returns the zone abbreviation in effect at dt.
+// tz.tzname(dt) -> str
+func zoneinfoTzname(vm *runtime.VM) int {
+	z := checkZoneInfo(vm, 1)
+	if z == nil {
+		return 0
+	}
+	dt := checkDatetimeArg(vm, 2)
+	if dt == nil {
+		return 0
+	}
+	name, _ := z.inLocation(dt).Zone()
+	vm.Push(runtime.NewString(name))
+	return 1
+}
+
+// zoneinfoDst returns the DST adjustment in effect at dt.
+// tz.dst(dt) -> timedelta
+func zoneinfoDst(vm *runtime.VM) int {
+	z := checkZoneInfo(vm, 1)
+	if z == nil {
+		return 0
+	}
+	dt := checkDatetimeArg(vm, 2)
+	if dt == nil {
+		return 0
+	}
+	_, offset := z.inLocation(dt).Zone()
+	vm.Push(wrapTimedelta(offsetTimedelta(offset - z.standardOffset(dt.Year))))
+	return 1
+}
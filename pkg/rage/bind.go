@@ -0,0 +1,262 @@
+package rage
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// This file implements State.BindGlobals, which populates a Go struct from
+// Python globals after Run, replacing manual GetGlobal/AsInt extraction
+// boilerplate. Field mapping is controlled with `rage` struct tags:
+//
+//	type Config struct {
+//	    Host     string         `rage:"host"`
+//	    Port     int64          `rage:"port"`
+//	    Debug    bool           `rage:"debug,optional"`
+//	    Features map[string]any `rage:"features,optional"`
+//	    ignored  string         // unexported fields are skipped
+//	}
+//
+// The tag names the Python global; without a tag the Go field name is used
+// as-is. Fields are required by default — a missing global is an error —
+// and `,optional` leaves the field at its zero value instead. A tag of "-"
+// skips the field.
+
+// BindError reports a single field that could not be bound.
+type BindError struct {
+	Field  string // Go struct field name
+	Global string // Python global name
+	Err    error
+}
+
+func (e *BindError) Error() string {
+	return fmt.Sprintf("field %s (global '%s'): %v", e.Field, e.Global, e.Err)
+}
+
+// BindErrors wraps the per-field errors from a BindGlobals call.
+type BindErrors struct {
+	Errors []*BindError
+}
+
+func (e *BindErrors) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	msgs := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		msgs[i] = fieldErr.Error()
+	}
+	return fmt.Sprintf("%d binding errors: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the first error for errors.Is/As compatibility.
+func (e *BindErrors) Unwrap() error {
+	if len(e.Errors) > 0 {
+		return e.Errors[0]
+	}
+	return nil
+}
+
+// BindGlobals populates dest, a pointer to a struct, from the State's
+// globals with per-field type conversion. All fields are attempted even if
+// some fail; the returned error is a *BindErrors listing every field that
+// was missing or had the wrong type.
+func (s *State) BindGlobals(dest any) error {
+	if err := s.checkClosed(); err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("BindGlobals: dest must be a non-nil pointer to a struct, got %T", dest)
+	}
+	elem := rv.Elem()
+	elemType := elem.Type()
+
+	var errs []*BindError
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, optional := parseBindTag(field)
+		if name == "-" {
+			continue
+		}
+
+		val := s.GetGlobal(name)
+		if val == nil || IsNone(val) {
+			if !optional {
+				errs = append(errs, &BindError{
+					Field:  field.Name,
+					Global: name,
+					Err:    fmt.Errorf("global is not defined"),
+				})
+			}
+			continue
+		}
+		if err := bindValue(val, elem.Field(i)); err != nil {
+			errs = append(errs, &BindError{Field: field.Name, Global: name, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return &BindErrors{Errors: errs}
+	}
+	return nil
+}
+
+// parseBindTag returns the global name and optional flag for a struct field.
+func parseBindTag(field reflect.StructField) (name string, optional bool) {
+	tag, ok := field.Tag.Lookup("rage")
+	if !ok {
+		return field.Name, false
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "optional" {
+			optional = true
+		}
+	}
+	return name, optional
+}
+
+// valueType is the reflect.Type of the Value interface, so fields declared
+// as rage.Value receive the value unconverted.
+var valueType = reflect.TypeOf((*Value)(nil)).Elem()
+
+// bindValue converts a Python value into the given Go destination,
+// recursing through slices, maps, pointers, and nested structs.
+func bindValue(v Value, dest reflect.Value) error {
+	destType := dest.Type()
+	if destType == valueType {
+		dest.Set(reflect.ValueOf(v))
+		return nil
+	}
+
+	switch destType.Kind() {
+	case reflect.Bool:
+		b, ok := AsBool(v)
+		if !ok {
+			return typeMismatch("bool", v)
+		}
+		dest.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := AsInt(v)
+		if !ok {
+			return typeMismatch("int", v)
+		}
+		if dest.OverflowInt(n) {
+			return fmt.Errorf("value %d overflows %s", n, destType)
+		}
+		dest.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := AsInt(v)
+		if !ok {
+			return typeMismatch("int", v)
+		}
+		if n < 0 || dest.OverflowUint(uint64(n)) {
+			return fmt.Errorf("value %d overflows %s", n, destType)
+		}
+		dest.SetUint(uint64(n))
+
+	case reflect.Float32, reflect.Float64:
+		f, ok := AsFloat(v)
+		if !ok {
+			return typeMismatch("float", v)
+		}
+		dest.SetFloat(f)
+
+	case reflect.String:
+		str, ok := AsString(v)
+		if !ok {
+			return typeMismatch("str", v)
+		}
+		dest.SetString(str)
+
+	case reflect.Slice:
+		items, ok := AsList(v)
+		if !ok {
+			return typeMismatch("list", v)
+		}
+		out := reflect.MakeSlice(destType, len(items), len(items))
+		for i, item := range items {
+			if err := bindValue(item, out.Index(i)); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+		dest.Set(out)
+
+	case reflect.Map:
+		if destType.Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map key type %s", destType.Key())
+		}
+		items, ok := AsDict(v)
+		if !ok {
+			return typeMismatch("dict", v)
+		}
+		out := reflect.MakeMapWithSize(destType, len(items))
+		for key, item := range items {
+			entry := reflect.New(destType.Elem()).Elem()
+			if err := bindValue(item, entry); err != nil {
+				return fmt.Errorf("key '%s': %w", key, err)
+			}
+			out.SetMapIndex(reflect.ValueOf(key), entry)
+		}
+		dest.Set(out)
+
+	case reflect.Ptr:
+		out := reflect.New(destType.Elem())
+		if err := bindValue(v, out.Elem()); err != nil {
+			return err
+		}
+		dest.Set(out)
+
+	case reflect.Struct:
+		items, ok := AsDict(v)
+		if !ok {
+			return typeMismatch("dict", v)
+		}
+		for i := 0; i < destType.NumField(); i++ {
+			field := destType.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, optional := parseBindTag(field)
+			if name == "-" {
+				continue
+			}
+			item, exists := items[name]
+			if !exists {
+				if !optional {
+					return fmt.Errorf("key '%s' is missing", name)
+				}
+				continue
+			}
+			if err := bindValue(item, dest.Field(i)); err != nil {
+				return fmt.Errorf("key '%s': %w", name, err)
+			}
+		}
+
+	case reflect.Interface:
+		if destType.NumMethod() != 0 {
+			return fmt.Errorf("unsupported field type %s", destType)
+		}
+		if goVal := v.GoValue(); goVal != nil {
+			dest.Set(reflect.ValueOf(goVal))
+		}
+
+	default:
+		return fmt.Errorf("unsupported field type %s", destType)
+	}
+	return nil
+}
+
+func typeMismatch(want string, got Value) error {
+	return fmt.Errorf("expected %s, got %s", want, got.Type())
+}
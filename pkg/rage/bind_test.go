@@ -0,0 +1,149 @@
+package rage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBindGlobals(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`
+host = "localhost"
+port = 8080
+debug = True
+ratio = 0.75
+tags = ["a", "b", "c"]
+limits = {"cpu": 4, "mem": 1024}
+`)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	var cfg struct {
+		Host   string           `rage:"host"`
+		Port   int              `rage:"port"`
+		Debug  bool             `rage:"debug"`
+		Ratio  float64          `rage:"ratio"`
+		Tags   []string         `rage:"tags"`
+		Limits map[string]int64 `rage:"limits"`
+	}
+	if err := state.BindGlobals(&cfg); err != nil {
+		t.Fatalf("BindGlobals failed: %v", err)
+	}
+	if cfg.Host != "localhost" || cfg.Port != 8080 || !cfg.Debug || cfg.Ratio != 0.75 {
+		t.Errorf("unexpected scalars: %+v", cfg)
+	}
+	if len(cfg.Tags) != 3 || cfg.Tags[2] != "c" {
+		t.Errorf("unexpected tags: %v", cfg.Tags)
+	}
+	if cfg.Limits["cpu"] != 4 || cfg.Limits["mem"] != 1024 {
+		t.Errorf("unexpected limits: %v", cfg.Limits)
+	}
+}
+
+func TestBindGlobals_OptionalAndMissing(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	if _, err := state.Run(`present = 1`); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	var ok struct {
+		Present int64 `rage:"present"`
+		Absent  int64 `rage:"absent,optional"`
+	}
+	if err := state.BindGlobals(&ok); err != nil {
+		t.Fatalf("BindGlobals failed: %v", err)
+	}
+	if ok.Present != 1 || ok.Absent != 0 {
+		t.Errorf("unexpected values: %+v", ok)
+	}
+
+	var missing struct {
+		Absent int64 `rage:"absent"`
+	}
+	err := state.BindGlobals(&missing)
+	if err == nil {
+		t.Fatal("expected error for missing required global")
+	}
+	var bindErrs *BindErrors
+	if !errors.As(err, &bindErrs) {
+		t.Fatalf("expected *BindErrors, got %T", err)
+	}
+	if bindErrs.Errors[0].Global != "absent" {
+		t.Errorf("unexpected bind error: %v", err)
+	}
+}
+
+func TestBindGlobals_ReportsAllFieldErrors(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	if _, err := state.Run(`port = "not a number"`); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	var cfg struct {
+		Port int64 `rage:"port"`
+		Host string
+	}
+	err := state.BindGlobals(&cfg)
+	var bindErrs *BindErrors
+	if !errors.As(err, &bindErrs) {
+		t.Fatalf("expected *BindErrors, got %v", err)
+	}
+	if len(bindErrs.Errors) != 2 {
+		t.Fatalf("expected 2 field errors, got %d: %v", len(bindErrs.Errors), err)
+	}
+	if !strings.Contains(bindErrs.Errors[0].Error(), "expected int, got str") {
+		t.Errorf("unexpected first error: %v", bindErrs.Errors[0])
+	}
+}
+
+func TestBindGlobals_NestedStruct(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`db = {"name": "game", "pool": {"size": 10}}`)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	var cfg struct {
+		DB struct {
+			Name string `rage:"name"`
+			Pool struct {
+				Size int64 `rage:"size"`
+			} `rage:"pool"`
+		} `rage:"db"`
+	}
+	if err := state.BindGlobals(&cfg); err != nil {
+		t.Fatalf("BindGlobals failed: %v", err)
+	}
+	if cfg.DB.Name != "game" || cfg.DB.Pool.Size != 10 {
+		t.Errorf("unexpected nested values: %+v", cfg)
+	}
+}
+
+func TestBindGlobals_RawValueField(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	if _, err := state.Run(`anything = [1, "two", 3.0]`); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	var out struct {
+		Anything Value `rage:"anything"`
+	}
+	if err := state.BindGlobals(&out); err != nil {
+		t.Fatalf("BindGlobals failed: %v", err)
+	}
+	if _, ok := out.Anything.(ListValue); !ok {
+		t.Errorf("expected ListValue, got %T", out.Anything)
+	}
+}
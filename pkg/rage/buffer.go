@@ -0,0 +1,128 @@
+package rage
+
+import (
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// Buffer views over Go slices.
+//
+// ByteArray and FloatArray expose a Go slice to Python without copying it:
+// the Python object and the Go slice share the same backing storage, so
+// element reads and writes on either side are visible to the other. This is
+// intended for image/asset processing scripts that would otherwise pay a
+// per-element conversion for every pixel or sample. Use Detach to take a
+// private copy when the host buffer will be reused.
+
+// ByteArrayValue is a mutable bytearray sharing storage with a Go []byte.
+type ByteArrayValue struct {
+	ba *runtime.PyByteArray
+}
+
+func (v ByteArrayValue) Type() string   { return "bytearray" }
+func (v ByteArrayValue) String() string { return v.ba.String() }
+func (v ByteArrayValue) GoValue() any   { return v.ba.Value }
+
+// Bytes returns the underlying byte slice without copying. Note that
+// Python-side operations that resize the bytearray (append, del, slice
+// assignment of a different length) reallocate the storage, after which the
+// slice returned here is the only up-to-date view.
+func (v ByteArrayValue) Bytes() []byte { return v.ba.Value }
+
+func (v ByteArrayValue) Detach() Value {
+	buf := make([]byte, len(v.ba.Value))
+	copy(buf, v.ba.Value)
+	return ByteArrayValue{ba: &runtime.PyByteArray{Value: buf}}
+}
+func (v ByteArrayValue) toRuntime() runtime.Value { return v.ba }
+
+// ByteArray creates a mutable Python bytearray that shares the given slice.
+// Unlike Bytes, scripts can assign elements in place (buf[0] = 255) and the
+// host sees the change through the original slice.
+func ByteArray(v []byte) Value {
+	return ByteArrayValue{ba: &runtime.PyByteArray{Value: v}}
+}
+
+// FloatArrayValue is a mutable float64 sequence sharing storage with a Go
+// []float64. In Python it supports len, indexing, slicing, iteration, and
+// element assignment.
+type FloatArrayValue struct {
+	fa *runtime.PyFloatArray
+}
+
+func (v FloatArrayValue) Type() string   { return "floatarray" }
+func (v FloatArrayValue) String() string { return v.fa.String() }
+func (v FloatArrayValue) GoValue() any   { return v.fa.Values }
+
+// Floats returns the underlying float slice without copying.
+func (v FloatArrayValue) Floats() []float64 { return v.fa.Values }
+
+func (v FloatArrayValue) Detach() Value {
+	buf := make([]float64, len(v.fa.Values))
+	copy(buf, v.fa.Values)
+	return FloatArrayValue{fa: &runtime.PyFloatArray{Values: buf}}
+}
+func (v FloatArrayValue) toRuntime() runtime.Value { return v.fa }
+
+// FloatArray creates a Python floatarray that shares the given slice, so a
+// script can read and update host numeric data in place:
+//
+//	samples := make([]float64, 1<<20)
+//	state.SetGlobal("samples", rage.FloatArray(samples))
+//	state.Run("samples[0] = samples[1] * 0.5")
+func FloatArray(v []float64) Value {
+	return FloatArrayValue{fa: &runtime.PyFloatArray{Values: v}}
+}
+
+// IntArrayValue is the int64 counterpart of FloatArrayValue.
+type IntArrayValue struct {
+	ia *runtime.PyIntArray
+}
+
+func (v IntArrayValue) Type() string   { return "intarray" }
+func (v IntArrayValue) String() string { return v.ia.String() }
+func (v IntArrayValue) GoValue() any   { return v.ia.Values }
+
+// Ints returns the underlying int slice without copying.
+func (v IntArrayValue) Ints() []int64 { return v.ia.Values }
+
+func (v IntArrayValue) Detach() Value {
+	buf := make([]int64, len(v.ia.Values))
+	copy(buf, v.ia.Values)
+	return IntArrayValue{ia: &runtime.PyIntArray{Values: buf}}
+}
+func (v IntArrayValue) toRuntime() runtime.Value { return v.ia }
+
+// IntArray creates a Python intarray that shares the given slice.
+func IntArray(v []int64) Value {
+	return IntArrayValue{ia: &runtime.PyIntArray{Values: v}}
+}
+
+// AsByteSlice returns the backing storage of a bytes or bytearray value
+// without copying, or nil if the value is neither.
+func AsByteSlice(v Value) ([]byte, bool) {
+	switch bv := v.(type) {
+	case BytesValue:
+		return bv.value, true
+	case ByteArrayValue:
+		return bv.ba.Value, true
+	}
+	return nil, false
+}
+
+// AsFloatSlice returns the backing storage of a floatarray value without
+// copying, or nil if the value is not a floatarray.
+func AsFloatSlice(v Value) ([]float64, bool) {
+	if fv, ok := v.(FloatArrayValue); ok {
+		return fv.fa.Values, true
+	}
+	return nil, false
+}
+
+// AsIntSlice returns the backing storage of an intarray value without
+// copying, or nil if the value is not an intarray.
+func AsIntSlice(v Value) ([]int64, bool) {
+	if iv, ok := v.(IntArrayValue); ok {
+		return iv.ia.Values, true
+	}
+	return nil, false
+}
@@ -0,0 +1,131 @@
+package rage
+
+import "testing"
+
+func TestBytes_SharesStorage(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	buf := []byte{1, 2, 3}
+	state.SetGlobal("data", Bytes(buf))
+
+	// The script sees mutations the host makes to the shared buffer
+	buf[0] = 99
+	_, err := state.Run(`first = data[0]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := state.GetGlobalInt("first"); v != 99 {
+		t.Errorf("expected shared view to see 99, got %d", v)
+	}
+}
+
+func TestByteArray_MutableFromPython(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	pixels := []byte{0, 0, 0, 0}
+	state.SetGlobal("pixels", ByteArray(pixels))
+	_, err := state.Run(`
+for i in range(len(pixels)):
+    pixels[i] = i * 10
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, want := range []byte{0, 10, 20, 30} {
+		if pixels[i] != want {
+			t.Errorf("pixels[%d] = %d, want %d", i, pixels[i], want)
+		}
+	}
+}
+
+func TestAsByteSlice(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`blob = bytes([5, 6, 7])`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	raw, ok := AsByteSlice(state.GetGlobal("blob"))
+	if !ok {
+		t.Fatal("expected AsByteSlice to accept a bytes global")
+	}
+	if len(raw) != 3 || raw[0] != 5 || raw[2] != 7 {
+		t.Errorf("unexpected bytes: %v", raw)
+	}
+
+	if _, ok := AsByteSlice(Int(42)); ok {
+		t.Error("expected AsByteSlice to reject an int")
+	}
+}
+
+func TestFloatArray(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	samples := []float64{1.0, 2.0, 3.0, 4.0}
+	state.SetGlobal("samples", FloatArray(samples))
+	_, err := state.Run(`
+n = len(samples)
+total = sum(samples)
+tail = samples[-1]
+window = samples[1:3]
+for i in range(n):
+    samples[i] = samples[i] * 0.5
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := state.GetGlobalInt("n"); v != 4 {
+		t.Errorf("expected len 4, got %d", v)
+	}
+	if v, _ := state.GetGlobalFloat("total"); v != 10.0 {
+		t.Errorf("expected sum 10.0, got %v", v)
+	}
+	if v, _ := state.GetGlobalFloat("tail"); v != 4.0 {
+		t.Errorf("expected negative indexing to yield 4.0, got %v", v)
+	}
+
+	// Slicing copies into a fresh floatarray
+	window, ok := AsFloatSlice(state.GetGlobal("window"))
+	if !ok {
+		t.Fatal("expected slice result to be a floatarray")
+	}
+	if len(window) != 2 || window[0] != 2.0 || window[1] != 3.0 {
+		t.Errorf("unexpected window: %v", window)
+	}
+
+	// In-place assignment went through the shared storage
+	for i, want := range []float64{0.5, 1.0, 1.5, 2.0} {
+		if samples[i] != want {
+			t.Errorf("samples[%d] = %v, want %v", i, samples[i], want)
+		}
+	}
+}
+
+func TestFloatArray_Repr(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetGlobal("arr", FloatArray([]float64{1.5, 2.0}))
+	_, err := state.Run(`text = repr(arr)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := state.GetGlobalString("text"); v != "floatarray([1.5, 2.0])" {
+		t.Errorf("unexpected repr: %q", v)
+	}
+}
+
+func TestFloatArray_RejectsNonNumericAssignment(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetGlobal("arr", FloatArray([]float64{1.0}))
+	_, err := state.Run(`arr[0] = "nope"`)
+	if err == nil {
+		t.Fatal("expected a TypeError assigning a string into a floatarray")
+	}
+}
@@ -0,0 +1,75 @@
+package rage
+
+import (
+	"fmt"
+)
+
+// Notebook-style incremental execution.
+//
+// RunCell executes a snippet against the state's accumulated globals, like
+// one cell of a notebook: definitions from earlier cells stay visible, a
+// trailing expression becomes the cell's displayed value, and every cell is
+// recorded in the state's history so console and notebook UIs can replay or
+// list what has run.
+
+// Cell records one RunCell invocation.
+type Cell struct {
+	Index  int    // Position in the history, starting at 0
+	Source string // The source text as submitted
+	Result Value  // Value of the trailing expression, or nil
+	Err    error  // Compile or runtime error, or nil
+}
+
+// RunCell compiles and executes source against the state's accumulated
+// globals. If the cell ends with an expression statement, its value is
+// returned (like a notebook cell's output); otherwise the result is nil.
+// The cell is appended to the history either way, including on error.
+func (s *State) RunCell(source string) (Value, error) {
+	vm := s.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
+	}
+
+	s.mu.Lock()
+	index := len(s.cells)
+	s.mu.Unlock()
+
+	result, err := s.runCell(source, fmt.Sprintf("<cell %d>", index))
+
+	s.mu.Lock()
+	s.cells = append(s.cells, Cell{Index: index, Source: source, Result: result, Err: err})
+	s.mu.Unlock()
+	return result, err
+}
+
+// CellHistory returns a copy of the cells run so far, in order.
+func (s *State) CellHistory() []Cell {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	history := make([]Cell, len(s.cells))
+	copy(history, s.cells)
+	return history
+}
+
+// ClearCellHistory forgets the recorded cells. Globals defined by earlier
+// cells are unaffected; cell numbering restarts at 0.
+func (s *State) ClearCellHistory() {
+	s.mu.Lock()
+	s.cells = nil
+	s.mu.Unlock()
+}
+
+// runCell executes one cell through the shared REPL-semantics path; a cell
+// without a trailing expression reports nil rather than None so UIs can skip
+// displaying an output.
+func (s *State) runCell(source, filename string) (Value, error) {
+	_, exprText := splitTrailingExpr(source, filename)
+	result, err := s.RunWithFilename(source, filename)
+	if err != nil {
+		return nil, err
+	}
+	if exprText == "" {
+		return nil, nil
+	}
+	return result, nil
+}
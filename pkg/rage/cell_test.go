@@ -0,0 +1,119 @@
+package rage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunCell_TrailingExpressionValue(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	v, err := state.RunCell(`
+x = 10
+x * 2
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := AsInt(v); n != 20 {
+		t.Errorf("expected 20, got %v", v)
+	}
+}
+
+func TestRunCell_GlobalsAccumulate(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	if _, err := state.RunCell(`
+def double(n):
+    return n * 2
+`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v, err := state.RunCell(`double(21)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := AsInt(v); n != 42 {
+		t.Errorf("expected 42, got %v", v)
+	}
+}
+
+func TestRunCell_NoTrailingExpression(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	v, err := state.RunCell(`y = 5`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != nil {
+		t.Errorf("expected nil for a statement-only cell, got %v", v)
+	}
+	if n, _ := state.GetGlobalInt("y"); n != 5 {
+		t.Errorf("expected y=5, got %d", n)
+	}
+}
+
+func TestRunCell_History(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.RunCell(`a = 1`)
+	state.RunCell(`a + 1`)
+	state.RunCell(`undefined_name`)
+
+	history := state.CellHistory()
+	if len(history) != 3 {
+		t.Fatalf("expected 3 cells, got %d", len(history))
+	}
+	if history[0].Index != 0 || history[0].Source != `a = 1` || history[0].Err != nil {
+		t.Errorf("unexpected first cell: %+v", history[0])
+	}
+	if n, _ := AsInt(history[1].Result); n != 2 {
+		t.Errorf("expected second cell result 2, got %v", history[1].Result)
+	}
+	if history[2].Err == nil {
+		t.Error("expected the failing cell to record its error")
+	}
+
+	state.ClearCellHistory()
+	if len(state.CellHistory()) != 0 {
+		t.Error("expected an empty history after ClearCellHistory")
+	}
+	if n, _ := state.GetGlobalInt("a"); n != 1 {
+		t.Error("expected globals to survive ClearCellHistory")
+	}
+}
+
+func TestRunCell_CompileErrorMentionsCell(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.RunCell(`def oops(:`)
+	if err == nil {
+		t.Fatal("expected a compile error")
+	}
+	if !strings.Contains(err.Error(), "cell 0") {
+		t.Errorf("expected the error to name the cell, got %v", err)
+	}
+}
+
+func TestRunCell_MultilineTrailingExpression(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	v, err := state.RunCell(`
+nums = [1, 2, 3, 4]
+str([n * n
+     for n in nums
+     if n % 2 == 0])
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, _ := AsString(v); s != "[4, 16]" {
+		t.Errorf("expected [4, 16], got %v", v)
+	}
+}
@@ -3,6 +3,8 @@ package rage
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/ATSOTECK/rage/internal/runtime"
 )
@@ -40,6 +42,24 @@ func RuntimeError(msg string) error {
 	return fmt.Errorf("RuntimeError: %s", msg)
 }
 
+// PyErrorer lets a Go error returned from a callback choose the Python
+// exception it raises, instead of relying on the "TypeError: ..." message
+// prefixes the helpers above produce. It is checked with errors.As, so it
+// works through wrapped errors.
+type PyErrorer = runtime.PyErrorer
+
+// MapError registers a sentinel error mapping for Go callbacks: any returned
+// error matching sentinel via errors.Is raises the named exception type, e.g.
+// state.MapError(os.ErrNotExist, "FileNotFoundError"). Mappings are checked
+// in registration order, after PyErrorer but before message-prefix matching;
+// errors that match nothing still default to RuntimeError, with any wrapped
+// cause exposed as __cause__.
+func (s *State) MapError(sentinel error, excType string) {
+	if vm := s.liveVM(); vm != nil {
+		vm.MapError(sentinel, excType)
+	}
+}
+
 // Object wraps a Python instance, providing Go methods to read and write attributes on self.
 type Object struct {
 	inst *runtime.PyInstance
@@ -91,6 +111,61 @@ func (o Object) Delete(name string) {
 	}
 }
 
+// GoState returns the hidden per-instance Go state attached with
+// ClassBuilder.WithGoState, or nil if the class declares none.
+func (o Object) GoState() any {
+	return o.inst.GoState
+}
+
+// SetGoState replaces the instance's hidden Go state.
+func (o Object) SetGoState(v any) {
+	o.inst.GoState = v
+}
+
+// GoStateAs returns the instance's hidden Go state as type T.
+func GoStateAs[T any](o Object) (T, bool) {
+	v, ok := o.inst.GoState.(T)
+	return v, ok
+}
+
+// CallVirtual calls the named method on the instance with Python's attribute
+// lookup rules: the class MRO is walked, so when a Python subclass overrides a
+// method defined in Go, the override is what runs. This is the dispatch path
+// for engine-driven callbacks such as update() on objects scripts may have
+// subclassed. The method is looked up on the instance, bound, and called with
+// the given arguments.
+func (o Object) CallVirtual(s *State, name string, args ...Value) (Value, error) {
+	return o.CallVirtualKw(s, name, args, nil)
+}
+
+// CallVirtualKw is CallVirtual with keyword argument support.
+func (o Object) CallVirtualKw(s *State, name string, args []Value, kwargs map[string]Value) (Value, error) {
+	vm := s.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
+	}
+	method, err := vm.GetAttr(o.inst, name)
+	if err != nil {
+		return nil, err
+	}
+	rtArgs := make([]runtime.Value, len(args))
+	for i, a := range args {
+		rtArgs[i] = toRuntime(a)
+	}
+	var rtKwargs map[string]runtime.Value
+	if len(kwargs) > 0 {
+		rtKwargs = make(map[string]runtime.Value, len(kwargs))
+		for k, v := range kwargs {
+			rtKwargs[k] = toRuntime(v)
+		}
+	}
+	result, err := vm.Call(method, rtArgs, rtKwargs)
+	if err != nil {
+		return nil, err
+	}
+	return fromRuntime(result), nil
+}
+
 // ClassName returns the name of the instance's class.
 func (o Object) ClassName() string {
 	return o.inst.Class.Name
@@ -110,6 +185,27 @@ func (o Object) String() string { return o.inst.String() }
 // GoValue returns the underlying *runtime.PyInstance.
 func (o Object) GoValue() any { return o.inst }
 
+// Detach returns a snapshot of the instance with deep-copied attributes, so
+// later Python code cannot mutate it and it stays valid after the State is
+// closed. The class itself is shared, not copied; calling methods on a
+// detached object still requires a live State.
+func (o Object) Detach() Value {
+	snapshot := &runtime.PyInstance{Class: o.inst.Class}
+	if o.inst.Dict != nil {
+		snapshot.Dict = make(map[string]runtime.Value, len(o.inst.Dict))
+		for k, v := range o.inst.Dict {
+			snapshot.Dict[k] = toRuntime(fromRuntime(v).Detach())
+		}
+	}
+	if o.inst.Slots != nil {
+		snapshot.Slots = make(map[string]runtime.Value, len(o.inst.Slots))
+		for k, v := range o.inst.Slots {
+			snapshot.Slots[k] = toRuntime(fromRuntime(v).Detach())
+		}
+	}
+	return Object{inst: snapshot}
+}
+
 // toRuntime returns the underlying runtime value.
 func (o Object) toRuntime() runtime.Value { return o.inst }
 
@@ -131,6 +227,13 @@ func (c ClassValue) NewInstance() Object {
 	return Object{inst: inst}
 }
 
+// SetAttr sets a class-level attribute after the class has been built. This
+// is the way to install constants whose values are instances of the class
+// itself (e.g. Vec2.ORIGIN), which cannot exist before Build returns.
+func (c ClassValue) SetAttr(name string, val Value) {
+	c.class.Dict[name] = toRuntime(val)
+}
+
 // Type returns "type".
 func (c ClassValue) Type() string { return "type" }
 
@@ -140,9 +243,59 @@ func (c ClassValue) String() string { return c.class.String() }
 // GoValue returns the underlying *runtime.PyClass.
 func (c ClassValue) GoValue() any { return c.class }
 
+// Detach returns the class unchanged. A class is a shared definition rather
+// than per-State data; instantiating it or calling its methods still requires
+// a live State, and doing so through a closed State reports ErrStateClosed.
+func (c ClassValue) Detach() Value { return c }
+
 // toRuntime returns the underlying runtime value.
 func (c ClassValue) toRuntime() runtime.Value { return c.class }
 
+// NewDynamicClass creates a class at runtime from a name, base classes, and a
+// member map, mirroring Python's type(name, bases, dict) form. Members are
+// installed directly into the class dict, so callables become methods and
+// plain values become class attributes. With no bases the class inherits from
+// object. Use ClassBuilder for classes backed by Go callbacks; this is for
+// code generators that assemble classes from already-converted values.
+func NewDynamicClass(s *State, name string, bases []ClassValue, members map[string]Value) ClassValue {
+	vm := s.liveVM()
+	objectClass := vm.GetBuiltin("object").(*runtime.PyClass)
+
+	baseClasses := make([]*runtime.PyClass, 0, len(bases))
+	for _, base := range bases {
+		baseClasses = append(baseClasses, base.class)
+	}
+	if len(baseClasses) == 0 {
+		baseClasses = []*runtime.PyClass{objectClass}
+	}
+
+	cls := &runtime.PyClass{
+		Name:  name,
+		Bases: baseClasses,
+		Dict:  make(map[string]runtime.Value, len(members)),
+	}
+	for memberName, val := range members {
+		cls.Dict[memberName] = toRuntime(val)
+	}
+
+	mro, err := vm.ComputeC3MRO(cls, cls.Bases)
+	if err != nil {
+		// Fallback: simple linear MRO
+		cls.Mro = []*runtime.PyClass{cls}
+		for _, base := range baseClasses {
+			cls.Mro = append(cls.Mro, base.Mro...)
+		}
+	} else {
+		cls.Mro = mro
+	}
+
+	if typeClass, ok := vm.GetBuiltin("type").(*runtime.PyClass); ok {
+		cls.Metaclass = typeClass
+	}
+
+	return ClassValue{class: cls}
+}
+
 // methodDef stores a Go function to be wrapped as an instance method at Build time.
 // All methods are stored kwargs-aware internally.
 type methodDef struct {
@@ -159,10 +312,20 @@ type staticMethodDef struct {
 	fn func(s *State, args []Value, kwargs map[string]Value) (Value, error)
 }
 
-// propertyDef stores getter/setter functions to be wrapped at Build time.
+// propertyDef stores getter/setter/deleter functions to be wrapped at Build time.
 type propertyDef struct {
-	getter func(s *State, self Object) (Value, error)
-	setter func(s *State, self Object, val Value) error // nil for read-only
+	getter  func(s *State, self Object) (Value, error)
+	setter  func(s *State, self Object, val Value) error // nil for read-only
+	deleter func(s *State, self Object) error            // nil if not deletable
+}
+
+// Param declares one constructor parameter for ClassBuilder.Signature.
+// A nil Default marks the parameter as required; KwOnly parameters can only
+// be passed by keyword.
+type Param struct {
+	Name    string
+	Default Value
+	KwOnly  bool
 }
 
 // ClassBuilder provides a fluent API for building Python classes from Go.
@@ -170,6 +333,8 @@ type ClassBuilder struct {
 	name         string
 	bases        []*runtime.PyClass
 	initFn       func(s *State, self Object, args []Value, kwargs map[string]Value) error
+	signature    []Param
+	goStateFn    func() any
 	newFn        func(s *State, cls ClassValue, args []Value, kwargs map[string]Value) (Object, error)
 	methods      map[string]methodDef
 	classMethods map[string]classMethodDef
@@ -219,6 +384,118 @@ func (b *ClassBuilder) InitKw(fn func(s *State, self Object, args []Value, kwarg
 	return b
 }
 
+// WithGoState attaches hidden per-instance Go state to the class. The
+// factory runs once per instance during construction, before Init; read the
+// value back with Object.GoState or GoStateAs. The state never appears as a
+// Python attribute and is released with the instance by the Go garbage
+// collector, so there is no need for the UserData-in-an-attribute
+// workaround.
+func (b *ClassBuilder) WithGoState(factory func() any) *ClassBuilder {
+	b.goStateFn = factory
+	return b
+}
+
+// Signature declares the constructor's parameters. Calls from Python are
+// bound against the declaration before Init/InitKw runs: positional
+// parameters arrive in args (defaults filled in), keyword-only parameters in
+// kwargs, and mismatches raise CPython-style TypeErrors naming the
+// parameter.
+func (b *ClassBuilder) Signature(params ...Param) *ClassBuilder {
+	b.signature = params
+	return b
+}
+
+// bindSignature maps call arguments onto declared parameters, returning the
+// canonical positional slice and keyword-only map.
+func bindSignature(className string, params []Param, args []Value, kwargs map[string]Value) ([]Value, map[string]Value, error) {
+	positional := make([]Param, 0, len(params))
+	for _, p := range params {
+		if !p.KwOnly {
+			positional = append(positional, p)
+		}
+	}
+	if len(args) > len(positional) {
+		return nil, nil, TypeError(fmt.Sprintf("%s.__init__() takes %d positional arguments but %d were given",
+			className, len(positional), len(args)))
+	}
+
+	bound := make(map[string]Value, len(params))
+	for i, arg := range args {
+		bound[positional[i].Name] = arg
+	}
+	for name, val := range kwargs {
+		param := -1
+		for i, p := range params {
+			if p.Name == name {
+				param = i
+				break
+			}
+		}
+		if param == -1 {
+			return nil, nil, TypeError(fmt.Sprintf("%s.__init__() got an unexpected keyword argument '%s'", className, name))
+		}
+		if _, dup := bound[name]; dup {
+			return nil, nil, TypeError(fmt.Sprintf("%s.__init__() got multiple values for argument '%s'", className, name))
+		}
+		bound[name] = val
+	}
+
+	var missingPos, missingKw []string
+	for _, p := range params {
+		if _, ok := bound[p.Name]; ok {
+			continue
+		}
+		if p.Default != nil {
+			bound[p.Name] = p.Default
+			continue
+		}
+		if p.KwOnly {
+			missingKw = append(missingKw, p.Name)
+		} else {
+			missingPos = append(missingPos, p.Name)
+		}
+	}
+	if len(missingPos) > 0 {
+		return nil, nil, TypeError(missingParamsError(className, "positional", missingPos))
+	}
+	if len(missingKw) > 0 {
+		return nil, nil, TypeError(missingParamsError(className, "keyword-only", missingKw))
+	}
+
+	outArgs := make([]Value, len(positional))
+	for i, p := range positional {
+		outArgs[i] = bound[p.Name]
+	}
+	outKwargs := make(map[string]Value)
+	for _, p := range params {
+		if p.KwOnly {
+			outKwargs[p.Name] = bound[p.Name]
+		}
+	}
+	return outArgs, outKwargs, nil
+}
+
+// missingParamsError formats the CPython "missing N required ... argument(s)"
+// message for the given parameter names.
+func missingParamsError(className, kind string, names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = "'" + n + "'"
+	}
+	list := quoted[0]
+	if len(quoted) == 2 {
+		list = quoted[0] + " and " + quoted[1]
+	} else if len(quoted) > 2 {
+		list = strings.Join(quoted[:len(quoted)-1], ", ") + ", and " + quoted[len(quoted)-1]
+	}
+	plural := "argument"
+	if len(names) > 1 {
+		plural = "arguments"
+	}
+	return fmt.Sprintf("%s.__init__() missing %d required %s %s: %s",
+		className, len(names), kind, plural, list)
+}
+
 // Method adds a regular instance method.
 func (b *ClassBuilder) Method(name string, fn func(s *State, self Object, args ...Value) (Value, error)) *ClassBuilder {
 	b.methods[name] = methodDef{fn: func(s *State, self Object, args []Value, kwargs map[string]Value) (Value, error) {
@@ -518,6 +795,38 @@ func (b *ClassBuilder) ANext(fn func(s *State, self Object) (Value, error)) *Cla
 	return b.unaryDunder("__anext__", fn)
 }
 
+// AEnter sets __aenter__ for async context managers.
+func (b *ClassBuilder) AEnter(fn func(s *State, self Object) (Value, error)) *ClassBuilder {
+	b.methods["__aenter__"] = methodDef{fn: func(s *State, self Object, args []Value, kwargs map[string]Value) (Value, error) {
+		return fn(s, self)
+	}}
+	return b
+}
+
+// AExit sets the __aexit__ method for async context managers.
+// Return true to suppress the exception, false to propagate it.
+// excType, excVal, and excTb are None when no exception occurred.
+func (b *ClassBuilder) AExit(fn func(s *State, self Object, excType, excVal, excTb Value) (bool, error)) *ClassBuilder {
+	b.methods["__aexit__"] = methodDef{fn: func(s *State, self Object, args []Value, kwargs map[string]Value) (Value, error) {
+		var excType, excVal, excTb Value = None, None, None
+		if len(args) > 0 {
+			excType = args[0]
+		}
+		if len(args) > 1 {
+			excVal = args[1]
+		}
+		if len(args) > 2 {
+			excTb = args[2]
+		}
+		suppress, err := fn(s, self, excType, excVal, excTb)
+		if err != nil {
+			return nil, err
+		}
+		return Bool(suppress), nil
+	}}
+	return b
+}
+
 // Dunder adds an arbitrary dunder method.
 func (b *ClassBuilder) Dunder(name string, fn func(s *State, self Object, args ...Value) (Value, error)) *ClassBuilder {
 	b.methods[name] = methodDef{fn: func(s *State, self Object, args []Value, kwargs map[string]Value) (Value, error) {
@@ -534,7 +843,29 @@ func (b *ClassBuilder) Property(name string, getter func(s *State, self Object)
 
 // PropertyWithSetter adds a read-write property.
 func (b *ClassBuilder) PropertyWithSetter(name string, getter func(s *State, self Object) (Value, error), setter func(s *State, self Object, val Value) error) *ClassBuilder {
-	b.properties[name] = propertyDef{getter: getter, setter: setter}
+	def := b.properties[name]
+	def.getter = getter
+	def.setter = setter
+	b.properties[name] = def
+	return b
+}
+
+// PropertySet attaches a setter to the property of the given name. The getter
+// is typically declared first with Property; declaring PropertySet alone
+// yields a write-only property.
+func (b *ClassBuilder) PropertySet(name string, setter func(s *State, self Object, val Value) error) *ClassBuilder {
+	def := b.properties[name]
+	def.setter = setter
+	b.properties[name] = def
+	return b
+}
+
+// PropertyDel attaches a deleter to the property of the given name, invoked
+// by `del obj.name`. Without a deleter, deletion raises AttributeError.
+func (b *ClassBuilder) PropertyDel(name string, deleter func(s *State, self Object) error) *ClassBuilder {
+	def := b.properties[name]
+	def.deleter = deleter
+	b.properties[name] = def
 	return b
 }
 
@@ -572,6 +903,13 @@ func (b *ClassBuilder) Attr(name string, val Value) *ClassBuilder {
 	return b
 }
 
+// ClassAttr sets a class-level attribute (installed into the class dict).
+// It is an alias for Attr, named for symmetry with ClassMethod, and reads
+// better for constants such as ClassAttr("MAX_SIZE", rage.Int(100)).
+func (b *ClassBuilder) ClassAttr(name string, val Value) *ClassBuilder {
+	return b.Attr(name, val)
+}
+
 // New sets a custom __new__ method. The function receives the class and args,
 // and should return a new Object (typically via cls.NewInstance()).
 func (b *ClassBuilder) New(fn func(s *State, cls ClassValue, args ...Value) (Object, error)) *ClassBuilder {
@@ -990,6 +1328,26 @@ func (b *ClassBuilder) Del(fn func(s *State, self Object) error) *ClassBuilder {
 	return b
 }
 
+// --- Copying ---
+
+// Copy sets __copy__ (copy.copy()).
+func (b *ClassBuilder) Copy(fn func(s *State, self Object) (Value, error)) *ClassBuilder {
+	return b.unaryDunder("__copy__", fn)
+}
+
+// DeepCopy sets __deepcopy__ (copy.deepcopy()). memo is the memo dict
+// tracking already-copied objects.
+func (b *ClassBuilder) DeepCopy(fn func(s *State, self Object, memo Value) (Value, error)) *ClassBuilder {
+	b.methods["__deepcopy__"] = methodDef{fn: func(s *State, self Object, args []Value, kwargs map[string]Value) (Value, error) {
+		var memo Value = None
+		if len(args) > 0 {
+			memo = args[0]
+		}
+		return fn(s, self, memo)
+	}}
+	return b
+}
+
 // --- Class lifecycle ---
 
 // InitSubclass sets __init_subclass__. Called when a class is subclassed.
@@ -1114,7 +1472,7 @@ func (b *ClassBuilder) SetName(fn func(s *State, self Object, owner Value, name
 // Build creates the Python class and registers it in the given State.
 // Returns a ClassValue that can be passed to State.SetGlobal.
 func (b *ClassBuilder) Build(s *State) ClassValue {
-	vm := s.vm
+	vm := s.liveVM()
 	objectClass := vm.GetBuiltin("object").(*runtime.PyClass)
 
 	bases := b.bases
@@ -1180,10 +1538,29 @@ func (b *ClassBuilder) Build(s *State) ClassValue {
 		}
 	}
 
-	// Add __init__ if provided
+	// Add __init__ if provided (or implied by WithGoState, which needs a
+	// construction hook to run its factory)
+	if b.initFn == nil && b.goStateFn != nil {
+		b.initFn = func(s *State, self Object, args []Value, kwargs map[string]Value) error {
+			return nil
+		}
+	}
 	if b.initFn != nil {
 		initFn := b.initFn
+		signature := b.signature
+		goStateFn := b.goStateFn
+		className := b.name
 		cls.Dict["__init__"] = makeInstanceMethodKw(b.name, "__init__", s, func(s *State, self Object, args []Value, kwargs map[string]Value) (Value, error) {
+			if goStateFn != nil && self.inst.GoState == nil {
+				self.inst.GoState = goStateFn()
+			}
+			if signature != nil {
+				var err error
+				args, kwargs, err = bindSignature(className, signature, args, kwargs)
+				if err != nil {
+					return nil, err
+				}
+			}
 			err := initFn(s, self, args, kwargs)
 			if err != nil {
 				return nil, err
@@ -1279,6 +1656,15 @@ func (b *ClassBuilder) Build(s *State) ClassValue {
 				return None, nil
 			})
 		}
+		if def.deleter != nil {
+			deleter := def.deleter
+			prop.Fdel = makeInstanceMethodKw(b.name, name+".fdel", s, func(s *State, self Object, args []Value, kwargs map[string]Value) (Value, error) {
+				if err := deleter(s, self); err != nil {
+					return nil, err
+				}
+				return None, nil
+			})
+		}
 		cls.Dict[name] = prop
 	}
 
@@ -1327,3 +1713,46 @@ func convertKwargs(kwargs map[string]runtime.Value) map[string]Value {
 	}
 	return result
 }
+
+// KwArg is a single keyword argument paired with its name (see OrderedKwargs).
+type KwArg struct {
+	Name  string
+	Value Value
+}
+
+// OrderedKwargs returns kwargs as name/value pairs in the order they were
+// written at the Python call site. The kwargs maps handed to Kw callbacks
+// lose that order, which makes any host output derived from them
+// nondeterministic; call this from inside the callback to restore it. Names
+// without a recorded position are appended in sorted order, so the result is
+// always deterministic.
+func (s *State) OrderedKwargs(kwargs map[string]Value) []KwArg {
+	if len(kwargs) == 0 {
+		return nil
+	}
+	var names []string
+	if vm := s.liveVM(); vm != nil {
+		names = vm.KwargNames()
+	}
+	result := make([]KwArg, 0, len(kwargs))
+	seen := make(map[string]bool, len(kwargs))
+	for _, name := range names {
+		if v, ok := kwargs[name]; ok && !seen[name] {
+			result = append(result, KwArg{Name: name, Value: v})
+			seen[name] = true
+		}
+	}
+	if len(result) < len(kwargs) {
+		rest := make([]string, 0, len(kwargs)-len(result))
+		for name := range kwargs {
+			if !seen[name] {
+				rest = append(rest, name)
+			}
+		}
+		sort.Strings(rest)
+		for _, name := range rest {
+			result = append(result, KwArg{Name: name, Value: kwargs[name]})
+		}
+	}
+	return result
+}
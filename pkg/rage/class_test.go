@@ -2,6 +2,7 @@ package rage
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -1343,6 +1344,293 @@ _iadd_result = a.v
 	}
 }
 
+func TestClassBuilder_AllInPlaceOps(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewClass("Num").
+		Init(func(s *State, self Object, args ...Value) error {
+			self.Set("v", args[0])
+			return nil
+		}).
+		ISub(func(s *State, self Object, other Value) (Value, error) {
+			a, _ := AsInt(self.Get("v"))
+			b, _ := AsInt(other)
+			self.Set("v", Int(a-b))
+			return self, nil
+		}).
+		IMul(func(s *State, self Object, other Value) (Value, error) {
+			a, _ := AsInt(self.Get("v"))
+			b, _ := AsInt(other)
+			self.Set("v", Int(a*b))
+			return self, nil
+		}).
+		IOr(func(s *State, self Object, other Value) (Value, error) {
+			a, _ := AsInt(self.Get("v"))
+			b, _ := AsInt(other)
+			self.Set("v", Int(a|b))
+			return self, nil
+		}).
+		IRShift(func(s *State, self Object, other Value) (Value, error) {
+			a, _ := AsInt(self.Get("v"))
+			b, _ := AsInt(other)
+			self.Set("v", Int(a>>uint(b)))
+			return self, nil
+		}).
+		Build(state)
+
+	state.SetGlobal("Num", cls)
+
+	_, err := state.Run(`
+n = Num(40)
+n -= 8
+n *= 2
+n |= 1
+n >>= 2
+result = n.v
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := state.GetGlobal("result")
+	if n, ok := AsInt(result); !ok || n != 16 {
+		t.Errorf("expected 16, got %v", result)
+	}
+}
+
+func TestClassBuilder_Signature(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewClass("User").
+		Signature(
+			Param{Name: "name"},
+			Param{Name: "age", Default: Int(0)},
+			Param{Name: "role", Default: String("user"), KwOnly: true},
+		).
+		InitKw(func(s *State, self Object, args []Value, kwargs map[string]Value) error {
+			self.Set("name", args[0])
+			self.Set("age", args[1])
+			self.Set("role", kwargs["role"])
+			return nil
+		}).
+		Build(state)
+
+	state.SetGlobal("User", cls)
+
+	_, err := state.Run(`
+u = User("bob", age=30, role="admin")
+name = u.name
+age = u.age
+role = u.role
+d = User("dee")
+d_age = d.age
+d_role = d.role
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, _ := AsString(state.GetGlobal("name")); s != "bob" {
+		t.Errorf("expected 'bob', got %v", state.GetGlobal("name"))
+	}
+	if n, _ := AsInt(state.GetGlobal("age")); n != 30 {
+		t.Errorf("expected 30, got %v", state.GetGlobal("age"))
+	}
+	if s, _ := AsString(state.GetGlobal("role")); s != "admin" {
+		t.Errorf("expected 'admin', got %v", state.GetGlobal("role"))
+	}
+	if n, _ := AsInt(state.GetGlobal("d_age")); n != 0 {
+		t.Errorf("expected default age 0, got %v", state.GetGlobal("d_age"))
+	}
+	if s, _ := AsString(state.GetGlobal("d_role")); s != "user" {
+		t.Errorf("expected default role 'user', got %v", state.GetGlobal("d_role"))
+	}
+}
+
+func TestClassBuilder_SignatureErrors(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewClass("User").
+		Signature(
+			Param{Name: "name"},
+			Param{Name: "role", KwOnly: true},
+		).
+		InitKw(func(s *State, self Object, args []Value, kwargs map[string]Value) error {
+			return nil
+		}).
+		Build(state)
+
+	state.SetGlobal("User", cls)
+
+	cases := []struct {
+		code string
+		want string
+	}{
+		{`User(role="x")`, "missing 1 required positional argument: 'name'"},
+		{`User("a")`, "missing 1 required keyword-only argument: 'role'"},
+		{`User("a", "b", role="x")`, "takes 1 positional arguments but 2 were given"},
+		{`User("a", nope=1, role="x")`, "got an unexpected keyword argument 'nope'"},
+		{`User("a", name="b", role="x")`, "got multiple values for argument 'name'"},
+	}
+	for _, tc := range cases {
+		_, err := state.Run(tc.code)
+		if err == nil {
+			t.Errorf("%s: expected error", tc.code)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected %q in error, got %q", tc.code, tc.want, err.Error())
+		}
+	}
+}
+
+func TestClassBuilder_WithGoState(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	type counter struct{ n int64 }
+
+	cls := NewClass("Counter").
+		WithGoState(func() any { return &counter{} }).
+		Method("bump", func(s *State, self Object, args ...Value) (Value, error) {
+			c := self.GoState().(*counter)
+			c.n++
+			return Int(c.n), nil
+		}).
+		Build(state)
+
+	state.SetGlobal("Counter", cls)
+
+	_, err := state.Run(`
+a = Counter()
+b = Counter()
+a.bump()
+a.bump()
+b.bump()
+a_n = a.bump()
+b_n = b.bump()
+has_attr = hasattr(a, "_data")
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := AsInt(state.GetGlobal("a_n")); n != 3 {
+		t.Errorf("expected a_n 3, got %v", state.GetGlobal("a_n"))
+	}
+	if n, _ := AsInt(state.GetGlobal("b_n")); n != 2 {
+		t.Errorf("expected b_n 2, got %v", state.GetGlobal("b_n"))
+	}
+	// The Go state must not leak into Python attribute space
+	if b, _ := AsBool(state.GetGlobal("has_attr")); b {
+		t.Error("Go state should not be visible as a Python attribute")
+	}
+}
+
+func TestGoStateAs(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	type payload struct{ tag string }
+
+	var got string
+	cls := NewClass("Holder").
+		WithGoState(func() any { return &payload{tag: "ok"} }).
+		Method("read", func(s *State, self Object, args ...Value) (Value, error) {
+			p, ok := GoStateAs[*payload](self)
+			if !ok {
+				return nil, TypeError("missing go state")
+			}
+			got = p.tag
+			return None, nil
+		}).
+		Build(state)
+
+	state.SetGlobal("Holder", cls)
+
+	if _, err := state.Run(`Holder().read()`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "ok" {
+		t.Errorf("expected 'ok', got %q", got)
+	}
+}
+
+func TestClassBuilder_CopyHooks(t *testing.T) {
+	state := NewStateWithModules(WithModule(ModuleCopy))
+	defer state.Close()
+
+	cls := NewClass("Tag").
+		Init(func(s *State, self Object, args ...Value) error {
+			self.Set("name", args[0])
+			return nil
+		}).
+		Copy(func(s *State, self Object) (Value, error) {
+			return String("shallow"), nil
+		}).
+		DeepCopy(func(s *State, self Object, memo Value) (Value, error) {
+			return String("deep"), nil
+		}).
+		Build(state)
+
+	state.SetGlobal("Tag", cls)
+
+	_, err := state.Run(`
+import copy
+t = Tag("x")
+shallow = copy.copy(t)
+deep = copy.deepcopy(t)
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, ok := AsString(state.GetGlobal("shallow")); !ok || s != "shallow" {
+		t.Errorf("expected 'shallow', got %v", state.GetGlobal("shallow"))
+	}
+	if s, ok := AsString(state.GetGlobal("deep")); !ok || s != "deep" {
+		t.Errorf("expected 'deep', got %v", state.GetGlobal("deep"))
+	}
+}
+
+func TestClassBuilder_AsyncContextManager(t *testing.T) {
+	state := NewStateWithModules(WithModule(ModuleAsyncio))
+	defer state.Close()
+
+	var events []string
+	cls := NewClass("Res").
+		AEnter(func(s *State, self Object) (Value, error) {
+			events = append(events, "enter")
+			return String("res"), nil
+		}).
+		AExit(func(s *State, self Object, excType, excVal, excTb Value) (bool, error) {
+			events = append(events, "exit")
+			return false, nil
+		}).
+		Build(state)
+
+	state.SetGlobal("Res", cls)
+
+	_, err := state.Run(`
+import asyncio
+
+async def main():
+    async with Res() as r:
+        out = r
+    return out
+
+got = asyncio.run(main())
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, ok := AsString(state.GetGlobal("got")); !ok || s != "res" {
+		t.Errorf("expected 'res', got %v", state.GetGlobal("got"))
+	}
+	if len(events) != 2 || events[0] != "enter" || events[1] != "exit" {
+		t.Errorf("expected [enter exit], got %v", events)
+	}
+}
+
 func TestClassBuilder_DescGet(t *testing.T) {
 	state := NewState()
 	defer state.Close()
@@ -1566,3 +1854,463 @@ _setname_result = Host().my_field
 		t.Errorf("expected 'my_field', got %v", result)
 	}
 }
+
+func TestNewDynamicClass_Basic(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	// A Python function captured from the state becomes a method.
+	_, err := state.Run(`
+def greet(self):
+    return "hi " + self.name
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cls := NewDynamicClass(state, "Person", nil, map[string]Value{
+		"greet":   state.GetGlobal("greet"),
+		"species": String("human"),
+	})
+	state.SetGlobal("Person", cls)
+
+	_, err = state.Run(`
+p = Person()
+p.name = "Alice"
+`)
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+
+	result := eval(t, state, `p.greet()`)
+	if s, ok := AsString(result); !ok || s != "hi Alice" {
+		t.Errorf("expected 'hi Alice', got %v", result)
+	}
+
+	result = eval(t, state, `Person.species`)
+	if s, ok := AsString(result); !ok || s != "human" {
+		t.Errorf("expected 'human', got %v", result)
+	}
+
+	result = eval(t, state, `type(Person) is type`)
+	if b, ok := AsBool(result); !ok || !b {
+		t.Errorf("expected type(Person) is type, got %v", result)
+	}
+}
+
+func TestNewDynamicClass_Inheritance(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	base := NewClass("Base").
+		Method("ping", func(s *State, self Object, args ...Value) (Value, error) {
+			return String("pong"), nil
+		}).
+		Build(state)
+	state.SetGlobal("Base", base)
+
+	child := NewDynamicClass(state, "Child", []ClassValue{base}, map[string]Value{
+		"extra": Int(1),
+	})
+	state.SetGlobal("Child", child)
+
+	result := eval(t, state, `Child().ping()`)
+	if s, ok := AsString(result); !ok || s != "pong" {
+		t.Errorf("expected 'pong', got %v", result)
+	}
+
+	result = eval(t, state, `issubclass(Child, Base)`)
+	if b, ok := AsBool(result); !ok || !b {
+		t.Errorf("expected issubclass(Child, Base), got %v", result)
+	}
+
+	result = eval(t, state, `isinstance(Child(), Base)`)
+	if b, ok := AsBool(result); !ok || !b {
+		t.Errorf("expected isinstance(Child(), Base), got %v", result)
+	}
+}
+
+func TestNewDynamicClass_DefaultBase(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewDynamicClass(state, "Empty", nil, nil)
+	state.SetGlobal("Empty", cls)
+
+	result := eval(t, state, `isinstance(Empty(), object)`)
+	if b, ok := AsBool(result); !ok || !b {
+		t.Errorf("expected isinstance(Empty(), object), got %v", result)
+	}
+}
+
+func TestState_OrderedKwargs(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	var got []string
+	cls := NewClass("Emitter").
+		MethodKw("emit", func(s *State, self Object, args []Value, kwargs map[string]Value) (Value, error) {
+			got = nil
+			for _, kw := range s.OrderedKwargs(kwargs) {
+				got = append(got, kw.Name)
+			}
+			return None, nil
+		}).
+		Build(state)
+	state.SetGlobal("Emitter", cls)
+
+	checkOrder := func(source string, want []string) {
+		t.Helper()
+		if _, err := state.Run(source); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+		}
+	}
+
+	// Call-site order is preserved, not sorted
+	checkOrder(`Emitter().emit(zebra=1, alpha=2, mid=3)`, []string{"zebra", "alpha", "mid"})
+
+	// ** expansion follows the dict's insertion order
+	checkOrder(`Emitter().emit(**{"b": 1, "a": 2, "c": 3})`, []string{"b", "a", "c"})
+
+	// Mixed positional and keyword arguments
+	checkOrder(`Emitter().emit(1, 2, second=20, first=10)`, []string{"second", "first"})
+}
+
+func TestState_OrderedKwargsFallbackSorted(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	// Outside a callback there is no recorded call-site order, so the
+	// result falls back to sorted names for determinism
+	pairs := state.OrderedKwargs(map[string]Value{"c": Int(1), "a": Int(2), "b": Int(3)})
+	want := []string{"a", "b", "c"}
+	if len(pairs) != len(want) {
+		t.Fatalf("expected %d pairs, got %d", len(want), len(pairs))
+	}
+	for i, kw := range pairs {
+		if kw.Name != want[i] {
+			t.Errorf("expected %q at index %d, got %q", want[i], i, kw.Name)
+		}
+	}
+}
+
+func TestClassBuilder_PropertySetDel(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewClass("Slot").
+		Init(func(s *State, self Object, args ...Value) error {
+			self.Set("_val", args[0])
+			return nil
+		}).
+		Property("val", func(s *State, self Object) (Value, error) {
+			if !self.Has("_val") {
+				return nil, fmt.Errorf("AttributeError: val")
+			}
+			return self.Get("_val"), nil
+		}).
+		PropertySet("val", func(s *State, self Object, val Value) error {
+			self.Set("_val", val)
+			return nil
+		}).
+		PropertyDel("val", func(s *State, self Object) error {
+			self.Delete("_val")
+			return nil
+		}).
+		Build(state)
+
+	state.SetGlobal("Slot", cls)
+
+	_, err := state.Run(`
+s = Slot(1)
+s.val = 7
+_before = s.val
+del s.val
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, ok := AsInt(state.GetGlobal("_before")); !ok || n != 7 {
+		t.Errorf("expected 7 before delete, got %v", state.GetGlobal("_before"))
+	}
+	_, err = state.Run(`s.val`)
+	if err == nil {
+		t.Error("expected error reading deleted property value")
+	}
+}
+
+func TestClassBuilder_ReadOnlyProperty(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewClass("Frozen").
+		Property("tag", func(s *State, self Object) (Value, error) {
+			return String("fixed"), nil
+		}).
+		Build(state)
+
+	state.SetGlobal("Frozen", cls)
+
+	_, err := state.Run(`Frozen().tag = "other"`)
+	if err == nil {
+		t.Fatal("expected error assigning to read-only property")
+	}
+	if !strings.Contains(err.Error(), "no setter") {
+		t.Errorf("expected 'no setter' error, got %v", err)
+	}
+
+	_, err = state.Run(`del Frozen().tag`)
+	if err == nil {
+		t.Fatal("expected error deleting property without deleter")
+	}
+	if !strings.Contains(err.Error(), "no deleter") {
+		t.Errorf("expected 'no deleter' error, got %v", err)
+	}
+}
+
+func TestClassBuilder_ClassAttr(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewClass("Limits").
+		ClassAttr("MAX_SIZE", Int(100)).
+		Build(state)
+
+	state.SetGlobal("Limits", cls)
+
+	result := eval(t, state, `Limits.MAX_SIZE`)
+	if n, ok := AsInt(result); !ok || n != 100 {
+		t.Errorf("expected 100, got %v", result)
+	}
+
+	// Also reachable through instances
+	result = eval(t, state, `Limits().MAX_SIZE`)
+	if n, ok := AsInt(result); !ok || n != 100 {
+		t.Errorf("expected 100 via instance, got %v", result)
+	}
+}
+
+func TestClassValue_SetAttr(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewClass("Point").
+		Init(func(s *State, self Object, args ...Value) error {
+			self.Set("x", args[0])
+			return nil
+		}).
+		Build(state)
+
+	// A constant whose value is an instance of the class can only be
+	// installed after Build
+	origin := cls.NewInstance()
+	origin.Set("x", Int(0))
+	cls.SetAttr("ORIGIN", origin)
+
+	state.SetGlobal("Point", cls)
+
+	result := eval(t, state, `Point.ORIGIN.x`)
+	if n, ok := AsInt(result); !ok || n != 0 {
+		t.Errorf("expected 0, got %v", result)
+	}
+}
+
+func TestClassBuilder_ReflectedWithBuiltins(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewClass("Scaled").
+		Init(func(s *State, self Object, args ...Value) error {
+			self.Set("n", args[0])
+			return nil
+		}).
+		Mul(func(s *State, self Object, other Value) (Value, error) {
+			n, _ := AsFloat(self.Get("n"))
+			m, ok := AsFloat(other)
+			if !ok {
+				return nil, TypeError("can only multiply Scaled by a number")
+			}
+			return Float(n * m), nil
+		}).
+		RMul(func(s *State, self Object, other Value) (Value, error) {
+			n, _ := AsFloat(self.Get("n"))
+			m, ok := AsFloat(other)
+			if !ok {
+				return nil, TypeError("can only multiply Scaled by a number")
+			}
+			return Float(m * n), nil
+		}).
+		RSub(func(s *State, self Object, other Value) (Value, error) {
+			n, _ := AsFloat(self.Get("n"))
+			m, _ := AsFloat(other)
+			return Float(m - n), nil
+		}).
+		Build(state)
+
+	state.SetGlobal("Scaled", cls)
+
+	cases := []struct {
+		src  string
+		want float64
+	}{
+		{`Scaled(5) * 2`, 10},   // Go class on the left
+		{`2 * Scaled(5)`, 10},   // builtin int on the left, routed to __rmul__
+		{`2.5 * Scaled(4)`, 10}, // builtin float on the left
+		{`12 - Scaled(2)`, 10},  // __rsub__ is not commutative
+	}
+	for _, tc := range cases {
+		result := eval(t, state, tc.src)
+		if f, ok := AsFloat(result); !ok || f != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.src, tc.want, result)
+		}
+	}
+
+	// In-place assignment with a builtin target also routes through __rmul__
+	_, err := state.Run(`
+x = 3
+x *= Scaled(4)
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f, ok := AsFloat(state.GetGlobal("x")); !ok || f != 12 {
+		t.Errorf("expected 12, got %v", state.GetGlobal("x"))
+	}
+}
+
+func TestClassBuilder_ComparisonWithBuiltinLeft(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewClass("Rank").
+		Init(func(s *State, self Object, args ...Value) error {
+			self.Set("n", args[0])
+			return nil
+		}).
+		Lt(func(s *State, self Object, other Value) (bool, error) {
+			a, _ := AsInt(self.Get("n"))
+			b, _ := AsInt(other)
+			return a < b, nil
+		}).
+		Gt(func(s *State, self Object, other Value) (bool, error) {
+			a, _ := AsInt(self.Get("n"))
+			b, _ := AsInt(other)
+			return a > b, nil
+		}).
+		Build(state)
+
+	state.SetGlobal("Rank", cls)
+
+	// A builtin on the left is compared via the mirrored dunder on the right
+	result := eval(t, state, `3 < Rank(5)`)
+	if b, ok := AsBool(result); !ok || !b {
+		t.Errorf("expected 3 < Rank(5) to be True, got %v", result)
+	}
+	result = eval(t, state, `3 > Rank(5)`)
+	if b, ok := AsBool(result); !ok || b {
+		t.Errorf("expected 3 > Rank(5) to be False, got %v", result)
+	}
+}
+
+func TestObject_CallVirtual(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewClass("Entity").
+		Init(func(s *State, self Object, args ...Value) error {
+			self.Set("ticks", Int(0))
+			return nil
+		}).
+		Method("update", func(s *State, self Object, args ...Value) (Value, error) {
+			n, _ := AsInt(self.Get("ticks"))
+			self.Set("ticks", Int(n+1))
+			return String("go"), nil
+		}).
+		Build(state)
+
+	state.SetGlobal("Entity", cls)
+
+	// A Python subclass overriding update() must win when Go dispatches
+	_, err := state.Run(`
+class Player(Entity):
+    def update(self):
+        self.ticks = self.ticks + 10
+        return "python"
+
+base = Entity()
+player = Player()
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	base, ok := AsObject(state.GetGlobal("base"))
+	if !ok {
+		t.Fatal("base is not an Object")
+	}
+	player, ok := AsObject(state.GetGlobal("player"))
+	if !ok {
+		t.Fatal("player is not an Object")
+	}
+
+	result, err := base.CallVirtual(state, "update")
+	if err != nil {
+		t.Fatalf("base.update: %v", err)
+	}
+	if sv, _ := AsString(result); sv != "go" {
+		t.Errorf("expected Go implementation on base instance, got %v", result)
+	}
+
+	result, err = player.CallVirtual(state, "update")
+	if err != nil {
+		t.Fatalf("player.update: %v", err)
+	}
+	if sv, _ := AsString(result); sv != "python" {
+		t.Errorf("expected Python override on subclass instance, got %v", result)
+	}
+	if n, _ := AsInt(player.Get("ticks")); n != 10 {
+		t.Errorf("expected override side effect ticks=10, got %d", n)
+	}
+
+	// Missing methods surface as AttributeError
+	_, err = player.CallVirtual(state, "missing")
+	if err == nil || !strings.Contains(err.Error(), "AttributeError") {
+		t.Errorf("expected AttributeError for missing method, got %v", err)
+	}
+}
+
+func TestObject_CallVirtualKw(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	cls := NewClass("Shape").Build(state)
+	state.SetGlobal("Shape", cls)
+
+	_, err := state.Run(`
+class Rect(Shape):
+    def area(self, w, h=2):
+        return w * h
+
+r = Rect()
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, _ := AsObject(state.GetGlobal("r"))
+
+	result, err := r.CallVirtualKw(state, "area", []Value{Int(3)}, map[string]Value{"h": Int(4)})
+	if err != nil {
+		t.Fatalf("area: %v", err)
+	}
+	if n, _ := AsInt(result); n != 12 {
+		t.Errorf("expected 12, got %v", result)
+	}
+}
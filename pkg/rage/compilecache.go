@@ -0,0 +1,158 @@
+package rage
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// Optional package-level compile cache.
+//
+// Hosts that repeatedly Run the same source strings (templated scripts, one
+// script per entity, hot-reload loops) pay for lexing, parsing and compiling
+// every time. EnableCompileCache keeps the most recently used compilations,
+// keyed by a hash of the source and filename, so repeat runs skip straight
+// to execution. Compiled code is immutable and shared safely between states.
+
+// CacheStats reports compile cache effectiveness (see CompileCacheStats).
+type CacheStats struct {
+	Hits     uint64 // Lookups served from the cache
+	Misses   uint64 // Lookups that had to compile
+	Size     int    // Entries currently cached
+	Capacity int    // Maximum entries, 0 when the cache is disabled
+}
+
+type compileCacheEntry struct {
+	key  [sha256.Size]byte
+	code *runtime.CodeObject
+}
+
+var compileCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // Front is most recently used
+	entries  map[[sha256.Size]byte]*list.Element
+	hits     uint64
+	misses   uint64
+}
+
+// EnableCompileCache turns on the package-level compile cache, holding up to
+// size successful compilations evicted least-recently-used. Calling it again
+// resizes the cache; a size of zero or less disables it and drops all
+// entries. Only successful compiles are cached, so errors are reported fresh
+// every time.
+func EnableCompileCache(size int) {
+	compileCache.mu.Lock()
+	defer compileCache.mu.Unlock()
+	if size <= 0 {
+		compileCache.capacity = 0
+		compileCache.order = nil
+		compileCache.entries = nil
+		return
+	}
+	compileCache.capacity = size
+	if compileCache.entries == nil {
+		compileCache.order = list.New()
+		compileCache.entries = make(map[[sha256.Size]byte]*list.Element)
+	}
+	for compileCache.order.Len() > size {
+		compileCacheEvictOldest()
+	}
+}
+
+// DisableCompileCache turns the compile cache off and drops all entries.
+// Hit and miss counters are preserved for inspection.
+func DisableCompileCache() {
+	EnableCompileCache(0)
+}
+
+// CompileCacheStats returns a snapshot of the cache's hit/miss counters and
+// current occupancy.
+func CompileCacheStats() CacheStats {
+	compileCache.mu.Lock()
+	defer compileCache.mu.Unlock()
+	stats := CacheStats{
+		Hits:     compileCache.hits,
+		Misses:   compileCache.misses,
+		Capacity: compileCache.capacity,
+	}
+	if compileCache.order != nil {
+		stats.Size = compileCache.order.Len()
+	}
+	return stats
+}
+
+// ResetCompileCacheStats zeroes the hit/miss counters.
+func ResetCompileCacheStats() {
+	compileCache.mu.Lock()
+	compileCache.hits = 0
+	compileCache.misses = 0
+	compileCache.mu.Unlock()
+}
+
+// compileCached is the compile path used by Run and friends: it consults the
+// cache when enabled and falls back to compiler.CompileSource.
+func compileCached(source, filename string) (*runtime.CodeObject, []error) {
+	compileCache.mu.Lock()
+	enabled := compileCache.capacity > 0
+	var key [sha256.Size]byte
+	if enabled {
+		key = compileCacheKey(source, filename)
+		if elem, ok := compileCache.entries[key]; ok {
+			compileCache.order.MoveToFront(elem)
+			compileCache.hits++
+			code := elem.Value.(*compileCacheEntry).code
+			compileCache.mu.Unlock()
+			return code, nil
+		}
+		compileCache.misses++
+	}
+	compileCache.mu.Unlock()
+
+	code, errs := compiler.CompileSource(source, filename)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if enabled {
+		compileCache.mu.Lock()
+		// The cache may have been resized or disabled while compiling
+		if compileCache.capacity > 0 {
+			if _, ok := compileCache.entries[key]; !ok {
+				elem := compileCache.order.PushFront(&compileCacheEntry{key: key, code: code})
+				compileCache.entries[key] = elem
+				for compileCache.order.Len() > compileCache.capacity {
+					compileCacheEvictOldest()
+				}
+			}
+		}
+		compileCache.mu.Unlock()
+	}
+	return code, nil
+}
+
+// compileCacheEvictOldest drops the least recently used entry. Callers must
+// hold compileCache.mu.
+func compileCacheEvictOldest() {
+	oldest := compileCache.order.Back()
+	if oldest == nil {
+		return
+	}
+	compileCache.order.Remove(oldest)
+	delete(compileCache.entries, oldest.Value.(*compileCacheEntry).key)
+}
+
+// compileCacheKey hashes the filename and source together, so identical
+// sources compiled under different names stay distinct in error messages.
+func compileCacheKey(source, filename string) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write([]byte(filename))
+	h.Write([]byte{0})
+	h.Write([]byte(source))
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
@@ -0,0 +1,119 @@
+package rage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCompileCache_HitsOnRepeatRuns(t *testing.T) {
+	EnableCompileCache(16)
+	defer DisableCompileCache()
+	ResetCompileCacheStats()
+
+	state := NewState()
+	defer state.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := state.Run(`x = 1 + 2`); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n, _ := state.GetGlobalInt("x"); n != 3 {
+		t.Errorf("expected x=3, got %d", n)
+	}
+
+	stats := CompileCacheStats()
+	if stats.Misses != 1 {
+		t.Errorf("expected 1 miss, got %d", stats.Misses)
+	}
+	if stats.Hits != 2 {
+		t.Errorf("expected 2 hits, got %d", stats.Hits)
+	}
+	if stats.Size != 1 {
+		t.Errorf("expected 1 cached entry, got %d", stats.Size)
+	}
+}
+
+func TestCompileCache_SharedAcrossStates(t *testing.T) {
+	EnableCompileCache(16)
+	defer DisableCompileCache()
+	ResetCompileCacheStats()
+
+	source := `y = sum([1, 2, 3])`
+	for i := 0; i < 2; i++ {
+		state := NewState()
+		if _, err := state.Run(source); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n, _ := state.GetGlobalInt("y"); n != 6 {
+			t.Errorf("expected y=6, got %d", n)
+		}
+		state.Close()
+	}
+
+	stats := CompileCacheStats()
+	if stats.Misses != 1 || stats.Hits != 1 {
+		t.Errorf("expected 1 miss and 1 hit, got %d/%d", stats.Misses, stats.Hits)
+	}
+}
+
+func TestCompileCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	EnableCompileCache(2)
+	defer DisableCompileCache()
+	ResetCompileCacheStats()
+
+	state := NewState()
+	defer state.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := state.Run(fmt.Sprintf("v%d = %d", i, i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	stats := CompileCacheStats()
+	if stats.Size != 2 {
+		t.Errorf("expected the cache to hold 2 entries, got %d", stats.Size)
+	}
+
+	// The first script was evicted, so running it again is a miss
+	ResetCompileCacheStats()
+	if _, err := state.Run("v0 = 0"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats := CompileCacheStats(); stats.Misses != 1 {
+		t.Errorf("expected the evicted script to miss, got %+v", stats)
+	}
+}
+
+func TestCompileCache_ErrorsNotCached(t *testing.T) {
+	EnableCompileCache(16)
+	defer DisableCompileCache()
+	ResetCompileCacheStats()
+
+	state := NewState()
+	defer state.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := state.Run(`def oops(:`); err == nil {
+			t.Fatal("expected a compile error")
+		}
+	}
+	stats := CompileCacheStats()
+	if stats.Hits != 0 || stats.Size != 0 {
+		t.Errorf("expected failed compiles to stay uncached, got %+v", stats)
+	}
+}
+
+func TestCompileCache_DisabledByDefault(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	before := CompileCacheStats()
+	if _, err := state.Run(`z = 1`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	after := CompileCacheStats()
+	if after.Capacity != 0 || after.Misses != before.Misses {
+		t.Errorf("expected no cache activity while disabled, got %+v", after)
+	}
+}
@@ -0,0 +1,170 @@
+// Package config exposes typed environment accessors to Python scripts, so
+// embedders that drive configuration from Python (like the demo) don't each
+// hand-roll the same env() glue. Install registers three functions:
+//
+//	env(name)                  -> str, error if the variable is unset
+//	env(name, default)         -> coerced to the default's type (str/int/float/bool)
+//	env_duration(name, default) -> seconds as a float, parsed like Go durations ("250ms", "1h30m")
+//	secret(name)               -> str from the secret source, error if unset
+//
+// Options add a lookup prefix (scripts write env("PORT"), the host controls
+// the namespace), fail-fast validation of required variables, and pluggable
+// sources for both plain variables and secrets.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+)
+
+// Options configures Install.
+type Options struct {
+	// Prefix is prepended to every variable name before lookup, e.g. with
+	// Prefix "MYAPP_" a script's env("PORT") reads MYAPP_PORT. Secrets are
+	// looked up with the same prefix.
+	Prefix string
+
+	// Required lists variables (without prefix) that must be set when
+	// Install runs; missing ones make Install fail so broken deployments
+	// are caught before any script executes.
+	Required []string
+
+	// Lookup overrides the variable source. Nil means os.LookupEnv.
+	Lookup func(name string) (string, bool)
+
+	// Secrets is the source consulted by secret(). Nil falls back to the
+	// variable source, so secrets default to environment variables.
+	Secrets func(name string) (string, bool)
+}
+
+// Install registers env, env_duration and secret in state. It returns an
+// error naming every required variable that is not set.
+func Install(state *rage.State, opts Options) error {
+	lookup := opts.Lookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	secrets := opts.Secrets
+	if secrets == nil {
+		secrets = lookup
+	}
+
+	var missing []string
+	for _, name := range opts.Required {
+		if _, ok := lookup(opts.Prefix + name); !ok {
+			missing = append(missing, opts.Prefix+name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("config: required variables not set: %s", strings.Join(missing, ", "))
+	}
+
+	if err := state.RegisterFunc("env", func(args ...rage.Value) (rage.Value, error) {
+		return getVar(lookup, opts.Prefix, "env", args)
+	}); err != nil {
+		return err
+	}
+	if err := state.RegisterFunc("env_duration", func(args ...rage.Value) (rage.Value, error) {
+		return getDuration(lookup, opts.Prefix, args)
+	}); err != nil {
+		return err
+	}
+	return state.RegisterFunc("secret", func(args ...rage.Value) (rage.Value, error) {
+		return getVar(secrets, opts.Prefix, "secret", args)
+	})
+}
+
+// getVar implements env() and secret(): look the variable up, fall back to
+// the default, and coerce the raw string to the default's type.
+func getVar(lookup func(string) (string, bool), prefix, fn string, args []rage.Value) (rage.Value, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("TypeError: %s() takes 1 or 2 arguments, got %d", fn, len(args))
+	}
+	name, ok := rage.AsString(args[0])
+	if !ok {
+		return nil, fmt.Errorf("TypeError: %s() name must be a string", fn)
+	}
+
+	raw, found := lookup(prefix + name)
+	if !found {
+		if len(args) == 2 {
+			return args[1], nil
+		}
+		return nil, fmt.Errorf("KeyError: %s variable '%s%s' is not set", fn, prefix, name)
+	}
+	if len(args) == 2 {
+		return coerce(raw, args[1], prefix+name)
+	}
+	return rage.String(raw), nil
+}
+
+// coerce converts a raw variable value to the type of the script's default.
+func coerce(raw string, def rage.Value, varName string) (rage.Value, error) {
+	switch def.Type() {
+	case "int":
+		n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ValueError: variable '%s' is not an integer: %q", varName, raw)
+		}
+		return rage.Int(n), nil
+	case "float":
+		f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return nil, fmt.Errorf("ValueError: variable '%s' is not a number: %q", varName, raw)
+		}
+		return rage.Float(f), nil
+	case "bool":
+		switch strings.ToLower(strings.TrimSpace(raw)) {
+		case "1", "true", "yes", "on":
+			return rage.Bool(true), nil
+		case "0", "false", "no", "off":
+			return rage.Bool(false), nil
+		}
+		return nil, fmt.Errorf("ValueError: variable '%s' is not a boolean: %q", varName, raw)
+	default:
+		return rage.String(raw), nil
+	}
+}
+
+// getDuration implements env_duration(): the variable (or default) is parsed
+// with Go duration syntax and returned as seconds, since Python scripts deal
+// most naturally in numbers.
+func getDuration(lookup func(string) (string, bool), prefix string, args []rage.Value) (rage.Value, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("TypeError: env_duration() takes 1 or 2 arguments, got %d", len(args))
+	}
+	name, ok := rage.AsString(args[0])
+	if !ok {
+		return nil, fmt.Errorf("TypeError: env_duration() name must be a string")
+	}
+
+	raw, found := lookup(prefix + name)
+	if !found {
+		if len(args) == 2 {
+			switch def := args[1]; def.Type() {
+			case "int", "float":
+				f, _ := rage.AsFloat(def)
+				return rage.Float(f), nil
+			default:
+				s, ok := rage.AsString(def)
+				if !ok {
+					return nil, fmt.Errorf("TypeError: env_duration() default must be a duration string or a number of seconds")
+				}
+				raw = s
+			}
+		} else {
+			return nil, fmt.Errorf("KeyError: env variable '%s%s' is not set", prefix, name)
+		}
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("ValueError: variable '%s%s' is not a duration: %q", prefix, name, raw)
+	}
+	return rage.Float(d.Seconds()), nil
+}
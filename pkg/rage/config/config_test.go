@@ -0,0 +1,150 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+)
+
+// fakeEnv returns a Lookup backed by a map.
+func fakeEnv(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func TestEnv_TypedCoercion(t *testing.T) {
+	state := rage.NewState()
+	defer state.Close()
+
+	err := Install(state, Options{
+		Prefix: "APP_",
+		Lookup: fakeEnv(map[string]string{
+			"APP_HOST":  "example.com",
+			"APP_PORT":  "8080",
+			"APP_DEBUG": "yes",
+			"APP_RATIO": "0.5",
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	if _, err := state.Run(`
+host = env("HOST")
+port = env("PORT", 80)
+debug = env("DEBUG", False)
+ratio = env("RATIO", 1.0)
+missing = env("MISSING", "fallback")
+`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, _ := state.GetGlobalString("host"); s != "example.com" {
+		t.Errorf("host = %q", s)
+	}
+	if n, _ := state.GetGlobalInt("port"); n != 8080 {
+		t.Errorf("port = %d", n)
+	}
+	if b, _ := state.GetGlobalBool("debug"); !b {
+		t.Error("expected debug to coerce to True")
+	}
+	if f, _ := state.GetGlobalFloat("ratio"); f != 0.5 {
+		t.Errorf("ratio = %v", f)
+	}
+	if s, _ := state.GetGlobalString("missing"); s != "fallback" {
+		t.Errorf("missing = %q", s)
+	}
+}
+
+func TestEnv_Errors(t *testing.T) {
+	state := rage.NewState()
+	defer state.Close()
+
+	err := Install(state, Options{
+		Lookup: fakeEnv(map[string]string{"PORT": "not-a-number"}),
+	})
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	if _, err := state.Run(`env("ABSENT")`); err == nil || !strings.Contains(err.Error(), "not set") {
+		t.Errorf("expected a missing-variable error, got %v", err)
+	}
+	if _, err := state.Run(`env("PORT", 80)`); err == nil || !strings.Contains(err.Error(), "not an integer") {
+		t.Errorf("expected a coercion error, got %v", err)
+	}
+}
+
+func TestInstall_RequiredValidation(t *testing.T) {
+	state := rage.NewState()
+	defer state.Close()
+
+	err := Install(state, Options{
+		Prefix:   "APP_",
+		Required: []string{"HOST", "TOKEN"},
+		Lookup:   fakeEnv(map[string]string{"APP_HOST": "h"}),
+	})
+	if err == nil || !strings.Contains(err.Error(), "APP_TOKEN") {
+		t.Errorf("expected Install to report the missing variable, got %v", err)
+	}
+}
+
+func TestEnvDuration(t *testing.T) {
+	state := rage.NewState()
+	defer state.Close()
+
+	err := Install(state, Options{
+		Lookup: fakeEnv(map[string]string{"TICK": "250ms", "BAD": "soon"}),
+	})
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	if _, err := state.Run(`
+tick = env_duration("TICK")
+idle = env_duration("IDLE", "1h30m")
+grace = env_duration("GRACE", 5)
+`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f, _ := state.GetGlobalFloat("tick"); f != 0.25 {
+		t.Errorf("tick = %v", f)
+	}
+	if f, _ := state.GetGlobalFloat("idle"); f != 5400 {
+		t.Errorf("idle = %v", f)
+	}
+	if f, _ := state.GetGlobalFloat("grace"); f != 5 {
+		t.Errorf("grace = %v", f)
+	}
+	if _, err := state.Run(`env_duration("BAD")`); err == nil || !strings.Contains(err.Error(), "not a duration") {
+		t.Errorf("expected a duration parse error, got %v", err)
+	}
+}
+
+func TestSecret_SeparateSource(t *testing.T) {
+	state := rage.NewState()
+	defer state.Close()
+
+	err := Install(state, Options{
+		Lookup:  fakeEnv(map[string]string{"API_KEY": "from-env"}),
+		Secrets: fakeEnv(map[string]string{"API_KEY": "from-vault"}),
+	})
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	if _, err := state.Run(`
+plain = env("API_KEY")
+hidden = secret("API_KEY")
+`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, _ := state.GetGlobalString("plain"); s != "from-env" {
+		t.Errorf("plain = %q", s)
+	}
+	if s, _ := state.GetGlobalString("hidden"); s != "from-vault" {
+		t.Errorf("hidden = %q", s)
+	}
+}
@@ -0,0 +1,259 @@
+package rage
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// This file implements State.DataclassFromStruct, which generates a real
+// Python dataclass (fields, defaults, annotations, __init__/__eq__/__repr__)
+// from a Go struct via reflection, plus UnmarshalDataclass to read instances
+// back into Go. It is the higher-level sibling of BindGlobals for config
+// schemas: field naming reuses the same `rage` struct tags.
+
+// DataclassFromStruct generates a Python dataclass from prototype, a struct
+// (or pointer to struct), registers it under name as a global, and returns
+// the class. The prototype's field values become the dataclass defaults, so
+// scripts can write Config(port=9000) and inherit the rest:
+//
+//	cls, err := state.DataclassFromStruct("Config", Config{Host: "localhost", Port: 8080})
+//
+// Mutable defaults (slices, maps, nested structs) are handled with
+// default_factory so instances do not share them.
+func (s *State) DataclassFromStruct(name string, prototype any) (ClassValue, error) {
+	if err := s.checkClosed(); err != nil {
+		return ClassValue{}, err
+	}
+	rv := reflect.ValueOf(prototype)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return ClassValue{}, fmt.Errorf("DataclassFromStruct: prototype must not be nil")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return ClassValue{}, fmt.Errorf("DataclassFromStruct: prototype must be a struct, got %T", prototype)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "from dataclasses import dataclass as __rage_dc__, field as __rage_dc_field__\n\n")
+	fmt.Fprintf(&b, "@__rage_dc__\nclass %s:\n", name)
+
+	fields := 0
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldName, _ := parseBindTag(field)
+		if fieldName == "-" {
+			continue
+		}
+		literal, err := pyLiteral(rv.Field(i))
+		if err != nil {
+			return ClassValue{}, fmt.Errorf("DataclassFromStruct: field %s: %w", field.Name, err)
+		}
+		if strings.HasPrefix(literal, "[") || strings.HasPrefix(literal, "{") {
+			// Mutable default: route through default_factory
+			literal = fmt.Sprintf("__rage_dc_field__(default_factory=lambda: %s)", literal)
+		}
+		fmt.Fprintf(&b, "    %s: %s = %s\n", fieldName, pyAnnotation(field.Type), literal)
+		fields++
+	}
+	if fields == 0 {
+		return ClassValue{}, fmt.Errorf("DataclassFromStruct: %s has no usable fields", rt)
+	}
+	b.WriteString("\ndel __rage_dc__\ndel __rage_dc_field__\n")
+
+	if _, err := s.Run(b.String()); err != nil {
+		return ClassValue{}, fmt.Errorf("DataclassFromStruct: %w", err)
+	}
+	cls, ok := AsClass(s.GetGlobal(name))
+	if !ok {
+		return ClassValue{}, fmt.Errorf("DataclassFromStruct: class %s was not created", name)
+	}
+	return cls, nil
+}
+
+// UnmarshalDataclass populates dest, a pointer to a struct, from a dataclass
+// instance built in Python — the inverse of DataclassFromStruct. Field naming
+// follows the same `rage` tags; a missing attribute is an error unless the
+// field is tagged `,optional`.
+func (s *State) UnmarshalDataclass(v Value, dest any) error {
+	if err := s.checkClosed(); err != nil {
+		return err
+	}
+	obj, ok := AsObject(v)
+	if !ok {
+		return fmt.Errorf("UnmarshalDataclass: expected a class instance, got %s", v.Type())
+	}
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("UnmarshalDataclass: dest must be a non-nil pointer to a struct, got %T", dest)
+	}
+	elem := rv.Elem()
+	elemType := elem.Type()
+
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, optional := parseBindTag(field)
+		if name == "-" {
+			continue
+		}
+		attr := obj.Get(name)
+		if attr == nil || IsNone(attr) {
+			if !optional {
+				return fmt.Errorf("UnmarshalDataclass: field %s (attribute '%s'): attribute is not set", field.Name, name)
+			}
+			continue
+		}
+		if err := bindValue(attr, elem.Field(i)); err != nil {
+			return fmt.Errorf("UnmarshalDataclass: field %s (attribute '%s'): %w", field.Name, name, err)
+		}
+	}
+	return nil
+}
+
+// pyAnnotation maps a Go type to the Python annotation used on the
+// generated dataclass field.
+func pyAnnotation(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.String:
+		return "str"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+		return "list"
+	case reflect.Map, reflect.Struct:
+		return "dict"
+	case reflect.Ptr:
+		return pyAnnotation(t.Elem())
+	default:
+		return "object"
+	}
+}
+
+// pyLiteral renders a Go value as a Python literal for use as a dataclass
+// default, recursing through slices, string-keyed maps, and nested structs.
+func pyLiteral(rv reflect.Value) (string, error) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return "True", nil
+		}
+		return "False", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		lit := strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+		if !strings.ContainsAny(lit, ".eE") {
+			lit += ".0"
+		}
+		return lit, nil
+	case reflect.String:
+		return pyQuote(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return "b" + pyQuote(string(rv.Bytes())), nil
+		}
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			lit, err := pyLiteral(rv.Index(i))
+			if err != nil {
+				return "", err
+			}
+			parts[i] = lit
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return "", fmt.Errorf("unsupported map key type %s", rv.Type().Key())
+		}
+		keys := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			lit, err := pyLiteral(rv.MapIndex(reflect.ValueOf(k)))
+			if err != nil {
+				return "", err
+			}
+			parts[i] = pyQuote(k) + ": " + lit
+		}
+		return "{" + strings.Join(parts, ", ") + "}", nil
+	case reflect.Struct:
+		rt := rv.Type()
+		var parts []string
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _ := parseBindTag(field)
+			if name == "-" {
+				continue
+			}
+			lit, err := pyLiteral(rv.Field(i))
+			if err != nil {
+				return "", fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			parts = append(parts, pyQuote(name)+": "+lit)
+		}
+		return "{" + strings.Join(parts, ", ") + "}", nil
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "None", nil
+		}
+		return pyLiteral(rv.Elem())
+	case reflect.Interface:
+		if rv.IsNil() {
+			return "None", nil
+		}
+		return pyLiteral(rv.Elem())
+	default:
+		return "", fmt.Errorf("unsupported type %s", rv.Type())
+	}
+}
+
+// pyQuote renders a Go string as a single-quoted Python string literal.
+func pyQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
@@ -0,0 +1,155 @@
+package rage
+
+import "testing"
+
+type dcConfig struct {
+	Host    string           `rage:"host"`
+	Port    int64            `rage:"port"`
+	Debug   bool             `rage:"debug"`
+	Ratio   float64          `rage:"ratio"`
+	Tags    []string         `rage:"tags"`
+	Limits  map[string]int64 `rage:"limits"`
+	Comment string           `rage:"-"`
+	hidden  string           //lint:ignore U1000 exercised as a skipped field
+}
+
+func newDCState(t *testing.T) *State {
+	t.Helper()
+	state := NewState()
+	t.Cleanup(state.Close)
+
+	proto := dcConfig{
+		Host:   "localhost",
+		Port:   8080,
+		Ratio:  0.5,
+		Tags:   []string{"a", "b"},
+		Limits: map[string]int64{"rps": 100},
+	}
+	if _, err := state.DataclassFromStruct("Config", proto); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return state
+}
+
+func TestDataclassFromStruct_DefaultsAndOverrides(t *testing.T) {
+	state := newDCState(t)
+
+	_, err := state.Run(`
+c = Config(port=9000, debug=True)
+host = c.host
+port = c.port
+debug = c.debug
+ratio = c.ratio
+tags = c.tags
+rps = c.limits["rps"]
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := state.GetGlobalString("host"); v != "localhost" {
+		t.Errorf("expected default host, got %q", v)
+	}
+	if v, _ := state.GetGlobalInt("port"); v != 9000 {
+		t.Errorf("expected overridden port, got %d", v)
+	}
+	if v, _ := state.GetGlobalBool("debug"); !v {
+		t.Error("expected debug=True")
+	}
+	if v, _ := state.GetGlobalFloat("ratio"); v != 0.5 {
+		t.Errorf("expected ratio=0.5, got %g", v)
+	}
+	if tags, _ := state.GetGlobalSlice("tags"); len(tags) != 2 {
+		t.Errorf("expected 2 default tags, got %v", tags)
+	}
+	if v, _ := state.GetGlobalInt("rps"); v != 100 {
+		t.Errorf("expected limits['rps']=100, got %d", v)
+	}
+}
+
+func TestDataclassFromStruct_IsRealDataclass(t *testing.T) {
+	state := newDCState(t)
+
+	_, err := state.Run(`
+import dataclasses
+c = Config()
+eq = c == Config()
+ne = c != Config(port=1)
+rep = repr(c)
+field_count = len(dataclasses.fields(Config))
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := state.GetGlobalBool("eq"); !v {
+		t.Error("expected instances with equal fields to compare equal")
+	}
+	if v, _ := state.GetGlobalBool("ne"); !v {
+		t.Error("expected differing instances to compare unequal")
+	}
+	if rep, _ := state.GetGlobalString("rep"); rep == "" {
+		t.Error("expected a dataclass __repr__")
+	}
+	if n, _ := state.GetGlobalInt("field_count"); n != 6 {
+		t.Errorf("expected 6 dataclass fields, got %d", n)
+	}
+}
+
+func TestDataclassFromStruct_MutableDefaultsNotShared(t *testing.T) {
+	state := newDCState(t)
+
+	_, err := state.Run(`
+c1 = Config()
+c2 = Config()
+c1.tags.append("extra")
+len1 = len(c1.tags)
+len2 = len(c2.tags)
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := state.GetGlobalInt("len1"); v != 3 {
+		t.Errorf("expected c1.tags to grow to 3, got %d", v)
+	}
+	if v, _ := state.GetGlobalInt("len2"); v != 2 {
+		t.Errorf("expected c2.tags to stay at 2, got %d", v)
+	}
+}
+
+func TestUnmarshalDataclass(t *testing.T) {
+	state := newDCState(t)
+
+	_, err := state.Run(`c = Config(host="example.com", port=443, tags=["x"])`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var cfg dcConfig
+	if err := state.UnmarshalDataclass(state.GetGlobal("c"), &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "example.com" || cfg.Port != 443 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if len(cfg.Tags) != 1 || cfg.Tags[0] != "x" {
+		t.Errorf("expected tags=[x], got %v", cfg.Tags)
+	}
+	if cfg.Limits["rps"] != 100 {
+		t.Errorf("expected default limits to round-trip, got %v", cfg.Limits)
+	}
+
+	if err := state.UnmarshalDataclass(Int(1), &cfg); err == nil {
+		t.Error("expected error for a non-instance value")
+	}
+}
+
+func TestDataclassFromStruct_InvalidPrototype(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	if _, err := state.DataclassFromStruct("X", 42); err == nil {
+		t.Error("expected error for non-struct prototype")
+	}
+	if _, err := state.DataclassFromStruct("X", (*dcConfig)(nil)); err == nil {
+		t.Error("expected error for nil prototype")
+	}
+}
@@ -0,0 +1,202 @@
+// Package docgen documents the host API a State exposes to scripts: every
+// function registered with Register, every class installed with SetGlobal,
+// and every plain global. The extracted model renders as Markdown for
+// humans or as a Python .pyi stub so script authors get IDE autocompletion
+// for the embedding's functions and classes.
+//
+//	api := docgen.Extract(state)
+//	os.WriteFile("host_api.md", []byte(api.Markdown()), 0o644)
+//	os.WriteFile("host.pyi", []byte(api.Stubs()), 0o644)
+package docgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/ATSOTECK/rage/pkg/rage"
+)
+
+// Param is one parameter in a documented signature.
+type Param struct {
+	Name    string
+	Default string // Python literal, empty when the parameter is required
+	KwOnly  bool
+}
+
+// Function documents one callable. Go-implemented functions carry no
+// signature information and are rendered with *args, **kwargs.
+type Function struct {
+	Name   string
+	Doc    string
+	Params []Param
+	Go     bool // implemented in Go; the signature is unknown
+}
+
+// Property documents a property on a class.
+type Property struct {
+	Name     string
+	ReadOnly bool
+}
+
+// Class documents one class and the members it defines directly.
+type Class struct {
+	Name       string
+	Doc        string
+	Bases      []string
+	Methods    []Function
+	Properties []Property
+	Attrs      []string // class-level attributes (constants)
+}
+
+// API is the extracted host API of a State.
+type API struct {
+	Functions []Function
+	Classes   []Class
+}
+
+// Extract walks the globals of a State and builds the documentation model.
+// Dunder globals are skipped; everything else is sorted by name.
+func Extract(s *rage.State) *API {
+	api := &API{}
+	for name, v := range s.Globals() {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		switch rv := v.GoValue().(type) {
+		case *runtime.PyGoFunc, *runtime.PyBuiltinFunc:
+			api.Functions = append(api.Functions, Function{Name: name, Go: true})
+		case *runtime.PyFunction:
+			fn := extractFunction(rv)
+			fn.Name = name
+			api.Functions = append(api.Functions, fn)
+		case *runtime.PyClass:
+			api.Classes = append(api.Classes, extractClass(name, rv))
+		}
+	}
+	sort.Slice(api.Functions, func(i, j int) bool { return api.Functions[i].Name < api.Functions[j].Name })
+	sort.Slice(api.Classes, func(i, j int) bool { return api.Classes[i].Name < api.Classes[j].Name })
+	return api
+}
+
+// extractFunction reads signature and docstring metadata from a compiled
+// Python function. The caller fills in Name.
+func extractFunction(fn *runtime.PyFunction) Function {
+	code := fn.Code
+	out := Function{Doc: code.Doc}
+
+	var defaults []runtime.Value
+	if fn.Defaults != nil {
+		defaults = fn.Defaults.Items
+	}
+	firstDefault := code.ArgCount - len(defaults)
+	for i := 0; i < code.ArgCount && i < len(code.VarNames); i++ {
+		p := Param{Name: code.VarNames[i]}
+		if i >= firstDefault {
+			p.Default = pyLiteral(defaults[i-firstDefault])
+		}
+		out.Params = append(out.Params, p)
+	}
+	for i := code.ArgCount; i < code.ArgCount+code.KwOnlyArgCount && i < len(code.VarNames); i++ {
+		p := Param{Name: code.VarNames[i], KwOnly: true}
+		if d, ok := fn.KwDefaults[code.VarNames[i]]; ok {
+			p.Default = pyLiteral(d)
+		}
+		out.Params = append(out.Params, p)
+	}
+	return out
+}
+
+// extractClass documents the members a class defines directly: methods
+// (Python or Go), properties, and plain class attributes. Dunders other
+// than __init__ are omitted.
+func extractClass(name string, cls *runtime.PyClass) Class {
+	out := Class{Name: name}
+	for _, base := range cls.Bases {
+		if base.Name != "object" {
+			out.Bases = append(out.Bases, base.Name)
+		}
+	}
+	if doc, ok := cls.Dict["__doc__"].(*runtime.PyString); ok {
+		out.Doc = doc.Value
+	}
+	for mname, mval := range cls.Dict {
+		if strings.HasPrefix(mname, "__") && mname != "__init__" {
+			continue
+		}
+		switch mv := mval.(type) {
+		case *runtime.PyFunction:
+			fn := extractFunction(mv)
+			fn.Name = mname
+			out.Methods = append(out.Methods, fn)
+		case *runtime.PyBuiltinFunc, *runtime.PyGoFunc:
+			out.Methods = append(out.Methods, Function{Name: mname, Go: true})
+		case *runtime.PyProperty:
+			out.Properties = append(out.Properties, Property{Name: mname, ReadOnly: mv.Fset == nil})
+		case *runtime.PyStaticMethod, *runtime.PyClassMethod:
+			out.Methods = append(out.Methods, Function{Name: mname, Go: true})
+		default:
+			out.Attrs = append(out.Attrs, mname)
+		}
+	}
+	sort.Slice(out.Methods, func(i, j int) bool { return out.Methods[i].Name < out.Methods[j].Name })
+	sort.Slice(out.Properties, func(i, j int) bool { return out.Properties[i].Name < out.Properties[j].Name })
+	sort.Strings(out.Attrs)
+	return out
+}
+
+// signature renders a function's parameter list, with self prepended for
+// methods when the compiled signature does not already include it.
+func (f Function) signature(method bool) string {
+	if f.Go {
+		if method {
+			return "self, *args, **kwargs"
+		}
+		return "*args, **kwargs"
+	}
+	var parts []string
+	kwMarker := false
+	for _, p := range f.Params {
+		if p.KwOnly && !kwMarker {
+			parts = append(parts, "*")
+			kwMarker = true
+		}
+		if p.Default != "" {
+			parts = append(parts, p.Name+"="+p.Default)
+		} else {
+			parts = append(parts, p.Name)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pyLiteral renders a default value as Python source. Values without an
+// obvious literal form render as "..." which is itself valid Python.
+func pyLiteral(v runtime.Value) string {
+	switch val := v.(type) {
+	case *runtime.PyNone:
+		return "None"
+	case *runtime.PyBool:
+		if val.Value {
+			return "True"
+		}
+		return "False"
+	case *runtime.PyInt:
+		return fmt.Sprintf("%d", val.Value)
+	case *runtime.PyFloat:
+		return fmt.Sprintf("%g", val.Value)
+	case *runtime.PyString:
+		return fmt.Sprintf("%q", val.Value)
+	default:
+		return "..."
+	}
+}
+
+// docSummary returns the first line of a docstring.
+func docSummary(doc string) string {
+	if i := strings.IndexByte(doc, '\n'); i >= 0 {
+		return strings.TrimSpace(doc[:i])
+	}
+	return strings.TrimSpace(doc)
+}
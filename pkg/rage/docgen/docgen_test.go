@@ -0,0 +1,128 @@
+package docgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+)
+
+func buildState(t *testing.T) *rage.State {
+	t.Helper()
+	state := rage.NewState()
+	t.Cleanup(state.Close)
+
+	state.Register("spawn", func(s *rage.State, args ...rage.Value) rage.Value {
+		return rage.None
+	})
+
+	cls := rage.NewClass("Timer").
+		Init(func(s *rage.State, self rage.Object, args ...rage.Value) error {
+			return nil
+		}).
+		Method("reset", func(s *rage.State, self rage.Object, args ...rage.Value) (rage.Value, error) {
+			return rage.None, nil
+		}).
+		Property("elapsed", func(s *rage.State, self rage.Object) (rage.Value, error) {
+			return rage.Float(0), nil
+		}).
+		ClassAttr("RESOLUTION", rage.Float(0.001)).
+		Build(state)
+	state.SetGlobal("Timer", cls)
+
+	_, err := state.Run(`
+def greet(name, punct="!"):
+    """Say hello."""
+    return "hello " + name + punct
+
+class Animal:
+    """A base animal."""
+    def speak(self):
+        """Make a noise."""
+        return "..."
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return state
+}
+
+func TestExtract(t *testing.T) {
+	api := Extract(buildState(t))
+
+	if len(api.Functions) != 2 {
+		t.Fatalf("expected 2 functions, got %d", len(api.Functions))
+	}
+	greet, spawn := api.Functions[0], api.Functions[1]
+	if greet.Name != "greet" || spawn.Name != "spawn" {
+		t.Fatalf("expected [greet spawn], got [%s %s]", greet.Name, spawn.Name)
+	}
+	if !spawn.Go {
+		t.Error("expected spawn to be marked as Go-implemented")
+	}
+	if greet.Go || greet.Doc != "Say hello." {
+		t.Errorf("unexpected greet metadata: %+v", greet)
+	}
+	if len(greet.Params) != 2 || greet.Params[1].Default != `"!"` {
+		t.Errorf("unexpected greet params: %+v", greet.Params)
+	}
+
+	if len(api.Classes) != 2 {
+		t.Fatalf("expected 2 classes, got %d", len(api.Classes))
+	}
+	animal, timer := api.Classes[0], api.Classes[1]
+	if animal.Name != "Animal" || timer.Name != "Timer" {
+		t.Fatalf("expected [Animal Timer], got [%s %s]", animal.Name, timer.Name)
+	}
+	if animal.Doc != "A base animal." {
+		t.Errorf("expected class docstring, got %q", animal.Doc)
+	}
+	if len(timer.Properties) != 1 || timer.Properties[0].Name != "elapsed" || !timer.Properties[0].ReadOnly {
+		t.Errorf("unexpected Timer properties: %+v", timer.Properties)
+	}
+	if len(timer.Attrs) != 1 || timer.Attrs[0] != "RESOLUTION" {
+		t.Errorf("unexpected Timer attrs: %+v", timer.Attrs)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	md := Extract(buildState(t)).Markdown()
+
+	for _, want := range []string{
+		"# Host API",
+		"### `greet(name, punct=\"!\")`",
+		"Say hello.",
+		"### `spawn(*args, **kwargs)`",
+		"Provided by the host application.",
+		"### `class Animal`",
+		"- `speak(self)` — Make a noise.",
+		"### `class Timer`",
+		"- `elapsed` (read-only)",
+		"- `RESOLUTION`",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q\n%s", want, md)
+		}
+	}
+}
+
+func TestStubs(t *testing.T) {
+	pyi := Extract(buildState(t)).Stubs()
+
+	for _, want := range []string{
+		"from typing import Any",
+		"def greet(name, punct=\"!\") -> Any: ...",
+		"def spawn(*args: Any, **kwargs: Any) -> Any: ...",
+		"class Animal:",
+		"def speak(self) -> Any: ...",
+		"class Timer:",
+		"RESOLUTION: Any",
+		"@property\n    def elapsed(self) -> Any: ...",
+		"def __init__(self, *args: Any, **kwargs: Any) -> None: ...",
+		"def reset(self, *args: Any, **kwargs: Any) -> Any: ...",
+	} {
+		if !strings.Contains(pyi, want) {
+			t.Errorf("stub missing %q\n%s", want, pyi)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package docgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown renders the API as a Markdown document.
+func (a *API) Markdown() string {
+	var b strings.Builder
+	b.WriteString("# Host API\n")
+
+	if len(a.Functions) > 0 {
+		b.WriteString("\n## Functions\n")
+		for _, fn := range a.Functions {
+			fmt.Fprintf(&b, "\n### `%s(%s)`\n", fn.Name, fn.signature(false))
+			if fn.Go {
+				b.WriteString("\nProvided by the host application.\n")
+			}
+			if fn.Doc != "" {
+				fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(fn.Doc))
+			}
+		}
+	}
+
+	if len(a.Classes) > 0 {
+		b.WriteString("\n## Classes\n")
+		for _, cls := range a.Classes {
+			heading := cls.Name
+			if len(cls.Bases) > 0 {
+				heading += "(" + strings.Join(cls.Bases, ", ") + ")"
+			}
+			fmt.Fprintf(&b, "\n### `class %s`\n", heading)
+			if cls.Doc != "" {
+				fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(cls.Doc))
+			}
+			if len(cls.Attrs) > 0 {
+				b.WriteString("\nAttributes:\n\n")
+				for _, attr := range cls.Attrs {
+					fmt.Fprintf(&b, "- `%s`\n", attr)
+				}
+			}
+			if len(cls.Properties) > 0 {
+				b.WriteString("\nProperties:\n\n")
+				for _, prop := range cls.Properties {
+					if prop.ReadOnly {
+						fmt.Fprintf(&b, "- `%s` (read-only)\n", prop.Name)
+					} else {
+						fmt.Fprintf(&b, "- `%s`\n", prop.Name)
+					}
+				}
+			}
+			if len(cls.Methods) > 0 {
+				b.WriteString("\nMethods:\n\n")
+				for _, m := range cls.Methods {
+					line := fmt.Sprintf("- `%s(%s)`", m.Name, m.signature(false))
+					if summary := docSummary(m.Doc); summary != "" {
+						line += " — " + summary
+					}
+					b.WriteString(line + "\n")
+				}
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// Stubs renders the API as a Python .pyi stub file suitable for IDE
+// autocompletion. Parameters without known types are annotated Any.
+func (a *API) Stubs() string {
+	var b strings.Builder
+	b.WriteString("from typing import Any\n")
+
+	for _, fn := range a.Functions {
+		fmt.Fprintf(&b, "\n%s\n", stubDef(fn, false))
+	}
+
+	for _, cls := range a.Classes {
+		heading := cls.Name
+		if len(cls.Bases) > 0 {
+			heading += "(" + strings.Join(cls.Bases, ", ") + ")"
+		}
+		fmt.Fprintf(&b, "\nclass %s:\n", heading)
+		empty := true
+		for _, attr := range cls.Attrs {
+			fmt.Fprintf(&b, "    %s: Any\n", attr)
+			empty = false
+		}
+		for _, prop := range cls.Properties {
+			fmt.Fprintf(&b, "    @property\n    def %s(self) -> Any: ...\n", prop.Name)
+			if !prop.ReadOnly {
+				fmt.Fprintf(&b, "    @%s.setter\n    def %s(self, value: Any) -> None: ...\n", prop.Name, prop.Name)
+			}
+			empty = false
+		}
+		for _, m := range cls.Methods {
+			fmt.Fprintf(&b, "    %s\n", stubDef(m, true))
+			empty = false
+		}
+		if empty {
+			b.WriteString("    ...\n")
+		}
+	}
+
+	return b.String()
+}
+
+// stubDef renders one def line for a stub. Methods get self prepended when
+// the compiled signature does not already carry it (Go methods).
+func stubDef(fn Function, method bool) string {
+	ret := "Any"
+	if fn.Name == "__init__" {
+		ret = "None"
+	}
+	if fn.Go {
+		sig := "*args: Any, **kwargs: Any"
+		if method {
+			sig = "self, " + sig
+		}
+		return fmt.Sprintf("def %s(%s) -> %s: ...", fn.Name, sig, ret)
+	}
+	var parts []string
+	kwMarker := false
+	for _, p := range fn.Params {
+		if p.KwOnly && !kwMarker {
+			parts = append(parts, "*")
+			kwMarker = true
+		}
+		if p.Default != "" {
+			parts = append(parts, p.Name+"="+p.Default)
+		} else {
+			parts = append(parts, p.Name)
+		}
+	}
+	return fmt.Sprintf("def %s(%s) -> %s: ...", fn.Name, strings.Join(parts, ", "), ret)
+}
@@ -0,0 +1,120 @@
+package rage
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// quotaError chooses its own Python exception type via PyErrorer.
+type quotaError struct {
+	limit int
+}
+
+func (e *quotaError) Error() string {
+	return fmt.Sprintf("quota of %d exceeded", e.limit)
+}
+
+func (e *quotaError) PyError() (string, string) {
+	return "ValueError", e.Error()
+}
+
+// registerFailer exposes a fail() global whose error the test controls.
+func registerFailer(state *State, err error) {
+	cls := NewClass("Failer").
+		Method("fail", func(s *State, self Object, args ...Value) (Value, error) {
+			return nil, err
+		}).
+		Build(state)
+	state.SetGlobal("failer", mustCall(state, cls))
+}
+
+func mustCall(state *State, cls ClassValue) Value {
+	obj, err := state.Call(cls)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+func runCatch(t *testing.T, state *State) (excType, cause string) {
+	t.Helper()
+	_, err := state.Run(`
+try:
+    failer.fail()
+    exc_type = "no error"
+    cause_type = ""
+except BaseException as e:
+    exc_type = type(e).__name__
+    cause_type = type(e.__cause__).__name__ if e.__cause__ is not None else ""
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	excType, _ = state.GetGlobalString("exc_type")
+	cause, _ = state.GetGlobalString("cause_type")
+	return excType, cause
+}
+
+func TestMapError_PyErrorerChoosesType(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	registerFailer(state, &quotaError{limit: 10})
+	excType, _ := runCatch(t, state)
+	if excType != "ValueError" {
+		t.Errorf("expected ValueError, got %s", excType)
+	}
+
+	// PyErrorer is found through wrapped errors too
+	registerFailer(state, fmt.Errorf("saving: %w", &quotaError{limit: 10}))
+	excType, _ = runCatch(t, state)
+	if excType != "ValueError" {
+		t.Errorf("expected ValueError through wrapping, got %s", excType)
+	}
+}
+
+func TestMapError_SentinelMapping(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+	state.MapError(os.ErrNotExist, "FileNotFoundError")
+	state.MapError(os.ErrPermission, "PermissionError")
+
+	registerFailer(state, fmt.Errorf("open config: %w", os.ErrNotExist))
+	excType, _ := runCatch(t, state)
+	if excType != "FileNotFoundError" {
+		t.Errorf("expected FileNotFoundError, got %s", excType)
+	}
+
+	registerFailer(state, os.ErrPermission)
+	excType, _ = runCatch(t, state)
+	if excType != "PermissionError" {
+		t.Errorf("expected PermissionError, got %s", excType)
+	}
+}
+
+func TestMapError_DefaultRuntimeErrorWithCause(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	// An unmapped, unprefixed error defaults to RuntimeError; the wrapped
+	// cause is surfaced as __cause__
+	registerFailer(state, fmt.Errorf("sync failed: %w", ValueError("bad record")))
+	excType, cause := runCatch(t, state)
+	if excType != "RuntimeError" {
+		t.Errorf("expected RuntimeError, got %s", excType)
+	}
+	if cause != "ValueError" {
+		t.Errorf("expected ValueError cause, got %q", cause)
+	}
+
+	// No wrapped cause: __cause__ stays None
+	registerFailer(state, fmt.Errorf("plain failure"))
+	excType, cause = runCatch(t, state)
+	if excType != "RuntimeError" {
+		t.Errorf("expected RuntimeError, got %s", excType)
+	}
+	if cause != "" {
+		t.Errorf("expected no cause, got %q", cause)
+	}
+}
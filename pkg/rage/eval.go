@@ -0,0 +1,218 @@
+package rage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/model"
+)
+
+// This file implements a restricted expression evaluator for user-supplied
+// formula fields (e.g. damage = "base * (1 + crit)"). The source is parsed
+// with the normal parser and the AST is checked against a whitelist of node
+// types before anything runs: statements, lambdas, comprehensions, assignment
+// expressions, dunder attribute access, and calls to anything but explicitly
+// allowed function names are all rejected at compile time.
+
+// EvalOption configures EvalExpr.
+type EvalOption func(*evalConfig)
+
+type evalConfig struct {
+	allowedCalls map[string]bool
+}
+
+// WithAllowedCalls whitelists function names the expression may call. The
+// names must resolve at evaluation time — either a default builtin (min, max,
+// abs, round, len, ...) or a callable passed in vars. Calls to any other
+// name, and calls through attributes or subscripts, are rejected before the
+// expression runs.
+func WithAllowedCalls(names ...string) EvalOption {
+	return func(c *evalConfig) {
+		for _, name := range names {
+			c.allowedCalls[name] = true
+		}
+	}
+}
+
+// EvalExpr evaluates a single Python expression against the given variables
+// in a sandboxed, throwaway State and returns the result.
+//
+// Only expression syntax is accepted: literals, names, arithmetic, boolean
+// and comparison operators, conditional expressions, f-strings, subscripts,
+// slices, and list/tuple/dict/set displays. Attribute access is allowed
+// except on dunder names, and no calls are permitted unless whitelisted via
+// WithAllowedCalls. Anything else — statements, lambdas, comprehensions,
+// yield/await, walrus assignments, starred unpacking — is reported as an
+// error without executing the expression.
+func EvalExpr(src string, vars map[string]Value, opts ...EvalOption) (Value, error) {
+	cfg := &evalConfig{allowedCalls: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	parser := compiler.NewParserWithFilename(src, "<expr>")
+	mod, parseErrs := parser.Parse()
+	if len(parseErrs) > 0 {
+		return nil, fmt.Errorf("invalid expression: %s", parseErrs[0].Error())
+	}
+	if len(mod.Body) != 1 {
+		return nil, fmt.Errorf("expected a single expression, got %d statements", len(mod.Body))
+	}
+	exprStmt, ok := mod.Body[0].(*model.ExprStmt)
+	if !ok {
+		return nil, fmt.Errorf("statements are not allowed in an expression sandbox")
+	}
+	if err := checkSandboxExpr(exprStmt.Value, cfg); err != nil {
+		return nil, err
+	}
+
+	state := NewState()
+	defer state.Close()
+	for name, v := range vars {
+		state.SetGlobal(name, v)
+	}
+	// Parenthesize so multi-line expressions work; the trailing newline keeps
+	// a comment in src from swallowing the closing paren.
+	if _, err := state.Run(fmt.Sprintf("__result__ = (%s\n)", src)); err != nil {
+		return nil, err
+	}
+	return state.GetGlobal("__result__"), nil
+}
+
+// checkSandboxExpr walks an expression tree and rejects anything outside the
+// sandbox whitelist. Errors name the offending construct so formula authors
+// can fix their input.
+func checkSandboxExpr(e model.Expr, cfg *evalConfig) error {
+	switch ex := e.(type) {
+	case *model.Identifier, *model.IntLit, *model.FloatLit, *model.ImaginaryLit,
+		*model.StringLit, *model.BytesLit, *model.BoolLit, *model.NoneLit, *model.Ellipsis:
+		return nil
+
+	case *model.FStringLit:
+		for _, part := range ex.Parts {
+			if part.IsExpr {
+				if err := checkSandboxExpr(part.Expr, cfg); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+
+	case *model.UnaryOp:
+		return checkSandboxExpr(ex.Operand, cfg)
+
+	case *model.BinaryOp:
+		if err := checkSandboxExpr(ex.Left, cfg); err != nil {
+			return err
+		}
+		return checkSandboxExpr(ex.Right, cfg)
+
+	case *model.BoolOp:
+		return checkSandboxExprs(ex.Values, cfg)
+
+	case *model.Compare:
+		if err := checkSandboxExpr(ex.Left, cfg); err != nil {
+			return err
+		}
+		return checkSandboxExprs(ex.Comparators, cfg)
+
+	case *model.IfExpr:
+		if err := checkSandboxExpr(ex.Test, cfg); err != nil {
+			return err
+		}
+		if err := checkSandboxExpr(ex.Body, cfg); err != nil {
+			return err
+		}
+		return checkSandboxExpr(ex.OrElse, cfg)
+
+	case *model.List:
+		return checkSandboxExprs(ex.Elts, cfg)
+	case *model.Tuple:
+		return checkSandboxExprs(ex.Elts, cfg)
+	case *model.Set:
+		return checkSandboxExprs(ex.Elts, cfg)
+
+	case *model.Dict:
+		for i, key := range ex.Keys {
+			if key == nil {
+				return fmt.Errorf("dict unpacking is not allowed in an expression sandbox")
+			}
+			if err := checkSandboxExpr(key, cfg); err != nil {
+				return err
+			}
+			if err := checkSandboxExpr(ex.Values[i], cfg); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *model.Subscript:
+		if err := checkSandboxExpr(ex.Value, cfg); err != nil {
+			return err
+		}
+		return checkSandboxExpr(ex.Slice, cfg)
+
+	case *model.Slice:
+		for _, part := range []model.Expr{ex.Lower, ex.Upper, ex.Step} {
+			if part != nil {
+				if err := checkSandboxExpr(part, cfg); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+
+	case *model.Attribute:
+		if name := ex.Attr.Name; strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__") {
+			return fmt.Errorf("access to dunder attribute '%s' is not allowed", name)
+		}
+		return checkSandboxExpr(ex.Value, cfg)
+
+	case *model.Call:
+		fn, ok := ex.Func.(*model.Identifier)
+		if !ok {
+			return fmt.Errorf("only calls to whitelisted function names are allowed")
+		}
+		if !cfg.allowedCalls[fn.Name] {
+			return fmt.Errorf("call to '%s' is not allowed; whitelist it with WithAllowedCalls", fn.Name)
+		}
+		if err := checkSandboxExprs(ex.Args, cfg); err != nil {
+			return err
+		}
+		for _, kw := range ex.Keywords {
+			if kw.Arg == nil {
+				return fmt.Errorf("keyword unpacking is not allowed in an expression sandbox")
+			}
+			if err := checkSandboxExpr(kw.Value, cfg); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *model.Lambda:
+		return fmt.Errorf("lambda expressions are not allowed in an expression sandbox")
+	case *model.ListComp, *model.SetComp, *model.DictComp, *model.GeneratorExpr:
+		return fmt.Errorf("comprehensions are not allowed in an expression sandbox")
+	case *model.Yield, *model.YieldFrom:
+		return fmt.Errorf("yield is not allowed in an expression sandbox")
+	case *model.Await:
+		return fmt.Errorf("await is not allowed in an expression sandbox")
+	case *model.NamedExpr:
+		return fmt.Errorf("assignment expressions are not allowed in an expression sandbox")
+	case *model.Starred:
+		return fmt.Errorf("starred unpacking is not allowed in an expression sandbox")
+
+	default:
+		return fmt.Errorf("unsupported syntax in an expression sandbox")
+	}
+}
+
+func checkSandboxExprs(exprs []model.Expr, cfg *evalConfig) error {
+	for _, e := range exprs {
+		if err := checkSandboxExpr(e, cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package rage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvalExpr_Formula(t *testing.T) {
+	result, err := EvalExpr("base * (1 + crit)", map[string]Value{
+		"base": Float(10),
+		"crit": Float(0.5),
+	})
+	if err != nil {
+		t.Fatalf("EvalExpr failed: %v", err)
+	}
+	f, ok := AsFloat(result)
+	if !ok || f != 15.0 {
+		t.Errorf("expected 15.0, got %v", result)
+	}
+}
+
+func TestEvalExpr_ConditionalAndComparison(t *testing.T) {
+	result, err := EvalExpr(`"high" if hp > 50 else "low"`, map[string]Value{
+		"hp": Int(75),
+	})
+	if err != nil {
+		t.Fatalf("EvalExpr failed: %v", err)
+	}
+	s, _ := AsString(result)
+	if s != "high" {
+		t.Errorf("expected 'high', got %v", result)
+	}
+}
+
+func TestEvalExpr_RejectsStatements(t *testing.T) {
+	cases := []string{
+		"x = 1",
+		"import os",
+		"del x",
+	}
+	for _, src := range cases {
+		if _, err := EvalExpr(src, nil); err == nil {
+			t.Errorf("expected %q to be rejected", src)
+		}
+	}
+}
+
+func TestEvalExpr_RejectsUnlistedCalls(t *testing.T) {
+	_, err := EvalExpr("min(1, 2)", nil)
+	if err == nil || !strings.Contains(err.Error(), "call to 'min' is not allowed") {
+		t.Errorf("expected unlisted call error, got %v", err)
+	}
+}
+
+func TestEvalExpr_AllowedCalls(t *testing.T) {
+	result, err := EvalExpr("min(a, b)", map[string]Value{
+		"a": Int(3),
+		"b": Int(7),
+	}, WithAllowedCalls("min"))
+	if err != nil {
+		t.Fatalf("EvalExpr failed: %v", err)
+	}
+	n, _ := AsInt(result)
+	if n != 3 {
+		t.Errorf("expected 3, got %v", result)
+	}
+}
+
+func TestEvalExpr_RejectsDunderAccess(t *testing.T) {
+	_, err := EvalExpr("x.__class__", map[string]Value{"x": Int(1)})
+	if err == nil || !strings.Contains(err.Error(), "dunder attribute '__class__'") {
+		t.Errorf("expected dunder access error, got %v", err)
+	}
+}
+
+func TestEvalExpr_RejectsEscapeHatches(t *testing.T) {
+	cases := []string{
+		"lambda: 1",
+		"[x for x in y]",
+		"(x := 1)",
+		"x.method()",
+		"f(*args)",
+	}
+	for _, src := range cases {
+		if _, err := EvalExpr(src, nil, WithAllowedCalls("f")); err == nil {
+			t.Errorf("expected %q to be rejected", src)
+		}
+	}
+}
+
+func TestEvalExpr_SubscriptAndFString(t *testing.T) {
+	result, err := EvalExpr(`f"{stats['str'] + 2}"`, map[string]Value{
+		"stats": Dict("str", Int(10)),
+	})
+	if err != nil {
+		t.Fatalf("EvalExpr failed: %v", err)
+	}
+	s, _ := AsString(result)
+	if s != "12" {
+		t.Errorf("expected '12', got %v", result)
+	}
+}
@@ -0,0 +1,141 @@
+package rage
+
+// Priority-scheduled execution of many scripts in one host.
+//
+// An Executor multiplexes any number of in-progress scripts over the
+// instruction-budget mechanism from StartRun: each Step call divides an
+// instruction budget among the unfinished jobs in proportion to their
+// priorities. A busy background script cannot starve a latency-sensitive
+// one — it only ever receives its slice of each step. Every job needs its
+// own State, since a State runs one script at a time.
+
+// Executor schedules scripts submitted with Submit across shared
+// instruction budgets. It is not safe for concurrent use.
+type Executor struct {
+	jobs []*Job
+}
+
+// Job is one script scheduled on an Executor.
+type Job struct {
+	name     string
+	priority int
+	run      *StepRun
+}
+
+// NewExecutor creates an empty Executor.
+func NewExecutor() *Executor {
+	return &Executor{}
+}
+
+// Submit compiles source on s and schedules it under the given priority.
+// Priorities below 1 are clamped to 1; a job with priority 3 receives
+// three times the instructions of a priority-1 job each Step. No
+// instructions run until the first Step call.
+func (e *Executor) Submit(s *State, name, source string, priority int) (*Job, error) {
+	if priority < 1 {
+		priority = 1
+	}
+	run, err := s.StartRun(source)
+	if err != nil {
+		return nil, err
+	}
+	t := &Job{name: name, priority: priority, run: run}
+	e.jobs = append(e.jobs, t)
+	return t, nil
+}
+
+// Step lets every unfinished job execute its share of budget instructions,
+// split in proportion to job priorities (each unfinished job always
+// receives at least one instruction). It returns the number of jobs still
+// unfinished afterwards.
+func (e *Executor) Step(budget int) int {
+	total := 0
+	for _, t := range e.jobs {
+		if !t.run.Done() {
+			total += t.priority
+		}
+	}
+	if total == 0 || budget <= 0 {
+		return e.runnable()
+	}
+
+	for _, t := range e.jobs {
+		if t.run.Done() {
+			continue
+		}
+		share := budget * t.priority / total
+		if share < 1 {
+			share = 1
+		}
+		t.run.RunSteps(share)
+	}
+	return e.runnable()
+}
+
+// Run keeps stepping with budget-sized slices until every job finishes.
+func (e *Executor) Run(budget int) {
+	for e.Step(budget) > 0 {
+	}
+}
+
+// Cancel aborts every unfinished job.
+func (e *Executor) Cancel() {
+	for _, t := range e.jobs {
+		if !t.run.Done() {
+			t.run.Cancel()
+		}
+	}
+}
+
+// Jobs returns the scheduled jobs in submission order, including
+// finished ones.
+func (e *Executor) Jobs() []*Job {
+	out := make([]*Job, len(e.jobs))
+	copy(out, e.jobs)
+	return out
+}
+
+func (e *Executor) runnable() int {
+	n := 0
+	for _, t := range e.jobs {
+		if !t.run.Done() {
+			n++
+		}
+	}
+	return n
+}
+
+// Name returns the name the job was submitted under.
+func (t *Job) Name() string {
+	return t.name
+}
+
+// Priority returns the job's current priority.
+func (t *Job) Priority() int {
+	return t.priority
+}
+
+// SetPriority adjusts the job's share of future steps. Values below 1 are
+// clamped to 1.
+func (t *Job) SetPriority(priority int) {
+	if priority < 1 {
+		priority = 1
+	}
+	t.priority = priority
+}
+
+// Done reports whether the job has finished (successfully or not).
+func (t *Job) Done() bool {
+	return t.run.Done()
+}
+
+// Result returns the job's final value. It is nil until the job has
+// completed successfully.
+func (t *Job) Result() Value {
+	return t.run.Result()
+}
+
+// Err returns the error the job stopped with, if any.
+func (t *Job) Err() error {
+	return t.run.Err()
+}
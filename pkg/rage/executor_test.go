@@ -0,0 +1,115 @@
+package rage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExecutorRunsJobsToCompletion(t *testing.T) {
+	exec := NewExecutor()
+
+	s1 := NewState()
+	defer s1.Close()
+	s2 := NewState()
+	defer s2.Close()
+
+	a, err := exec.Submit(s1, "a", "total = sum(range(100))", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := exec.Submit(s2, "b", "x = 2 ** 8", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exec.Run(200)
+
+	if !a.Done() || !b.Done() {
+		t.Fatal("expected both jobs to finish")
+	}
+	if n, ok := AsInt(s1.GetGlobal("total")); !ok || n != 4950 {
+		t.Errorf("job a: expected 4950, got %v", s1.GetGlobal("total"))
+	}
+	if n, ok := AsInt(s2.GetGlobal("x")); !ok || n != 256 {
+		t.Errorf("job b: expected 256, got %v", s2.GetGlobal("x"))
+	}
+}
+
+func TestExecutorPriorityGetsBiggerSlice(t *testing.T) {
+	exec := NewExecutor()
+
+	loop := `
+total = 0
+for i in range(500):
+    total += i
+`
+	low := NewState()
+	defer low.Close()
+	high := NewState()
+	defer high.Close()
+
+	lowJob, err := exec.Submit(low, "background", loop, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	highJob, err := exec.Submit(high, "handler", loop, 9)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Step until the high-priority job finishes; with a 9:1 split over
+	// identical scripts the low-priority one must still be running
+	for i := 0; i < 10000 && !highJob.Done(); i++ {
+		exec.Step(100)
+	}
+	if !highJob.Done() {
+		t.Fatal("high-priority job never finished")
+	}
+	if lowJob.Done() {
+		t.Error("low-priority job should still be running when the high-priority one finishes")
+	}
+
+	exec.Run(1000)
+	if !lowJob.Done() {
+		t.Error("low-priority job should finish once it has the executor to itself")
+	}
+}
+
+func TestExecutorCancel(t *testing.T) {
+	exec := NewExecutor()
+
+	s := NewState()
+	defer s.Close()
+
+	job, err := exec.Submit(s, "spinner", "while True:\n    pass", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exec.Step(100)
+	if job.Done() {
+		t.Fatal("spinner should not finish")
+	}
+
+	exec.Cancel()
+	if !job.Done() {
+		t.Fatal("expected the job to be finished after Cancel")
+	}
+	if job.Err() == nil || !strings.Contains(job.Err().Error(), "cancelled") {
+		t.Errorf("expected a cancellation error, got %v", job.Err())
+	}
+}
+
+func TestExecutorSubmitCompileError(t *testing.T) {
+	exec := NewExecutor()
+
+	s := NewState()
+	defer s.Close()
+
+	if _, err := exec.Submit(s, "bad", "def broken(:", 1); err == nil {
+		t.Fatal("expected a compile error")
+	}
+	if exec.Step(100) != 0 {
+		t.Error("a failed Submit must not leave a job scheduled")
+	}
+}
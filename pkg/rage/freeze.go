@@ -0,0 +1,30 @@
+package rage
+
+import (
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// Frozen modules: Python libraries compiled ahead of time.
+//
+// RegisterPythonModule compiles and executes its source on the registering
+// State every time. FreezeModule instead compiles the source exactly once,
+// up front, and registers the bytecode process-wide: any State can import
+// the module, and each first import executes the frozen bytecode in a
+// fresh namespace so states stay isolated. Hosts that ship Python support
+// libraries (embedded with go:embed or otherwise) freeze them at init time
+// and skip the per-State compile entirely — the same treatment the
+// Go-level stdlib modules get from their package-level registries.
+
+// FreezeModule compiles source ahead of time and registers it as an
+// importable module named name, available to every State. The module body
+// runs on first import per State. When compilation fails the module is not
+// registered and the compile errors are returned.
+func FreezeModule(name, source string) error {
+	code, errs := compiler.CompileSource(source, name+".py")
+	if len(errs) > 0 {
+		return &CompileErrors{Errors: errs}
+	}
+	runtime.RegisterCompiledModule(name, code)
+	return nil
+}
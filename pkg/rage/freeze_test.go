@@ -0,0 +1,79 @@
+package rage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFreezeModuleImport(t *testing.T) {
+	err := FreezeModule("frozen_util", `
+def double(n):
+    return n * 2
+
+VERSION = "1.0"
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state := NewState()
+	defer state.Close()
+
+	result, err := state.Run("import frozen_util\nfrozen_util.double(21)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, ok := AsInt(result); !ok || n != 42 {
+		t.Errorf("expected 42, got %v", result)
+	}
+}
+
+func TestFreezeModuleStatesAreIsolated(t *testing.T) {
+	err := FreezeModule("frozen_counter", "hits = []\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s1 := NewState()
+	defer s1.Close()
+	s2 := NewState()
+	defer s2.Close()
+
+	if _, err := s1.Run("import frozen_counter\nfrozen_counter.hits.append(1)"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := s2.Run("import frozen_counter\nlen(frozen_counter.hits)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, ok := AsInt(result); !ok || n != 0 {
+		t.Errorf("expected the second state to see a fresh module, got %v", result)
+	}
+}
+
+func TestFreezeModuleCompileError(t *testing.T) {
+	err := FreezeModule("frozen_broken", "def broken(:")
+	if err == nil {
+		t.Fatal("expected a compile error")
+	}
+
+	state := NewState()
+	defer state.Close()
+	if _, err := state.Run("import frozen_broken"); err == nil {
+		t.Error("a failed FreezeModule must not register the module")
+	}
+}
+
+func TestFreezeModuleImportTimeError(t *testing.T) {
+	err := FreezeModule("frozen_raiser", "raise ValueError('boom')\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state := NewState()
+	defer state.Close()
+	_, err = state.Run("import frozen_raiser")
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected the import to surface the module's error, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package rage
+
+import (
+	"fmt"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// SetTranslations installs a gettext message catalog from Go maps, so hosts
+// can drive localization without shipping catalog files to scripts. singular
+// maps a message id to its translation; plural maps a message id to its
+// [singular, plural] forms for ngettext. Either map may be nil. Entries merge
+// into any catalog the script has already installed, with later calls
+// overriding earlier ones key by key. The State must have been created with
+// ModuleGettext enabled (AllModules includes it).
+func (s *State) SetTranslations(singular map[string]string, plural map[string][2]string) error {
+	if err := s.checkClosed(); err != nil {
+		return err
+	}
+	vm := s.liveVM()
+	if vm == nil {
+		return ErrStateClosed
+	}
+
+	catalog := runtime.NewDict()
+	for k, v := range singular {
+		catalog.Items[runtime.NewString(k)] = runtime.NewString(v)
+	}
+	for k, forms := range plural {
+		catalog.Items[runtime.NewString(k)] = runtime.NewList([]runtime.Value{
+			runtime.NewString(forms[0]),
+			runtime.NewString(forms[1]),
+		})
+	}
+
+	mod, err := vm.ImportModule("gettext")
+	if err != nil {
+		return fmt.Errorf("gettext module is not available: %w", err)
+	}
+	if _, err := vm.Call(mod.Dict["install"], []runtime.Value{catalog}, nil); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package rage
+
+import (
+	"testing"
+)
+
+func TestSetTranslations(t *testing.T) {
+	s := NewState()
+	defer s.Close()
+
+	err := s.SetTranslations(
+		map[string]string{"hello": "hola"},
+		map[string][2]string{"%d cat": {"%d gato", "%d gatos"}},
+	)
+	if err != nil {
+		t.Fatalf("SetTranslations failed: %v", err)
+	}
+
+	if _, err := s.Run(`
+import gettext
+greeting = gettext.gettext("hello")
+other = gettext.gettext("goodbye")
+two = gettext.ngettext("%d cat", "%d cats", 2)
+`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := s.GetGlobalString("greeting"); v != "hola" {
+		t.Errorf("greeting = %q, want %q", v, "hola")
+	}
+	if v, _ := s.GetGlobalString("other"); v != "goodbye" {
+		t.Errorf("other = %q, want untranslated fallback", v)
+	}
+	if v, _ := s.GetGlobalString("two"); v != "%d gatos" {
+		t.Errorf("two = %q, want %q", v, "%d gatos")
+	}
+}
+
+func TestSetTranslations_ClosedState(t *testing.T) {
+	s := NewState()
+	s.Close()
+	if err := s.SetTranslations(map[string]string{"a": "b"}, nil); err != ErrStateClosed {
+		t.Errorf("expected ErrStateClosed, got %v", err)
+	}
+}
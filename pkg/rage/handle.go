@@ -0,0 +1,109 @@
+package rage
+
+// Host object handles.
+//
+// A Handle wraps an arbitrary Go value so it can travel through Python as an
+// opaque userdata and be recovered later with its static type intact. Unlike
+// raw UserData, handles are registered with their State: they stop resolving
+// when the State is closed (or the handle is released), and a value received
+// from a script only unwraps if it was actually minted by NewHandle on that
+// same State — scripts cannot fabricate or forge one.
+
+// handleBox is the opaque payload stored inside the userdata backing a
+// Handle. Scripts cannot construct userdata, so any value carrying a
+// *handleBox is guaranteed to have come from NewHandle.
+type handleBox struct {
+	state *State
+	id    uint64
+}
+
+// Handle is a type-safe reference to a Go value registered with a State.
+// The zero Handle is invalid; create handles with NewHandle.
+type Handle[T any] struct {
+	box *handleBox
+}
+
+// NewHandle registers v with the state and returns a handle for it. The
+// returned handle is invalid if the state is already closed.
+func NewHandle[T any](s *State, v T) Handle[T] {
+	s.mu.RLock()
+	closed := s.closed
+	s.mu.RUnlock()
+	if closed {
+		return Handle[T]{}
+	}
+	s.handleMu.Lock()
+	defer s.handleMu.Unlock()
+	if s.handles == nil {
+		s.handles = make(map[uint64]any)
+	}
+	s.nextHandleID++
+	id := s.nextHandleID
+	s.handles[id] = v
+	return Handle[T]{box: &handleBox{state: s, id: id}}
+}
+
+// Value returns the Python-facing value for the handle, suitable for
+// SetGlobal, Call arguments, or callback return values.
+func (h Handle[T]) Value() Value {
+	if h.box == nil {
+		return None
+	}
+	return UserDataValue{value: h.box}
+}
+
+// Get returns the wrapped Go value. The second result is false when the
+// handle is invalid, released, or its State has been closed.
+func (h Handle[T]) Get() (T, bool) {
+	var zero T
+	if h.box == nil {
+		return zero, false
+	}
+	s := h.box.state
+	s.handleMu.Lock()
+	v, ok := s.handles[h.box.id]
+	s.handleMu.Unlock()
+	if !ok {
+		return zero, false
+	}
+	t, ok := v.(T)
+	if !ok {
+		return zero, false
+	}
+	return t, true
+}
+
+// Valid reports whether the handle still resolves to a value.
+func (h Handle[T]) Valid() bool {
+	_, ok := h.Get()
+	return ok
+}
+
+// Release invalidates the handle ahead of State.Close, dropping the State's
+// reference to the wrapped value. Releasing an invalid handle is a no-op.
+func (h Handle[T]) Release() {
+	if h.box == nil {
+		return
+	}
+	s := h.box.state
+	s.handleMu.Lock()
+	delete(s.handles, h.box.id)
+	s.handleMu.Unlock()
+}
+
+// UnwrapHandle recovers the Go value behind a handle that has passed through
+// Python, typically from a callback argument. The second result is false when
+// v is not a handle, was minted by a different State, wraps a different type,
+// or has been invalidated.
+func UnwrapHandle[T any](s *State, v Value) (T, bool) {
+	var zero T
+	ud, ok := v.(UserDataValue)
+	if !ok {
+		return zero, false
+	}
+	box, ok := ud.value.(*handleBox)
+	if !ok || box.state != s {
+		return zero, false
+	}
+	return Handle[T]{box: box}.Get()
+}
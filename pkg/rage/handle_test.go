@@ -0,0 +1,122 @@
+package rage
+
+import "testing"
+
+type handleResource struct {
+	name string
+}
+
+func TestHandle_RoundTripThroughPython(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	res := &handleResource{name: "db"}
+	h := NewHandle(state, res)
+	state.SetGlobal("conn", h.Value())
+
+	var got *handleResource
+	state.Register("use", func(s *State, args ...Value) Value {
+		got, _ = UnwrapHandle[*handleResource](s, args[0])
+		return nil
+	})
+
+	if _, err := state.Run(`use(conn)`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != res {
+		t.Errorf("expected the original resource back, got %v", got)
+	}
+}
+
+func TestHandle_GetAndValid(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	h := NewHandle(state, 42)
+	if v, ok := h.Get(); !ok || v != 42 {
+		t.Errorf("expected (42, true), got (%d, %v)", v, ok)
+	}
+	if !h.Valid() {
+		t.Error("expected handle to be valid")
+	}
+
+	h.Release()
+	if h.Valid() {
+		t.Error("expected handle to be invalid after Release")
+	}
+	if _, ok := h.Get(); ok {
+		t.Error("expected Get to fail after Release")
+	}
+	// Releasing again is a no-op
+	h.Release()
+
+	var zero Handle[int]
+	if zero.Valid() {
+		t.Error("expected zero handle to be invalid")
+	}
+}
+
+func TestHandle_InvalidatedOnClose(t *testing.T) {
+	state := NewState()
+	h := NewHandle(state, "payload")
+	state.Close()
+
+	if _, ok := h.Get(); ok {
+		t.Error("expected handle to be invalid after Close")
+	}
+	if h2 := NewHandle(state, 1); h2.Valid() {
+		t.Error("expected NewHandle on a closed state to return an invalid handle")
+	}
+}
+
+func TestUnwrapHandle_RejectsForgeries(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	h := NewHandle(state, &handleResource{name: "real"})
+	state.SetGlobal("real", h.Value())
+
+	checks := make(map[string]bool)
+	state.Register("probe", func(s *State, args ...Value) Value {
+		name, _ := AsString(args[0])
+		_, ok := UnwrapHandle[*handleResource](s, args[1])
+		checks[name] = ok
+		return nil
+	})
+
+	// Scripts cannot fabricate a handle from ordinary values
+	_, err := state.Run(`
+probe("real", real)
+probe("int", 12345)
+probe("str", "real")
+probe("obj", object())
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !checks["real"] {
+		t.Error("expected the genuine handle to unwrap")
+	}
+	for _, name := range []string{"int", "str", "obj"} {
+		if checks[name] {
+			t.Errorf("expected %s forgery to be rejected", name)
+		}
+	}
+
+	// Plain UserData is not a handle either
+	if _, ok := UnwrapHandle[*handleResource](state, UserData(&handleResource{})); ok {
+		t.Error("expected plain UserData to be rejected")
+	}
+
+	// Handles from another state are rejected
+	other := NewState()
+	defer other.Close()
+	if _, ok := UnwrapHandle[*handleResource](other, h.Value()); ok {
+		t.Error("expected a handle from another state to be rejected")
+	}
+
+	// Unwrapping with the wrong type parameter fails
+	if _, ok := UnwrapHandle[int](state, h.Value()); ok {
+		t.Error("expected wrong-type unwrap to fail")
+	}
+}
@@ -0,0 +1,74 @@
+package rage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSetImportPolicy_DeniesModule(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetImportPolicy(func(name string) error {
+		if name == "time" {
+			return errors.New("time is not available in sandboxed scripts")
+		}
+		return nil
+	})
+
+	if _, err := state.Run(`
+import math
+x = math.floor(2.5)
+`); err != nil {
+		t.Fatalf("expected math to be allowed, got %v", err)
+	}
+	if n, _ := state.GetGlobalInt("x"); n != 2 {
+		t.Errorf("expected x=2, got %d", n)
+	}
+
+	_, err := state.Run(`import time`)
+	if err == nil {
+		t.Fatal("expected the time import to be blocked")
+	}
+	if !strings.Contains(err.Error(), "ImportError") || !strings.Contains(err.Error(), "sandboxed") {
+		t.Errorf("expected an ImportError with the policy message, got %v", err)
+	}
+}
+
+func TestSetImportPolicy_CoversDynamicImport(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetImportPolicy(func(name string) error {
+		if name != "math" {
+			return errors.New("only math is allowed")
+		}
+		return nil
+	})
+
+	_, err := state.Run(`mod = __import__("json")`)
+	if err == nil || !strings.Contains(err.Error(), "ImportError") {
+		t.Errorf("expected __import__ to be blocked, got %v", err)
+	}
+	if _, err := state.Run(`mod = __import__("math")`); err != nil {
+		t.Errorf("expected __import__ of math to be allowed, got %v", err)
+	}
+}
+
+func TestSetImportPolicy_NilAllowsEverything(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetImportPolicy(func(name string) error {
+		return errors.New("nothing allowed")
+	})
+	if _, err := state.Run(`import math`); err == nil {
+		t.Fatal("expected the policy to block math")
+	}
+
+	state.SetImportPolicy(nil)
+	if _, err := state.Run(`import math`); err != nil {
+		t.Errorf("expected imports to work after clearing the policy, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package rage
+
+import (
+	"sort"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// ParamInfo describes one parameter of a Python function.
+type ParamInfo struct {
+	Name    string
+	Default Value // nil when the parameter has no default
+	KwOnly  bool
+}
+
+// FunctionInfo describes a Python function defined in the globals, with
+// enough metadata to power admin UIs and generated documentation.
+type FunctionInfo struct {
+	Name   string
+	Params []ParamInfo
+	Doc    string // docstring, empty if none
+	File   string // source filename the function was compiled from
+	Line   int    // first line of the definition
+}
+
+// Arity returns the number of required positional parameters — those
+// without defaults that must be supplied on every call.
+func (f FunctionInfo) Arity() int {
+	n := 0
+	for _, p := range f.Params {
+		if !p.KwOnly && p.Default == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// ClassInfo describes a Python class defined in the globals.
+type ClassInfo struct {
+	Name    string
+	Bases   []string
+	Doc     string
+	Methods []FunctionInfo // methods defined directly on the class, not inherited
+}
+
+// GlobalInfo describes one entry in the globals.
+type GlobalInfo struct {
+	Name  string
+	Type  string // Python type name, e.g. "int", "function", "type"
+	Value Value
+}
+
+// ListGlobals returns every global sorted by name, with its Python type
+// name. This is the entry point for hosts that want to show what a config
+// script defined.
+func (s *State) ListGlobals() []GlobalInfo {
+	vm := s.liveVM()
+	if vm == nil {
+		return nil
+	}
+	out := make([]GlobalInfo, 0, len(vm.Globals))
+	for name, v := range vm.Globals {
+		wrapped := fromRuntime(v)
+		out = append(out, GlobalInfo{Name: name, Type: wrapped.Type(), Value: wrapped})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// ListFunctions returns metadata for every Python function in the globals,
+// sorted by name. Go-registered builtins are not included; they carry no
+// signature or source information.
+func (s *State) ListFunctions() []FunctionInfo {
+	vm := s.liveVM()
+	if vm == nil {
+		return nil
+	}
+	var out []FunctionInfo
+	for name, v := range vm.Globals {
+		if fn, ok := v.(*runtime.PyFunction); ok {
+			info := functionInfo(fn)
+			info.Name = name
+			out = append(out, info)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// ListClasses returns metadata for every Python class in the globals, sorted
+// by name, including the methods each class defines directly.
+func (s *State) ListClasses() []ClassInfo {
+	vm := s.liveVM()
+	if vm == nil {
+		return nil
+	}
+	var out []ClassInfo
+	for name, v := range vm.Globals {
+		cls, ok := v.(*runtime.PyClass)
+		if !ok {
+			continue
+		}
+		info := ClassInfo{Name: name}
+		for _, base := range cls.Bases {
+			info.Bases = append(info.Bases, base.Name)
+		}
+		if doc, ok := cls.Dict["__doc__"].(*runtime.PyString); ok {
+			info.Doc = doc.Value
+		}
+		for mname, mval := range cls.Dict {
+			fn, ok := mval.(*runtime.PyFunction)
+			if !ok {
+				continue
+			}
+			minfo := functionInfo(fn)
+			minfo.Name = mname
+			info.Methods = append(info.Methods, minfo)
+		}
+		sort.Slice(info.Methods, func(i, j int) bool { return info.Methods[i].Name < info.Methods[j].Name })
+		out = append(out, info)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// functionInfo extracts signature and source metadata from a compiled
+// function. The caller fills in Name, which may differ from the compiled
+// name after reassignment.
+func functionInfo(fn *runtime.PyFunction) FunctionInfo {
+	code := fn.Code
+	info := FunctionInfo{
+		Doc:  code.Doc,
+		File: code.Filename,
+		Line: code.FirstLine,
+	}
+
+	// Positional parameters; defaults align with the rightmost ones
+	var defaults []runtime.Value
+	if fn.Defaults != nil {
+		defaults = fn.Defaults.Items
+	}
+	firstDefault := code.ArgCount - len(defaults)
+	for i := 0; i < code.ArgCount && i < len(code.VarNames); i++ {
+		p := ParamInfo{Name: code.VarNames[i]}
+		if i >= firstDefault {
+			p.Default = fromRuntime(defaults[i-firstDefault])
+		}
+		info.Params = append(info.Params, p)
+	}
+
+	// Keyword-only parameters follow the positional ones in VarNames
+	for i := code.ArgCount; i < code.ArgCount+code.KwOnlyArgCount && i < len(code.VarNames); i++ {
+		p := ParamInfo{Name: code.VarNames[i], KwOnly: true}
+		if d, ok := fn.KwDefaults[code.VarNames[i]]; ok {
+			p.Default = fromRuntime(d)
+		}
+		info.Params = append(info.Params, p)
+	}
+
+	return info
+}
@@ -0,0 +1,168 @@
+package rage
+
+import "testing"
+
+func TestState_ListFunctions(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.RunWithFilename(`
+def greet(name, punct="!"):
+    """Say hello."""
+    return "hello " + name + punct
+
+def clamp(x, lo, hi, *, strict=False):
+    return max(lo, min(x, hi))
+`, "config.py")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fns := state.ListFunctions()
+	if len(fns) != 2 {
+		t.Fatalf("expected 2 functions, got %d", len(fns))
+	}
+
+	// Sorted by name
+	clamp, greet := fns[0], fns[1]
+	if clamp.Name != "clamp" || greet.Name != "greet" {
+		t.Fatalf("expected [clamp greet], got [%s %s]", fns[0].Name, fns[1].Name)
+	}
+
+	if greet.Doc != "Say hello." {
+		t.Errorf("expected docstring, got %q", greet.Doc)
+	}
+	if greet.File != "config.py" {
+		t.Errorf("expected file config.py, got %q", greet.File)
+	}
+	if greet.Line == 0 {
+		t.Error("expected a source line number")
+	}
+	if greet.Arity() != 1 {
+		t.Errorf("expected arity 1, got %d", greet.Arity())
+	}
+	if len(greet.Params) != 2 {
+		t.Fatalf("expected 2 params, got %d", len(greet.Params))
+	}
+	if greet.Params[0].Name != "name" || greet.Params[0].Default != nil {
+		t.Errorf("expected required param 'name', got %+v", greet.Params[0])
+	}
+	if sv, _ := AsString(greet.Params[1].Default); greet.Params[1].Name != "punct" || sv != "!" {
+		t.Errorf("expected param punct with default \"!\", got %+v", greet.Params[1])
+	}
+
+	if clamp.Arity() != 3 {
+		t.Errorf("expected arity 3, got %d", clamp.Arity())
+	}
+	if len(clamp.Params) != 4 {
+		t.Fatalf("expected 4 params, got %d", len(clamp.Params))
+	}
+	strict := clamp.Params[3]
+	if strict.Name != "strict" || !strict.KwOnly {
+		t.Errorf("expected keyword-only param 'strict', got %+v", strict)
+	}
+	if b, ok := AsBool(strict.Default); !ok || b {
+		t.Errorf("expected default False, got %v", strict.Default)
+	}
+}
+
+func TestState_ListClasses(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`
+class Animal:
+    """A base animal."""
+    def speak(self):
+        return "..."
+
+class Dog(Animal):
+    def speak(self):
+        return "woof"
+    def fetch(self, item):
+        return item
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	classes := state.ListClasses()
+	if len(classes) != 2 {
+		t.Fatalf("expected 2 classes, got %d", len(classes))
+	}
+
+	animal, dog := classes[0], classes[1]
+	if animal.Name != "Animal" || dog.Name != "Dog" {
+		t.Fatalf("expected [Animal Dog], got [%s %s]", animal.Name, dog.Name)
+	}
+	if animal.Doc != "A base animal." {
+		t.Errorf("expected class docstring, got %q", animal.Doc)
+	}
+	if len(dog.Bases) != 1 || dog.Bases[0] != "Animal" {
+		t.Errorf("expected base Animal, got %v", dog.Bases)
+	}
+
+	// Only directly defined methods, sorted by name
+	if len(dog.Methods) != 2 || dog.Methods[0].Name != "fetch" || dog.Methods[1].Name != "speak" {
+		t.Errorf("expected methods [fetch speak], got %+v", dog.Methods)
+	}
+	// self counts as a parameter in the raw signature
+	if dog.Methods[0].Arity() != 2 {
+		t.Errorf("expected fetch arity 2 (self, item), got %d", dog.Methods[0].Arity())
+	}
+}
+
+func TestState_ListGlobals(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`
+count = 42
+label = "hi"
+def f():
+    pass
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	globals := state.ListGlobals()
+	byName := make(map[string]GlobalInfo)
+	for _, g := range globals {
+		byName[g.Name] = g
+	}
+	if g, ok := byName["count"]; !ok || g.Type != "int" {
+		t.Errorf("expected count of type int, got %+v", g)
+	}
+	if g, ok := byName["label"]; !ok || g.Type != "str" {
+		t.Errorf("expected label of type str, got %+v", g)
+	}
+	if g, ok := byName["f"]; !ok || g.Type != "function" {
+		t.Errorf("expected f of type function, got %+v", g)
+	}
+	if n, ok := AsInt(byName["count"].Value); !ok || n != 42 {
+		t.Errorf("expected value 42, got %v", byName["count"].Value)
+	}
+
+	// Sorted by name
+	for i := 1; i < len(globals); i++ {
+		if globals[i-1].Name > globals[i].Name {
+			t.Fatalf("globals not sorted: %s before %s", globals[i-1].Name, globals[i].Name)
+		}
+	}
+}
+
+func TestState_ListOnClosedState(t *testing.T) {
+	state := NewState()
+	state.Close()
+
+	if got := state.ListGlobals(); got != nil {
+		t.Errorf("expected nil on closed state, got %v", got)
+	}
+	if got := state.ListFunctions(); got != nil {
+		t.Errorf("expected nil on closed state, got %v", got)
+	}
+	if got := state.ListClasses(); got != nil {
+		t.Errorf("expected nil on closed state, got %v", got)
+	}
+}
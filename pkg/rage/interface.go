@@ -0,0 +1,189 @@
+package rage
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// This file implements State.RegisterInterface, the Go-to-Python counterpart
+// of BindGlobals: given any Go value it builds a Python object exposing the
+// value's exported methods via reflection, so services can be handed to
+// scripts without writing a ClassBuilder per type. Method names are converted
+// to snake_case (GetUser becomes get_user), arguments are converted with the
+// same rules as BindGlobals fields, and a trailing error return becomes a
+// Python exception.
+
+// RegisterInterface exposes the exported methods of impl to Python as a
+// global object:
+//
+//	state.RegisterInterface("store", myStore)
+//
+// Then in Python: store.get_user("alice"). Methods may return nothing, a
+// single value, multiple values (returned as a tuple), and/or a trailing
+// error. Keyword arguments are rejected since Go parameters have no names.
+func (s *State) RegisterInterface(name string, impl any) error {
+	if err := s.checkClosed(); err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(impl)
+	if !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return fmt.Errorf("RegisterInterface: impl must not be nil")
+	}
+	rt := rv.Type()
+	if rt.NumMethod() == 0 {
+		return fmt.Errorf("RegisterInterface: %s has no exported methods", rt)
+	}
+
+	typeName := rt.Name()
+	if rt.Kind() == reflect.Ptr {
+		typeName = rt.Elem().Name()
+	}
+	if typeName == "" {
+		typeName = name
+	}
+
+	builder := NewClass(typeName)
+	for i := 0; i < rt.NumMethod(); i++ {
+		method := rt.Method(i)
+		builder.MethodKw(snakeCase(method.Name), makeInterfaceMethod(typeName, snakeCase(method.Name), rv.Method(i)))
+	}
+	cls := builder.Build(s)
+	obj, err := s.Call(cls)
+	if err != nil {
+		return fmt.Errorf("RegisterInterface: %w", err)
+	}
+	s.SetGlobal(name, obj)
+	return nil
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// makeInterfaceMethod wraps a reflected Go method as a ClassBuilder callback,
+// converting arguments in and results out.
+func makeInterfaceMethod(typeName, pyName string, fn reflect.Value) func(s *State, self Object, args []Value, kwargs map[string]Value) (Value, error) {
+	ft := fn.Type()
+	return func(s *State, self Object, args []Value, kwargs map[string]Value) (Value, error) {
+		if len(kwargs) > 0 {
+			return nil, TypeError(fmt.Sprintf("%s.%s() takes no keyword arguments", typeName, pyName))
+		}
+		numIn := ft.NumIn()
+		if ft.IsVariadic() {
+			if len(args) < numIn-1 {
+				return nil, TypeError(fmt.Sprintf("%s.%s() takes at least %d arguments, got %d",
+					typeName, pyName, numIn-1, len(args)))
+			}
+		} else if len(args) != numIn {
+			return nil, TypeError(fmt.Sprintf("%s.%s() takes %d arguments, got %d",
+				typeName, pyName, numIn, len(args)))
+		}
+
+		in := make([]reflect.Value, len(args))
+		for i, arg := range args {
+			var paramType reflect.Type
+			if ft.IsVariadic() && i >= numIn-1 {
+				paramType = ft.In(numIn - 1).Elem()
+			} else {
+				paramType = ft.In(i)
+			}
+			param := reflect.New(paramType).Elem()
+			if err := bindValue(arg, param); err != nil {
+				return nil, TypeError(fmt.Sprintf("%s.%s() argument %d: %v", typeName, pyName, i+1, err))
+			}
+			in[i] = param
+		}
+
+		out := fn.Call(in)
+
+		// A trailing error return becomes a Python exception
+		if n := len(out); n > 0 && ft.Out(n-1) == errorType {
+			if !out[n-1].IsNil() {
+				return nil, out[n-1].Interface().(error)
+			}
+			out = out[:n-1]
+		}
+
+		switch len(out) {
+		case 0:
+			return None, nil
+		case 1:
+			return resultToValue(out[0]), nil
+		default:
+			items := make([]Value, len(out))
+			for i, res := range out {
+				items[i] = resultToValue(res)
+			}
+			return Tuple(items...), nil
+		}
+	}
+}
+
+// resultToValue converts a reflected Go return value to a Python value,
+// recursing through slices and string-keyed maps. Values FromGo does not
+// understand (structs, pointers, channels, ...) become userdata.
+func resultToValue(rv reflect.Value) Value {
+	if !rv.IsValid() {
+		return None
+	}
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return None
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return Bytes(rv.Bytes())
+		}
+		items := make([]Value, rv.Len())
+		for i := range items {
+			items[i] = resultToValue(rv.Index(i))
+		}
+		return List(items...)
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return UserData(rv.Interface())
+		}
+		if rv.IsNil() {
+			return None
+		}
+		items := make(map[string]Value, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			items[iter.Key().String()] = resultToValue(iter.Value())
+		}
+		return DictValue{items: items}
+	case reflect.Interface:
+		if rv.IsNil() {
+			return None
+		}
+		return resultToValue(rv.Elem())
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return None
+		}
+		return FromGo(rv.Interface())
+	default:
+		return FromGo(rv.Interface())
+	}
+}
+
+// snakeCase converts an exported Go method name to Python style:
+// GetUser -> get_user, HTTPGet -> http_get, ID -> id.
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word at an upper rune that follows a lower rune,
+			// or that starts the tail of an acronym (HTTPGet -> http_get)
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,199 @@
+package rage
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeStore struct {
+	data map[string]string
+}
+
+func (f *fakeStore) Get(key string) (string, error) {
+	v, ok := f.data[key]
+	if !ok {
+		return "", errors.New("KeyError: " + key)
+	}
+	return v, nil
+}
+
+func (f *fakeStore) Put(key, value string) error {
+	f.data[key] = value
+	return nil
+}
+
+func (f *fakeStore) Len() int64 {
+	return int64(len(f.data))
+}
+
+func (f *fakeStore) Keys() []string {
+	keys := make([]string, 0, len(f.data))
+	for k := range f.data {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (f *fakeStore) MinMax(nums ...int64) (int64, int64) {
+	lo, hi := nums[0], nums[0]
+	for _, n := range nums[1:] {
+		if n < lo {
+			lo = n
+		}
+		if n > hi {
+			hi = n
+		}
+	}
+	return lo, hi
+}
+
+func TestRegisterInterface_Basic(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	store := &fakeStore{data: map[string]string{"greeting": "hello"}}
+	if err := state.RegisterInterface("store", store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := state.Run(`
+before = store.len()
+store.put("name", "world")
+after = store.len()
+got = store.get("greeting")
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := state.GetGlobalInt("before"); n != 1 {
+		t.Errorf("expected before=1, got %d", n)
+	}
+	if n, _ := state.GetGlobalInt("after"); n != 2 {
+		t.Errorf("expected after=2, got %d", n)
+	}
+	if s, _ := state.GetGlobalString("got"); s != "hello" {
+		t.Errorf("expected got='hello', got %q", s)
+	}
+	if store.data["name"] != "world" {
+		t.Errorf("expected Put to reach the Go value, got %v", store.data)
+	}
+}
+
+func TestRegisterInterface_ErrorBecomesException(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	store := &fakeStore{data: map[string]string{}}
+	if err := state.RegisterInterface("store", store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := state.Run(`
+try:
+    store.get("missing")
+    outcome = "no error"
+except KeyError as e:
+    outcome = "caught"
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, _ := state.GetGlobalString("outcome"); s != "caught" {
+		t.Errorf("expected the Go error to surface as KeyError, got %q", s)
+	}
+}
+
+func TestRegisterInterface_SliceAndMultipleReturns(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	store := &fakeStore{data: map[string]string{"a": "1", "b": "2"}}
+	if err := state.RegisterInterface("store", store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := state.Run(`
+keys = sorted(store.keys())
+lo, hi = store.min_max(3, 1, 4, 1, 5)
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keys, ok := state.GetGlobalSlice("keys")
+	if !ok || len(keys) != 2 {
+		t.Fatalf("expected 2 keys, got %v", keys)
+	}
+	if s, _ := AsString(keys[0]); s != "a" {
+		t.Errorf("expected first key 'a', got %v", keys[0])
+	}
+	if n, _ := state.GetGlobalInt("lo"); n != 1 {
+		t.Errorf("expected lo=1, got %d", n)
+	}
+	if n, _ := state.GetGlobalInt("hi"); n != 5 {
+		t.Errorf("expected hi=5, got %d", n)
+	}
+}
+
+func TestRegisterInterface_ArgumentErrors(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	store := &fakeStore{data: map[string]string{}}
+	if err := state.RegisterInterface("store", store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := state.Run(`
+results = []
+try:
+    store.get()
+except TypeError as e:
+    results.append("arity")
+try:
+    store.get(42)
+except TypeError as e:
+    results.append("type")
+try:
+    store.get(key="x")
+except TypeError as e:
+    results.append("kwargs")
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, _ := state.GetGlobalSlice("results")
+	if len(results) != 3 {
+		t.Errorf("expected 3 TypeErrors, got %v", results)
+	}
+}
+
+func TestRegisterInterface_RejectsNilAndMethodless(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	if err := state.RegisterInterface("x", nil); err == nil {
+		t.Error("expected error for nil impl")
+	}
+	if err := state.RegisterInterface("x", (*fakeStore)(nil)); err == nil {
+		t.Error("expected error for typed nil impl")
+	}
+	if err := state.RegisterInterface("x", 42); err == nil {
+		t.Error("expected error for a value with no methods")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"Get":      "get",
+		"GetUser":  "get_user",
+		"HTTPGet":  "http_get",
+		"ID":       "id",
+		"ParseURL": "parse_url",
+		"MinMax":   "min_max",
+	}
+	for in, want := range cases {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
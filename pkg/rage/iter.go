@@ -0,0 +1,75 @@
+package rage
+
+import (
+	"fmt"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// Lazy iterables backed by Go data sources.
+//
+// IterFromSlice, IterFromFunc, and IterFromChan produce one-shot Python
+// iterators that yield values on demand, so huge Go datasets can be consumed
+// by for-loops, comprehensions, and builtins like sum/any without copying
+// everything into a list upfront. Like generators they cannot be restarted;
+// create a fresh iterator for each pass.
+
+// IteratorValue wraps a lazy Go-backed iterator.
+type IteratorValue struct {
+	it *runtime.PyGoIterator
+}
+
+func (v IteratorValue) Type() string   { return "iterator" }
+func (v IteratorValue) String() string { return fmt.Sprintf("<go iterator %s>", v.it.Name) }
+func (v IteratorValue) GoValue() any   { return v.it }
+
+// Detach returns the value unchanged: the iterator draws from host memory
+// and cannot be deep-copied generically.
+func (v IteratorValue) Detach() Value            { return v }
+func (v IteratorValue) toRuntime() runtime.Value { return v.it }
+
+// IterFromFunc creates a lazy iterable from a next function. next is called
+// once per element and returns false when the source is exhausted:
+//
+//	state.SetGlobal("lines", rage.IterFromFunc(func() (rage.Value, bool) {
+//	    if !scanner.Scan() {
+//	        return nil, false
+//	    }
+//	    return rage.String(scanner.Text()), true
+//	}))
+func IterFromFunc(next func() (Value, bool)) Value {
+	return IteratorValue{it: runtime.NewGoIterator("func", func() (runtime.Value, bool, error) {
+		v, ok := next()
+		if !ok {
+			return nil, true, nil
+		}
+		return toRuntime(v), false, nil
+	})}
+}
+
+// IterFromSlice creates a lazy iterable over a Go slice. Elements are
+// converted with FromGo one at a time as Python consumes them.
+func IterFromSlice[T any](items []T) Value {
+	i := 0
+	return IteratorValue{it: runtime.NewGoIterator("slice", func() (runtime.Value, bool, error) {
+		if i >= len(items) {
+			return nil, true, nil
+		}
+		v := toRuntime(FromGo(items[i]))
+		i++
+		return v, false, nil
+	})}
+}
+
+// IterFromChan creates a lazy iterable that receives from a Go channel and
+// ends when the channel is closed. Iteration blocks while the channel is
+// open but empty, so producers should close it when done.
+func IterFromChan[T any](ch <-chan T) Value {
+	return IteratorValue{it: runtime.NewGoIterator("chan", func() (runtime.Value, bool, error) {
+		item, ok := <-ch
+		if !ok {
+			return nil, true, nil
+		}
+		return toRuntime(FromGo(item)), false, nil
+	})}
+}
@@ -0,0 +1,108 @@
+package rage
+
+import "testing"
+
+func TestIterFromSlice(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetGlobal("nums", IterFromSlice([]int64{1, 2, 3, 4}))
+	_, err := state.Run(`
+total = 0
+for n in nums:
+    total += n
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := state.GetGlobalInt("total"); v != 10 {
+		t.Errorf("expected total=10, got %d", v)
+	}
+
+	// One-shot: a second pass yields nothing
+	_, err = state.Run(`second = sum(nums)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := state.GetGlobalInt("second"); v != 0 {
+		t.Errorf("expected exhausted iterator to sum to 0, got %d", v)
+	}
+}
+
+func TestIterFromSlice_WorksWithBuiltinsAndComprehensions(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetGlobal("words", IterFromSlice([]string{"alpha", "", "gamma"}))
+	state.SetGlobal("nums", IterFromSlice([]int64{3, 1, 2}))
+	_, err := state.Run(`
+found_empty = any(w == "" for w in words)
+squares = [n * n for n in nums]
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := state.GetGlobalBool("found_empty"); !v {
+		t.Error("expected any() to find the empty word")
+	}
+	squares, _ := state.GetGlobalSlice("squares")
+	if len(squares) != 3 {
+		t.Fatalf("expected 3 squares, got %v", squares)
+	}
+	if n, _ := AsInt(squares[0]); n != 9 {
+		t.Errorf("expected first square 9, got %v", squares[0])
+	}
+}
+
+func TestIterFromFunc_IsLazy(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	calls := 0
+	state.SetGlobal("counter", IterFromFunc(func() (Value, bool) {
+		calls++
+		if calls > 100 {
+			return nil, false
+		}
+		return Int(int64(calls)), true
+	}))
+
+	// Only consume the first three elements; the source must not be drained
+	_, err := state.Run(`
+first = []
+for n in counter:
+    first.append(n)
+    if len(first) == 3:
+        break
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	firsts, _ := state.GetGlobalSlice("first")
+	if len(firsts) != 3 {
+		t.Fatalf("expected 3 elements, got %v", firsts)
+	}
+	if calls > 4 {
+		t.Errorf("expected at most 4 next calls for a lazy source, got %d", calls)
+	}
+}
+
+func TestIterFromChan(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	ch := make(chan string, 3)
+	ch <- "a"
+	ch <- "b"
+	ch <- "c"
+	close(ch)
+
+	state.SetGlobal("events", IterFromChan(ch))
+	_, err := state.Run(`joined = ",".join(events)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := state.GetGlobalString("joined"); v != "a,b,c" {
+		t.Errorf("expected 'a,b,c', got %q", v)
+	}
+}
@@ -0,0 +1,47 @@
+package lsp
+
+// builtinDocs holds one-line hover descriptions for the builtins scripts
+// use most. It intentionally covers the common names rather than the full
+// builtin surface; host functions are documented through docgen metadata.
+var builtinDocs = map[string]string{
+	"abs":        "Return the absolute value of a number.",
+	"all":        "Return True if all elements of the iterable are true (or the iterable is empty).",
+	"any":        "Return True if any element of the iterable is true.",
+	"bool":       "Convert a value to a Boolean.",
+	"bytes":      "Return an immutable bytes object.",
+	"dict":       "Create a new dictionary.",
+	"dir":        "Return the list of names in the given object's namespace.",
+	"enumerate":  "Return an iterator of (index, value) pairs.",
+	"filter":     "Return an iterator yielding items for which the function is true.",
+	"float":      "Convert a value to a floating point number.",
+	"frozenset":  "Return an immutable set object.",
+	"getattr":    "Get a named attribute from an object.",
+	"hasattr":    "Return whether the object has an attribute with the given name.",
+	"hash":       "Return the hash value of an object.",
+	"input":      "Read a line from standard input.",
+	"int":        "Convert a value to an integer.",
+	"isinstance": "Return whether an object is an instance of a class or its subclasses.",
+	"issubclass": "Return whether a class is a subclass of another class.",
+	"iter":       "Return an iterator for an object.",
+	"len":        "Return the number of items in a container.",
+	"list":       "Create a new list.",
+	"map":        "Return an iterator applying a function to every item of an iterable.",
+	"max":        "Return the largest item in an iterable or among arguments.",
+	"min":        "Return the smallest item in an iterable or among arguments.",
+	"next":       "Retrieve the next item from an iterator.",
+	"open":       "Open a file and return a file object.",
+	"ord":        "Return the Unicode code point of a one-character string.",
+	"print":      "Print values to standard output.",
+	"range":      "Return a sequence of numbers from start to stop by step.",
+	"repr":       "Return the canonical string representation of an object.",
+	"reversed":   "Return a reverse iterator over a sequence.",
+	"round":      "Round a number to a given precision in decimal digits.",
+	"set":        "Create a new set.",
+	"setattr":    "Set a named attribute on an object.",
+	"sorted":     "Return a new sorted list from the items of an iterable.",
+	"str":        "Convert a value to a string.",
+	"sum":        "Sum the items of an iterable.",
+	"tuple":      "Create a new tuple.",
+	"type":       "Return the type of an object.",
+	"zip":        "Return an iterator aggregating items from each iterable.",
+}
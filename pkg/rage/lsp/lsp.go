@@ -0,0 +1,243 @@
+// Package lsp provides the language smarts behind editor support for
+// embedded scripts: compile diagnostics, go-to-definition within a script,
+// and hover text for builtins and host-registered functions. The rage CLI
+// wires these into a Language Server Protocol server (rage lsp); embedders
+// with their own editor integration can call the functions directly.
+//
+// Positions are 1-based lines and columns, matching compiler error
+// positions; LSP's 0-based positions are converted at the protocol layer.
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/model"
+	"github.com/ATSOTECK/rage/pkg/rage/docgen"
+)
+
+// Diagnostic is one compile problem in a script.
+type Diagnostic struct {
+	Line    int // 1-based
+	Column  int // 1-based
+	Message string
+}
+
+// Location is a position in the script.
+type Location struct {
+	Line   int // 1-based
+	Column int // 1-based
+}
+
+// Diagnostics compiles the source and returns every compile error with its
+// position. A clean compile returns an empty slice.
+func Diagnostics(source, filename string) []Diagnostic {
+	_, errs := compiler.CompileSource(source, filename)
+	out := make([]Diagnostic, 0, len(errs))
+	for _, err := range errs {
+		if ce, ok := err.(compiler.CompileError); ok {
+			out = append(out, Diagnostic{Line: ce.Pos.Line, Column: ce.Pos.Column, Message: ce.Message})
+			continue
+		}
+		out = append(out, Diagnostic{Line: 1, Column: 1, Message: err.Error()})
+	}
+	return out
+}
+
+// Definition resolves the identifier at the given position to where it is
+// defined in the same script: a def, a class, a parameter, or the first
+// assignment to the name. It reports false when the position is not on an
+// identifier or the name has no visible definition.
+func Definition(source string, line, column int) (Location, bool) {
+	word := wordAt(source, line, column)
+	if word == "" {
+		return Location{}, false
+	}
+	parser := compiler.NewParserWithFilename(source, "<lsp>")
+	mod, errs := parser.Parse()
+	if len(errs) > 0 || mod == nil {
+		return Location{}, false
+	}
+	defs := collectDefinitions(mod.Body)
+	pos, ok := defs[word]
+	if !ok {
+		return Location{}, false
+	}
+	return Location{Line: pos.Line, Column: pos.Column}, true
+}
+
+// Hover returns Markdown hover text for the identifier at the given
+// position: the signature and docstring of a host-registered function or
+// class from the docgen metadata, or a short description of a builtin.
+// api may be nil, in which case only builtins are described.
+func Hover(api *docgen.API, source string, line, column int) (string, bool) {
+	word := wordAt(source, line, column)
+	if word == "" {
+		return "", false
+	}
+	if api != nil {
+		for _, fn := range api.Functions {
+			if fn.Name == word {
+				return functionHover(fn), true
+			}
+		}
+		for _, cls := range api.Classes {
+			if cls.Name == word {
+				return classHover(cls), true
+			}
+		}
+	}
+	if doc, ok := builtinDocs[word]; ok {
+		return fmt.Sprintf("```python\n%s\n```\n%s", word, doc), true
+	}
+	return "", false
+}
+
+// functionHover renders hover text for a documented function.
+func functionHover(fn docgen.Function) string {
+	sig := "*args, **kwargs"
+	if !fn.Go {
+		var parts []string
+		kwMarker := false
+		for _, p := range fn.Params {
+			if p.KwOnly && !kwMarker {
+				parts = append(parts, "*")
+				kwMarker = true
+			}
+			if p.Default != "" {
+				parts = append(parts, p.Name+"="+p.Default)
+			} else {
+				parts = append(parts, p.Name)
+			}
+		}
+		sig = strings.Join(parts, ", ")
+	}
+	text := fmt.Sprintf("```python\ndef %s(%s)\n```", fn.Name, sig)
+	if fn.Go {
+		text += "\nProvided by the host application."
+	}
+	if fn.Doc != "" {
+		text += "\n" + strings.TrimSpace(fn.Doc)
+	}
+	return text
+}
+
+// classHover renders hover text for a documented class.
+func classHover(cls docgen.Class) string {
+	heading := cls.Name
+	if len(cls.Bases) > 0 {
+		heading += "(" + strings.Join(cls.Bases, ", ") + ")"
+	}
+	text := fmt.Sprintf("```python\nclass %s\n```", heading)
+	if cls.Doc != "" {
+		text += "\n" + strings.TrimSpace(cls.Doc)
+	}
+	return text
+}
+
+// collectDefinitions walks statements (including nested bodies) and records
+// where each name is first defined: defs, classes, parameters, assignments,
+// and for-loop targets.
+func collectDefinitions(stmts []model.Stmt) map[string]model.Position {
+	defs := make(map[string]model.Position)
+	record := func(name string, pos model.Position) {
+		if _, seen := defs[name]; !seen {
+			defs[name] = pos
+		}
+	}
+	recordTarget := func(target model.Expr) {
+		if ident, ok := target.(*model.Identifier); ok {
+			record(ident.Name, ident.StartPos)
+		}
+	}
+	var walk func([]model.Stmt)
+	walk = func(body []model.Stmt) {
+		for _, stmt := range body {
+			switch s := stmt.(type) {
+			case *model.FunctionDef:
+				record(s.Name.Name, s.Name.StartPos)
+				if s.Args != nil {
+					for _, arg := range s.Args.Args {
+						record(arg.Arg.Name, arg.Arg.StartPos)
+					}
+				}
+				walk(s.Body)
+			case *model.ClassDef:
+				record(s.Name.Name, s.Name.StartPos)
+				walk(s.Body)
+			case *model.Assign:
+				for _, target := range s.Targets {
+					recordTarget(target)
+				}
+			case *model.AugAssign:
+				recordTarget(s.Target)
+			case *model.AnnAssign:
+				recordTarget(s.Target)
+			case *model.For:
+				recordTarget(s.Target)
+				walk(s.Body)
+				walk(s.OrElse)
+			case *model.If:
+				walk(s.Body)
+				walk(s.OrElse)
+			case *model.While:
+				walk(s.Body)
+				walk(s.OrElse)
+			case *model.With:
+				walk(s.Body)
+			case *model.Try:
+				walk(s.Body)
+				for _, handler := range s.Handlers {
+					walk(handler.Body)
+				}
+				walk(s.OrElse)
+				walk(s.FinalBody)
+			case *model.Match:
+				for _, matchCase := range s.Cases {
+					walk(matchCase.Body)
+				}
+			}
+		}
+	}
+	walk(stmts)
+	return defs
+}
+
+// wordAt extracts the identifier covering the given 1-based position, or ""
+// when the position is not inside an identifier.
+func wordAt(source string, line, column int) string {
+	lines := strings.Split(source, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	text := lines[line-1]
+	idx := column - 1
+	if idx < 0 || idx > len(text) {
+		return ""
+	}
+	// Allow hovering just past the last character of a word
+	if idx == len(text) || !isWordByte(text[idx]) {
+		if idx == 0 || !isWordByte(text[idx-1]) {
+			return ""
+		}
+		idx--
+	}
+	start, end := idx, idx+1
+	for start > 0 && isWordByte(text[start-1]) {
+		start--
+	}
+	for end < len(text) && isWordByte(text[end]) {
+		end++
+	}
+	word := text[start:end]
+	// Identifiers cannot start with a digit
+	if word == "" || (word[0] >= '0' && word[0] <= '9') {
+		return ""
+	}
+	return word
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,108 @@
+package lsp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+	"github.com/ATSOTECK/rage/pkg/rage/docgen"
+)
+
+func TestDiagnostics(t *testing.T) {
+	if diags := Diagnostics("x = 1\ny = x + 2\n", "ok.py"); len(diags) != 0 {
+		t.Errorf("expected no diagnostics, got %+v", diags)
+	}
+
+	diags := Diagnostics("def broken(:\n    pass\n", "bad.py")
+	if len(diags) == 0 {
+		t.Fatal("expected diagnostics for a syntax error")
+	}
+	if diags[0].Line != 1 {
+		t.Errorf("expected error on line 1, got %+v", diags[0])
+	}
+}
+
+func TestDefinition(t *testing.T) {
+	source := `speed = 10
+
+def update(dt):
+    return speed * dt
+
+result = update(2)
+`
+	// "update" on the call site resolves to the def on line 3
+	loc, ok := Definition(source, 6, 10)
+	if !ok {
+		t.Fatal("expected a definition for update")
+	}
+	if loc.Line != 3 {
+		t.Errorf("expected definition on line 3, got %+v", loc)
+	}
+
+	// "speed" inside the function resolves to the assignment on line 1
+	loc, ok = Definition(source, 4, 12)
+	if !ok {
+		t.Fatal("expected a definition for speed")
+	}
+	if loc.Line != 1 {
+		t.Errorf("expected definition on line 1, got %+v", loc)
+	}
+
+	// Whitespace has no definition
+	if _, ok := Definition(source, 2, 1); ok {
+		t.Error("expected no definition on a blank line")
+	}
+
+	// Unknown names have no definition
+	if _, ok := Definition("x = unknown_name\n", 1, 5); ok {
+		t.Error("expected no definition for an unresolved name")
+	}
+}
+
+func TestHoverBuiltin(t *testing.T) {
+	text, ok := Hover(nil, "n = len([1, 2])\n", 1, 5)
+	if !ok {
+		t.Fatal("expected hover for len")
+	}
+	if !strings.Contains(text, "number of items") {
+		t.Errorf("unexpected hover text: %q", text)
+	}
+
+	if _, ok := Hover(nil, "n = 42\n", 1, 5); ok {
+		t.Error("expected no hover on a number literal")
+	}
+}
+
+func TestHoverHostFunction(t *testing.T) {
+	state := rage.NewState()
+	defer state.Close()
+
+	state.Register("spawn", func(s *rage.State, args ...rage.Value) rage.Value {
+		return rage.None
+	})
+	_, err := state.Run(`
+def greet(name):
+    """Say hello."""
+    return "hello " + name
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	api := docgen.Extract(state)
+
+	text, ok := Hover(api, "spawn()\n", 1, 2)
+	if !ok {
+		t.Fatal("expected hover for host function")
+	}
+	if !strings.Contains(text, "Provided by the host application.") {
+		t.Errorf("unexpected hover text: %q", text)
+	}
+
+	text, ok = Hover(api, "greet('bob')\n", 1, 2)
+	if !ok {
+		t.Fatal("expected hover for Python function")
+	}
+	if !strings.Contains(text, "def greet(name)") || !strings.Contains(text, "Say hello.") {
+		t.Errorf("unexpected hover text: %q", text)
+	}
+}
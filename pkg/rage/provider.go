@@ -0,0 +1,103 @@
+package rage
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Plug-in modules from external Go packages.
+//
+// Third-party packages can ship a rage module — a Redis client, a game
+// engine binding — without forking the interpreter: implement
+// ModuleProvider and register it from the package's init function. Hosts
+// enable the module by importing the package (a blank import suffices);
+// every State created afterwards has the provider's Init applied at
+// construction, mirroring RegisterGlobalFunc and RegisterGlobalModule.
+
+// ModuleProvider is implemented by Go packages that ship a rage module.
+type ModuleProvider interface {
+	// ModuleName returns the name scripts import the module under.
+	ModuleName() string
+
+	// ModuleDoc returns a short description used by documentation tooling.
+	ModuleDoc() string
+
+	// Init installs the module on a freshly built State, typically via
+	// RegisterPythonModule, Register, or a ClassBuilder. It runs once per
+	// State; returning an error leaves the module uninstalled on that
+	// State (see ProviderErrors).
+	Init(s *State) error
+}
+
+// ProviderInfo describes a registered provider (see Providers).
+type ProviderInfo struct {
+	Name string
+	Doc  string
+}
+
+var providerRegistry struct {
+	mu        sync.Mutex
+	providers []ModuleProvider
+}
+
+// RegisterProvider records a provider process-wide; every State created
+// afterwards has the provider's Init applied at construction. Typically
+// called from the providing package's init function. Registering a second
+// provider under an already-registered module name is an error.
+func RegisterProvider(p ModuleProvider) error {
+	providerRegistry.mu.Lock()
+	defer providerRegistry.mu.Unlock()
+	for _, existing := range providerRegistry.providers {
+		if existing.ModuleName() == p.ModuleName() {
+			return fmt.Errorf("RegisterProvider: module %q is already provided", p.ModuleName())
+		}
+	}
+	providerRegistry.providers = append(providerRegistry.providers, p)
+	return nil
+}
+
+// Providers returns metadata for every registered provider, in
+// registration order.
+func Providers() []ProviderInfo {
+	providerRegistry.mu.Lock()
+	defer providerRegistry.mu.Unlock()
+	infos := make([]ProviderInfo, len(providerRegistry.providers))
+	for i, p := range providerRegistry.providers {
+		infos[i] = ProviderInfo{Name: p.ModuleName(), Doc: p.ModuleDoc()}
+	}
+	return infos
+}
+
+// ClearProviders removes all registered providers. Existing States keep
+// what they were given; only States created afterwards are affected.
+// Mainly useful in tests.
+func ClearProviders() {
+	providerRegistry.mu.Lock()
+	defer providerRegistry.mu.Unlock()
+	providerRegistry.providers = nil
+}
+
+// applyProviders runs every registered provider's Init on a freshly built
+// State, recording failures for ProviderErrors.
+func applyProviders(s *State) {
+	providerRegistry.mu.Lock()
+	providers := make([]ModuleProvider, len(providerRegistry.providers))
+	copy(providers, providerRegistry.providers)
+	providerRegistry.mu.Unlock()
+
+	for _, p := range providers {
+		if err := p.Init(s); err != nil {
+			if s.providerErrs == nil {
+				s.providerErrs = make(map[string]error)
+			}
+			s.providerErrs[p.ModuleName()] = err
+		}
+	}
+}
+
+// ProviderErrors reports providers whose Init failed while building this
+// State, keyed by module name. It is nil when every provider installed
+// cleanly.
+func (s *State) ProviderErrors() map[string]error {
+	return s.providerErrs
+}
@@ -0,0 +1,104 @@
+package rage
+
+import (
+	"errors"
+	"testing"
+)
+
+type kvProvider struct{}
+
+func (kvProvider) ModuleName() string { return "kvstore" }
+func (kvProvider) ModuleDoc() string  { return "In-memory key/value store." }
+func (kvProvider) Init(s *State) error {
+	store := map[string]Value{}
+	s.RegisterBuiltin("kv_get", func(s *State, args ...Value) Value {
+		return store[args[0].String()]
+	})
+	s.RegisterBuiltin("kv_set", func(s *State, args ...Value) Value {
+		store[args[0].String()] = args[1]
+		return nil
+	})
+	return s.RegisterPythonModule("kvstore", `
+def get(key):
+    return kv_get(key)
+
+def set(key, value):
+    kv_set(key, value)
+`)
+}
+
+type failingProvider struct{}
+
+func (failingProvider) ModuleName() string { return "flaky" }
+func (failingProvider) ModuleDoc() string  { return "Always fails to install." }
+func (failingProvider) Init(s *State) error {
+	return errors.New("backend unreachable")
+}
+
+func TestProviderInstallsModule(t *testing.T) {
+	defer ClearProviders()
+	if err := RegisterProvider(kvProvider{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state := NewState()
+	defer state.Close()
+
+	result, err := state.Run(`
+import kvstore
+kvstore.set("hp", 100)
+kvstore.get("hp")
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, ok := AsInt(result); !ok || n != 100 {
+		t.Errorf("expected 100, got %v", result)
+	}
+	if state.ProviderErrors() != nil {
+		t.Errorf("unexpected provider errors: %v", state.ProviderErrors())
+	}
+}
+
+func TestProviderDuplicateNameRejected(t *testing.T) {
+	defer ClearProviders()
+	if err := RegisterProvider(kvProvider{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := RegisterProvider(kvProvider{}); err == nil {
+		t.Error("expected a duplicate registration error")
+	}
+}
+
+func TestProviderInitFailureRecorded(t *testing.T) {
+	defer ClearProviders()
+	if err := RegisterProvider(failingProvider{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state := NewState()
+	defer state.Close()
+
+	errs := state.ProviderErrors()
+	if errs == nil || errs["flaky"] == nil {
+		t.Fatalf("expected the Init failure to be recorded, got %v", errs)
+	}
+	if _, err := state.Run("import flaky"); err == nil {
+		t.Error("a failed provider must not leave its module importable")
+	}
+}
+
+func TestProvidersMetadata(t *testing.T) {
+	defer ClearProviders()
+	if err := RegisterProvider(kvProvider{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	infos := Providers()
+	if len(infos) != 1 {
+		t.Fatalf("expected 1 provider, got %d", len(infos))
+	}
+	if infos[0].Name != "kvstore" || infos[0].Doc == "" {
+		t.Errorf("unexpected metadata: %+v", infos[0])
+	}
+}
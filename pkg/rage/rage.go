@@ -17,16 +17,27 @@ package rage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/model"
 	"github.com/ATSOTECK/rage/internal/runtime"
 	"github.com/ATSOTECK/rage/internal/stdlib"
 )
 
+// ErrStateClosed is returned by operations on a State after Close has been
+// called. Test for it with errors.Is. Values already extracted from the State
+// remain readable; use Detach to deep-copy one if Python code may still
+// mutate it before Close.
+var ErrStateClosed = errors.New("operation on closed State")
+
 // Module represents a standard library module that can be enabled.
 type Module int
 
@@ -56,6 +67,17 @@ const (
 	ModuleHeapq
 	ModuleBisect
 	ModuleContextlib
+	ModuleDis
+	ModuleAtexit
+	ModuleYAML
+	ModuleTOML
+	ModuleTemplate
+	ModuleGettext
+	ModuleCalendar
+	ModuleZoneinfo
+	ModuleTimeit
+	ModulePprint
+	ModuleCompat
 )
 
 // AllModules is a convenience slice containing all available modules.
@@ -85,6 +107,17 @@ var AllModules = []Module{
 	ModuleHeapq,
 	ModuleBisect,
 	ModuleContextlib,
+	ModuleDis,
+	ModuleAtexit,
+	ModuleYAML,
+	ModuleTOML,
+	ModuleTemplate,
+	ModuleGettext,
+	ModuleCalendar,
+	ModuleZoneinfo,
+	ModuleTimeit,
+	ModulePprint,
+	ModuleCompat,
 }
 
 // Builtin represents an opt-in builtin function that can be enabled.
@@ -98,6 +131,7 @@ const (
 	BuiltinGlobals
 	BuiltinLocals
 	BuiltinVars
+	BuiltinHelp
 	BuiltinCompile
 	BuiltinExec
 	BuiltinEval
@@ -111,7 +145,7 @@ const (
 	BuiltinOpen
 )
 
-// ReflectionBuiltins contains all reflection-related builtins (repr, dir, globals, locals, vars).
+// ReflectionBuiltins contains all reflection-related builtins (repr, dir, globals, locals, vars, help).
 // These are relatively safe introspection functions.
 var ReflectionBuiltins = []Builtin{
 	BuiltinRepr,
@@ -119,6 +153,7 @@ var ReflectionBuiltins = []Builtin{
 	BuiltinGlobals,
 	BuiltinLocals,
 	BuiltinVars,
+	BuiltinHelp,
 }
 
 // ExecutionBuiltins contains all code execution builtins (compile, exec, eval).
@@ -137,6 +172,7 @@ var AllBuiltins = []Builtin{
 	BuiltinGlobals,
 	BuiltinLocals,
 	BuiltinVars,
+	BuiltinHelp,
 	BuiltinCompile,
 	BuiltinExec,
 	BuiltinEval,
@@ -151,6 +187,11 @@ type stateConfig struct {
 	maxRecursionDepth int64
 	maxMemoryBytes    int64
 	maxCollectionSize int64
+	panicRethrow      bool
+	langMajor         int
+	langMinor         int
+	hashSeed          uint64
+	hashSeedSet       bool
 }
 
 // WithModule enables a specific stdlib module.
@@ -194,6 +235,30 @@ func WithBuiltins(builtins ...Builtin) StateOption {
 	}
 }
 
+// WithoutBuiltin disables a specific opt-in builtin, overriding any earlier
+// option that enabled it. Useful for sandboxed deployments that want most
+// builtins but not code execution, e.g.:
+//
+//	state := rage.NewStateWithModules(
+//	    rage.WithAllBuiltins(),
+//	    rage.WithoutBuiltin(rage.BuiltinExec),
+//	)
+func WithoutBuiltin(b Builtin) StateOption {
+	return func(c *stateConfig) {
+		c.builtins[b] = false
+	}
+}
+
+// WithoutBuiltins disables multiple opt-in builtins, overriding any earlier
+// option that enabled them.
+func WithoutBuiltins(builtins ...Builtin) StateOption {
+	return func(c *stateConfig) {
+		for _, b := range builtins {
+			c.builtins[b] = false
+		}
+	}
+}
+
 // WithReflectionBuiltins enables all reflection builtins (repr, dir, globals, locals, vars).
 func WithReflectionBuiltins() StateOption {
 	return func(c *stateConfig) {
@@ -257,14 +322,71 @@ func WithMaxCollectionSize(n int64) StateOption {
 	}
 }
 
+// WithHashSeed fixes the State's str/bytes hash seed, making hash() results
+// and dict iteration behavior reproducible across runs. By default every
+// State gets a random seed so untrusted keys can't be crafted to collide;
+// pass 0 to disable randomization entirely.
+func WithHashSeed(seed uint64) StateOption {
+	return func(c *stateConfig) {
+		c.hashSeed = seed
+		c.hashSeedSet = true
+	}
+}
+
+// WithPanicRethrow makes panics from registered Go functions propagate to the
+// host instead of being converted into a Python RuntimeError. By default a
+// panicking callback surfaces as a catchable RuntimeError with the Go stack
+// trace in its message; enable this when the host wants to see the original
+// panic (e.g. to crash loudly in tests).
+func WithPanicRethrow() StateOption {
+	return func(c *stateConfig) {
+		c.panicRethrow = true
+	}
+}
+
+// WithLanguageLevel sets the CPython version the State reports to scripts
+// through sys.version_info and sys.version (e.g. 3, 10 to emulate Python
+// 3.10), so scripts that branch on the interpreter version behave
+// predictably. It does not restrict which syntax the compiler accepts.
+func WithLanguageLevel(major, minor int) StateOption {
+	return func(c *stateConfig) {
+		c.langMajor = major
+		c.langMinor = minor
+	}
+}
+
 // State represents a Python execution state.
 // It wraps the VM and provides a clean API for running Python code.
+//
+// A State is not safe for concurrent use by multiple goroutines, with one
+// exception: Close may be called while another goroutine is inside Run (the
+// in-flight Run completes normally and subsequent calls report the state as
+// closed). For concurrent execution, create a separate State per goroutine.
 type State struct {
+	mu              sync.RWMutex
 	vm              *runtime.VM
 	compiled        map[string]*runtime.CodeObject
 	enabledModules  map[Module]bool
 	enabledBuiltins map[Builtin]bool
 	closed          bool
+
+	// Notebook-style cell history (see RunCell)
+	cells []Cell
+
+	// Active session recording, nil when not recording (see Record)
+	recorder *Recording
+
+	// Resource accounting for the most recent run (see Usage)
+	usage  Usage
+	cbTime map[string]*CallbackUsage
+
+	// Providers whose Init failed at construction (see ProviderErrors)
+	providerErrs map[string]error
+
+	// Live host object handles, keyed by id (see NewHandle)
+	handleMu     sync.Mutex
+	handles      map[uint64]any
+	nextHandleID uint64
 }
 
 // NewState creates a new Python execution state with all stdlib modules enabled.
@@ -307,12 +429,29 @@ func NewStateWithModules(opts ...StateOption) *State {
 
 	runtime.ResetModules()
 
-	// Set up the compile function bridge for exec/eval/compile builtins
-	runtime.CompileFunc = compileForBuiltin
-
-	// Initialize only the requested modules
-	for m := range cfg.modules {
-		initModule(m)
+	// Set up the compile function bridge for exec/eval/compile builtins.
+	// Installed exactly once so concurrent state creation does not race on the
+	// package-level function variable.
+	compileFuncOnce.Do(func() {
+		runtime.CompileFunc = compileForBuiltin
+	})
+
+	// Initialize only the requested modules. The full-stdlib configuration
+	// (the NewState fast path) is identical every time, so it is built once
+	// and the registered instances are frozen as an immutable snapshot that
+	// imports copy-on-write per state; repeat constructions then skip the
+	// registration work entirely.
+	if len(cfg.modules) == len(AllModules) {
+		stdlibSnapshotOnce.Do(func() {
+			for m := range cfg.modules {
+				initModule(m)
+			}
+			runtime.FreezeRegisteredModules()
+		})
+	} else {
+		for m := range cfg.modules {
+			initModule(m)
+		}
 	}
 
 	vm := runtime.NewVM()
@@ -327,6 +466,16 @@ func NewStateWithModules(opts ...StateOption) *State {
 	if cfg.maxCollectionSize > 0 {
 		vm.SetMaxCollectionSize(cfg.maxCollectionSize)
 	}
+	if cfg.panicRethrow {
+		vm.SetPanicRethrow(true)
+	}
+	if cfg.hashSeedSet {
+		vm.SetHashSeed(cfg.hashSeed)
+	}
+	if cfg.langMajor > 0 {
+		vm.SetLanguageLevel(cfg.langMajor, cfg.langMinor)
+		vm.OverrideModule("sys", stdlib.SysModuleForVersion(cfg.langMajor, cfg.langMinor))
+	}
 
 	// Set up filesystem imports
 	vm.FileImporter = func(path string) (*runtime.CodeObject, error) {
@@ -341,19 +490,34 @@ func NewStateWithModules(opts ...StateOption) *State {
 		return code, nil
 	}
 
-	// Initialize opt-in builtins
-	for b := range cfg.builtins {
+	// Initialize opt-in builtins; entries disabled via WithoutBuiltin are dropped
+	// so EnabledBuiltins only reports what is actually available.
+	for b, enabled := range cfg.builtins {
+		if !enabled {
+			delete(cfg.builtins, b)
+			continue
+		}
 		initBuiltin(vm, b)
 	}
 
-	return &State{
+	s := &State{
 		vm:              vm,
 		compiled:        make(map[string]*runtime.CodeObject),
 		enabledModules:  cfg.modules,
 		enabledBuiltins: cfg.builtins,
 	}
+	applyGlobalRegistrations(s)
+	applyProviders(s)
+	return s
 }
 
+// compileFuncOnce guards installation of the runtime compile bridge.
+var compileFuncOnce sync.Once
+
+// stdlibSnapshotOnce guards the one-time build of the frozen stdlib snapshot
+// shared by all full-stdlib states.
+var stdlibSnapshotOnce sync.Once
+
 // compileForBuiltin wraps compiler.CompileSource for use by exec/eval/compile builtins
 func compileForBuiltin(source, filename, mode string) (*runtime.CodeObject, error) {
 	// For "eval" mode, wrap the expression to capture its result
@@ -383,6 +547,8 @@ func initBuiltin(vm *runtime.VM, b Builtin) {
 		vm.RegisterBuiltin("locals", runtime.BuiltinLocals)
 	case BuiltinVars:
 		vm.RegisterBuiltin("vars", runtime.BuiltinVars)
+	case BuiltinHelp:
+		vm.RegisterBuiltin("help", runtime.BuiltinHelp)
 	case BuiltinCompile:
 		vm.RegisterBuiltin("compile", runtime.BuiltinCompile)
 	case BuiltinExec:
@@ -394,6 +560,33 @@ func initBuiltin(vm *runtime.VM, b Builtin) {
 	}
 }
 
+// builtinName returns the Python-level name an opt-in builtin is registered under.
+func builtinName(b Builtin) string {
+	switch b {
+	case BuiltinRepr:
+		return "repr"
+	case BuiltinDir:
+		return "dir"
+	case BuiltinGlobals:
+		return "globals"
+	case BuiltinLocals:
+		return "locals"
+	case BuiltinVars:
+		return "vars"
+	case BuiltinHelp:
+		return "help"
+	case BuiltinCompile:
+		return "compile"
+	case BuiltinExec:
+		return "exec"
+	case BuiltinEval:
+		return "eval"
+	case BuiltinOpen:
+		return "open"
+	}
+	return ""
+}
+
 // initModule initializes a single stdlib module.
 func initModule(m Module) {
 	switch m {
@@ -447,12 +640,39 @@ func initModule(m Module) {
 		stdlib.InitBisectModule()
 	case ModuleContextlib:
 		stdlib.InitContextlibModule()
+	case ModuleDis:
+		stdlib.InitDisModule()
+	case ModuleAtexit:
+		stdlib.InitAtexitModule()
+	case ModuleYAML:
+		stdlib.InitYAMLModule()
+	case ModuleTOML:
+		stdlib.InitTOMLModule()
+	case ModuleTemplate:
+		stdlib.InitTemplateModule()
+	case ModuleGettext:
+		stdlib.InitGettextModule()
+	case ModuleCalendar:
+		stdlib.InitCalendarModule()
+	case ModuleZoneinfo:
+		stdlib.InitZoneinfoModule()
+	case ModuleTimeit:
+		stdlib.InitTimeitModule()
+	case ModulePprint:
+		stdlib.InitPprintModule()
+	case ModuleCompat:
+		stdlib.InitCompatModule()
 	}
 }
 
 // EnableModule enables a specific stdlib module.
 // This can be called after state creation to add modules.
 func (s *State) EnableModule(m Module) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
 	if !s.enabledModules[m] {
 		initModule(m)
 		s.enabledModules[m] = true
@@ -477,11 +697,15 @@ func (s *State) EnableAllModules() {
 
 // IsModuleEnabled returns true if the specified module is enabled.
 func (s *State) IsModuleEnabled(m Module) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.enabledModules[m]
 }
 
 // EnabledModules returns a slice of all enabled modules.
 func (s *State) EnabledModules() []Module {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	var result []Module
 	for m := range s.enabledModules {
 		result = append(result, m)
@@ -492,6 +716,11 @@ func (s *State) EnabledModules() []Module {
 // EnableBuiltin enables a specific opt-in builtin function.
 // This can be called after state creation to add builtins.
 func (s *State) EnableBuiltin(b Builtin) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
 	if s.enabledBuiltins == nil {
 		s.enabledBuiltins = make(map[Builtin]bool)
 	}
@@ -538,8 +767,40 @@ func (s *State) EnableAllBuiltins() {
 	}
 }
 
+// DisableBuiltin removes a previously enabled opt-in builtin from the state.
+// Python code running afterwards sees the builtin as undefined (NameError).
+// This allows sandboxed deployments to revoke individual capabilities, e.g.
+// enabling all builtins but eval/exec.
+func (s *State) DisableBuiltin(b Builtin) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if name := builtinName(b); name != "" {
+		s.vm.RemoveBuiltin(name)
+	}
+	delete(s.enabledBuiltins, b)
+}
+
+// DisableBuiltins removes multiple opt-in builtins from the state.
+func (s *State) DisableBuiltins(builtins ...Builtin) {
+	for _, b := range builtins {
+		s.DisableBuiltin(b)
+	}
+}
+
+// DisableExecutionBuiltins removes all execution builtins (compile, exec, eval).
+func (s *State) DisableExecutionBuiltins() {
+	for _, b := range ExecutionBuiltins {
+		s.DisableBuiltin(b)
+	}
+}
+
 // IsBuiltinEnabled returns true if the specified builtin is enabled.
 func (s *State) IsBuiltinEnabled(b Builtin) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	if s.enabledBuiltins == nil {
 		return false
 	}
@@ -548,6 +809,8 @@ func (s *State) IsBuiltinEnabled(b Builtin) bool {
 
 // EnabledBuiltins returns a slice of all enabled builtins.
 func (s *State) EnabledBuiltins() []Builtin {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	var result []Builtin
 	for b := range s.enabledBuiltins {
 		result = append(result, b)
@@ -557,27 +820,73 @@ func (s *State) EnabledBuiltins() []Builtin {
 
 // SetMaxRecursionDepth sets the maximum call stack depth. 0 means unlimited.
 func (s *State) SetMaxRecursionDepth(n int64) {
-	if s.closed {
-		return
+	if vm := s.liveVM(); vm != nil {
+		vm.SetMaxRecursionDepth(n)
 	}
-	s.vm.SetMaxRecursionDepth(n)
 }
 
 // SetMaxMemoryBytes sets the approximate memory limit in bytes. 0 means unlimited.
 func (s *State) SetMaxMemoryBytes(n int64) {
-	if s.closed {
-		return
+	if vm := s.liveVM(); vm != nil {
+		vm.SetMaxMemoryBytes(n)
+	}
+}
+
+// SetStdout redirects script output (print, displayed expression values,
+// input prompts) to w, so hosts can capture it instead of sharing the
+// process's stdout. A nil w restores the default, os.Stdout.
+func (s *State) SetStdout(w io.Writer) {
+	if vm := s.liveVM(); vm != nil {
+		vm.SetStdout(w)
+	}
+}
+
+// SetIntMaxStrDigits caps the number of digits accepted when converting a
+// string to an integer, like CPython's sys.set_int_max_str_digits. The
+// default is 4300; 0 disables the limit.
+func (s *State) SetIntMaxStrDigits(n int) {
+	if vm := s.liveVM(); vm != nil {
+		vm.SetIntMaxStrDigits(n)
 	}
-	s.vm.SetMaxMemoryBytes(n)
 }
 
 // SetMaxCollectionSize sets the maximum number of elements in a single collection.
 // 0 means unlimited.
 func (s *State) SetMaxCollectionSize(n int64) {
-	if s.closed {
-		return
+	if vm := s.liveVM(); vm != nil {
+		vm.SetMaxCollectionSize(n)
+	}
+}
+
+// SetCrashSink installs a sink that receives a diagnostic bundle whenever
+// the VM recovers from an internal panic: the panic value, the executing
+// bytecode and value stack, the last opcodes dispatched, a globals summary,
+// and the Go stack. The script still fails with a RuntimeError as before —
+// the bundle exists so VM bugs triggered by one bad script can be reported
+// without crashing or attaching to the whole process. Pass nil to uninstall.
+func (s *State) SetCrashSink(sink func(dump []byte)) {
+	if vm := s.liveVM(); vm != nil {
+		vm.SetCrashSink(sink)
+	}
+}
+
+// SetPanicRethrow controls whether panics from registered Go functions are
+// rethrown to the host instead of being converted into a Python RuntimeError.
+// See WithPanicRethrow.
+func (s *State) SetPanicRethrow(on bool) {
+	if vm := s.liveVM(); vm != nil {
+		vm.SetPanicRethrow(on)
+	}
+}
+
+// SetLanguageLevel sets the CPython version the State reports to scripts
+// through sys.version_info and sys.version. See WithLanguageLevel. Call it
+// before running code that imports sys.
+func (s *State) SetLanguageLevel(major, minor int) {
+	if vm := s.liveVM(); vm != nil {
+		vm.SetLanguageLevel(major, minor)
+		vm.OverrideModule("sys", stdlib.SysModuleForVersion(major, minor))
 	}
-	s.vm.SetMaxCollectionSize(n)
 }
 
 // StringInternPoolSize returns the current size of the global string intern pool.
@@ -588,37 +897,73 @@ func StringInternPoolSize() int {
 
 // AllocatedBytes returns the approximate number of bytes currently tracked by the VM.
 func (s *State) AllocatedBytes() int64 {
-	if s.closed {
+	vm := s.liveVM()
+	if vm == nil {
 		return 0
 	}
-	return s.vm.AllocatedBytes()
+	return vm.AllocatedBytes()
 }
 
 // Close releases resources associated with the state.
 // Always call this when done with the state.
 // After Close is called, the state should not be used.
+//
+// Close is safe to call concurrently with Run: the in-flight execution
+// keeps its own reference to the VM and completes normally, while any
+// calls made after Close report the state as closed.
 func (s *State) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.closed {
 		return // Already closed, idempotent
 	}
 	s.closed = true
+	// Run close hooks (atexit callbacks, OnClose funcs) before tearing down
+	if s.vm != nil {
+		s.vm.RunCloseHooks()
+	}
 	// Clear references to allow GC to reclaim memory
 	s.vm = nil
 	s.compiled = nil
 	s.enabledModules = nil
 	s.enabledBuiltins = nil
+	// Invalidate all outstanding host object handles
+	s.handleMu.Lock()
+	s.handles = nil
+	s.handleMu.Unlock()
+}
+
+// OnClose registers a Go hook to run when this State is closed. Hooks run in
+// last-in-first-out order, interleaved with script-registered atexit
+// callbacks in the same LIFO sequence.
+func (s *State) OnClose(fn func()) {
+	if vm := s.liveVM(); vm != nil {
+		vm.OnClose(fn)
+	}
 }
 
 // checkClosed returns an error if the state has been closed.
 func (s *State) checkClosed() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	if s.closed {
-		return fmt.Errorf("operation on closed State")
+		return ErrStateClosed
 	}
 	return nil
 }
 
-// Run compiles and executes Python source code.
-// Returns the result of the last expression or nil.
+// liveVM returns the underlying VM, or nil if the state has been closed.
+// Callers capture the pointer once so a concurrent Close cannot pull the
+// VM out from under an operation that is already in progress.
+func (s *State) liveVM() *runtime.VM {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.vm
+}
+
+// Run compiles and executes Python source code with REPL semantics: if the
+// final top-level statement is an expression, its value is returned;
+// otherwise the result is None. Use RunExpr to require a single expression.
 func (s *State) Run(source string) (Value, error) {
 	if err := s.checkClosed(); err != nil {
 		return nil, err
@@ -626,11 +971,42 @@ func (s *State) Run(source string) (Value, error) {
 	return s.RunWithFilename(source, "<string>")
 }
 
+// RunExpr evaluates source as a single Python expression against the state's
+// globals and returns its value. Unlike Run, which accepts arbitrary code, it
+// errors if source is anything other than one expression — statements,
+// definitions, or multiple expressions — so embedders can distinguish
+// "evaluate this" from "execute this".
+func (s *State) RunExpr(source string) (Value, error) {
+	vm := s.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
+	}
+
+	parser := compiler.NewParserWithFilename(source, "<expr>")
+	module, parseErrs := parser.Parse()
+	if len(parseErrs) > 0 {
+		// Recompile through the shared path so errors carry source snippets
+		if _, errs := compiler.CompileSource(source, "<expr>"); len(errs) > 0 {
+			return nil, &CompileErrors{Errors: errs}
+		}
+	}
+	if module == nil || len(module.Body) != 1 {
+		return nil, fmt.Errorf("RunExpr: source must be a single expression")
+	}
+	if _, ok := module.Body[0].(*model.ExprStmt); !ok {
+		return nil, fmt.Errorf("RunExpr: source must be a single expression, not a statement")
+	}
+	return s.evalTrailingExpr(vm, source, "<expr>")
+}
+
 // RunWithFilename compiles and executes Python source code with a filename for error messages.
 func (s *State) RunWithFilename(source, filename string) (Value, error) {
-	if err := s.checkClosed(); err != nil {
-		return nil, err
+	vm := s.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
 	}
+	s.recorder.logRun(source, filename)
+	defer s.endUsage(vm, s.beginUsage(vm))
 
 	// Set search paths from the script's directory so local imports work
 	if filename != "<string>" {
@@ -639,41 +1015,94 @@ func (s *State) RunWithFilename(source, filename string) (Value, error) {
 			dir := filepath.Dir(absPath)
 			// Add the script's directory if not already present
 			found := false
-			for _, p := range s.vm.SearchPaths {
+			for _, p := range vm.SearchPaths {
 				if p == dir {
 					found = true
 					break
 				}
 			}
 			if !found {
-				s.vm.SearchPaths = append(s.vm.SearchPaths, dir)
+				vm.SearchPaths = append(vm.SearchPaths, dir)
 			}
 		}
 	}
 
-	code, errs := compiler.CompileSource(source, filename)
+	// REPL semantics: a trailing expression statement's value becomes the
+	// result of the run
+	body, exprText := splitTrailingExpr(source, filename)
+	if exprText == "" {
+		code, errs := compileCached(source, filename)
+		if len(errs) > 0 {
+			return nil, &CompileErrors{Errors: errs}
+		}
+		result, err := vm.Execute(code)
+		if err != nil {
+			return nil, err
+		}
+		return fromRuntime(result), nil
+	}
+
+	code, errs := compileCached(body, filename)
 	if len(errs) > 0 {
 		return nil, &CompileErrors{Errors: errs}
 	}
+	if _, err := vm.Execute(code); err != nil {
+		return nil, err
+	}
+	return s.evalTrailingExpr(vm, exprText, filename)
+}
 
-	result, err := s.vm.Execute(code)
-	if err != nil {
+// splitTrailingExpr parses source and, when the final top-level statement is
+// an expression statement, splits the source into the leading body and the
+// expression's text. Both results are empty when there is no trailing
+// expression or the source does not parse.
+func splitTrailingExpr(source, filename string) (string, string) {
+	parser := compiler.NewParserWithFilename(source, filename)
+	module, parseErrs := parser.Parse()
+	if len(parseErrs) > 0 || module == nil || len(module.Body) == 0 {
+		return "", ""
+	}
+	last, ok := module.Body[len(module.Body)-1].(*model.ExprStmt)
+	if !ok {
+		return "", ""
+	}
+	stmtStart := last.Pos().Offset
+	exprStart := last.Value.Pos().Offset
+	exprEnd := last.Value.End().Offset
+	if stmtStart < 0 || exprStart < stmtStart || exprEnd > len(source) || exprStart >= exprEnd {
+		return "", ""
+	}
+	return source[:stmtStart], source[exprStart:exprEnd]
+}
+
+// evalTrailingExpr evaluates an expression's text in the VM's globals and
+// returns its value, using a reserved global to carry the result out.
+func (s *State) evalTrailingExpr(vm *runtime.VM, exprText, filename string) (Value, error) {
+	code, errs := compileCached("__run_result__ = ("+exprText+"\n)", filename)
+	if len(errs) > 0 {
+		return nil, &CompileErrors{Errors: errs}
+	}
+	if _, err := vm.Execute(code); err != nil {
 		return nil, err
 	}
-	return fromRuntime(result), nil
+	result := fromRuntime(vm.Globals["__run_result__"])
+	delete(vm.Globals, "__run_result__")
+	return result, nil
 }
 
 // RunWithTimeout executes Python code with a timeout.
 func (s *State) RunWithTimeout(source string, timeout time.Duration) (Value, error) {
-	if err := s.checkClosed(); err != nil {
-		return nil, err
+	vm := s.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
 	}
-	code, errs := compiler.CompileSource(source, "<string>")
+	code, errs := compileCached(source, "<string>")
 	if len(errs) > 0 {
 		return nil, &CompileErrors{Errors: errs}
 	}
 
-	result, err := s.vm.ExecuteWithTimeout(timeout, code)
+	defer s.endUsage(vm, s.beginUsage(vm))
+	result, err := vm.ExecuteWithTimeout(timeout, code)
 	if err != nil {
 		return nil, err
 	}
@@ -682,15 +1111,17 @@ func (s *State) RunWithTimeout(source string, timeout time.Duration) (Value, err
 
 // RunWithContext executes Python code with a context for cancellation.
 func (s *State) RunWithContext(ctx context.Context, source string) (Value, error) {
-	if err := s.checkClosed(); err != nil {
-		return nil, err
+	vm := s.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
 	}
-	code, errs := compiler.CompileSource(source, "<string>")
+	code, errs := compileCached(source, "<string>")
 	if len(errs) > 0 {
 		return nil, &CompileErrors{Errors: errs}
 	}
 
-	result, err := s.vm.ExecuteWithContext(ctx, code)
+	defer s.endUsage(vm, s.beginUsage(vm))
+	result, err := vm.ExecuteWithContext(ctx, code)
 	if err != nil {
 		return nil, err
 	}
@@ -701,7 +1132,7 @@ func (s *State) RunWithContext(ctx context.Context, source string) (Value, error
 // The compiled code can be executed later with Execute.
 func (s *State) Compile(source, filename string) (*Code, error) {
 	// Note: Compile doesn't need checkClosed as it doesn't use the VM
-	code, errs := compiler.CompileSource(source, filename)
+	code, errs := compileCached(source, filename)
 	if len(errs) > 0 {
 		return nil, &CompileErrors{Errors: errs}
 	}
@@ -710,63 +1141,166 @@ func (s *State) Compile(source, filename string) (*Code, error) {
 
 // Execute runs previously compiled code.
 func (s *State) Execute(code *Code) (Value, error) {
-	if err := s.checkClosed(); err != nil {
-		return nil, err
+	vm := s.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
 	}
-	result, err := s.vm.Execute(code.code)
+	defer s.endUsage(vm, s.beginUsage(vm))
+	result, err := vm.Execute(code.code)
 	if err != nil {
-		return nil, err
+		return nil, code.remapExecuteError(err)
 	}
 	return fromRuntime(result), nil
 }
 
 // ExecuteWithTimeout runs previously compiled code with a timeout.
 func (s *State) ExecuteWithTimeout(code *Code, timeout time.Duration) (Value, error) {
-	if err := s.checkClosed(); err != nil {
-		return nil, err
+	vm := s.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
 	}
-	result, err := s.vm.ExecuteWithTimeout(timeout, code.code)
+	defer s.endUsage(vm, s.beginUsage(vm))
+	result, err := vm.ExecuteWithTimeout(timeout, code.code)
 	if err != nil {
-		return nil, err
+		return nil, code.remapExecuteError(err)
 	}
 	return fromRuntime(result), nil
 }
 
 // SetGlobal sets a global variable accessible from Python code.
 func (s *State) SetGlobal(name string, value Value) {
-	if s.closed {
+	vm := s.liveVM()
+	if vm == nil {
+		return // Silently ignore on closed state
+	}
+	vm.SetGlobal(name, toRuntime(value))
+}
+
+// SetGlobalReadOnly sets a global variable and marks it read-only: script
+// attempts to rebind or delete the name raise a TypeError. The host can still
+// replace the value through SetGlobal or SetGlobalReadOnly. Freezing guards
+// the binding only — prefer immutable values (tuples over lists) if the
+// object's contents must not change either.
+func (s *State) SetGlobalReadOnly(name string, value Value) {
+	vm := s.liveVM()
+	if vm == nil {
+		return // Silently ignore on closed state
+	}
+	vm.SetGlobal(name, toRuntime(value))
+	vm.FreezeGlobal(name)
+}
+
+// FreezeGlobals marks existing globals as read-only by name, with the same
+// semantics as SetGlobalReadOnly. Useful after Run has defined values (e.g.
+// classes) that later scripts should not be able to shadow.
+func (s *State) FreezeGlobals(names ...string) {
+	vm := s.liveVM()
+	if vm == nil {
 		return // Silently ignore on closed state
 	}
-	s.vm.SetGlobal(name, toRuntime(value))
+	for _, name := range names {
+		vm.FreezeGlobal(name)
+	}
 }
 
 // GetGlobal retrieves a global variable set by Python code.
 func (s *State) GetGlobal(name string) Value {
-	if s.closed {
+	vm := s.liveVM()
+	if vm == nil {
 		return nil
 	}
-	return fromRuntime(s.vm.GetGlobal(name))
+	return fromRuntime(vm.GetGlobal(name))
 }
 
 // GetGlobals returns all global variables as a map.
 func (s *State) GetGlobals() map[string]Value {
-	if s.closed {
+	vm := s.liveVM()
+	if vm == nil {
 		return nil
 	}
 	result := make(map[string]Value)
-	for k, v := range s.vm.Globals {
+	for k, v := range vm.Globals {
 		result[k] = fromRuntime(v)
 	}
 	return result
 }
 
+// HasGlobal reports whether a global with the given name is defined.
+func (s *State) HasGlobal(name string) bool {
+	vm := s.liveVM()
+	if vm == nil {
+		return false
+	}
+	_, ok := vm.Globals[name]
+	return ok
+}
+
+// Globals iterates over all global variables in an unspecified order, without
+// materializing the map that GetGlobals builds.
+func (s *State) Globals() iter.Seq2[string, Value] {
+	return func(yield func(string, Value) bool) {
+		vm := s.liveVM()
+		if vm == nil {
+			return
+		}
+		for k, v := range vm.Globals {
+			if !yield(k, fromRuntime(v)) {
+				return
+			}
+		}
+	}
+}
+
+// GetGlobalString returns the named global as a Go string. The second result
+// is false when the global is missing or not a str.
+func (s *State) GetGlobalString(name string) (string, bool) {
+	return AsString(s.GetGlobal(name))
+}
+
+// GetGlobalInt returns the named global as a Go int64. The second result is
+// false when the global is missing or not an int.
+func (s *State) GetGlobalInt(name string) (int64, bool) {
+	return AsInt(s.GetGlobal(name))
+}
+
+// GetGlobalFloat returns the named global as a Go float64, accepting either a
+// float or an int. The second result is false when the global is missing or
+// not numeric.
+func (s *State) GetGlobalFloat(name string) (float64, bool) {
+	return AsFloat(s.GetGlobal(name))
+}
+
+// GetGlobalBool returns the named global as a Go bool. The second result is
+// false when the global is missing or not a bool.
+func (s *State) GetGlobalBool(name string) (bool, bool) {
+	return AsBool(s.GetGlobal(name))
+}
+
+// GetGlobalSlice returns the named global's elements, accepting either a list
+// or a tuple. The second result is false when the global is missing or not a
+// sequence.
+func (s *State) GetGlobalSlice(name string) ([]Value, bool) {
+	v := s.GetGlobal(name)
+	if items, ok := AsList(v); ok {
+		return items, true
+	}
+	return AsTuple(v)
+}
+
+// GetGlobalMap returns the named global as a Go map. The second result is
+// false when the global is missing or not a dict.
+func (s *State) GetGlobalMap(name string) (map[string]Value, bool) {
+	return AsDict(s.GetGlobal(name))
+}
+
 // GetModuleAttr retrieves an attribute from an imported module.
 // Returns nil if the module doesn't exist or the attribute isn't found.
 func (s *State) GetModuleAttr(moduleName, attrName string) Value {
-	if s.closed {
+	vm := s.liveVM()
+	if vm == nil {
 		return nil
 	}
-	mod, ok := s.vm.GetModule(moduleName)
+	mod, ok := vm.GetModule(moduleName)
 	if !ok {
 		return nil
 	}
@@ -777,15 +1311,38 @@ func (s *State) GetModuleAttr(moduleName, attrName string) Value {
 	return fromRuntime(val)
 }
 
+// SetImportPolicy installs a callback consulted before every import, whether
+// written as an import statement or a dynamic __import__ call. It complements
+// WithModules: module options decide what exists, the policy decides what this
+// State's scripts may reach at import time. The policy sees each module name
+// being imported (for dotted imports, each partial name such as "pkg" then
+// "pkg.sub"); returning a non-nil error blocks the import with an ImportError
+// carrying the error's message. Pass nil to remove the policy.
+//
+//	state.SetImportPolicy(func(name string) error {
+//	    if name == "time" {
+//	        return errors.New("time is not available in sandboxed scripts")
+//	    }
+//	    return nil
+//	})
+func (s *State) SetImportPolicy(policy func(name string) error) {
+	vm := s.liveVM()
+	if vm == nil {
+		return // Silently ignore on closed state
+	}
+	vm.SetImportPolicy(policy)
+}
+
 // RegisterPythonModule compiles and registers Python source code as an importable module.
 // The module can then be imported using "import moduleName" or "from moduleName import ...".
 func (s *State) RegisterPythonModule(moduleName, source string) error {
-	if err := s.checkClosed(); err != nil {
-		return err
+	vm := s.liveVM()
+	if vm == nil {
+		return ErrStateClosed
 	}
 
 	// Ensure parent packages exist before registering the module
-	s.ensureParentPackages(moduleName)
+	ensureParentPackages(vm, moduleName)
 
 	// Compile the source
 	code, errs := compiler.CompileSource(source, moduleName+".py")
@@ -808,19 +1365,19 @@ func (s *State) RegisterPythonModule(moduleName, source string) error {
 	mod.Dict["__package__"] = runtime.NewString(packageName)
 
 	// Execute the code to populate the module's namespace
-	err := s.vm.ExecuteInModule(code, mod)
+	err := vm.ExecuteInModule(code, mod)
 	if err != nil {
 		return err
 	}
 
 	// Register the module so it can be imported
-	s.vm.RegisterModuleInstance(moduleName, mod)
+	vm.RegisterModuleInstance(moduleName, mod)
 
 	// Link this module as an attribute of its parent package
 	if lastDot := lastIndexByte(moduleName, '.'); lastDot >= 0 {
 		parentName := moduleName[:lastDot]
 		childName := moduleName[lastDot+1:]
-		if parentMod, ok := s.vm.GetModule(parentName); ok {
+		if parentMod, ok := vm.GetModule(parentName); ok {
 			parentMod.Dict[childName] = mod
 		}
 	}
@@ -830,7 +1387,7 @@ func (s *State) RegisterPythonModule(moduleName, source string) error {
 
 // ensureParentPackages creates empty parent packages for a dotted module name.
 // For "a.b.c", it ensures "a" and "a.b" exist as packages.
-func (s *State) ensureParentPackages(moduleName string) {
+func ensureParentPackages(vm *runtime.VM, moduleName string) {
 	parts := splitModuleName(moduleName)
 	if len(parts) <= 1 {
 		return // No parent packages needed
@@ -839,13 +1396,13 @@ func (s *State) ensureParentPackages(moduleName string) {
 	// Create each parent package if it doesn't exist
 	for i := 1; i < len(parts); i++ {
 		parentName := joinModuleName(parts[:i])
-		if _, ok := s.vm.GetModule(parentName); !ok {
+		if _, ok := vm.GetModule(parentName); !ok {
 			// Create an empty package
 			pkg := runtime.NewModule(parentName)
 			pkg.Package = parentName
 			pkg.Dict["__package__"] = runtime.NewString(parentName)
 			pkg.Dict["__path__"] = runtime.NewList([]runtime.Value{}) // Mark as package
-			s.vm.RegisterModuleInstance(parentName, pkg)
+			vm.RegisterModuleInstance(parentName, pkg)
 		}
 	}
 
@@ -853,8 +1410,8 @@ func (s *State) ensureParentPackages(moduleName string) {
 	for i := 1; i < len(parts)-1; i++ {
 		parentName := joinModuleName(parts[:i])
 		childName := joinModuleName(parts[:i+1])
-		if parentMod, ok := s.vm.GetModule(parentName); ok {
-			if childMod, ok := s.vm.GetModule(childName); ok {
+		if parentMod, ok := vm.GetModule(parentName); ok {
+			if childMod, ok := vm.GetModule(childName); ok {
 				parentMod.Dict[parts[i]] = childMod
 			}
 		}
@@ -911,7 +1468,8 @@ func lastIndexByte(s string, c byte) int {
 //
 // Then in Python: greet("World")
 func (s *State) Register(name string, fn GoFunc) {
-	if s.closed {
+	stateVM := s.liveVM()
+	if stateVM == nil {
 		return // Silently ignore on closed state
 	}
 	wrapper := func(vm *runtime.VM) int {
@@ -923,7 +1481,10 @@ func (s *State) Register(name string, fn GoFunc) {
 		}
 
 		// Call the Go function
+		started := time.Now()
 		result := fn(s, args...)
+		s.noteCallback(name, time.Since(started))
+		s.recorder.logCall(name, args, result)
 
 		// Push result if not nil
 		if result != nil {
@@ -932,12 +1493,13 @@ func (s *State) Register(name string, fn GoFunc) {
 		}
 		return 0
 	}
-	s.vm.Register(name, wrapper)
+	stateVM.Register(name, wrapper)
 }
 
 // RegisterBuiltin registers a Go function as a builtin.
 func (s *State) RegisterBuiltin(name string, fn GoFunc) {
-	if s.closed {
+	stateVM := s.liveVM()
+	if stateVM == nil {
 		return // Silently ignore on closed state
 	}
 	wrapper := func(vm *runtime.VM) int {
@@ -953,20 +1515,21 @@ func (s *State) RegisterBuiltin(name string, fn GoFunc) {
 		}
 		return 0
 	}
-	s.vm.RegisterBuiltin(name, wrapper)
+	stateVM.RegisterBuiltin(name, wrapper)
 }
 
 // Call invokes a callable Python value (function, class, etc.) with the given arguments.
 // This allows Go code to call Python functions or instantiate classes directly.
 func (s *State) Call(callable Value, args ...Value) (Value, error) {
-	if err := s.checkClosed(); err != nil {
-		return nil, err
+	vm := s.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
 	}
 	rtArgs := make([]runtime.Value, len(args))
 	for i, a := range args {
 		rtArgs[i] = toRuntime(a)
 	}
-	result, err := s.vm.Call(toRuntime(callable), rtArgs, nil)
+	result, err := vm.Call(toRuntime(callable), rtArgs, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -976,6 +1539,7 @@ func (s *State) Call(callable Value, args ...Value) (Value, error) {
 // Code represents compiled Python bytecode.
 type Code struct {
 	code *runtime.CodeObject
+	sm   *SourceMap // non-nil when compiled with CompileWithSourceMap
 }
 
 // Name returns the name of the compiled code (module/function name).
@@ -2,6 +2,7 @@ package rage
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -110,7 +111,7 @@ func TestWithAllBuiltins(t *testing.T) {
 func TestWithResourceLimits(t *testing.T) {
 	state := NewStateWithModules(
 		WithMaxRecursionDepth(50),
-		WithMaxMemoryBytes(1024 * 1024),
+		WithMaxMemoryBytes(1024*1024),
 		WithMaxCollectionSize(1000),
 	)
 	defer state.Close()
@@ -136,6 +137,9 @@ func TestStateClose(t *testing.T) {
 	if !strings.Contains(err.Error(), "closed") {
 		t.Errorf("expected 'closed' in error, got %q", err.Error())
 	}
+	if !errors.Is(err, ErrStateClosed) {
+		t.Errorf("expected errors.Is(err, ErrStateClosed), got %v", err)
+	}
 
 	// SetGlobal on closed state should be a no-op
 	state.SetGlobal("x", Int(1))
@@ -367,6 +371,98 @@ func TestSetGetGlobal(t *testing.T) {
 	}
 }
 
+func TestSetGlobalReadOnly_RejectsReassignment(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetGlobalReadOnly("cpu_count", Int(8))
+	_, err := state.Run(`cpu_count = 1`)
+	if err == nil {
+		t.Fatal("expected error reassigning read-only global")
+	}
+	if !strings.Contains(err.Error(), "read-only global 'cpu_count'") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	n, _ := AsInt(state.GetGlobal("cpu_count"))
+	if n != 8 {
+		t.Errorf("expected cpu_count to stay 8, got %d", n)
+	}
+}
+
+func TestSetGlobalReadOnly_RejectsDelete(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetGlobalReadOnly("cpu_count", Int(8))
+	_, err := state.Run(`del cpu_count`)
+	if err == nil || !strings.Contains(err.Error(), "read-only global 'cpu_count'") {
+		t.Errorf("expected read-only delete error, got %v", err)
+	}
+}
+
+func TestSetGlobalReadOnly_GlobalStatement(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetGlobalReadOnly("cpu_count", Int(8))
+	_, err := state.Run(`
+def f():
+    global cpu_count
+    cpu_count = 1
+
+f()
+`)
+	if err == nil || !strings.Contains(err.Error(), "read-only global 'cpu_count'") {
+		t.Errorf("expected read-only assignment error, got %v", err)
+	}
+}
+
+func TestSetGlobalReadOnly_CatchableAsTypeError(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetGlobalReadOnly("cpu_count", Int(8))
+	_, err := state.Run(`
+caught = False
+try:
+    cpu_count = 1
+except TypeError:
+    caught = True
+`)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if b, _ := AsBool(state.GetGlobal("caught")); !b {
+		t.Error("expected TypeError to be caught by script")
+	}
+}
+
+func TestFreezeGlobals(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	if _, err := state.Run(`config = {"retries": 3}`); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	state.FreezeGlobals("config")
+
+	_, err := state.Run(`config = None`)
+	if err == nil || !strings.Contains(err.Error(), "read-only global 'config'") {
+		t.Errorf("expected read-only error, got %v", err)
+	}
+
+	// Unfrozen names remain assignable
+	if _, err := state.Run(`other = 1`); err != nil {
+		t.Errorf("assigning unfrozen global failed: %v", err)
+	}
+
+	// The host can still replace the value
+	state.SetGlobal("config", None)
+	if !IsNone(state.GetGlobal("config")) {
+		t.Error("host SetGlobal should bypass the freeze")
+	}
+}
+
 func TestGetGlobal_Nonexistent(t *testing.T) {
 	state := NewState()
 	defer state.Close()
@@ -397,6 +493,103 @@ func TestGetGlobals(t *testing.T) {
 	}
 }
 
+func TestGetGlobalTyped(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`
+s = "hello"
+i = 42
+f = 2.5
+b = True
+l = [1, 2, 3]
+tup = (4, 5)
+d = {"k": "v"}
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v, ok := state.GetGlobalString("s"); !ok || v != "hello" {
+		t.Errorf("expected s='hello', got %q (%v)", v, ok)
+	}
+	if v, ok := state.GetGlobalInt("i"); !ok || v != 42 {
+		t.Errorf("expected i=42, got %d (%v)", v, ok)
+	}
+	if v, ok := state.GetGlobalFloat("f"); !ok || v != 2.5 {
+		t.Errorf("expected f=2.5, got %g (%v)", v, ok)
+	}
+	// Ints are accepted as floats, matching AsFloat
+	if v, ok := state.GetGlobalFloat("i"); !ok || v != 42 {
+		t.Errorf("expected i as float 42, got %g (%v)", v, ok)
+	}
+	if v, ok := state.GetGlobalBool("b"); !ok || !v {
+		t.Errorf("expected b=true, got %v (%v)", v, ok)
+	}
+	if items, ok := state.GetGlobalSlice("l"); !ok || len(items) != 3 {
+		t.Errorf("expected 3 list items, got %v (%v)", items, ok)
+	}
+	// Tuples work through GetGlobalSlice too
+	if items, ok := state.GetGlobalSlice("tup"); !ok || len(items) != 2 {
+		t.Errorf("expected 2 tuple items, got %v (%v)", items, ok)
+	}
+	if m, ok := state.GetGlobalMap("d"); !ok || len(m) != 1 {
+		t.Errorf("expected 1 dict entry, got %v (%v)", m, ok)
+	} else if v, ok := AsString(m["k"]); !ok || v != "v" {
+		t.Errorf("expected d['k']='v', got %v", m["k"])
+	}
+
+	// Wrong type and missing name both report false
+	if _, ok := state.GetGlobalInt("s"); ok {
+		t.Error("expected GetGlobalInt to fail for a str global")
+	}
+	if _, ok := state.GetGlobalString("nonexistent"); ok {
+		t.Error("expected GetGlobalString to fail for a missing global")
+	}
+}
+
+func TestHasGlobal(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetGlobal("present", Int(1))
+	if !state.HasGlobal("present") {
+		t.Error("expected HasGlobal to report present global")
+	}
+	if state.HasGlobal("absent") {
+		t.Error("expected HasGlobal to report absent global as missing")
+	}
+}
+
+func TestGlobalsIterator(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.SetGlobal("a", Int(1))
+	state.SetGlobal("b", String("two"))
+
+	seen := make(map[string]Value)
+	for name, val := range state.Globals() {
+		seen[name] = val
+	}
+	if n, ok := AsInt(seen["a"]); !ok || n != 1 {
+		t.Errorf("expected a=1, got %v", seen["a"])
+	}
+	if s, ok := AsString(seen["b"]); !ok || s != "two" {
+		t.Errorf("expected b='two', got %v", seen["b"])
+	}
+
+	// Early break must stop the iteration cleanly
+	count := 0
+	for range state.Globals() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("expected exactly one iteration after break, got %d", count)
+	}
+}
+
 func TestSetGlobal_AllTypes(t *testing.T) {
 	state := NewState()
 	defer state.Close()
@@ -501,6 +694,75 @@ func TestRegisterBuiltin(t *testing.T) {
 	}
 }
 
+func TestRegister_PanicBecomesRuntimeError(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.Register("explode", func(s *State, args ...Value) Value {
+		panic("kaboom")
+	})
+
+	_, err := state.Run("explode()")
+	if err == nil {
+		t.Fatal("expected an error from a panicking Go function")
+	}
+	if !strings.Contains(err.Error(), "RuntimeError") {
+		t.Errorf("expected RuntimeError, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "kaboom") {
+		t.Errorf("expected panic message in error, got: %v", err)
+	}
+	// The Go stack should be attached for debugging
+	if !strings.Contains(err.Error(), "goroutine") {
+		t.Errorf("expected Go stack trace in error, got: %v", err)
+	}
+}
+
+func TestRegister_PanicIsCatchableFromPython(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.Register("explode", func(s *State, args ...Value) Value {
+		panic("kaboom")
+	})
+
+	_, err := state.Run(`
+try:
+    explode()
+    caught = False
+except RuntimeError:
+    caught = True
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	caught := state.GetGlobal("caught")
+	if b, ok := AsBool(caught); !ok || !b {
+		t.Errorf("expected panic to surface as a catchable RuntimeError, got %v", caught)
+	}
+}
+
+func TestRegister_PanicRethrow(t *testing.T) {
+	state := NewStateWithModules(WithPanicRethrow())
+	defer state.Close()
+
+	state.Register("explode", func(s *State, args ...Value) Value {
+		panic("kaboom")
+	})
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected the panic to reach the host")
+		}
+		if r != "kaboom" {
+			t.Errorf("expected original panic value, got %v", r)
+		}
+	}()
+	_, _ = state.Run("explode()")
+	t.Fatal("expected Run to panic")
+}
+
 // =====================================
 // Module Tests
 // =====================================
@@ -640,6 +902,200 @@ func TestEnableAllBuiltins(t *testing.T) {
 	}
 }
 
+func TestLocalsBuiltinModuleLevel(t *testing.T) {
+	state := NewStateWithModules(WithReflectionBuiltins())
+	defer state.Close()
+
+	// At module level locals() is the global namespace, like CPython.
+	_, err := state.Run(`
+x = 10
+has_x = "x" in locals()
+same_as_globals = "x" in globals()
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b, _ := AsBool(state.GetGlobal("has_x")); !b {
+		t.Error("expected module-level locals() to contain x")
+	}
+	if b, _ := AsBool(state.GetGlobal("same_as_globals")); !b {
+		t.Error("expected globals() to contain x")
+	}
+}
+
+func TestLocalsBuiltinFastLocals(t *testing.T) {
+	state := NewStateWithModules(WithReflectionBuiltins())
+	defer state.Close()
+
+	_, err := state.Run(`
+def f(a, b):
+    c = a + b
+    def inner():
+        return c
+    return sorted(locals().keys())
+
+names = f(1, 2)
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names, ok := AsList(state.GetGlobal("names"))
+	if !ok {
+		t.Fatalf("expected list, got %v", state.GetGlobal("names"))
+	}
+	got := make([]string, len(names))
+	for i, n := range names {
+		got[i], _ = AsString(n)
+	}
+	want := []string{"a", "b", "c", "inner"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestLocalsBuiltinFreeVariables(t *testing.T) {
+	state := NewStateWithModules(WithReflectionBuiltins())
+	defer state.Close()
+
+	// Free variables captured from an enclosing scope appear in locals().
+	_, err := state.Run(`
+def outer():
+    q = 41
+    def inner():
+        return q + 1, "q" in locals()
+    return inner()
+
+value, has_q = outer()
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := AsInt(state.GetGlobal("value")); n != 42 {
+		t.Errorf("expected 42, got %v", state.GetGlobal("value"))
+	}
+	if b, _ := AsBool(state.GetGlobal("has_q")); !b {
+		t.Error("expected free variable q in locals()")
+	}
+}
+
+func TestVarsBuiltinObject(t *testing.T) {
+	state := NewStateWithModules(WithReflectionBuiltins())
+	defer state.Close()
+
+	_, err := state.Run(`
+class Point:
+    def __init__(self, x, y):
+        self.x = x
+        self.y = y
+
+p = Point(3, 4)
+d = vars(p)
+x = d["x"]
+y = d["y"]
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := AsInt(state.GetGlobal("x")); n != 3 {
+		t.Errorf("expected 3, got %v", state.GetGlobal("x"))
+	}
+	if n, _ := AsInt(state.GetGlobal("y")); n != 4 {
+		t.Errorf("expected 4, got %v", state.GetGlobal("y"))
+	}
+}
+
+func TestDisableBuiltin(t *testing.T) {
+	state := NewStateWithModules(WithAllBuiltins())
+	defer state.Close()
+
+	_, err := state.Run(`a = eval("1 + 2")`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state.DisableBuiltin(BuiltinEval)
+	if state.IsBuiltinEnabled(BuiltinEval) {
+		t.Error("expected BuiltinEval disabled")
+	}
+	_, err = state.Run(`b = eval("3 + 4")`)
+	if err == nil {
+		t.Error("expected error calling eval after disabling it")
+	}
+
+	// Other execution builtins are unaffected.
+	_, err = state.Run(`exec("c = 5")`)
+	if err != nil {
+		t.Errorf("exec should still work: %v", err)
+	}
+}
+
+func TestDisableExecutionBuiltins(t *testing.T) {
+	state := NewStateWithModules(WithAllBuiltins())
+	defer state.Close()
+
+	state.DisableExecutionBuiltins()
+	for _, b := range ExecutionBuiltins {
+		if state.IsBuiltinEnabled(b) {
+			t.Errorf("expected execution builtin %d disabled", b)
+		}
+	}
+	for _, src := range []string{`eval("1")`, `exec("x = 1")`, `compile("1", "<s>", "eval")`} {
+		if _, err := state.Run(src); err == nil {
+			t.Errorf("expected error running %s after disabling execution builtins", src)
+		}
+	}
+
+	// Reflection builtins remain available.
+	if _, err := state.Run(`r = repr(42)`); err != nil {
+		t.Errorf("repr should still work: %v", err)
+	}
+}
+
+func TestWithoutBuiltin(t *testing.T) {
+	state := NewStateWithModules(
+		WithAllBuiltins(),
+		WithoutBuiltin(BuiltinExec),
+	)
+	defer state.Close()
+
+	if state.IsBuiltinEnabled(BuiltinExec) {
+		t.Error("expected BuiltinExec disabled")
+	}
+	if !state.IsBuiltinEnabled(BuiltinEval) {
+		t.Error("expected BuiltinEval enabled")
+	}
+	if _, err := state.Run(`exec("x = 1")`); err == nil {
+		t.Error("expected error calling exec")
+	}
+	if _, err := state.Run(`y = eval("2 + 2")`); err != nil {
+		t.Errorf("eval should work: %v", err)
+	}
+}
+
+func TestWithoutBuiltins(t *testing.T) {
+	state := NewStateWithModules(
+		WithAllBuiltins(),
+		WithoutBuiltins(BuiltinExec, BuiltinEval, BuiltinCompile),
+	)
+	defer state.Close()
+
+	for _, b := range ExecutionBuiltins {
+		if state.IsBuiltinEnabled(b) {
+			t.Errorf("expected execution builtin %d disabled", b)
+		}
+	}
+	enabled := state.EnabledBuiltins()
+	if len(enabled) != len(ReflectionBuiltins) {
+		t.Errorf("expected %d enabled builtins, got %d", len(ReflectionBuiltins), len(enabled))
+	}
+}
+
 func TestEnabledBuiltins(t *testing.T) {
 	state := NewStateWithModules(
 		WithBuiltin(BuiltinRepr),
@@ -1213,3 +1669,191 @@ func TestGoFuncAccessState(t *testing.T) {
 		t.Errorf("expected 123, got %v", result)
 	}
 }
+
+func TestHelpBuiltin(t *testing.T) {
+	state := NewStateWithModules(WithBuiltin(BuiltinHelp))
+	defer state.Close()
+
+	_, err := state.Run(`
+def documented(a, b):
+    """Adds two values."""
+    return a + b
+
+class Example:
+    """An example class."""
+
+help(documented)
+help(Example)
+help(len)
+help(42)
+help()
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestHelpBuiltinNotEnabledByDefault(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`help(len)`)
+	if err == nil {
+		t.Error("expected error calling help() without enabling it")
+	}
+}
+
+func TestWithLanguageLevel(t *testing.T) {
+	state := NewStateWithModules(WithModule(ModuleSys), WithLanguageLevel(3, 10))
+	defer state.Close()
+
+	_, err := state.Run(`
+import sys
+major = sys.version_info[0]
+minor = sys.version_info[1]
+`)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if n, _ := AsInt(state.GetGlobal("major")); n != 3 {
+		t.Errorf("major = %d, want 3", n)
+	}
+	if n, _ := AsInt(state.GetGlobal("minor")); n != 10 {
+		t.Errorf("minor = %d, want 10", n)
+	}
+
+	// A State without a language level still reports the default version.
+	def := NewStateWithModules(WithModule(ModuleSys))
+	defer def.Close()
+	if _, err := def.Run("import sys\nminor = sys.version_info[1]"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if n, _ := AsInt(def.GetGlobal("minor")); n == 10 {
+		t.Error("default State should not report the overridden version")
+	}
+}
+
+func TestFutureAnnotationsImport(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`
+from __future__ import annotations
+
+class Config:
+    retries: int
+
+ann = Config.__annotations__["retries"]
+`)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if s, _ := AsString(state.GetGlobal("ann")); s != "int" {
+		t.Errorf("ann = %q, want \"int\" (annotations should be lazy strings)", s)
+	}
+}
+
+func TestAtexitRunsOnClose(t *testing.T) {
+	state := NewStateWithModules(WithModule(ModuleAtexit))
+
+	var order []string
+	state.Register("notify", func(s *State, args ...Value) Value {
+		name, _ := AsString(args[0])
+		order = append(order, name)
+		return nil
+	})
+
+	_, err := state.Run(`
+import atexit
+
+atexit.register(notify, "first")
+atexit.register(notify, "second")
+
+def removed():
+    notify("removed")
+
+atexit.register(removed)
+atexit.unregister(removed)
+`)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(order) != 0 {
+		t.Fatalf("callbacks ran before Close: %v", order)
+	}
+
+	state.Close()
+	want := []string{"second", "first"}
+	if len(order) != len(want) || order[0] != want[0] || order[1] != want[1] {
+		t.Errorf("callbacks = %v, want %v (LIFO, unregistered removed)", order, want)
+	}
+
+	// Close is idempotent; callbacks must not run twice.
+	state.Close()
+	if len(order) != 2 {
+		t.Errorf("callbacks ran again on second Close: %v", order)
+	}
+}
+
+func TestOnCloseHook(t *testing.T) {
+	state := NewState()
+	closed := false
+	state.OnClose(func() { closed = true })
+	state.Close()
+	if !closed {
+		t.Error("OnClose hook did not run on Close")
+	}
+}
+
+func TestRun_ReturnsTrailingExpression(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	v, err := state.Run(`
+total = 0
+for i in range(5):
+    total += i
+total * 10
+`)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if n, _ := AsInt(v); n != 100 {
+		t.Errorf("expected 100, got %v", v)
+	}
+
+	// Without a trailing expression the result is None
+	v, err = state.Run(`x = 1`)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if v == nil || v.Type() != "NoneType" {
+		t.Errorf("expected None for statement-only source, got %v", v)
+	}
+}
+
+func TestRunExpr(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	if _, err := state.Run(`base = 7`); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	v, err := state.RunExpr(`base * 6`)
+	if err != nil {
+		t.Fatalf("RunExpr failed: %v", err)
+	}
+	if n, _ := AsInt(v); n != 42 {
+		t.Errorf("expected 42, got %v", v)
+	}
+
+	if _, err := state.RunExpr(`x = 1`); err == nil {
+		t.Error("expected RunExpr to reject a statement")
+	}
+	if _, err := state.RunExpr("1\n2"); err == nil {
+		t.Error("expected RunExpr to reject multiple expressions")
+	}
+	if _, err := state.RunExpr(`def f(:`); err == nil {
+		t.Error("expected RunExpr to report a parse error")
+	}
+}
@@ -0,0 +1,183 @@
+// Package ragetest is a golden-file test harness for Python scripts, promoted
+// from the repository's own integration runner so downstream embedders can
+// gate script changes in CI.
+//
+// Each script under test populates a module-level `results` dict; the harness
+// runs the script in a fresh State, serializes `results` to JSON, and
+// compares it against a sibling golden file named `<script>.expected.json`.
+// Run with Update set to (re)write the golden files instead of comparing.
+package ragetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+)
+
+// Options configures a RunDir call.
+type Options struct {
+	// Timeout bounds each script's execution. Zero means DefaultTimeout.
+	Timeout time.Duration
+
+	// Update writes golden files from the scripts' results instead of
+	// comparing against them.
+	Update bool
+
+	// StateOptions configure the State each script runs in. Defaults to
+	// rage.WithAllModules().
+	StateOptions []rage.StateOption
+}
+
+// DefaultTimeout is the per-script timeout when Options.Timeout is zero.
+const DefaultTimeout = 10 * time.Second
+
+// Result reports the outcome of one script.
+type Result struct {
+	Script   string // path relative to the directory passed to RunDir
+	Duration time.Duration
+	Updated  bool  // true when the golden file was written in update mode
+	Err      error // nil when the script passed
+}
+
+// RunDir runs every .py script under dir (recursively, sorted by path) and
+// checks each one's `results` dict against its golden file. The returned
+// error covers harness problems only — per-script failures are reported in
+// the Results so a caller can print all of them.
+func RunDir(dir string, opts Options) ([]Result, error) {
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	stateOpts := opts.StateOptions
+	if stateOpts == nil {
+		stateOpts = []rage.StateOption{rage.WithAllModules()}
+	}
+
+	var scripts []string
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && strings.HasSuffix(path, ".py") {
+			scripts = append(scripts, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", dir, err)
+	}
+	if len(scripts) == 0 {
+		return nil, fmt.Errorf("no .py scripts found under %s", dir)
+	}
+	sort.Strings(scripts)
+
+	results := make([]Result, 0, len(scripts))
+	for _, script := range scripts {
+		rel, relErr := filepath.Rel(dir, script)
+		if relErr != nil {
+			rel = script
+		}
+		start := time.Now()
+		updated, runErr := runScript(script, timeout, stateOpts, opts.Update)
+		results = append(results, Result{
+			Script:   rel,
+			Duration: time.Since(start),
+			Updated:  updated,
+			Err:      runErr,
+		})
+	}
+	return results, nil
+}
+
+// Failed reports whether any script in results failed.
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// GoldenPath returns the golden file path for a script.
+func GoldenPath(script string) string {
+	return script + ".expected.json"
+}
+
+// runScript executes one script and compares (or updates) its golden file.
+func runScript(script string, timeout time.Duration, stateOpts []rage.StateOption, update bool) (updated bool, err error) {
+	source, err := os.ReadFile(script)
+	if err != nil {
+		return false, err
+	}
+
+	state := rage.NewStateWithModules(stateOpts...)
+	defer state.Close()
+
+	if _, err := state.RunWithTimeout(string(source), timeout); err != nil {
+		return false, fmt.Errorf("execution error: %w", err)
+	}
+
+	resultsVal := state.GetGlobal("results")
+	if resultsVal == nil || rage.IsNone(resultsVal) {
+		return false, fmt.Errorf("script does not define a 'results' dict")
+	}
+	if _, ok := rage.AsDict(resultsVal); !ok {
+		return false, fmt.Errorf("'results' must be a dict, got %s", resultsVal.Type())
+	}
+	actual, err := marshalResults(resultsVal)
+	if err != nil {
+		return false, fmt.Errorf("serializing results: %w", err)
+	}
+
+	golden := GoldenPath(script)
+	if update {
+		if err := os.WriteFile(golden, actual, 0o644); err != nil {
+			return false, fmt.Errorf("writing golden file: %w", err)
+		}
+		return true, nil
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, fmt.Errorf("golden file %s is missing (run with update mode to create it)", filepath.Base(golden))
+		}
+		return false, err
+	}
+	if !bytes.Equal(normalizeJSON(expected), normalizeJSON(actual)) {
+		return false, fmt.Errorf("results differ from %s:\n--- expected\n%s\n--- actual\n%s",
+			filepath.Base(golden), strings.TrimSpace(string(expected)), strings.TrimSpace(string(actual)))
+	}
+	return false, nil
+}
+
+// marshalResults serializes the results dict as stable, indented JSON.
+func marshalResults(v rage.Value) ([]byte, error) {
+	out, err := json.MarshalIndent(v.GoValue(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// normalizeJSON re-marshals JSON so formatting differences (indentation,
+// trailing newlines, key order) don't cause spurious mismatches.
+func normalizeJSON(raw []byte) []byte {
+	var parsed any
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return raw
+	}
+	out, err := json.Marshal(parsed)
+	if err != nil {
+		return raw
+	}
+	return out
+}
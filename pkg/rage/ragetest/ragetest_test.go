@@ -0,0 +1,103 @@
+package ragetest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeScript(t *testing.T, dir, name, source string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunDir_UpdateThenCompare(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "math_checks.py", `
+import math
+
+results = {
+    "pi_floor": math.floor(math.pi),
+    "powers": [2 ** n for n in range(4)],
+}
+`)
+
+	results, err := RunDir(dir, Options{Update: true})
+	if err != nil {
+		t.Fatalf("RunDir failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Err != nil || !results[0].Updated {
+		t.Fatalf("unexpected update results: %+v", results)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "math_checks.py.expected.json")); err != nil {
+		t.Fatalf("golden file not written: %v", err)
+	}
+
+	results, err = RunDir(dir, Options{})
+	if err != nil {
+		t.Fatalf("RunDir failed: %v", err)
+	}
+	if Failed(results) {
+		t.Errorf("expected pass against fresh golden, got %+v", results)
+	}
+}
+
+func TestRunDir_DetectsRegression(t *testing.T) {
+	dir := t.TempDir()
+	path := writeScript(t, dir, "calc.py", `results = {"answer": 42}`)
+
+	if _, err := RunDir(dir, Options{Update: true}); err != nil {
+		t.Fatalf("RunDir failed: %v", err)
+	}
+	writeScript(t, dir, "calc.py", `results = {"answer": 41}`)
+
+	results, err := RunDir(dir, Options{})
+	if err != nil {
+		t.Fatalf("RunDir failed: %v", err)
+	}
+	if !Failed(results) {
+		t.Fatal("expected regression to be detected")
+	}
+	if !strings.Contains(results[0].Err.Error(), "results differ") {
+		t.Errorf("unexpected error: %v", results[0].Err)
+	}
+	_ = path
+}
+
+func TestRunDir_MissingGoldenAndResults(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "no_golden.py", `results = {"x": 1}`)
+	writeScript(t, dir, "no_results.py", `x = 1`)
+
+	results, err := RunDir(dir, Options{})
+	if err != nil {
+		t.Fatalf("RunDir failed: %v", err)
+	}
+	for _, r := range results {
+		if r.Err == nil {
+			t.Errorf("expected %s to fail", r.Script)
+		}
+	}
+}
+
+func TestRunDir_Timeout(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "spin.py", `
+while True:
+    pass
+`)
+
+	results, err := RunDir(dir, Options{Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("RunDir failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Err == nil {
+		t.Fatalf("expected timeout failure, got %+v", results)
+	}
+}
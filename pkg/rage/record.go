@@ -0,0 +1,198 @@
+package rage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RecordEvent is one entry in a session recording.
+type RecordEvent struct {
+	Kind     string `json:"kind"`               // "seed", "run", or "call"
+	Seed     int64  `json:"seed,omitempty"`     // seed: value passed to random.seed
+	Filename string `json:"filename,omitempty"` // run: filename used for error messages
+	Source   string `json:"source,omitempty"`   // run: executed source
+	Name     string `json:"name,omitempty"`     // call: registered function name
+	Args     []any  `json:"args,omitempty"`     // call: arguments, converted to Go values
+	Result   any    `json:"result,omitempty"`   // call: return value, converted to a Go value
+}
+
+// Recording captures a session for later replay: every source executed
+// through Run, every call into a Register-ed host function with its
+// arguments and result, and the seed applied to the random module so RNG
+// draws repeat. Values are stored as their Go conversions, so recordings
+// are limited to JSON-representable data — the common case for the config
+// and gameplay scripts recordings exist to debug.
+type Recording struct {
+	mu     sync.Mutex
+	active bool
+	events []RecordEvent
+}
+
+// Record starts recording the session and returns the live recording.
+// When the random module is enabled, the RNG is reseeded with a recorded
+// value so replayed draws match. Recording stops at StopRecording or when
+// the state is closed.
+func (s *State) Record() *Recording {
+	r := &Recording{active: true}
+	s.recorder = r
+	if s.IsModuleEnabled(ModuleRandom) {
+		seed := time.Now().UnixNano()
+		if err := s.applySeed(seed); err == nil {
+			r.append(RecordEvent{Kind: "seed", Seed: seed})
+		}
+	}
+	return r
+}
+
+// StopRecording detaches the current recording; its log remains readable.
+func (s *State) StopRecording() {
+	if s.recorder != nil {
+		s.recorder.mu.Lock()
+		s.recorder.active = false
+		s.recorder.mu.Unlock()
+		s.recorder = nil
+	}
+}
+
+// Log serializes the recording as JSON for storage or bug reports.
+func (r *Recording) Log() ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return json.MarshalIndent(r.events, "", "  ")
+}
+
+// Events returns a copy of the recorded events.
+func (r *Recording) Events() []RecordEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]RecordEvent, len(r.events))
+	copy(out, r.events)
+	return out
+}
+
+func (r *Recording) append(ev RecordEvent) {
+	r.mu.Lock()
+	if r.active {
+		r.events = append(r.events, ev)
+	}
+	r.mu.Unlock()
+}
+
+// logRun records one executed source. It is a no-op on a nil recording, so
+// call sites need no guard.
+func (r *Recording) logRun(source, filename string) {
+	if r == nil {
+		return
+	}
+	r.append(RecordEvent{Kind: "run", Source: source, Filename: filename})
+}
+
+// logCall records one call into a registered host function.
+func (r *Recording) logCall(name string, args []Value, result Value) {
+	if r == nil {
+		return
+	}
+	ev := RecordEvent{Kind: "call", Name: name}
+	for _, a := range args {
+		ev.Args = append(ev.Args, a.GoValue())
+	}
+	if result != nil {
+		ev.Result = result.GoValue()
+	}
+	r.append(ev)
+}
+
+// applySeed seeds the random module without recording the seeding script
+// itself as a run event.
+func (s *State) applySeed(seed int64) error {
+	saved := s.recorder
+	s.recorder = nil
+	_, err := s.Run(fmt.Sprintf("import random\nrandom.seed(%d)", seed))
+	s.recorder = saved
+	return err
+}
+
+// Replay rebuilds a session from a recording log: a fresh state is created,
+// every host function that appears in the log is stubbed to return its
+// recorded results in order, the RNG is reseeded, and the recorded sources
+// are re-run. The replayed state is returned for inspection even when a
+// source fails — reproducing the failure is usually the point.
+func Replay(log []byte) (*State, error) {
+	var events []RecordEvent
+	dec := json.NewDecoder(bytes.NewReader(log))
+	dec.UseNumber() // keep ints as ints; plain decoding turns them into float64
+	if err := dec.Decode(&events); err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+
+	state := NewState()
+
+	// Stub recorded host functions with their recorded results, in call order
+	results := make(map[string][]any)
+	for _, ev := range events {
+		if ev.Kind == "call" {
+			results[ev.Name] = append(results[ev.Name], ev.Result)
+		}
+	}
+	for name := range results {
+		stubName := name
+		state.Register(stubName, func(s *State, args ...Value) Value {
+			queue := results[stubName]
+			if len(queue) == 0 {
+				return None
+			}
+			next := queue[0]
+			results[stubName] = queue[1:]
+			if next == nil {
+				return None
+			}
+			return replayValue(next)
+		})
+	}
+
+	for _, ev := range events {
+		switch ev.Kind {
+		case "seed":
+			if err := state.applySeed(ev.Seed); err != nil {
+				return state, err
+			}
+		case "run":
+			if _, err := state.RunWithFilename(ev.Source, ev.Filename); err != nil {
+				return state, err
+			}
+		}
+	}
+	return state, nil
+}
+
+// replayValue converts a decoded log value back to a Python value,
+// resolving json.Number to int where possible.
+func replayValue(v any) Value {
+	switch val := v.(type) {
+	case json.Number:
+		if n, err := val.Int64(); err == nil {
+			return Int(n)
+		}
+		if f, err := val.Float64(); err == nil {
+			return Float(f)
+		}
+		return String(val.String())
+	case []any:
+		items := make([]Value, len(val))
+		for i, item := range val {
+			items[i] = replayValue(item)
+		}
+		return List(items...)
+	case map[string]any:
+		var pairs []any
+		for k, item := range val {
+			pairs = append(pairs, k, replayValue(item))
+		}
+		return Dict(pairs...)
+	default:
+		return FromGo(v)
+	}
+}
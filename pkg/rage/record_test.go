@@ -0,0 +1,126 @@
+package rage
+
+import "testing"
+
+func TestRecordCapturesRunsAndCalls(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	calls := 0
+	state.Register("get_config", func(s *State, args ...Value) Value {
+		calls++
+		return Int(int64(100 * calls))
+	})
+
+	rec := state.Record()
+	if _, err := state.Run("a = get_config('x')\nb = get_config('y')\nresult = a + b"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	state.StopRecording()
+
+	// Runs after StopRecording are not captured
+	if _, err := state.Run("result = 0"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var runs, callEvents int
+	for _, ev := range rec.Events() {
+		switch ev.Kind {
+		case "run":
+			runs++
+		case "call":
+			callEvents++
+			if ev.Name != "get_config" || len(ev.Args) != 1 {
+				t.Errorf("unexpected call event: %+v", ev)
+			}
+		}
+	}
+	if runs != 1 {
+		t.Errorf("expected 1 run event, got %d", runs)
+	}
+	if callEvents != 2 {
+		t.Errorf("expected 2 call events, got %d", callEvents)
+	}
+}
+
+func TestReplayReproducesSession(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	// A host function whose behavior the replayed session cannot re-create
+	calls := 0
+	state.Register("next_id", func(s *State, args ...Value) Value {
+		calls++
+		return Int(int64(calls * 7))
+	})
+
+	rec := state.Record()
+	if _, err := state.Run("ids = [next_id(), next_id(), next_id()]"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	state.StopRecording()
+
+	log, err := rec.Log()
+	if err != nil {
+		t.Fatalf("log: %v", err)
+	}
+
+	replayed, err := Replay(log)
+	if replayed != nil {
+		defer replayed.Close()
+	}
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	ids, ok := replayed.GetGlobalSlice("ids")
+	if !ok || len(ids) != 3 {
+		t.Fatalf("expected 3 replayed ids, got %v", ids)
+	}
+	for i, want := range []int64{7, 14, 21} {
+		if n, _ := AsInt(ids[i]); n != want {
+			t.Errorf("id %d: expected %d, got %v", i, want, ids[i])
+		}
+	}
+}
+
+func TestReplayReproducesRandomDraws(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	rec := state.Record()
+	if _, err := state.Run("import random\ndraws = [random.randint(0, 10**9) for _ in range(5)]"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	state.StopRecording()
+
+	log, err := rec.Log()
+	if err != nil {
+		t.Fatalf("log: %v", err)
+	}
+	replayed, err := Replay(log)
+	if replayed != nil {
+		defer replayed.Close()
+	}
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+
+	orig, _ := state.GetGlobalSlice("draws")
+	got, ok := replayed.GetGlobalSlice("draws")
+	if !ok || len(got) != len(orig) {
+		t.Fatalf("expected %d replayed draws, got %v", len(orig), got)
+	}
+	for i := range orig {
+		a, _ := AsInt(orig[i])
+		b, _ := AsInt(got[i])
+		if a != b {
+			t.Errorf("draw %d: expected %d, got %d", i, a, b)
+		}
+	}
+}
+
+func TestReplayRejectsBadLog(t *testing.T) {
+	if _, err := Replay([]byte("not json")); err == nil {
+		t.Error("expected error for malformed log")
+	}
+}
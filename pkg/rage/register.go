@@ -0,0 +1,119 @@
+package rage
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// This file implements State.RegisterFunc, a reflection-based alternative to
+// Register for hosts that would rather write natural Go signatures than the
+// fixed GoFunc shape. Argument and result conversion follow the same rules
+// as RegisterInterface methods, so the two APIs behave identically.
+
+var valueSliceType = reflect.TypeOf([]Value(nil))
+var statePtrType = reflect.TypeOf((*State)(nil))
+
+// RegisterFunc registers a Go function callable from Python, inferring the
+// call convention from its signature:
+//
+//	state.RegisterFunc("clamp", func(v, lo, hi float64) float64 { ... })
+//	state.RegisterFunc("lookup", func(key string) (string, error) { ... })
+//	state.RegisterFunc("stats", func() (int, int, float64) { ... })
+//
+// Parameters are converted from Python with the same rules as BindGlobals
+// fields; an optional leading *State parameter receives the state. A trailing
+// error return becomes a Python exception, multiple return values become a
+// tuple, and native Go results (string, int, map, ...) are converted with
+// FromGo. A single []Value return also becomes a tuple, for callbacks that
+// return a variable number of values.
+func (s *State) RegisterFunc(name string, fn any) error {
+	if err := s.checkClosed(); err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(fn)
+	if !rv.IsValid() || rv.Kind() != reflect.Func {
+		return fmt.Errorf("RegisterFunc: %q must be a function, got %T", name, fn)
+	}
+	vm := s.liveVM()
+	vm.SetGlobal(name, &runtime.PyBuiltinFunc{
+		Name: name,
+		Fn:   makeReflectedFunc(s, name, rv),
+	})
+	return nil
+}
+
+// makeReflectedFunc wraps a reflected Go function as a builtin callback,
+// converting arguments in and results out.
+func makeReflectedFunc(s *State, name string, fn reflect.Value) func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+	ft := fn.Type()
+	takesState := ft.NumIn() > 0 && ft.In(0) == statePtrType
+	return func(args []runtime.Value, kwargs map[string]runtime.Value) (runtime.Value, error) {
+		if len(kwargs) > 0 {
+			return nil, fmt.Errorf("TypeError: %s() takes no keyword arguments", name)
+		}
+
+		numIn := ft.NumIn()
+		numPy := numIn // parameters filled from Python
+		if takesState {
+			numPy--
+		}
+		if ft.IsVariadic() {
+			if len(args) < numPy-1 {
+				return nil, fmt.Errorf("TypeError: %s() takes at least %d arguments, got %d",
+					name, numPy-1, len(args))
+			}
+		} else if len(args) != numPy {
+			return nil, fmt.Errorf("TypeError: %s() takes %d arguments, got %d",
+				name, numPy, len(args))
+		}
+
+		in := make([]reflect.Value, 0, numIn+len(args))
+		if takesState {
+			in = append(in, reflect.ValueOf(s))
+		}
+		for i, arg := range args {
+			pos := len(in)
+			var paramType reflect.Type
+			if ft.IsVariadic() && pos >= numIn-1 {
+				paramType = ft.In(numIn - 1).Elem()
+			} else {
+				paramType = ft.In(pos)
+			}
+			param := reflect.New(paramType).Elem()
+			if err := bindValue(fromRuntime(arg), param); err != nil {
+				return nil, fmt.Errorf("TypeError: %s() argument %d: %v", name, i+1, err)
+			}
+			in = append(in, param)
+		}
+
+		out := fn.Call(in)
+
+		// A trailing error return becomes a Python exception
+		if n := len(out); n > 0 && ft.Out(n-1) == errorType {
+			if !out[n-1].IsNil() {
+				return nil, out[n-1].Interface().(error)
+			}
+			out = out[:n-1]
+		}
+
+		switch len(out) {
+		case 0:
+			return runtime.None, nil
+		case 1:
+			// A []Value result is a multi-return spelled as a slice
+			if out[0].Type() == valueSliceType && !out[0].IsNil() {
+				items := out[0].Interface().([]Value)
+				return toRuntime(Tuple(items...)), nil
+			}
+			return toRuntime(resultToValue(out[0])), nil
+		default:
+			items := make([]Value, len(out))
+			for i, res := range out {
+				items[i] = resultToValue(res)
+			}
+			return toRuntime(Tuple(items...)), nil
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package rage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegisterFunc_NativeSignature(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	err := state.RegisterFunc("clamp", func(v, lo, hi float64) float64 {
+		if v < lo {
+			return lo
+		}
+		if v > hi {
+			return hi
+		}
+		return v
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := state.Run(`x = clamp(15.0, 0.0, 10.0)`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f, _ := state.GetGlobalFloat("x"); f != 10.0 {
+		t.Errorf("expected 10.0, got %v", f)
+	}
+}
+
+func TestRegisterFunc_MultiReturnBecomesTuple(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	err := state.RegisterFunc("divmod_go", func(a, b int64) (int64, int64) {
+		return a / b, a % b
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := state.Run(`q, r = divmod_go(17, 5)`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q, _ := state.GetGlobalInt("q"); q != 3 {
+		t.Errorf("expected q=3, got %d", q)
+	}
+	if r, _ := state.GetGlobalInt("r"); r != 2 {
+		t.Errorf("expected r=2, got %d", r)
+	}
+}
+
+func TestRegisterFunc_TrailingError(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	err := state.RegisterFunc("lookup", func(key string) (string, error) {
+		if key == "name" {
+			return "rage", nil
+		}
+		return "", errors.New("KeyError: " + key)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := state.Run(`found = lookup("name")`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, _ := state.GetGlobalString("found"); s != "rage" {
+		t.Errorf("expected 'rage', got %q", s)
+	}
+
+	_, err = state.Run(`
+try:
+    lookup("missing")
+    caught = "no"
+except KeyError:
+    caught = "caught"
+`)
+	if err != nil {
+		t.Fatalf("expected the script to catch the KeyError, got %v", err)
+	}
+	if s, _ := state.GetGlobalString("caught"); s != "caught" {
+		t.Errorf("expected the KeyError to be catchable, got %q", s)
+	}
+}
+
+func TestRegisterFunc_ValueSliceBecomesTuple(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	err := state.RegisterFunc("spread", func(n int64) ([]Value, error) {
+		items := make([]Value, n)
+		for i := range items {
+			items[i] = Int(int64(i))
+		}
+		return items, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := state.Run(`
+vals = spread(3)
+kind = type(vals).__name__
+shown = repr(vals)
+`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, _ := state.GetGlobalString("kind"); s != "tuple" {
+		t.Errorf("expected a tuple, got %q", s)
+	}
+	if s, _ := state.GetGlobalString("shown"); s != "(0, 1, 2)" {
+		t.Errorf("expected (0, 1, 2), got %q", s)
+	}
+}
+
+func TestRegisterFunc_NativeReturnsConvert(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	err := state.RegisterFunc("config", func() map[string]any {
+		return map[string]any{"level": 3, "name": "boss"}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := state.Run(`n = config()["level"] + len(config()["name"])`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := state.GetGlobalInt("n"); n != 7 {
+		t.Errorf("expected 7, got %d", n)
+	}
+}
+
+func TestRegisterFunc_StateParamAndVariadic(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	err := state.RegisterFunc("tally", func(s *State, label string, nums ...int64) string {
+		var total int64
+		for _, n := range nums {
+			total += n
+		}
+		s.SetGlobal("last_total", Int(total))
+		return label
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := state.Run(`label = tally("score", 1, 2, 3)`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, _ := state.GetGlobalString("label"); s != "score" {
+		t.Errorf("expected 'score', got %q", s)
+	}
+	if n, _ := state.GetGlobalInt("last_total"); n != 6 {
+		t.Errorf("expected last_total=6, got %d", n)
+	}
+}
+
+func TestRegisterFunc_ArityAndTypeErrors(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	if err := state.RegisterFunc("double", func(n int64) int64 { return n * 2 }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := state.RegisterFunc("bad", 42); err == nil {
+		t.Error("expected an error registering a non-function")
+	}
+
+	_, err := state.Run(`double(1, 2)`)
+	if err == nil || !strings.Contains(err.Error(), "takes 1 arguments") {
+		t.Errorf("expected an arity error, got %v", err)
+	}
+	_, err = state.Run(`double("x")`)
+	if err == nil || !strings.Contains(err.Error(), "argument 1") {
+		t.Errorf("expected a conversion error, got %v", err)
+	}
+	_, err = state.Run(`double(n=2)`)
+	if err == nil || !strings.Contains(err.Error(), "keyword") {
+		t.Errorf("expected a keyword-argument error, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package rage
+
+import (
+	"sync"
+
+	"github.com/ATSOTECK/rage/internal/compiler"
+)
+
+// Process-wide registrations applied to every new State.
+//
+// Hosts that create many States (one per request, per entity, or from a pool)
+// would otherwise repeat the same Register calls after each construction.
+// RegisterGlobalFunc and RegisterGlobalModule record the registration once at
+// startup and every State created afterwards — through NewState, NewBareState
+// or NewStateWithModules — picks it up automatically.
+
+var globalRegistry struct {
+	mu      sync.Mutex
+	funcs   []globalFuncEntry
+	modules []globalModuleEntry
+}
+
+type globalFuncEntry struct {
+	name string
+	fn   GoFunc
+}
+
+type globalModuleEntry struct {
+	name   string
+	source string
+}
+
+// RegisterGlobalFunc registers fn under name in every State created after
+// this call, exactly as if state.Register(name, fn) ran at construction.
+// Typically called from init or main before any States exist.
+func RegisterGlobalFunc(name string, fn GoFunc) {
+	globalRegistry.mu.Lock()
+	defer globalRegistry.mu.Unlock()
+	globalRegistry.funcs = append(globalRegistry.funcs, globalFuncEntry{name: name, fn: fn})
+}
+
+// RegisterGlobalModule registers Python source as an importable module in
+// every State created after this call, exactly as if
+// state.RegisterPythonModule(moduleName, source) ran at construction. The
+// source is compile-checked immediately so broken modules fail at startup
+// rather than in every State.
+func RegisterGlobalModule(moduleName, source string) error {
+	if _, errs := compiler.CompileSource(source, moduleName+".py"); len(errs) > 0 {
+		return &CompileErrors{Errors: errs}
+	}
+	globalRegistry.mu.Lock()
+	defer globalRegistry.mu.Unlock()
+	globalRegistry.modules = append(globalRegistry.modules, globalModuleEntry{name: moduleName, source: source})
+	return nil
+}
+
+// ClearGlobalRegistrations removes all global registrations. Existing States
+// keep what they were given; only States created afterwards are affected.
+// Mainly useful in tests.
+func ClearGlobalRegistrations() {
+	globalRegistry.mu.Lock()
+	defer globalRegistry.mu.Unlock()
+	globalRegistry.funcs = nil
+	globalRegistry.modules = nil
+}
+
+// applyGlobalRegistrations installs the recorded functions and modules on a
+// freshly built State. Module bodies were compile-checked at registration;
+// runtime failures executing one leave that module unregistered.
+func applyGlobalRegistrations(s *State) {
+	globalRegistry.mu.Lock()
+	funcs := make([]globalFuncEntry, len(globalRegistry.funcs))
+	copy(funcs, globalRegistry.funcs)
+	modules := make([]globalModuleEntry, len(globalRegistry.modules))
+	copy(modules, globalRegistry.modules)
+	globalRegistry.mu.Unlock()
+
+	for _, f := range funcs {
+		s.Register(f.name, f.fn)
+	}
+	for _, m := range modules {
+		_ = s.RegisterPythonModule(m.name, m.source)
+	}
+}
@@ -0,0 +1,67 @@
+package rage
+
+import (
+	"testing"
+)
+
+func TestRegisterGlobalFunc_AppliesToNewStates(t *testing.T) {
+	defer ClearGlobalRegistrations()
+	RegisterGlobalFunc("shout", func(s *State, args ...Value) Value {
+		str, _ := AsString(args[0])
+		return String(str + "!")
+	})
+
+	for i := 0; i < 2; i++ {
+		state := NewState()
+		if _, err := state.Run(`msg = shout("go")`); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s, _ := state.GetGlobalString("msg"); s != "go!" {
+			t.Errorf("expected 'go!', got %q", s)
+		}
+		state.Close()
+	}
+}
+
+func TestRegisterGlobalModule_AppliesToNewStates(t *testing.T) {
+	defer ClearGlobalRegistrations()
+	err := RegisterGlobalModule("gamedefs", `
+MAX_HEALTH = 100
+
+def heal(hp, amount):
+    return min(hp + amount, MAX_HEALTH)
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state := NewState()
+	defer state.Close()
+	if _, err := state.Run(`
+import gamedefs
+hp = gamedefs.heal(95, 20)
+`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := state.GetGlobalInt("hp"); n != 100 {
+		t.Errorf("expected hp=100, got %d", n)
+	}
+}
+
+func TestRegisterGlobalModule_CompileCheck(t *testing.T) {
+	defer ClearGlobalRegistrations()
+	if err := RegisterGlobalModule("broken", `def oops(:`); err == nil {
+		t.Error("expected a compile error for broken module source")
+	}
+}
+
+func TestClearGlobalRegistrations(t *testing.T) {
+	RegisterGlobalFunc("transient", func(s *State, args ...Value) Value { return None })
+	ClearGlobalRegistrations()
+
+	state := NewState()
+	defer state.Close()
+	if _, err := state.Run(`transient()`); err == nil {
+		t.Error("expected the cleared function to be absent from new states")
+	}
+}
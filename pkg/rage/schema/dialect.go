@@ -0,0 +1,139 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+)
+
+// FromValue builds a Schema from a Python value, so scripts can declare the
+// shape of their own output:
+//
+//	SCHEMA = {
+//	    "host": "str",
+//	    "port": {"type": "int", "min": 1, "max": 65535},
+//	    "mode": {"type": "str", "enum": ["dev", "prod"]},
+//	    "tags?": ["str"],
+//	}
+//
+// The dialect: a type-name string ("int", "float", "str", "bool", "any"), a
+// one-element list for homogeneous lists, a dict with a "type" key for a
+// constrained scalar (optional "min"/"max"/"enum"), or any other dict for a
+// nested object whose keys may end in "?" to mark the field optional.
+func FromValue(v rage.Value) (*Schema, error) {
+	return fromValue(v, "")
+}
+
+func fromValue(v rage.Value, path string) (*Schema, error) {
+	if v == nil || rage.IsNone(v) {
+		return nil, dialectError(path, "schema value is None")
+	}
+
+	if name, ok := rage.AsString(v); ok {
+		s, err := scalarSchema(name)
+		if err != nil {
+			return nil, dialectError(path, "%v", err)
+		}
+		return s, nil
+	}
+
+	if items, ok := rage.AsList(v); ok {
+		if len(items) != 1 {
+			return nil, dialectError(path, "list schemas take exactly one element schema, got %d", len(items))
+		}
+		elem, err := fromValue(items[0], path+"[0]")
+		if err != nil {
+			return nil, err
+		}
+		return List(elem), nil
+	}
+
+	dict, ok := rage.AsDict(v)
+	if !ok {
+		return nil, dialectError(path, "unsupported schema value of type %s", v.Type())
+	}
+
+	// A dict with a type-name under "type" is a constrained scalar spec.
+	if typeName, ok := rage.AsString(dict["type"]); ok {
+		if _, err := scalarSchema(typeName); err == nil {
+			return constrainedSchema(typeName, dict, path)
+		}
+	}
+
+	// Anything else is a nested object; a trailing '?' marks the key optional.
+	fields := make(map[string]*Schema, len(dict))
+	var optionalNames []string
+	for key, spec := range dict {
+		name := key
+		if strings.HasSuffix(name, "?") {
+			name = strings.TrimSuffix(name, "?")
+			optionalNames = append(optionalNames, name)
+		}
+		sub, err := fromValue(spec, joinPath(path, name))
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = sub
+	}
+	return Object(fields).Optional(optionalNames...), nil
+}
+
+// scalarSchema maps a dialect type name to its schema.
+func scalarSchema(name string) (*Schema, error) {
+	switch name {
+	case "any":
+		return Any(), nil
+	case "bool":
+		return Bool(), nil
+	case "int":
+		return Int(), nil
+	case "float":
+		return Float(), nil
+	case "str":
+		return String(), nil
+	default:
+		return nil, fmt.Errorf("unknown type name '%s'", name)
+	}
+}
+
+// constrainedSchema builds a scalar schema from a {"type": ..., "min": ...,
+// "max": ..., "enum": [...]} spec dict.
+func constrainedSchema(typeName string, dict map[string]rage.Value, path string) (*Schema, error) {
+	s, _ := scalarSchema(typeName)
+	for key, val := range dict {
+		switch key {
+		case "type":
+			// Already consumed.
+		case "min":
+			f, ok := rage.AsFloat(val)
+			if !ok {
+				return nil, dialectError(path, "'min' must be a number, got %s", val.Type())
+			}
+			s.Min(f)
+		case "max":
+			f, ok := rage.AsFloat(val)
+			if !ok {
+				return nil, dialectError(path, "'max' must be a number, got %s", val.Type())
+			}
+			s.Max(f)
+		case "enum":
+			choices, ok := rage.AsList(val)
+			if !ok {
+				return nil, dialectError(path, "'enum' must be a list, got %s", val.Type())
+			}
+			s.enum = append(s.enum, choices...)
+		default:
+			return nil, dialectError(path, "unknown constraint '%s'", key)
+		}
+	}
+	return s, nil
+}
+
+func dialectError(path, format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+	if path == "" {
+		return fmt.Errorf("invalid schema: %s", msg)
+	}
+	return fmt.Errorf("invalid schema at %s: %s", path, msg)
+}
@@ -0,0 +1,281 @@
+// Package schema validates values returned by Python scripts against a
+// declared shape: types, required keys, numeric ranges, and enums. Schemas
+// can be built in Go with the fluent constructors, or loaded from a Python
+// dict in the dialect accepted by FromValue. Validation failures are
+// reported with the path to the offending value (e.g. "settings.port" or
+// "tags[2]") so config authors can find them quickly.
+//
+//	s := schema.Object(map[string]*schema.Schema{
+//	    "host": schema.String(),
+//	    "port": schema.Int().Min(1).Max(65535),
+//	    "mode": schema.String().Enum("dev", "prod"),
+//	    "tags": schema.List(schema.String()),
+//	}).Optional("tags")
+//	err := s.Validate(state.GetGlobal("settings"))
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+)
+
+// kind identifies what a schema node accepts.
+type kind int
+
+const (
+	kindAny kind = iota
+	kindBool
+	kindInt
+	kindFloat
+	kindString
+	kindList
+	kindObject
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindBool:
+		return "bool"
+	case kindInt:
+		return "int"
+	case kindFloat:
+		return "float"
+	case kindString:
+		return "str"
+	case kindList:
+		return "list"
+	case kindObject:
+		return "dict"
+	default:
+		return "any"
+	}
+}
+
+// Schema describes the expected shape of a single value. Build one with
+// Object, List, Int, Float, String, Bool, or Any, then refine it with the
+// chainable Min/Max/Enum/Optional methods.
+type Schema struct {
+	kind     kind
+	fields   map[string]*Schema // object fields
+	optional map[string]bool    // object fields that may be absent
+	elem     *Schema            // list element schema
+	enum     []rage.Value       // allowed values, if non-empty
+	min, max *float64           // numeric bounds, inclusive
+}
+
+// Any accepts any value.
+func Any() *Schema { return &Schema{kind: kindAny} }
+
+// Bool accepts a boolean.
+func Bool() *Schema { return &Schema{kind: kindBool} }
+
+// Int accepts an integer.
+func Int() *Schema { return &Schema{kind: kindInt} }
+
+// Float accepts a float or an int.
+func Float() *Schema { return &Schema{kind: kindFloat} }
+
+// String accepts a string.
+func String() *Schema { return &Schema{kind: kindString} }
+
+// List accepts a list whose elements all match elem.
+func List(elem *Schema) *Schema { return &Schema{kind: kindList, elem: elem} }
+
+// Object accepts a dict with the given fields. All fields are required
+// unless marked with Optional; unknown keys are reported as errors.
+func Object(fields map[string]*Schema) *Schema {
+	return &Schema{kind: kindObject, fields: fields, optional: make(map[string]bool)}
+}
+
+// Min sets an inclusive lower bound on a numeric schema.
+func (s *Schema) Min(n float64) *Schema {
+	s.min = &n
+	return s
+}
+
+// Max sets an inclusive upper bound on a numeric schema.
+func (s *Schema) Max(n float64) *Schema {
+	s.max = &n
+	return s
+}
+
+// Enum restricts the value to one of the given choices.
+func (s *Schema) Enum(choices ...any) *Schema {
+	for _, c := range choices {
+		s.enum = append(s.enum, rage.FromGo(c))
+	}
+	return s
+}
+
+// Optional marks object fields that may be absent.
+func (s *Schema) Optional(names ...string) *Schema {
+	for _, name := range names {
+		s.optional[name] = true
+	}
+	return s
+}
+
+// Error reports a single validation failure at a path within the value.
+type Error struct {
+	Path    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Errors collects every validation failure found in one Validate call.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Validate checks v against the schema. It returns nil on success, or an
+// Errors value listing every mismatch with its path.
+func (s *Schema) Validate(v rage.Value) error {
+	var errs Errors
+	s.validate(v, "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (s *Schema) validate(v rage.Value, path string, errs *Errors) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, &Error{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	if v == nil || rage.IsNone(v) {
+		if s.kind != kindAny {
+			fail("expected %s, got None", s.kind)
+		}
+		return
+	}
+
+	switch s.kind {
+	case kindAny:
+		// Anything goes.
+
+	case kindBool:
+		if _, ok := rage.AsBool(v); !ok {
+			fail("expected bool, got %s", v.Type())
+			return
+		}
+
+	case kindInt:
+		if _, ok := rage.AsInt(v); !ok {
+			fail("expected int, got %s", v.Type())
+			return
+		}
+
+	case kindFloat:
+		if _, ok := rage.AsFloat(v); !ok {
+			fail("expected float, got %s", v.Type())
+			return
+		}
+
+	case kindString:
+		if _, ok := rage.AsString(v); !ok {
+			fail("expected str, got %s", v.Type())
+			return
+		}
+
+	case kindList:
+		items, ok := rage.AsList(v)
+		if !ok {
+			fail("expected list, got %s", v.Type())
+			return
+		}
+		for i, item := range items {
+			s.elem.validate(item, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+
+	case kindObject:
+		dict, ok := rage.AsDict(v)
+		if !ok {
+			fail("expected dict, got %s", v.Type())
+			return
+		}
+		for _, name := range sortedKeys(s.fields) {
+			item, exists := dict[name]
+			if !exists {
+				if !s.optional[name] {
+					fail("missing required key '%s'", name)
+				}
+				continue
+			}
+			s.fields[name].validate(item, joinPath(path, name), errs)
+		}
+		for _, name := range sortedDictKeys(dict) {
+			if _, known := s.fields[name]; !known {
+				*errs = append(*errs, &Error{
+					Path:    joinPath(path, name),
+					Message: "unexpected key",
+				})
+			}
+		}
+	}
+
+	if s.min != nil || s.max != nil {
+		if f, ok := rage.AsFloat(v); ok {
+			if s.min != nil && f < *s.min {
+				fail("value %v is below minimum %v", f, *s.min)
+			}
+			if s.max != nil && f > *s.max {
+				fail("value %v is above maximum %v", f, *s.max)
+			}
+		}
+	}
+	if len(s.enum) > 0 {
+		for _, choice := range s.enum {
+			if choice.String() == v.String() {
+				return
+			}
+		}
+		choices := make([]string, len(s.enum))
+		for i, choice := range s.enum {
+			choices[i] = choice.String()
+		}
+		fail("value %s is not one of [%s]", v.String(), strings.Join(choices, ", "))
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedDictKeys(m map[string]rage.Value) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
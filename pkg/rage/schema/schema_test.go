@@ -0,0 +1,124 @@
+package schema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+)
+
+func settingsSchema() *Schema {
+	return Object(map[string]*Schema{
+		"host": String(),
+		"port": Int().Min(1).Max(65535),
+		"mode": String().Enum("dev", "prod"),
+		"tags": List(String()),
+	}).Optional("tags")
+}
+
+func runGlobal(t *testing.T, source, name string) rage.Value {
+	t.Helper()
+	state := rage.NewState()
+	defer state.Close()
+	if _, err := state.Run(source); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	return state.GetGlobal(name).Detach()
+}
+
+func TestValidate_OK(t *testing.T) {
+	v := runGlobal(t, `settings = {"host": "localhost", "port": 8080, "mode": "dev"}`, "settings")
+	if err := settingsSchema().Validate(v); err != nil {
+		t.Errorf("expected valid, got %v", err)
+	}
+}
+
+func TestValidate_PathAddressedErrors(t *testing.T) {
+	v := runGlobal(t, `settings = {"host": 1, "port": 99999, "mode": "staging", "extra": True}`, "settings")
+	err := settingsSchema().Validate(v)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected Errors, got %T", err)
+	}
+	want := map[string]string{
+		"host":  "expected str",
+		"port":  "above maximum",
+		"mode":  "not one of",
+		"extra": "unexpected key",
+	}
+	for path, fragment := range want {
+		found := false
+		for _, e := range errs {
+			if e.Path == path && strings.Contains(e.Message, fragment) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("missing error at %q containing %q in %v", path, fragment, err)
+		}
+	}
+}
+
+func TestValidate_MissingRequiredAndNestedPaths(t *testing.T) {
+	v := runGlobal(t, `settings = {"host": "x", "port": 1, "mode": "dev", "tags": ["a", 2]}`, "settings")
+	err := settingsSchema().Validate(v)
+	if err == nil || !strings.Contains(err.Error(), "tags[1]: expected str, got int") {
+		t.Errorf("expected tags[1] error, got %v", err)
+	}
+
+	v = runGlobal(t, `settings = {"host": "x", "mode": "dev"}`, "settings")
+	err = settingsSchema().Validate(v)
+	if err == nil || !strings.Contains(err.Error(), "missing required key 'port'") {
+		t.Errorf("expected missing port error, got %v", err)
+	}
+}
+
+func TestFromValue_Dialect(t *testing.T) {
+	schemaVal := runGlobal(t, `
+SCHEMA = {
+    "host": "str",
+    "port": {"type": "int", "min": 1, "max": 65535},
+    "mode": {"type": "str", "enum": ["dev", "prod"]},
+    "tags?": ["str"],
+}
+`, "SCHEMA")
+	s, err := FromValue(schemaVal)
+	if err != nil {
+		t.Fatalf("FromValue failed: %v", err)
+	}
+
+	good := runGlobal(t, `v = {"host": "h", "port": 80, "mode": "prod"}`, "v")
+	if err := s.Validate(good); err != nil {
+		t.Errorf("expected valid, got %v", err)
+	}
+
+	bad := runGlobal(t, `v = {"host": "h", "port": 0, "mode": "prod", "tags": [1]}`, "v")
+	err = s.Validate(bad)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	if !strings.Contains(err.Error(), "port: value 0 is below minimum 1") {
+		t.Errorf("expected port range error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "tags[0]: expected str, got int") {
+		t.Errorf("expected tags element error, got %v", err)
+	}
+}
+
+func TestFromValue_RejectsBadDialect(t *testing.T) {
+	cases := []string{
+		`S = {"x": "integer"}`,
+		`S = {"x": ["str", "int"]}`,
+		`S = {"x": {"type": "int", "step": 2}}`,
+	}
+	for _, src := range cases {
+		if _, err := FromValue(runGlobal(t, src, "S")); err == nil {
+			t.Errorf("expected %q to be rejected", src)
+		}
+	}
+}
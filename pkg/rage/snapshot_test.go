@@ -0,0 +1,51 @@
+package rage
+
+import (
+	"testing"
+)
+
+// With the frozen stdlib snapshot, full-stdlib states share registered module
+// instances copy-on-write; scripts cannot mutate them (module attributes are
+// read-only), so the snapshot stays pristine across states.
+func TestStdlibSnapshot_StaysImmutable(t *testing.T) {
+	first := NewState()
+	defer first.Close()
+	if _, err := first.Run(`
+import math
+math.custom = 42
+`); err == nil {
+		t.Fatal("expected module attribute assignment to be refused")
+	}
+
+	second := NewState()
+	defer second.Close()
+	if _, err := second.Run(`
+import math
+leaked = hasattr(math, "custom")
+pi_ok = math.floor(math.pi) == 3
+`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := second.GetGlobalBool("leaked"); v {
+		t.Error("expected no attribute to leak into a new state")
+	}
+	if v, _ := second.GetGlobalBool("pi_ok"); !v {
+		t.Error("expected math to work normally in the new state")
+	}
+}
+
+func TestStdlibSnapshot_RepeatConstructionWorks(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		state := NewState()
+		if _, err := state.Run(`
+import json
+x = json.loads("[1, 2, 3]")[2]
+`); err != nil {
+			t.Fatalf("state %d: unexpected error: %v", i, err)
+		}
+		if n, _ := state.GetGlobalInt("x"); n != 3 {
+			t.Errorf("state %d: expected 3, got %d", i, n)
+		}
+		state.Close()
+	}
+}
@@ -0,0 +1,142 @@
+package rage
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/model"
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// SourceMap maps lines of generated Python back to positions in the source
+// it was generated from, so compile errors and tracebacks for templated
+// scripts point at the template rather than the generated code. Each
+// mapping covers a region: generated lines after the mapped line advance
+// the original line by the same offset, until the next mapping begins —
+// the same model as Go's //line directives.
+type SourceMap struct {
+	entries []sourceMapEntry
+}
+
+type sourceMapEntry struct {
+	genLine  int
+	file     string
+	origLine int
+}
+
+// NewSourceMap returns an empty source map.
+func NewSourceMap() *SourceMap {
+	return &SourceMap{}
+}
+
+// Add records that the given 1-based generated line corresponds to the
+// given original file and line. Mappings may be added in any order.
+func (m *SourceMap) Add(generatedLine int, file string, originalLine int) {
+	m.entries = append(m.entries, sourceMapEntry{genLine: generatedLine, file: file, origLine: originalLine})
+	sort.Slice(m.entries, func(i, j int) bool { return m.entries[i].genLine < m.entries[j].genLine })
+}
+
+// Resolve translates a generated line to its original file and line. It
+// reports false for lines before the first mapping.
+func (m *SourceMap) Resolve(generatedLine int) (file string, line int, ok bool) {
+	idx := sort.Search(len(m.entries), func(i int) bool { return m.entries[i].genLine > generatedLine }) - 1
+	if idx < 0 {
+		return "", 0, false
+	}
+	entry := m.entries[idx]
+	return entry.file, entry.origLine + (generatedLine - entry.genLine), true
+}
+
+// CompileWithSourceMap compiles generated source with a source map attached:
+// compile error positions are translated through the map, and exceptions
+// raised while executing the returned Code get their tracebacks translated
+// too.
+func (s *State) CompileWithSourceMap(source, filename string, sm *SourceMap) (*Code, error) {
+	code, errs := compileCached(source, filename)
+	if len(errs) > 0 {
+		remapped := make([]error, len(errs))
+		for i, err := range errs {
+			remapped[i] = sm.remapCompileError(err)
+		}
+		return nil, &CompileErrors{Errors: remapped}
+	}
+	return &Code{code: code, sm: sm}, nil
+}
+
+// remapCompileError translates one compile error's position, leaving errors
+// without a mapped position untouched. Compile errors arrive as snippet
+// wrappers around lex, parse, or compile errors, each carrying a Position.
+func (m *SourceMap) remapCompileError(err error) error {
+	switch e := err.(type) {
+	case *compiler.SnippetError:
+		inner := m.remapCompileError(e.Err)
+		pos, ok := m.remapPosition(e.Pos)
+		if !ok {
+			return err
+		}
+		return &compiler.SnippetError{Err: inner, Pos: pos, Line: e.Line}
+	case compiler.CompileError:
+		if pos, ok := m.remapPosition(e.Pos); ok {
+			e.Pos = pos
+			return e
+		}
+	case compiler.ParseError:
+		if pos, ok := m.remapPosition(e.Pos); ok {
+			e.Pos = pos
+			return e
+		}
+	case compiler.LexError:
+		if pos, ok := m.remapPosition(e.Pos); ok {
+			e.Pos = pos
+			return e
+		}
+	}
+	return err
+}
+
+// remapPosition translates a generated position through the map.
+func (m *SourceMap) remapPosition(pos model.Position) (model.Position, bool) {
+	file, line, ok := m.Resolve(pos.Line)
+	if !ok {
+		return pos, false
+	}
+	pos.Filename = file
+	pos.Line = line
+	return pos, true
+}
+
+// remapException translates traceback positions for frames compiled from
+// the mapped file, following the cause/context chain. The seen map guards
+// against cycles.
+func (m *SourceMap) remapException(exc *runtime.PyException, filename string, seen map[*runtime.PyException]bool) {
+	if exc == nil || seen[exc] {
+		return
+	}
+	seen[exc] = true
+	for i := range exc.Traceback {
+		tb := &exc.Traceback[i]
+		if tb.Filename != filename {
+			continue
+		}
+		if file, line, ok := m.Resolve(tb.Line); ok {
+			tb.Filename = file
+			tb.Line = line
+		}
+	}
+	m.remapException(exc.Cause, filename, seen)
+	m.remapException(exc.Context, filename, seen)
+}
+
+// remapExecuteError translates traceback positions in a runtime error from
+// executing mapped code. Errors that are not exceptions pass through.
+func (c *Code) remapExecuteError(err error) error {
+	if err == nil || c.sm == nil {
+		return err
+	}
+	var exc *runtime.PyException
+	if errors.As(err, &exc) {
+		c.sm.remapException(exc, c.code.Filename, make(map[*runtime.PyException]bool))
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+package rage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSourceMapResolve(t *testing.T) {
+	sm := NewSourceMap()
+	sm.Add(10, "template.tmpl", 3)
+	sm.Add(1, "template.tmpl", 1)
+
+	if _, _, ok := sm.Resolve(0); ok {
+		t.Error("expected no mapping before the first entry")
+	}
+	if file, line, ok := sm.Resolve(1); !ok || file != "template.tmpl" || line != 1 {
+		t.Errorf("expected template.tmpl:1, got %s:%d ok=%v", file, line, ok)
+	}
+	// Lines within a region advance by the same offset
+	if _, line, _ := sm.Resolve(5); line != 5 {
+		t.Errorf("expected line 5, got %d", line)
+	}
+	// A later mapping starts a new region
+	if _, line, _ := sm.Resolve(12); line != 5 {
+		t.Errorf("expected line 5 in second region, got %d", line)
+	}
+}
+
+func TestCompileWithSourceMapCompileError(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	sm := NewSourceMap()
+	sm.Add(1, "config.tmpl", 20)
+
+	// Syntax error on generated line 2 should report template line 21
+	_, err := state.CompileWithSourceMap("x = 1\ny = (\n", "<generated>", sm)
+	if err == nil {
+		t.Fatal("expected a compile error")
+	}
+	if !strings.Contains(err.Error(), "config.tmpl:21") {
+		t.Errorf("expected error at config.tmpl:21, got %v", err)
+	}
+}
+
+func TestCompileWithSourceMapTraceback(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	sm := NewSourceMap()
+	sm.Add(1, "config.tmpl", 20)
+
+	code, err := state.CompileWithSourceMap("x = 1\ny = 2\nraise ValueError('boom')\n", "<generated>", sm)
+	if err != nil {
+		t.Fatalf("unexpected compile error: %v", err)
+	}
+	_, err = state.Execute(code)
+	if err == nil {
+		t.Fatal("expected a runtime error")
+	}
+	if !strings.Contains(err.Error(), "config.tmpl:22") {
+		t.Errorf("expected traceback at config.tmpl:22, got %v", err)
+	}
+}
+
+func TestCompileWithoutSourceMapUnchanged(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	code, err := state.Compile("raise ValueError('boom')\n", "plain.py")
+	if err != nil {
+		t.Fatalf("unexpected compile error: %v", err)
+	}
+	_, err = state.Execute(code)
+	if err == nil || !strings.Contains(err.Error(), "plain.py:1") {
+		t.Errorf("expected unmapped traceback at plain.py:1, got %v", err)
+	}
+}
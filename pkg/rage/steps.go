@@ -0,0 +1,166 @@
+package rage
+
+import (
+	"sync"
+
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// Time-budgeted cooperative scripting.
+//
+// StartRun begins executing a script under an instruction budget: each
+// RunSteps(n) call lets the script run at most n bytecode instructions and
+// then reports whether it yielded, finished, or failed. This spreads long
+// scripts across game-loop frames without goroutines or timeouts on the host
+// side — the host decides exactly when and how much the script advances.
+
+// StepStatus reports the outcome of a RunSteps call.
+type StepStatus int
+
+const (
+	// StepYielded means the budget was exhausted before the script finished.
+	StepYielded StepStatus = iota
+	// StepDone means the script ran to completion.
+	StepDone
+	// StepError means the script stopped with an error (see Err).
+	StepError
+)
+
+// StepRun is an in-progress script execution driven by RunSteps. A StepRun
+// is not safe for concurrent use, and the owning State must not run other
+// code until the StepRun has finished or been cancelled.
+type StepRun struct {
+	state  *State
+	vm     *runtime.VM
+	paused chan struct{}
+	resume chan int64
+	done   chan struct{}
+	cancel chan struct{}
+
+	cancelOnce sync.Once
+	parked     bool
+	finished   bool
+	result     Value
+	err        error
+}
+
+// StartRun compiles source and starts executing it under an instruction
+// budget. No instructions run until the first RunSteps call.
+func (s *State) StartRun(source string) (*StepRun, error) {
+	vm := s.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
+	}
+
+	code, errs := compiler.CompileSource(source, "<steps>")
+	if len(errs) > 0 {
+		return nil, &CompileErrors{Errors: errs}
+	}
+
+	r := &StepRun{
+		state:  s,
+		vm:     vm,
+		paused: make(chan struct{}),
+		resume: make(chan int64),
+		done:   make(chan struct{}),
+		cancel: make(chan struct{}),
+	}
+
+	// The pause callback runs on the executing goroutine each time the
+	// budget is spent: report the park, then wait for a new grant
+	vm.EnableStepLimit(func() error {
+		select {
+		case r.paused <- struct{}{}:
+		case <-r.cancel:
+			return &runtime.CancelledError{}
+		}
+		select {
+		case n := <-r.resume:
+			r.vm.AddSteps(n)
+			return nil
+		case <-r.cancel:
+			return &runtime.CancelledError{}
+		}
+	})
+
+	go func() {
+		result, err := vm.Execute(code)
+		vm.DisableStepLimit()
+		if err != nil {
+			r.err = err
+		} else {
+			r.result = fromRuntime(result)
+		}
+		close(r.done)
+	}()
+
+	return r, nil
+}
+
+// RunSteps lets the script execute at most n more bytecode instructions.
+// It returns StepYielded if the budget ran out first, StepDone if the script
+// finished, or StepError if it stopped with an error. Calling RunSteps after
+// completion returns the final status again.
+func (r *StepRun) RunSteps(n int) (StepStatus, error) {
+	if r.finished {
+		return r.finish()
+	}
+	if n <= 0 {
+		return StepYielded, nil
+	}
+
+	// Wait for the VM to reach its pause point (it parks before the first
+	// instruction after StartRun)
+	if !r.parked {
+		select {
+		case <-r.paused:
+			r.parked = true
+		case <-r.done:
+			return r.finish()
+		}
+	}
+
+	// Grant the budget, then wait for the next park or for completion
+	r.parked = false
+	r.resume <- int64(n)
+	select {
+	case <-r.paused:
+		r.parked = true
+		return StepYielded, nil
+	case <-r.done:
+		return r.finish()
+	}
+}
+
+// Cancel aborts a yielded run. The script stops with a CancelledError the
+// next time it would execute; Cancel is a no-op after completion.
+func (r *StepRun) Cancel() {
+	r.cancelOnce.Do(func() { close(r.cancel) })
+	<-r.done
+	r.finished = true
+}
+
+// Done reports whether the script has finished (successfully or not).
+func (r *StepRun) Done() bool {
+	return r.finished
+}
+
+// Result returns the script's final value. It is nil until the run has
+// completed successfully.
+func (r *StepRun) Result() Value {
+	return r.result
+}
+
+// Err returns the error the script stopped with, if any.
+func (r *StepRun) Err() error {
+	return r.err
+}
+
+func (r *StepRun) finish() (StepStatus, error) {
+	r.finished = true
+	if r.err != nil {
+		return StepError, r.err
+	}
+	return StepDone, nil
+}
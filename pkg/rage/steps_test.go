@@ -0,0 +1,146 @@
+package rage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStepRun_SpreadsWorkAcrossCalls(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	run, err := state.StartRun(`
+total = 0
+for i in range(1000):
+    total += i
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	yields := 0
+	for {
+		status, err := run.RunSteps(100)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status == StepDone {
+			break
+		}
+		if status != StepYielded {
+			t.Fatalf("unexpected status: %v", status)
+		}
+		yields++
+		if yields > 10000 {
+			t.Fatal("script never finished")
+		}
+	}
+	if yields < 2 {
+		t.Errorf("expected the loop to span several budgets, got %d yields", yields)
+	}
+	if v, _ := state.GetGlobalInt("total"); v != 499500 {
+		t.Errorf("expected total=499500, got %d", v)
+	}
+	if !run.Done() {
+		t.Error("expected Done() after completion")
+	}
+}
+
+func TestStepRun_FinishesWithinBudget(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	run, err := state.StartRun(`x = 1 + 2`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status, err := run.RunSteps(1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != StepDone {
+		t.Fatalf("expected StepDone, got %v", status)
+	}
+	if v, _ := state.GetGlobalInt("x"); v != 3 {
+		t.Errorf("expected x=3, got %d", v)
+	}
+}
+
+func TestStepRun_Error(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	run, err := state.StartRun(`
+x = 1
+raise ValueError("boom")
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var status StepStatus
+	for {
+		status, err = run.RunSteps(10)
+		if status != StepYielded {
+			break
+		}
+	}
+	if status != StepError {
+		t.Fatalf("expected StepError, got %v", status)
+	}
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected the ValueError, got %v", err)
+	}
+	if run.Err() == nil {
+		t.Error("expected Err() to report the failure")
+	}
+}
+
+func TestStepRun_Cancel(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	run, err := state.StartRun(`
+while True:
+    pass
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status, err := run.RunSteps(100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != StepYielded {
+		t.Fatalf("expected the infinite loop to yield, got %v", status)
+	}
+
+	run.Cancel()
+	if !run.Done() {
+		t.Error("expected Done() after Cancel")
+	}
+	if run.Err() == nil {
+		t.Error("expected a cancellation error")
+	}
+}
+
+func TestStepRun_ZeroStepsIsNoop(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	run, err := state.StartRun(`x = 1`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status, err := run.RunSteps(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != StepYielded {
+		t.Fatalf("expected StepYielded for a zero budget, got %v", status)
+	}
+	if status, _ := run.RunSteps(100); status != StepDone {
+		t.Fatalf("expected StepDone, got %v", status)
+	}
+}
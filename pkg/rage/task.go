@@ -0,0 +1,160 @@
+package rage
+
+import (
+	"fmt"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// Host-driven script tasks.
+//
+// Spawn calls a Python generator (or coroutine) function and wraps the result
+// as a Task the host resumes explicitly, so game entities scripted as
+// generators can be ticked by the engine:
+//
+//	task, _ := state.Spawn("main_loop", rage.Int(entityID))
+//	for !task.Done() {
+//	    cmd, _ := task.Resume(rage.None)  // run until the next yield
+//	    handleCommand(cmd)
+//	}
+
+// Task is a Python generator or coroutine resumed from Go.
+type Task struct {
+	state *State
+	gen   *runtime.PyGenerator
+	coro  *runtime.PyCoroutine
+	done  bool
+	ret   Value
+}
+
+// Spawn looks up a global generator or coroutine function, calls it with the
+// given arguments, and returns the resulting Task. No script code beyond the
+// call itself runs until the first Resume.
+func (s *State) Spawn(name string, args ...Value) (*Task, error) {
+	vm := s.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
+	}
+	fn, ok := vm.Globals[name]
+	if !ok {
+		return nil, fmt.Errorf("no global named %q", name)
+	}
+	rtArgs := make([]runtime.Value, len(args))
+	for i, arg := range args {
+		rtArgs[i] = toRuntime(arg)
+	}
+	result, err := vm.Call(fn, rtArgs, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch v := result.(type) {
+	case *runtime.PyGenerator:
+		return &Task{state: s, gen: v}, nil
+	case *runtime.PyCoroutine:
+		return &Task{state: s, coro: v}, nil
+	}
+	return nil, fmt.Errorf("%q is not a generator or coroutine function", name)
+}
+
+// Resume runs the task until its next yield (or await) and returns the
+// yielded value. Pass None unless the script expects a sent value; the first
+// Resume must send None. When the task finishes, Resume returns nil with no
+// error and Done starts reporting true; the return value, if any, is
+// available from Result.
+func (t *Task) Resume(value Value) (Value, error) {
+	vm := t.state.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
+	}
+	if t.done {
+		return nil, fmt.Errorf("task already finished")
+	}
+	if value == nil {
+		value = None
+	}
+
+	var yielded runtime.Value
+	var done bool
+	var err error
+	if t.gen != nil {
+		yielded, done, err = vm.GeneratorSend(t.gen, toRuntime(value))
+	} else {
+		yielded, done, err = vm.CoroutineSend(t.coro, toRuntime(value))
+	}
+	return t.afterStep(yielded, done, err)
+}
+
+// Throw injects err into the task at its current yield point as the Python
+// exception it maps to. The task may catch it and keep running; the returned
+// value is the next yield, as with Resume.
+func (t *Task) Throw(err error) (Value, error) {
+	vm := t.state.liveVM()
+	if vm == nil {
+		return nil, ErrStateClosed
+	}
+	if t.done {
+		return nil, fmt.Errorf("task already finished")
+	}
+
+	exc := vm.WrapError(err)
+	var yielded runtime.Value
+	var done bool
+	var stepErr error
+	if t.gen != nil {
+		yielded, done, stepErr = vm.GeneratorThrow(t.gen, exc, nil)
+	} else {
+		yielded, done, stepErr = vm.CoroutineThrow(t.coro, exc, nil)
+	}
+	return t.afterStep(yielded, done, stepErr)
+}
+
+// afterStep folds the (value, done, error) triple from GeneratorSend-style
+// calls into Task state. StopIteration is completion, not an error.
+func (t *Task) afterStep(yielded runtime.Value, done bool, err error) (Value, error) {
+	if err != nil {
+		t.done = true
+		// StopIteration is completion; its argument carries the return value
+		if pyErr, ok := err.(*runtime.PyException); ok && pyErr.Type() == "StopIteration" {
+			if pyErr.Args != nil && len(pyErr.Args.Items) > 0 {
+				t.ret = fromRuntime(pyErr.Args.Items[0])
+			}
+			return nil, nil
+		}
+		return nil, err
+	}
+	if done {
+		t.done = true
+		if yielded != nil {
+			t.ret = fromRuntime(yielded)
+		}
+		return nil, nil
+	}
+	return fromRuntime(yielded), nil
+}
+
+// Done reports whether the task has run to completion (or failed).
+func (t *Task) Done() bool {
+	return t.done
+}
+
+// Result returns the task's return value once it has finished, or nil.
+func (t *Task) Result() Value {
+	return t.ret
+}
+
+// Close shuts the task down by raising GeneratorExit at its yield point,
+// running any finally blocks. It is a no-op on a finished task.
+func (t *Task) Close() error {
+	vm := t.state.liveVM()
+	if vm == nil {
+		return ErrStateClosed
+	}
+	if t.done {
+		return nil
+	}
+	t.done = true
+	if t.gen != nil {
+		return vm.GeneratorClose(t.gen)
+	}
+	return vm.CoroutineClose(t.coro)
+}
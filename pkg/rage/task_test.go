@@ -0,0 +1,190 @@
+package rage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTask_ResumeYields(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`
+def patrol(start):
+    yield start
+    yield start + 1
+    yield start + 2
+    return "done"
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task, err := state.Spawn("patrol", Int(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []int64
+	for !task.Done() {
+		v, err := task.Resume(None)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v != nil {
+			n, _ := AsInt(v)
+			got = append(got, n)
+		}
+	}
+	if len(got) != 3 || got[0] != 10 || got[2] != 12 {
+		t.Errorf("unexpected yields: %v", got)
+	}
+	ret, _ := AsString(task.Result())
+	if ret != "done" {
+		t.Errorf("expected return value 'done', got %v", task.Result())
+	}
+}
+
+func TestTask_SendValues(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`
+def adder():
+    total = 0
+    while True:
+        got = yield total
+        if got is None:
+            return total
+        total += got
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task, err := state.Spawn("adder")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := task.Resume(None); err != nil {
+		t.Fatalf("priming resume failed: %v", err)
+	}
+	if _, err := task.Resume(Int(5)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v, err := task.Resume(Int(7))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := AsInt(v); n != 12 {
+		t.Errorf("expected running total 12, got %v", v)
+	}
+	if _, err := task.Resume(None); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !task.Done() {
+		t.Fatal("expected task to finish after sending None")
+	}
+	if n, _ := AsInt(task.Result()); n != 12 {
+		t.Errorf("expected result 12, got %v", task.Result())
+	}
+}
+
+func TestTask_Throw(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`
+def guarded():
+    caught = None
+    try:
+        yield "ok"
+    except ValueError as e:
+        caught = str(e)
+    yield caught
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task, err := state.Spawn("guarded")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := task.Resume(None); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v, err := task.Throw(errors.New("ValueError: bad input"))
+	if err != nil {
+		t.Fatalf("expected the script to catch the exception, got %v", err)
+	}
+	if s, _ := AsString(v); s != "bad input" {
+		t.Errorf("expected caught message 'bad input', got %v", v)
+	}
+
+	// An uncaught throw surfaces as an error and finishes the task
+	v2, err := task.Throw(errors.New("ValueError: again"))
+	if err == nil || !strings.Contains(err.Error(), "again") {
+		t.Fatalf("expected the uncaught exception, got %v (%v)", err, v2)
+	}
+	if !task.Done() {
+		t.Error("expected task to be done after an uncaught throw")
+	}
+}
+
+func TestTask_Close(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`
+cleaned = False
+
+def worker():
+    global cleaned
+    try:
+        while True:
+            yield
+    finally:
+        cleaned = True
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task, err := state.Spawn("worker")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := task.Resume(None); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := task.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !task.Done() {
+		t.Error("expected Done() after Close")
+	}
+	if v, _ := state.GetGlobalBool("cleaned"); !v {
+		t.Error("expected the finally block to run on Close")
+	}
+}
+
+func TestTask_SpawnErrors(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`
+def plain():
+    return 1
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := state.Spawn("missing"); err == nil {
+		t.Error("expected an error for an unknown global")
+	}
+	if _, err := state.Spawn("plain"); err == nil {
+		t.Error("expected an error for a non-generator function")
+	}
+}
@@ -0,0 +1,111 @@
+package rage
+
+import (
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/model"
+)
+
+// TokenKind classifies a source token for syntax highlighting. The kinds
+// are deliberately coarse — one per highlight color — rather than the full
+// token vocabulary the parser uses.
+type TokenKind int
+
+const (
+	TokenIllegal TokenKind = iota
+	TokenComment
+	TokenIdentifier
+	TokenKeyword
+	TokenNumber
+	TokenString
+	TokenOperator
+	TokenPunctuation
+)
+
+// String returns the kind name used by highlighters (e.g. CSS classes).
+func (k TokenKind) String() string {
+	switch k {
+	case TokenComment:
+		return "comment"
+	case TokenIdentifier:
+		return "identifier"
+	case TokenKeyword:
+		return "keyword"
+	case TokenNumber:
+		return "number"
+	case TokenString:
+		return "string"
+	case TokenOperator:
+		return "operator"
+	case TokenPunctuation:
+		return "punctuation"
+	default:
+		return "illegal"
+	}
+}
+
+// Token is one highlightable span of source.
+type Token struct {
+	Kind      TokenKind
+	Text      string
+	Line      int // 1-based start line
+	Column    int // 1-based start column
+	EndLine   int // 1-based end line
+	EndColumn int // 1-based column just past the token
+}
+
+// Tokenize lexes source into highlightable tokens, in order. Layout tokens
+// (newlines, indents) are omitted, and lexing never fails: unrecognized
+// input comes back as TokenIllegal spans so a highlighter can still render
+// the rest of the file. This is the lexer the interpreter itself uses, so
+// highlighting always agrees with what will parse.
+func Tokenize(source string) []Token {
+	lexer := compiler.NewLexer(source)
+	var out []Token
+	for {
+		tok := lexer.NextToken()
+		if tok.Kind == model.TK_EOF {
+			return out
+		}
+		kind, ok := highlightKind(tok.Kind)
+		if !ok {
+			continue
+		}
+		text := tok.Literal
+		if start, end := tok.Pos.Offset, tok.EndPos.Offset; text == "" && start >= 0 && end <= len(source) && start < end {
+			text = source[start:end]
+		}
+		out = append(out, Token{
+			Kind:      kind,
+			Text:      text,
+			Line:      tok.Pos.Line,
+			Column:    tok.Pos.Column,
+			EndLine:   tok.EndPos.Line,
+			EndColumn: tok.EndPos.Column,
+		})
+	}
+}
+
+// highlightKind maps lexer token kinds onto highlight categories. Layout
+// tokens report false and are dropped.
+func highlightKind(kind model.TokenKind) (TokenKind, bool) {
+	switch {
+	case kind == model.TK_Newline || kind == model.TK_Indent || kind == model.TK_Dedent:
+		return 0, false
+	case kind == model.TK_Comment:
+		return TokenComment, true
+	case kind == model.TK_Identifier:
+		return TokenIdentifier, true
+	case kind >= model.TK_IntLit && kind <= model.TK_ImaginaryLit:
+		return TokenNumber, true
+	case kind >= model.TK_StringLit && kind <= model.TK_FStringEnd:
+		return TokenString, true
+	case kind >= model.TK_False && kind <= model.TK_Yield:
+		return TokenKeyword, true
+	case kind >= model.TK_Plus && kind <= model.TK_IsNot:
+		return TokenOperator, true
+	case kind >= model.TK_LParen && kind <= model.TK_DoubleStarAssign:
+		return TokenPunctuation, true
+	default:
+		return TokenIllegal, true
+	}
+}
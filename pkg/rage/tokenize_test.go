@@ -0,0 +1,82 @@
+package rage
+
+import "testing"
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize(`def area(r):  # circle
+    return 3.14 * r ** 2
+`)
+
+	type want struct {
+		kind TokenKind
+		text string
+	}
+	wants := []want{
+		{TokenKeyword, "def"},
+		{TokenIdentifier, "area"},
+		{TokenPunctuation, "("},
+		{TokenIdentifier, "r"},
+		{TokenPunctuation, ")"},
+		{TokenPunctuation, ":"},
+		{TokenComment, "# circle"},
+		{TokenKeyword, "return"},
+		{TokenNumber, "3.14"},
+		{TokenOperator, "*"},
+		{TokenIdentifier, "r"},
+		{TokenOperator, "**"},
+		{TokenNumber, "2"},
+	}
+	if len(tokens) != len(wants) {
+		t.Fatalf("expected %d tokens, got %d: %+v", len(wants), len(tokens), tokens)
+	}
+	for i, w := range wants {
+		if tokens[i].Kind != w.kind || tokens[i].Text != w.text {
+			t.Errorf("token %d: expected %v %q, got %v %q", i, w.kind, w.text, tokens[i].Kind, tokens[i].Text)
+		}
+	}
+}
+
+func TestTokenizePositions(t *testing.T) {
+	tokens := Tokenize("x = 'hi'\n")
+	if len(tokens) != 3 {
+		t.Fatalf("expected 3 tokens, got %d: %+v", len(tokens), tokens)
+	}
+	x := tokens[0]
+	if x.Line != 1 || x.Column != 1 {
+		t.Errorf("expected x at 1:1, got %d:%d", x.Line, x.Column)
+	}
+	s := tokens[2]
+	if s.Kind != TokenString {
+		t.Errorf("expected string token, got %v", s.Kind)
+	}
+	if s.Line != 1 || s.Column != 5 {
+		t.Errorf("expected string at 1:5, got %d:%d", s.Line, s.Column)
+	}
+}
+
+func TestTokenizeIllegalInput(t *testing.T) {
+	// A stray character must not stop tokenization of the rest
+	tokens := Tokenize("x = 1\n? = 2\ny = 3\n")
+	sawIllegal := false
+	sawY := false
+	for _, tok := range tokens {
+		if tok.Kind == TokenIllegal {
+			sawIllegal = true
+		}
+		if tok.Kind == TokenIdentifier && tok.Text == "y" {
+			sawY = true
+		}
+	}
+	if !sawIllegal {
+		t.Error("expected an illegal token for '?'")
+	}
+	if !sawY {
+		t.Error("expected tokenization to continue past the illegal token")
+	}
+}
+
+func TestTokenKindString(t *testing.T) {
+	if TokenKeyword.String() != "keyword" || TokenIllegal.String() != "illegal" {
+		t.Error("unexpected TokenKind names")
+	}
+}
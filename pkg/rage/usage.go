@@ -0,0 +1,105 @@
+package rage
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+)
+
+// Per-run resource accounting.
+//
+// Every Run- and Execute-family call records how much work the script did:
+// wall-clock time, instructions executed, peak operand-stack depth, and how
+// much of the time was spent inside registered Go callbacks. Multi-tenant
+// hosts can read the report with Usage after each run to bill or throttle
+// heavy scripts.
+
+// Usage reports resource accounting for the most recent completed run.
+type Usage struct {
+	// WallTime is the wall-clock duration of the run.
+	WallTime time.Duration
+
+	// CPUTime approximates the time spent inside the interpreter itself:
+	// wall time minus time spent in registered Go callbacks. The VM runs
+	// the script on a single goroutine without blocking, so interpreter
+	// wall time is a close stand-in for CPU time.
+	CPUTime time.Duration
+
+	// Instructions is the number of bytecode instructions executed.
+	Instructions int64
+
+	// PeakValues is the deepest operand stack observed during the run, a
+	// proxy for how many intermediate values the script kept live at once.
+	PeakValues int
+
+	// GoCallbacks breaks down time spent in registered Go functions,
+	// sorted by time spent (largest first).
+	GoCallbacks []CallbackUsage
+}
+
+// CallbackUsage is the per-function entry of Usage.GoCallbacks.
+type CallbackUsage struct {
+	Name  string
+	Calls int64
+	Time  time.Duration
+}
+
+// Usage returns resource accounting for the most recent completed Run- or
+// Execute-family call. The report is overwritten by the next run, so hosts
+// that aggregate across runs should read it after each one.
+func (s *State) Usage() Usage {
+	return s.usage
+}
+
+// beginUsage resets per-run accounting before a script executes.
+func (s *State) beginUsage(vm *runtime.VM) time.Time {
+	vm.TakeUsageCounters()
+	s.cbTime = nil
+	return time.Now()
+}
+
+// endUsage snapshots accounting for the run that just finished.
+func (s *State) endUsage(vm *runtime.VM, started time.Time) {
+	wall := time.Since(started)
+	instructions, peakValues := vm.TakeUsageCounters()
+	u := Usage{
+		WallTime:     wall,
+		Instructions: instructions,
+		PeakValues:   peakValues,
+	}
+
+	var callbackTotal time.Duration
+	for _, cb := range s.cbTime {
+		u.GoCallbacks = append(u.GoCallbacks, *cb)
+		callbackTotal += cb.Time
+	}
+	sort.Slice(u.GoCallbacks, func(i, j int) bool {
+		if u.GoCallbacks[i].Time != u.GoCallbacks[j].Time {
+			return u.GoCallbacks[i].Time > u.GoCallbacks[j].Time
+		}
+		return u.GoCallbacks[i].Name < u.GoCallbacks[j].Name
+	})
+
+	u.CPUTime = wall - callbackTotal
+	if u.CPUTime < 0 {
+		u.CPUTime = 0
+	}
+	s.usage = u
+}
+
+// noteCallback accumulates time spent inside one registered Go function for
+// the current run's report. Callbacks run on the executing goroutine, so no
+// locking is needed.
+func (s *State) noteCallback(name string, d time.Duration) {
+	if s.cbTime == nil {
+		s.cbTime = make(map[string]*CallbackUsage)
+	}
+	cb := s.cbTime[name]
+	if cb == nil {
+		cb = &CallbackUsage{Name: name}
+		s.cbTime[name] = cb
+	}
+	cb.Calls++
+	cb.Time += d
+}
@@ -0,0 +1,108 @@
+package rage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUsageReportsInstructions(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	_, err := state.Run(`
+total = 0
+for i in range(100):
+    total += i
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	u := state.Usage()
+	if u.Instructions == 0 {
+		t.Error("expected a nonzero instruction count")
+	}
+	if u.WallTime <= 0 {
+		t.Error("expected a positive wall time")
+	}
+	if u.PeakValues == 0 {
+		t.Error("expected a nonzero peak value count")
+	}
+	if u.CPUTime > u.WallTime {
+		t.Errorf("CPU time %v exceeds wall time %v", u.CPUTime, u.WallTime)
+	}
+}
+
+func TestUsageResetsBetweenRuns(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	if _, err := state.Run("x = sum(range(1000))"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	big := state.Usage().Instructions
+
+	if _, err := state.Run("y = 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	small := state.Usage().Instructions
+
+	if small == 0 {
+		t.Fatal("expected a nonzero instruction count for the second run")
+	}
+	if small >= big {
+		t.Errorf("expected the trivial run to execute fewer instructions: %d >= %d", small, big)
+	}
+}
+
+func TestUsageGoCallbackBreakdown(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	state.Register("slow", func(s *State, args ...Value) Value {
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	state.Register("fast", func(s *State, args ...Value) Value {
+		return nil
+	})
+
+	_, err := state.Run(`
+for i in range(3):
+    slow()
+fast()
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	u := state.Usage()
+	if len(u.GoCallbacks) != 2 {
+		t.Fatalf("expected 2 callback entries, got %d", len(u.GoCallbacks))
+	}
+	if u.GoCallbacks[0].Name != "slow" {
+		t.Errorf("expected 'slow' to rank first, got %q", u.GoCallbacks[0].Name)
+	}
+	if u.GoCallbacks[0].Calls != 3 {
+		t.Errorf("expected 3 calls to 'slow', got %d", u.GoCallbacks[0].Calls)
+	}
+	if u.GoCallbacks[0].Time < 3*time.Millisecond {
+		t.Errorf("expected at least 3ms in 'slow', got %v", u.GoCallbacks[0].Time)
+	}
+}
+
+func TestUsageExecute(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+
+	code, err := state.Compile("n = 2 ** 10", "quota.py")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := state.Execute(code); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Usage().Instructions == 0 {
+		t.Error("expected Execute to be accounted for")
+	}
+}
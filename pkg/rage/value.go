@@ -18,10 +18,23 @@ type Value interface {
 	// GoValue returns the underlying Go value
 	GoValue() any
 
+	// Detach returns a deep copy of the value backed only by Go memory, so
+	// it stays valid after the originating State is closed. Scalars return
+	// themselves; collections are copied recursively. Functions and classes
+	// cannot be copied out of the interpreter — they are returned as-is and
+	// calling them through a closed State reports ErrStateClosed.
+	Detach() Value
+
 	// internal conversion
 	toRuntime() runtime.Value
 }
 
+// Clone returns a deep copy of v backed only by Go memory. It is equivalent
+// to v.Detach().
+func Clone(v Value) Value {
+	return v.Detach()
+}
+
 // =====================================
 // Concrete Value Types
 // =====================================
@@ -32,6 +45,7 @@ type NoneValue struct{}
 func (v NoneValue) Type() string             { return "NoneType" }
 func (v NoneValue) String() string           { return "None" }
 func (v NoneValue) GoValue() any             { return nil }
+func (v NoneValue) Detach() Value            { return v }
 func (v NoneValue) toRuntime() runtime.Value { return runtime.None }
 
 // None is the singleton None value
@@ -46,6 +60,7 @@ func (v BoolValue) Type() string             { return "bool" }
 func (v BoolValue) String() string           { return fmt.Sprintf("%v", v.value) }
 func (v BoolValue) GoValue() any             { return v.value }
 func (v BoolValue) Bool() bool               { return v.value }
+func (v BoolValue) Detach() Value            { return v }
 func (v BoolValue) toRuntime() runtime.Value { return runtime.NewBool(v.value) }
 
 // True and False are the singleton bool values
@@ -63,6 +78,7 @@ func (v IntValue) Type() string             { return "int" }
 func (v IntValue) String() string           { return fmt.Sprintf("%d", v.value) }
 func (v IntValue) GoValue() any             { return v.value }
 func (v IntValue) Int() int64               { return v.value }
+func (v IntValue) Detach() Value            { return v }
 func (v IntValue) toRuntime() runtime.Value { return runtime.NewInt(v.value) }
 
 // FloatValue represents a Python float
@@ -74,6 +90,7 @@ func (v FloatValue) Type() string             { return "float" }
 func (v FloatValue) String() string           { return fmt.Sprintf("%g", v.value) }
 func (v FloatValue) GoValue() any             { return v.value }
 func (v FloatValue) Float() float64           { return v.value }
+func (v FloatValue) Detach() Value            { return v }
 func (v FloatValue) toRuntime() runtime.Value { return runtime.NewFloat(v.value) }
 
 // ComplexValue represents a Python complex
@@ -87,6 +104,7 @@ func (v ComplexValue) String() string           { return runtime.MakeComplex(v.r
 func (v ComplexValue) GoValue() any             { return complex(v.real, v.imag) }
 func (v ComplexValue) Real() float64            { return v.real }
 func (v ComplexValue) Imag() float64            { return v.imag }
+func (v ComplexValue) Detach() Value            { return v }
 func (v ComplexValue) toRuntime() runtime.Value { return runtime.NewComplex(v.real, v.imag) }
 
 // StringValue represents a Python str
@@ -98,6 +116,7 @@ func (v StringValue) Type() string             { return "str" }
 func (v StringValue) String() string           { return v.value }
 func (v StringValue) GoValue() any             { return v.value }
 func (v StringValue) Str() string              { return v.value }
+func (v StringValue) Detach() Value            { return v }
 func (v StringValue) toRuntime() runtime.Value { return runtime.NewString(v.value) }
 
 // ListValue represents a Python list
@@ -124,6 +143,13 @@ func (v ListValue) Get(i int) Value {
 	}
 	return None
 }
+func (v ListValue) Detach() Value {
+	items := make([]Value, len(v.items))
+	for i, item := range v.items {
+		items[i] = item.Detach()
+	}
+	return ListValue{items: items}
+}
 func (v ListValue) toRuntime() runtime.Value {
 	items := make([]runtime.Value, len(v.items))
 	for i, item := range v.items {
@@ -156,6 +182,13 @@ func (v TupleValue) Get(i int) Value {
 	}
 	return None
 }
+func (v TupleValue) Detach() Value {
+	items := make([]Value, len(v.items))
+	for i, item := range v.items {
+		items[i] = item.Detach()
+	}
+	return TupleValue{items: items}
+}
 func (v TupleValue) toRuntime() runtime.Value {
 	items := make([]runtime.Value, len(v.items))
 	for i, item := range v.items {
@@ -188,6 +221,13 @@ func (v DictValue) Get(key string) Value {
 	}
 	return None
 }
+func (v DictValue) Detach() Value {
+	items := make(map[string]Value, len(v.items))
+	for k, val := range v.items {
+		items[k] = val.Detach()
+	}
+	return DictValue{items: items}
+}
 func (v DictValue) toRuntime() runtime.Value {
 	d := runtime.NewDict()
 	for k, val := range v.items {
@@ -201,10 +241,15 @@ type BytesValue struct {
 	value []byte
 }
 
-func (v BytesValue) Type() string             { return "bytes" }
-func (v BytesValue) String() string           { return fmt.Sprintf("b'%s'", string(v.value)) }
-func (v BytesValue) GoValue() any             { return v.value }
-func (v BytesValue) Bytes() []byte            { return v.value }
+func (v BytesValue) Type() string   { return "bytes" }
+func (v BytesValue) String() string { return fmt.Sprintf("b'%s'", string(v.value)) }
+func (v BytesValue) GoValue() any   { return v.value }
+func (v BytesValue) Bytes() []byte  { return v.value }
+func (v BytesValue) Detach() Value {
+	buf := make([]byte, len(v.value))
+	copy(buf, v.value)
+	return BytesValue{value: buf}
+}
 func (v BytesValue) toRuntime() runtime.Value { return runtime.NewBytes(v.value) }
 
 // UserDataValue wraps arbitrary Go values
@@ -212,9 +257,13 @@ type UserDataValue struct {
 	value any
 }
 
-func (v UserDataValue) Type() string             { return "userdata" }
-func (v UserDataValue) String() string           { return fmt.Sprintf("<userdata %T>", v.value) }
-func (v UserDataValue) GoValue() any             { return v.value }
+func (v UserDataValue) Type() string   { return "userdata" }
+func (v UserDataValue) String() string { return fmt.Sprintf("<userdata %T>", v.value) }
+func (v UserDataValue) GoValue() any   { return v.value }
+
+// Detach returns the value unchanged: the wrapped Go value already lives in
+// host memory and cannot be deep-copied generically.
+func (v UserDataValue) Detach() Value            { return v }
 func (v UserDataValue) toRuntime() runtime.Value { return runtime.NewUserData(v.value) }
 
 // FunctionValue represents a Python function (for introspection)
@@ -223,10 +272,19 @@ type FunctionValue struct {
 	rv   runtime.Value
 }
 
-func (v FunctionValue) Type() string             { return "function" }
-func (v FunctionValue) String() string           { return fmt.Sprintf("<function %s>", v.name) }
-func (v FunctionValue) GoValue() any             { return nil }
-func (v FunctionValue) Name() string             { return v.name }
+func (v FunctionValue) Type() string   { return "function" }
+func (v FunctionValue) String() string { return fmt.Sprintf("<function %s>", v.name) }
+
+// GoValue returns the underlying runtime function object (*runtime.PyFunction,
+// *runtime.PyGoFunc, or *runtime.PyBuiltinFunc), mirroring ClassValue.GoValue.
+// Introspection tooling uses it to read signature metadata.
+func (v FunctionValue) GoValue() any { return v.rv }
+func (v FunctionValue) Name() string { return v.name }
+
+// Detach returns the value unchanged: functions are tied to the interpreter
+// and cannot be copied into pure Go memory. Calling one through a closed
+// State reports ErrStateClosed.
+func (v FunctionValue) Detach() Value            { return v }
 func (v FunctionValue) toRuntime() runtime.Value { return v.rv }
 
 // =====================================
@@ -286,7 +344,9 @@ func Dict(pairs ...any) Value {
 	return DictValue{items: items}
 }
 
-// Bytes creates a Python bytes value
+// Bytes creates a Python bytes value. The slice is shared, not copied;
+// use Detach (or ByteArray for a mutable view) if the host buffer will be
+// reused
 func Bytes(v []byte) Value {
 	return BytesValue{value: v}
 }
@@ -575,6 +635,12 @@ func fromRuntime(v runtime.Value) Value {
 		return String(val.Value)
 	case *runtime.PyBytes:
 		return BytesValue{value: val.Value}
+	case *runtime.PyByteArray:
+		return ByteArrayValue{ba: val}
+	case *runtime.PyFloatArray:
+		return FloatArrayValue{fa: val}
+	case *runtime.PyIntArray:
+		return IntArrayValue{ia: val}
 	case *runtime.PyList:
 		items := make([]Value, len(val.Items))
 		for i, item := range val.Items {
@@ -599,6 +665,8 @@ func fromRuntime(v runtime.Value) Value {
 		return DictValue{items: items}
 	case *runtime.PyUserData:
 		return UserDataValue{value: val.Value}
+	case *runtime.PyGoIterator:
+		return IteratorValue{it: val}
 	case *runtime.PyFunction:
 		return FunctionValue{name: val.Name, rv: val}
 	case *runtime.PyBuiltinFunc:
@@ -732,8 +732,8 @@ def my_func():
 	if fv.Name() != "my_func" {
 		t.Errorf("expected name 'my_func', got %q", fv.Name())
 	}
-	if fv.GoValue() != nil {
-		t.Error("FunctionValue.GoValue() should be nil")
+	if fv.GoValue() == nil {
+		t.Error("FunctionValue.GoValue() should expose the runtime function")
 	}
 }
 
@@ -771,3 +771,74 @@ func TestTupleGoValue(t *testing.T) {
 		t.Errorf("item 1: expected nil, got %v", goVal[1])
 	}
 }
+
+// =====================================
+// Detach / Clone
+// =====================================
+
+func TestDetachSurvivesClose(t *testing.T) {
+	state := NewState()
+	_, err := state.Run(`data = {"nums": [1, 2, 3], "name": "rage"}`)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	detached := state.GetGlobal("data").Detach()
+	state.Close()
+
+	d, ok := detached.(DictValue)
+	if !ok {
+		t.Fatalf("expected DictValue, got %T", detached)
+	}
+	nums, ok := d.Get("nums").(ListValue)
+	if !ok {
+		t.Fatal("detached dict missing 'nums' list")
+	}
+	if nums.Len() != 3 {
+		t.Errorf("expected 3 items, got %d", nums.Len())
+	}
+}
+
+func TestDetachIsolatesFromMutation(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+	if _, err := state.Run(`items = [1, 2, 3]`); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	snapshot := Clone(state.GetGlobal("items"))
+	if _, err := state.Run(`items.append(4)`); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if n := snapshot.(ListValue).Len(); n != 3 {
+		t.Errorf("detached list should still have 3 items, got %d", n)
+	}
+	if n := state.GetGlobal("items").(ListValue).Len(); n != 4 {
+		t.Errorf("live list should have 4 items, got %d", n)
+	}
+}
+
+func TestDetachObjectSnapshot(t *testing.T) {
+	state := NewState()
+	defer state.Close()
+	_, err := state.Run(`
+class Point:
+    def __init__(self, x):
+        self.x = x
+
+p = Point(1)
+`)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	obj := state.GetGlobal("p").(Object)
+	snapshot := obj.Detach().(Object)
+	if _, err := state.Run(`p.x = 99`); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	x, ok := snapshot.Get("x").(IntValue)
+	if !ok {
+		t.Fatal("detached object missing attribute 'x'")
+	}
+	if x.Int() != 1 {
+		t.Errorf("detached x: expected 1, got %d", x.Int())
+	}
+}
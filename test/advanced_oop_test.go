@@ -301,6 +301,152 @@ has_dict = hasattr(obj, '__dict__')
 	}
 }
 
+func TestSlotsSubclassWithoutSlotsGetsDict(t *testing.T) {
+	// A subclass that doesn't define __slots__ gives instances a __dict__,
+	// while the base slot names keep routing to slot storage.
+	source := `
+class Base:
+    __slots__ = ('x',)
+
+class Child(Base):
+    pass
+
+c = Child()
+c.x = 1
+c.extra = 2
+x = c.x
+extra = c.extra
+dict_keys = sorted(c.__dict__.keys())
+`
+	vm := runCode(t, source)
+	assert.Equal(t, int64(1), vm.GetGlobal("x").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), vm.GetGlobal("extra").(*runtime.PyInt).Value)
+	keys := vm.GetGlobal("dict_keys").(*runtime.PyList)
+	require.Len(t, keys.Items, 1)
+	assert.Equal(t, "extra", keys.Items[0].(*runtime.PyString).Value)
+}
+
+func TestSlotsWithDictEntry(t *testing.T) {
+	// '__dict__' in __slots__ re-enables arbitrary attributes.
+	source := `
+class Flexible:
+    __slots__ = ('a', '__dict__')
+
+f = Flexible()
+f.a = 1
+f.anything = 2
+a = f.a
+anything = f.anything
+in_dict = 'anything' in f.__dict__ and 'a' not in f.__dict__
+`
+	vm := runCode(t, source)
+	assert.Equal(t, int64(1), vm.GetGlobal("a").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), vm.GetGlobal("anything").(*runtime.PyInt).Value)
+	assert.Equal(t, runtime.True, vm.GetGlobal("in_dict"))
+}
+
+func TestSlotsWithNonSlottedBaseKeepsDict(t *testing.T) {
+	// Slots only restrict attributes when every base is fully slotted.
+	source := `
+class Plain:
+    pass
+
+class Slotted(Plain):
+    __slots__ = ('y',)
+
+s = Slotted()
+s.y = 1
+s.other = 2
+total = s.y + s.other
+`
+	vm := runCode(t, source)
+	assert.Equal(t, int64(3), vm.GetGlobal("total").(*runtime.PyInt).Value)
+}
+
+func TestSlotsSingleString(t *testing.T) {
+	// __slots__ = "x" names a single slot, not the letters of the string.
+	source := `
+class Single:
+    __slots__ = "only"
+
+s = Single()
+s.only = 5
+result = s.only
+`
+	vm := runCode(t, source)
+	assert.Equal(t, int64(5), vm.GetGlobal("result").(*runtime.PyInt).Value)
+	runCodeExpectError(t, source+"s.o = 1\n", "AttributeError")
+}
+
+func TestSlotsClassVariableConflict(t *testing.T) {
+	source := `
+class Conflict:
+    __slots__ = ('v',)
+    v = 3
+`
+	runCodeExpectError(t, source, "'v' in __slots__ conflicts with class variable")
+}
+
+func TestSlotsNonStringItems(t *testing.T) {
+	source := `
+class Bad:
+    __slots__ = (1, 2)
+`
+	runCodeExpectError(t, source, "__slots__ items must be strings, not 'int'")
+}
+
+func TestSlotsDuplicateWeakref(t *testing.T) {
+	source := `
+class A:
+    __slots__ = ('__weakref__',)
+
+class B(A):
+    __slots__ = ('__weakref__',)
+`
+	runCodeExpectError(t, source, "__weakref__ slot disallowed")
+}
+
+func TestSlotsDuplicateDict(t *testing.T) {
+	source := `
+class A:
+    __slots__ = ('__dict__',)
+
+class B(A):
+    __slots__ = ('__dict__',)
+`
+	runCodeExpectError(t, source, "__dict__ slot disallowed: we already got one")
+}
+
+func TestSlotsDiamondInheritance(t *testing.T) {
+	// Shared base slots are deduplicated and the hierarchy stays restricted.
+	source := `
+class Root:
+    __slots__ = ('q',)
+
+class Left(Root):
+    __slots__ = ()
+
+class Right(Root):
+    __slots__ = ()
+
+class Diamond(Left, Right):
+    __slots__ = ('r',)
+
+d = Diamond()
+d.q = 1
+d.r = 2
+total = d.q + d.r
+try:
+    d.s = 3
+    restricted = False
+except AttributeError:
+    restricted = True
+`
+	vm := runCode(t, source)
+	assert.Equal(t, int64(3), vm.GetGlobal("total").(*runtime.PyInt).Value)
+	assert.Equal(t, runtime.True, vm.GetGlobal("restricted"))
+}
+
 // =============================================================================
 // Abstract Base Class Tests
 // =============================================================================
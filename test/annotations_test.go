@@ -0,0 +1,77 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =====================================
+// __annotations__ on functions, classes, and modules
+// =====================================
+
+func TestFunctionAnnotations(t *testing.T) {
+	vm := runCode(t, `
+def f(a: int, b: str = "hi", *args: int, c: bool = True, **kw: float) -> str:
+    return b
+
+names = sorted(f.__annotations__.keys())
+ret = f.__annotations__["return"]
+`)
+	names := vm.GetGlobal("names").(*runtime.PyList)
+	var got []string
+	for _, item := range names.Items {
+		got = append(got, item.(*runtime.PyString).Value)
+	}
+	assert.Equal(t, []string{"a", "args", "b", "c", "kw", "return"}, got)
+}
+
+func TestFunctionAnnotationsEmptyByDefault(t *testing.T) {
+	vm := runCode(t, `
+def g():
+    pass
+
+n = len(g.__annotations__)
+`)
+	assert.Equal(t, int64(0), vm.GetGlobal("n").(*runtime.PyInt).Value)
+}
+
+func TestModuleAnnotations(t *testing.T) {
+	vm := runCode(t, `
+x: int = 5
+y: str
+
+has_x = "x" in __annotations__
+has_y = "y" in __annotations__
+`)
+	assert.True(t, vm.GetGlobal("has_x").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("has_y").(*runtime.PyBool).Value)
+}
+
+func TestGetTypeHintsMergesBaseClasses(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import typing
+
+class Base:
+    a: int
+
+class Child(Base):
+    b: str
+
+hints = typing.get_type_hints(Child)
+has_a = "a" in hints
+has_b = "b" in hints
+
+def f(x: int) -> str:
+    return ""
+
+fn_hints = typing.get_type_hints(f)
+fn_count = len(fn_hints)
+`)
+	assert.True(t, vm.GetGlobal("has_a").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("has_b").(*runtime.PyBool).Value)
+	require.NotNil(t, vm.GetGlobal("fn_count"))
+	assert.Equal(t, int64(2), vm.GetGlobal("fn_count").(*runtime.PyInt).Value)
+}
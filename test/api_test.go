@@ -448,6 +448,58 @@ while True:
 	assert.True(t, ok, "expected CancelledError, got %T: %v", err, err)
 }
 
+// TestInterruptRaisesKeyboardInterrupt tests that Interrupt() surfaces as an
+// uncatchable-by-default but Python-catchable KeyboardInterrupt
+func TestInterruptRaisesKeyboardInterrupt(t *testing.T) {
+	vm := runtime.NewVM()
+
+	source := `
+x = 0
+while True:
+    x = x + 1
+`
+	code, errs := compiler.CompileSource(source, "<test>")
+	require.Empty(t, errs)
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		vm.Interrupt()
+	}()
+
+	_, err := vm.Execute(code)
+	require.Error(t, err)
+	exc, ok := err.(*runtime.PyException)
+	require.True(t, ok, "expected PyException, got %T: %v", err, err)
+	assert.Equal(t, "KeyboardInterrupt", exc.Type())
+}
+
+// TestInterruptIsCatchableInScript tests that scripts can handle the
+// interrupt and keep running
+func TestInterruptIsCatchableInScript(t *testing.T) {
+	vm := runtime.NewVM()
+
+	source := `
+caught = False
+x = 0
+try:
+    while True:
+        x = x + 1
+except KeyboardInterrupt:
+    caught = True
+`
+	code, errs := compiler.CompileSource(source, "<test>")
+	require.Empty(t, errs)
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		vm.Interrupt()
+	}()
+
+	_, err := vm.Execute(code)
+	require.NoError(t, err)
+	assert.True(t, vm.GetGlobal("caught").(*runtime.PyBool).Value)
+}
+
 // TestExecuteWithoutTimeoutNoOverhead tests that regular Execute still works
 func TestExecuteWithoutTimeoutNoOverhead(t *testing.T) {
 	vm := runtime.NewVM()
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayConstructAndIndex(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import array
+a = array.array("d", [1.5, 2.5, 3.0])
+n = len(a)
+first = a[0]
+last = a[-1]
+a[1] = 9.0
+second = a[1]
+text = repr(a)
+`)
+	assert.Equal(t, int64(3), vm.GetGlobal("n").(*runtime.PyInt).Value)
+	assert.Equal(t, 1.5, vm.GetGlobal("first").(*runtime.PyFloat).Value)
+	assert.Equal(t, 3.0, vm.GetGlobal("last").(*runtime.PyFloat).Value)
+	assert.Equal(t, 9.0, vm.GetGlobal("second").(*runtime.PyFloat).Value)
+	assert.Equal(t, "floatarray([1.5, 9.0, 3.0])", vm.GetGlobal("text").(*runtime.PyString).Value)
+}
+
+func TestArrayIntTypecode(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import array
+a = array.array("q", [10, 20, 30])
+total = array.sum(a)
+text = repr(a)
+sliced = repr(a[1:])
+`)
+	assert.Equal(t, int64(60), vm.GetGlobal("total").(*runtime.PyInt).Value)
+	assert.Equal(t, "intarray([10, 20, 30])", vm.GetGlobal("text").(*runtime.PyString).Value)
+	assert.Equal(t, "intarray([20, 30])", vm.GetGlobal("sliced").(*runtime.PyString).Value)
+}
+
+func TestArrayElementwise(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import array
+a = array.array("d", [1.0, 2.0, 3.0])
+b = array.array("d", [10.0, 20.0, 30.0])
+added = repr(array.add(a, b))
+scaled = repr(array.mul(a, 2))
+halved = repr(array.div(b, a))
+ints = repr(array.add(array.array("q", [1, 2]), 10))
+`)
+	assert.Equal(t, "floatarray([11.0, 22.0, 33.0])", vm.GetGlobal("added").(*runtime.PyString).Value)
+	assert.Equal(t, "floatarray([2.0, 4.0, 6.0])", vm.GetGlobal("scaled").(*runtime.PyString).Value)
+	assert.Equal(t, "floatarray([10.0, 10.0, 10.0])", vm.GetGlobal("halved").(*runtime.PyString).Value)
+	assert.Equal(t, "intarray([11, 12])", vm.GetGlobal("ints").(*runtime.PyString).Value)
+}
+
+func TestArrayElementwiseLengthMismatch(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import array
+array.add(array.array("d", [1.0]), array.array("d", [1.0, 2.0]))
+`, "different lengths")
+}
+
+func TestArrayReductions(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import array
+a = array.array("d", [4.0, 1.0, 7.0, 2.0])
+total = array.sum(a)
+lo = array.min(a)
+hi = array.max(a)
+avg = array.mean(a)
+imax = array.max(array.array("q", [3, 9, 5]))
+`)
+	assert.Equal(t, 14.0, vm.GetGlobal("total").(*runtime.PyFloat).Value)
+	assert.Equal(t, 1.0, vm.GetGlobal("lo").(*runtime.PyFloat).Value)
+	assert.Equal(t, 7.0, vm.GetGlobal("hi").(*runtime.PyFloat).Value)
+	assert.Equal(t, 3.5, vm.GetGlobal("avg").(*runtime.PyFloat).Value)
+	assert.Equal(t, int64(9), vm.GetGlobal("imax").(*runtime.PyInt).Value)
+}
+
+func TestArrayReductionEmpty(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import array
+array.min(array.array("d"))
+`, "empty sequence")
+}
+
+func TestArrayZerosAndTolist(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import array
+z = array.zeros("q", 4)
+n = len(z)
+items = array.tolist(array.array("d", [1.0, 2.0]))
+is_list = isinstance(items, list)
+`)
+	assert.Equal(t, int64(4), vm.GetGlobal("n").(*runtime.PyInt).Value)
+	assert.Equal(t, true, vm.GetGlobal("is_list").(*runtime.PyBool).Value)
+}
+
+func TestArrayBadTypecode(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import array
+array.array("x", [1])
+`, "bad typecode")
+}
+
+func TestArrayIteration(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import array
+a = array.array("d", [1.0, 2.0, 3.0])
+doubled = [x * 2 for x in a]
+total = sum(a)
+`)
+	assert.Equal(t, 6.0, vm.GetGlobal("total").(*runtime.PyFloat).Value)
+	doubled := vm.GetGlobal("doubled").(*runtime.PyList)
+	assert.Equal(t, 3, len(doubled.Items))
+	assert.Equal(t, 4.0, doubled.Items[1].(*runtime.PyFloat).Value)
+}
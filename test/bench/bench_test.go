@@ -30,6 +30,28 @@ while i < 100:
 result = [x * x for x in range(500)]
 `
 
+	srcStringMethods = `
+s = "Hello, World"
+i = 0
+while i < 1000:
+    u = s.upper()
+    l = s.lower()
+    t = s.strip()
+    f = s.find("World")
+    b = s.startswith("Hello")
+    i = i + 1
+`
+
+	srcStringSplitJoin = `
+csv = "a,b,c,d,e,f,g,h"
+i = 0
+while i < 500:
+    parts = csv.split(",")
+    joined = "-".join(parts)
+    r = joined.replace("-", "+")
+    i = i + 1
+`
+
 	srcFunctionCalls = `
 def add(a, b):
     return a + b
@@ -63,6 +85,16 @@ def fib(n):
     return fib(n - 1) + fib(n - 2)
 
 result = fib(20)
+`
+
+	srcSetOperations = `
+a = set(range(100000))
+b = set(range(50000, 150000))
+inter = a & b
+diff = a - b
+sym = a ^ b
+sub = a.issubset(b)
+disjoint = a.isdisjoint(b)
 `
 
 	srcCompileOnly = `
@@ -143,6 +175,54 @@ func BenchmarkStringConcat(b *testing.B) {
 	}
 }
 
+func BenchmarkStringMethods(b *testing.B) {
+	code, errs := compiler.CompileSource(srcStringMethods, "bench")
+	if code == nil || len(errs) > 0 {
+		b.Fatal("compilation failed")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vm := runtime.NewVM()
+		_, err := vm.Execute(code)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStringSplitJoin(b *testing.B) {
+	code, errs := compiler.CompileSource(srcStringSplitJoin, "bench")
+	if code == nil || len(errs) > 0 {
+		b.Fatal("compilation failed")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vm := runtime.NewVM()
+		_, err := vm.Execute(code)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSetOperations(b *testing.B) {
+	code, errs := compiler.CompileSource(srcSetOperations, "bench")
+	if code == nil || len(errs) > 0 {
+		b.Fatal("compilation failed")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vm := runtime.NewVM()
+		_, err := vm.Execute(code)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func BenchmarkListComprehension(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -182,3 +262,13 @@ func BenchmarkRecursion(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkNewState(b *testing.B) {
+	// Warm the frozen stdlib snapshot so the loop measures steady-state cost
+	rage.NewState().Close()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		state := rage.NewState()
+		state.Close()
+	}
+}
@@ -364,6 +364,106 @@ result3 = issubclass(Dog, Dog)
 	assert.True(t, vm.GetGlobal("result3").(*runtime.PyBool).Value)
 }
 
+func TestIsinstanceUnionType(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   bool
+	}{
+		{"int matches", `result = isinstance(42, int | str)`, true},
+		{"str matches", `result = isinstance("hello", int | str)`, true},
+		{"no match", `result = isinstance(3.5, int | str)`, false},
+		{"none in union", `result = isinstance(None, int | None)`, true},
+		{"bool is int", `result = isinstance(True, int | str)`, true},
+		{"nested union flattens", `result = isinstance(3.5, (int | str) | float)`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := runCode(t, tt.source)
+			result := vm.GetGlobal("result").(*runtime.PyBool)
+			assert.Equal(t, tt.want, result.Value)
+		})
+	}
+}
+
+func TestIssubclassUnionType(t *testing.T) {
+	vm := runCode(t, `
+result1 = issubclass(bool, int | str)
+result2 = issubclass(float, int | str)
+`)
+	assert.True(t, vm.GetGlobal("result1").(*runtime.PyBool).Value)
+	assert.False(t, vm.GetGlobal("result2").(*runtime.PyBool).Value)
+}
+
+func TestUnionTypeEquality(t *testing.T) {
+	vm := runCode(t, `
+result1 = (int | str) == (str | int)
+result2 = (int | str) == (int | float)
+result3 = hash(int | str) == hash(str | int)
+result4 = repr(int | str | float)
+`)
+	assert.True(t, vm.GetGlobal("result1").(*runtime.PyBool).Value)
+	assert.False(t, vm.GetGlobal("result2").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("result3").(*runtime.PyBool).Value)
+	assert.Equal(t, "int | str | float", vm.GetGlobal("result4").(*runtime.PyString).Value)
+}
+
+func TestUnionTypeInAnnotations(t *testing.T) {
+	vm := runCode(t, `
+def f(x: int | None) -> int | str:
+    if x is None:
+        return "none"
+    return x
+
+result1 = f(5)
+result2 = f(None)
+`)
+	assert.Equal(t, int64(5), vm.GetGlobal("result1").(*runtime.PyInt).Value)
+	assert.Equal(t, "none", vm.GetGlobal("result2").(*runtime.PyString).Value)
+}
+
+func TestGenericAliasConstructionAndEquality(t *testing.T) {
+	vm := runCode(t, `
+alias = list[int]
+result1 = repr(alias)
+result2 = repr(dict[str, int])
+result3 = list[int] == list[int]
+result4 = list[int] == list[str]
+result5 = list[list[int]] == list[list[int]]
+built = alias([1, 2, 3])
+result6 = type(built).__name__
+`)
+	assert.Equal(t, "list[int]", vm.GetGlobal("result1").(*runtime.PyString).Value)
+	assert.Equal(t, "dict[str, int]", vm.GetGlobal("result2").(*runtime.PyString).Value)
+	assert.True(t, vm.GetGlobal("result3").(*runtime.PyBool).Value)
+	assert.False(t, vm.GetGlobal("result4").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("result5").(*runtime.PyBool).Value)
+	assert.Equal(t, "list", vm.GetGlobal("result6").(*runtime.PyString).Value)
+}
+
+func TestGenericAliasInAnnotations(t *testing.T) {
+	vm := runCode(t, `
+def f(xs: list[int]) -> dict[str, int]:
+    return {"n": len(xs)}
+
+result = f([1, 2, 3])["n"]
+`)
+	assert.Equal(t, int64(3), vm.GetGlobal("result").(*runtime.PyInt).Value)
+}
+
+func TestIsinstanceRejectsParameterizedGeneric(t *testing.T) {
+	runCodeExpectError(t, `isinstance([1], list[int])`,
+		"isinstance() argument 2 cannot be a parameterized generic")
+	runCodeExpectError(t, `issubclass(list, list[int])`,
+		"issubclass() argument 2 cannot be a parameterized generic")
+	// A matching member earlier in the tuple short-circuits, like CPython
+	vm := runCode(t, `result = isinstance(1, (int, list[int]))`)
+	assert.True(t, vm.GetGlobal("result").(*runtime.PyBool).Value)
+	runCodeExpectError(t, `isinstance("a", (int, list[int]))`,
+		"isinstance() argument 2 cannot be a parameterized generic")
+}
+
 // =====================================
 // hex() / oct() / bin()
 // =====================================
@@ -484,6 +584,68 @@ first = result[0]
 	assert.Equal(t, "a", first.Items[1].(*runtime.PyString).Value)
 }
 
+func TestBuiltinZipStrict(t *testing.T) {
+	vm := runCode(t, `
+result = list(zip([1, 2], ["a", "b"], strict=True))
+length = len(result)
+`)
+	assert.Equal(t, int64(2), vm.GetGlobal("length").(*runtime.PyInt).Value)
+
+	runCodeExpectError(t, `list(zip([1, 2, 3], ["a", "b"], strict=True))`,
+		"zip() argument 2 is shorter than argument 1")
+	runCodeExpectError(t, `list(zip([1], ["a", "b"], strict=True))`,
+		"zip() argument 2 is longer than argument 1")
+}
+
+func TestBuiltinEnumerateStart(t *testing.T) {
+	vm := runCode(t, `
+result = list(enumerate(["a", "b"], start=5))
+idx = result[1][0]
+`)
+	assert.Equal(t, int64(6), vm.GetGlobal("idx").(*runtime.PyInt).Value)
+}
+
+func TestBuiltinEnumerateLazy(t *testing.T) {
+	vm := runCode(t, `
+log = []
+
+def gen():
+    log.append("a")
+    yield "a"
+    log.append("b")
+    yield "b"
+
+e = enumerate(gen())
+first = next(e)
+consumed = len(log)
+`)
+	// Only the first element has been pulled from the generator
+	assert.Equal(t, int64(1), vm.GetGlobal("consumed").(*runtime.PyInt).Value)
+	first := vm.GetGlobal("first").(*runtime.PyTuple)
+	assert.Equal(t, int64(0), first.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, "a", first.Items[1].(*runtime.PyString).Value)
+}
+
+func TestBuiltinIterSentinel(t *testing.T) {
+	vm := runCode(t, `
+counter = [0]
+
+def advance():
+    counter[0] += 1
+    return counter[0]
+
+result = list(iter(advance, 4))
+`)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	require.Equal(t, 3, len(result.Items))
+	assert.Equal(t, int64(1), result.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(3), result.Items[2].(*runtime.PyInt).Value)
+}
+
+func TestBuiltinIterSentinelRequiresCallable(t *testing.T) {
+	runCodeExpectError(t, `iter(42, 0)`, "iter(v, w): v must be callable")
+}
+
 func TestBuiltinMap(t *testing.T) {
 	vm := runCode(t, `
 result = list(map(str, [1, 2, 3]))
@@ -711,6 +873,46 @@ func TestBuiltinType(t *testing.T) {
 	}
 }
 
+func TestBuiltinTypeThreeArg(t *testing.T) {
+	source := `
+def greet(self):
+    return "hi " + self.name
+
+Person = type("Person", (), {"greet": greet, "species": "human"})
+
+p = Person()
+p.name = "Alice"
+greeting = p.greet()
+species = Person.species
+name = Person.__name__
+is_type = type(Person) is type
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "hi Alice", vm.GetGlobal("greeting").(*runtime.PyString).Value)
+	assert.Equal(t, "human", vm.GetGlobal("species").(*runtime.PyString).Value)
+	assert.Equal(t, "Person", vm.GetGlobal("name").(*runtime.PyString).Value)
+	assert.Equal(t, runtime.True, vm.GetGlobal("is_type"))
+}
+
+func TestBuiltinTypeThreeArgInheritance(t *testing.T) {
+	source := `
+class Base:
+    def ping(self):
+        return "pong"
+
+Child = type("Child", (Base,), {"extra": 1})
+
+c = Child()
+pong = c.ping()
+is_base = isinstance(c, Base)
+is_sub = issubclass(Child, Base)
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "pong", vm.GetGlobal("pong").(*runtime.PyString).Value)
+	assert.Equal(t, runtime.True, vm.GetGlobal("is_base"))
+	assert.Equal(t, runtime.True, vm.GetGlobal("is_sub"))
+}
+
 // =====================================
 // repr()
 // =====================================
@@ -955,3 +1157,80 @@ result = length == count
 	result := vm.GetGlobal("result")
 	assert.Equal(t, runtime.True, result)
 }
+
+// =====================================
+// Keyword argument validation
+// =====================================
+
+func TestBuiltinKwargsRejectUnknown(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{"len", `len([1], bogus=1)`},
+		{"abs", `abs(-1, bogus=1)`},
+		{"range", `range(3, bogus=1)`},
+		{"sorted", `sorted([1, 2], revers=True)`},
+		{"min", `min([1, 2], ky=None)`},
+		{"print", `print("x", seperator=" ")`},
+		{"sum", `sum([1], begin=0)`},
+		{"round", `round(1.5, digits=1)`},
+		{"isinstance", `isinstance(1, int, bogus=True)`},
+		{"getattr", `getattr(1, "real", default=None)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCodeExpectError(t, tt.source, "TypeError")
+		})
+	}
+}
+
+func TestBuiltinSumStartKeyword(t *testing.T) {
+	vm := runCode(t, `result = sum([1, 2, 3], start=10)`)
+	result := vm.GetGlobal("result").(*runtime.PyInt)
+	assert.Equal(t, int64(16), result.Value)
+}
+
+func TestBuiltinSumStartDuplicate(t *testing.T) {
+	runCodeExpectError(t, `sum([1], 5, start=10)`, "multiple values for argument 'start'")
+}
+
+func TestBuiltinRoundNdigitsKeyword(t *testing.T) {
+	vm := runCode(t, `result = round(3.14159, ndigits=2)`)
+	result := vm.GetGlobal("result").(*runtime.PyFloat)
+	assert.InDelta(t, 3.14, result.Value, 1e-9)
+}
+
+func TestBuiltinPowKeywords(t *testing.T) {
+	vm := runCode(t, `
+a = pow(2, 10)
+b = pow(2, exp=10)
+c = pow(base=2, exp=10, mod=1000)
+`)
+	assert.Equal(t, int64(1024), vm.GetGlobal("a").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(1024), vm.GetGlobal("b").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(24), vm.GetGlobal("c").(*runtime.PyInt).Value)
+}
+
+func TestBuiltinComplexKeywords(t *testing.T) {
+	vm := runCode(t, `
+a = complex(real=1, imag=2)
+b = complex(imag=3)
+ok = a == 1 + 2j and b == 3j
+`)
+	assert.Equal(t, runtime.True, vm.GetGlobal("ok"))
+}
+
+func TestBuiltinMinMaxDefaultWithMultipleArgs(t *testing.T) {
+	runCodeExpectError(t, `min(1, 2, default=0)`, "Cannot specify a default")
+	runCodeExpectError(t, `max(1, 2, default=0)`, "Cannot specify a default")
+}
+
+func TestBuiltinMinMaxDefaultEmpty(t *testing.T) {
+	vm := runCode(t, `
+a = min([], default=7)
+b = max([], default=8)
+`)
+	assert.Equal(t, int64(7), vm.GetGlobal("a").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(8), vm.GetGlobal("b").(*runtime.PyInt).Value)
+}
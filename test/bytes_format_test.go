@@ -0,0 +1,137 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// bytes.fromhex and bytes % Formatting Tests
+// =============================================================================
+
+func TestBytesFromHex(t *testing.T) {
+	vm := runCode(t, `
+plain = bytes.fromhex("deadbeef")
+spaced = bytes.fromhex("de ad be ef")
+ba = bytearray.fromhex("0a0b")
+roundtrip = bytes.fromhex(b"\x01\x02\xff".hex())
+`)
+	plain := vm.GetGlobal("plain").(*runtime.PyBytes)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, plain.Value)
+	spaced := vm.GetGlobal("spaced").(*runtime.PyBytes)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, spaced.Value)
+	ba := vm.GetGlobal("ba").(*runtime.PyByteArray)
+	assert.Equal(t, []byte{0x0a, 0x0b}, ba.Value)
+	roundtrip := vm.GetGlobal("roundtrip").(*runtime.PyBytes)
+	assert.Equal(t, []byte{0x01, 0x02, 0xff}, roundtrip.Value)
+}
+
+func TestBytesFromHexRejectsBadInput(t *testing.T) {
+	runCodeExpectError(t, `bytes.fromhex("zz")`, "non-hexadecimal number found in fromhex() arg at position 0")
+	runCodeExpectError(t, `bytes.fromhex("abc")`, "non-hexadecimal number found in fromhex() arg at position 3")
+}
+
+func TestBytesPercentFormatting(t *testing.T) {
+	vm := runCode(t, `
+a = b"key=%s n=%d" % (b"abc", 5)
+b = b"%b!" % b"raw"
+c = b"%x/%o" % (255, 8)
+d = b"%05.2f" % 3.14159
+e = bytearray(b"%d items") % 7
+`)
+	assert.Equal(t, []byte("key=abc n=5"), vm.GetGlobal("a").(*runtime.PyBytes).Value)
+	assert.Equal(t, []byte("raw!"), vm.GetGlobal("b").(*runtime.PyBytes).Value)
+	assert.Equal(t, []byte("ff/10"), vm.GetGlobal("c").(*runtime.PyBytes).Value)
+	assert.Equal(t, []byte("03.14"), vm.GetGlobal("d").(*runtime.PyBytes).Value)
+	e := vm.GetGlobal("e").(*runtime.PyByteArray)
+	assert.Equal(t, []byte("7 items"), e.Value)
+}
+
+func TestBytesPercentArgumentMismatch(t *testing.T) {
+	runCodeExpectError(t, `b"%d %d" % (1,)`, "not enough arguments")
+	runCodeExpectError(t, `b"%d" % (1, 2)`, "not all arguments converted")
+}
+
+func TestBytesTranslateWithMaketrans(t *testing.T) {
+	vm := runCode(t, `
+table = bytes.maketrans(b"abc", b"xyz")
+out = b"aabbcc-dd".translate(table)
+deleted = b"hello world".translate(None, b"lo")
+`)
+	out := vm.GetGlobal("out").(*runtime.PyBytes)
+	assert.Equal(t, []byte("xxyyzz-dd"), out.Value)
+	deleted := vm.GetGlobal("deleted").(*runtime.PyBytes)
+	require.Equal(t, []byte("he wrd"), deleted.Value)
+}
+
+func TestByteArrayMutableSequenceMethods(t *testing.T) {
+	vm := runCode(t, `
+buf = bytearray()
+buf.append(1)
+buf.extend(b"\x02\x03")
+buf.extend([4, 5])
+buf.insert(0, 0)
+popped = buf.pop()
+buf.remove(2)
+snapshot = bytes(buf)
+dup = buf.copy()
+dup.reverse()
+reversed_copy = bytes(dup)
+buf.clear()
+emptied = len(buf)
+`)
+	assert.Equal(t, int64(5), vm.GetGlobal("popped").(*runtime.PyInt).Value)
+	assert.Equal(t, []byte{0, 1, 3, 4}, vm.GetGlobal("snapshot").(*runtime.PyBytes).Value)
+	assert.Equal(t, []byte{4, 3, 1, 0}, vm.GetGlobal("reversed_copy").(*runtime.PyBytes).Value)
+	assert.Equal(t, int64(0), vm.GetGlobal("emptied").(*runtime.PyInt).Value)
+}
+
+func TestByteArrayMutationErrors(t *testing.T) {
+	runCodeExpectError(t, `bytearray().append(256)`, "byte must be in range(0, 256)")
+	runCodeExpectError(t, `bytearray().pop()`, "pop from empty bytearray")
+	runCodeExpectError(t, `bytearray(b"ab").remove(99)`, "value not found in bytearray")
+}
+
+func TestByteArrayConcatenationAndRepetition(t *testing.T) {
+	vm := runCode(t, `
+buf = bytearray()
+buf += b"\x01\x02"
+buf += bytearray(b"\x03")
+added = bytearray(b"ab") + b"cd"
+bytes_left = b"ab" + bytearray(b"cd")
+repeated = bytearray(b"xy") * 3
+added_type = type(added).__name__
+bytes_left_type = type(bytes_left).__name__
+`)
+	buf := vm.GetGlobal("buf").(*runtime.PyByteArray)
+	assert.Equal(t, []byte{1, 2, 3}, buf.Value)
+	assert.Equal(t, []byte("abcd"), vm.GetGlobal("added").(*runtime.PyByteArray).Value)
+	assert.Equal(t, []byte("abcd"), vm.GetGlobal("bytes_left").(*runtime.PyBytes).Value)
+	assert.Equal(t, []byte("xyxyxy"), vm.GetGlobal("repeated").(*runtime.PyByteArray).Value)
+	assert.Equal(t, "bytearray", vm.GetGlobal("added_type").(*runtime.PyString).Value)
+	assert.Equal(t, "bytes", vm.GetGlobal("bytes_left_type").(*runtime.PyString).Value)
+}
+
+func TestByteArrayDelegatesBytesMethods(t *testing.T) {
+	vm := runCode(t, `
+h = bytearray(b"hello world")
+decoded = h.decode()
+hexed = h.hex()
+found = h.find(b"world")
+starts = h.startswith(b"hell")
+parts = h.split(b" ")
+part_types = [type(p).__name__ for p in parts]
+first = parts[0].decode()
+`)
+	assert.Equal(t, "hello world", vm.GetGlobal("decoded").(*runtime.PyString).Value)
+	assert.Equal(t, "68656c6c6f20776f726c64", vm.GetGlobal("hexed").(*runtime.PyString).Value)
+	assert.Equal(t, int64(6), vm.GetGlobal("found").(*runtime.PyInt).Value)
+	assert.Equal(t, true, vm.GetGlobal("starts").(*runtime.PyBool).Value)
+	partTypes := vm.GetGlobal("part_types").(*runtime.PyList)
+	require.Len(t, partTypes.Items, 2)
+	assert.Equal(t, "bytearray", partTypes.Items[0].(*runtime.PyString).Value)
+	assert.Equal(t, "hello", vm.GetGlobal("first").(*runtime.PyString).Value)
+}
@@ -0,0 +1,108 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// Calendar Module Tests
+// =============================================================================
+
+func TestCalendarIsleap(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import calendar
+a = calendar.isleap(2024)
+b = calendar.isleap(2023)
+c = calendar.isleap(1900)
+d = calendar.isleap(2000)
+n = calendar.leapdays(2000, 2025)
+`)
+	assert.True(t, vm.GetGlobal("a").(*runtime.PyBool).Value)
+	assert.False(t, vm.GetGlobal("b").(*runtime.PyBool).Value)
+	assert.False(t, vm.GetGlobal("c").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("d").(*runtime.PyBool).Value)
+	assert.Equal(t, int64(7), vm.GetGlobal("n").(*runtime.PyInt).Value)
+}
+
+func TestCalendarWeekdayAndMonthrange(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import calendar
+wd = calendar.weekday(2024, 2, 29)       # a leap-day Thursday
+first, days = calendar.monthrange(2024, 2)
+feb23 = calendar.monthrange(2023, 2)[1]
+name = calendar.day_name[calendar.weekday(2026, 1, 1)]
+month = calendar.month_name[12]
+`)
+	assert.Equal(t, int64(3), vm.GetGlobal("wd").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(3), vm.GetGlobal("first").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(29), vm.GetGlobal("days").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(28), vm.GetGlobal("feb23").(*runtime.PyInt).Value)
+	assert.Equal(t, "Thursday", vm.GetGlobal("name").(*runtime.PyString).Value)
+	assert.Equal(t, "December", vm.GetGlobal("month").(*runtime.PyString).Value)
+}
+
+func TestCalendarMonthOutOfRange(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import calendar
+calendar.monthrange(2024, 13)
+`, "month must be in 1..12")
+}
+
+// =============================================================================
+// Zoneinfo Module Tests
+// =============================================================================
+
+func TestZoneInfoOffsets(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import datetime
+import zoneinfo
+paris = zoneinfo.ZoneInfo("Europe/Paris")
+key = paris.key()
+winter = datetime.datetime(2024, 1, 15, 12, 0, 0)
+summer = datetime.datetime(2024, 7, 15, 12, 0, 0)
+winter_off = paris.utcoffset(winter).total_seconds()
+summer_off = paris.utcoffset(summer).total_seconds()
+winter_name = paris.tzname(winter)
+summer_dst = paris.dst(summer).total_seconds()
+winter_dst = paris.dst(winter).total_seconds()
+`)
+	assert.Equal(t, "Europe/Paris", vm.GetGlobal("key").(*runtime.PyString).Value)
+	assert.Equal(t, 3600.0, vm.GetGlobal("winter_off").(*runtime.PyFloat).Value)
+	assert.Equal(t, 7200.0, vm.GetGlobal("summer_off").(*runtime.PyFloat).Value)
+	assert.Equal(t, "CET", vm.GetGlobal("winter_name").(*runtime.PyString).Value)
+	assert.Equal(t, 3600.0, vm.GetGlobal("summer_dst").(*runtime.PyFloat).Value)
+	assert.Equal(t, 0.0, vm.GetGlobal("winter_dst").(*runtime.PyFloat).Value)
+}
+
+func TestZoneInfoNegativeOffset(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import datetime
+import zoneinfo
+ny = zoneinfo.ZoneInfo("America/New_York")
+winter = datetime.datetime(2024, 1, 15, 12, 0, 0)
+off = ny.utcoffset(winter).total_seconds()
+`)
+	assert.Equal(t, -18000.0, vm.GetGlobal("off").(*runtime.PyFloat).Value)
+}
+
+func TestZoneInfoUnknownKey(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import zoneinfo
+zoneinfo.ZoneInfo("Nowhere/Atlantis")
+`, "ZoneInfoNotFoundError")
+}
+
+func TestDatetimeNowWithZone(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import datetime
+import zoneinfo
+utc = datetime.now(zoneinfo.ZoneInfo("UTC"))
+tokyo = datetime.now(zoneinfo.ZoneInfo("Asia/Tokyo"))
+# Tokyo is UTC+9 year-round
+diff = (tokyo.hour() - utc.hour()) % 24
+`)
+	assert.Equal(t, int64(9), vm.GetGlobal("diff").(*runtime.PyInt).Value)
+}
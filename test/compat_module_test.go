@@ -0,0 +1,71 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// Compat Module Tests
+// =============================================================================
+
+func TestCompatFlagsAndTypes(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import compat
+py3 = compat.PY3
+py2 = compat.PY2
+is_str = isinstance("hello", compat.string_types)
+is_int = isinstance(42, compat.integer_types)
+not_str = isinstance(42, compat.string_types)
+`)
+	assert.True(t, vm.GetGlobal("py3").(*runtime.PyBool).Value)
+	assert.False(t, vm.GetGlobal("py2").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("is_str").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("is_int").(*runtime.PyBool).Value)
+	assert.False(t, vm.GetGlobal("not_str").(*runtime.PyBool).Value)
+}
+
+func TestCompatIterHelpers(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import compat
+d = {"a": 1, "b": 2}
+items = str(sorted(compat.iteritems(d)))
+keys = str(sorted(compat.iterkeys(d)))
+values = str(sorted(compat.itervalues(d)))
+`)
+	assert.Equal(t, "[('a', 1), ('b', 2)]", vm.GetGlobal("items").(*runtime.PyString).Value)
+	assert.Equal(t, "['a', 'b']", vm.GetGlobal("keys").(*runtime.PyString).Value)
+	assert.Equal(t, "[1, 2]", vm.GetGlobal("values").(*runtime.PyString).Value)
+}
+
+func TestCompatTextType(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import compat
+s = compat.text_type(42)
+`)
+	assert.Equal(t, "42", vm.GetGlobal("s").(*runtime.PyString).Value)
+}
+
+func TestCompatPlatformBasics(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import compat
+system = compat.system()
+machine = compat.machine()
+version = compat.python_version()
+`)
+	assert.NotEmpty(t, vm.GetGlobal("system").(*runtime.PyString).Value)
+	assert.NotEmpty(t, vm.GetGlobal("machine").(*runtime.PyString).Value)
+	assert.Regexp(t, `^\d+\.\d+\.\d+$`, vm.GetGlobal("version").(*runtime.PyString).Value)
+}
+
+func TestCompatConstants(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import compat
+sep = compat.linesep
+big = compat.maxsize > 2 ** 62
+`)
+	assert.Contains(t, []string{"\n", "\r\n"}, vm.GetGlobal("sep").(*runtime.PyString).Value)
+	assert.True(t, vm.GetGlobal("big").(*runtime.PyBool).Value)
+}
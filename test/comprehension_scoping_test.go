@@ -0,0 +1,180 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// Scoping and laziness conformance tests for comprehensions and generator
+// expressions. Comprehensions run in their own scope: iteration variables
+// must not leak, and genexps must not pre-materialize their source.
+
+func TestGenexpIsLazy(t *testing.T) {
+	vm := runCode(t, `
+log = []
+def side(x):
+    log.append(x)
+    return x
+
+g = (side(i) for i in range(5))
+before = len(log)
+first = next(g)
+second = next(g)
+after = len(log)
+`)
+	assert.Equal(t, int64(0), vm.GetGlobal("before").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(0), vm.GetGlobal("first").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(1), vm.GetGlobal("second").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), vm.GetGlobal("after").(*runtime.PyInt).Value)
+}
+
+func TestGenexpFirstIterableEvaluatedEagerly(t *testing.T) {
+	// The outermost iterable is bound when the genexp is created, not when
+	// it is first iterated.
+	vm := runCode(t, `
+lst = [1, 2, 3]
+g = (x for x in lst)
+lst = [9]
+result = list(g)
+`)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	assert.Equal(t, 3, len(result.Items))
+	assert.Equal(t, int64(1), result.Items[0].(*runtime.PyInt).Value)
+}
+
+func TestComprehensionVariableDoesNotLeak(t *testing.T) {
+	vm := runCode(t, `
+[i for i in range(3)]
+try:
+    i
+    leaked = True
+except NameError:
+    leaked = False
+`)
+	assert.False(t, vm.GetGlobal("leaked").(*runtime.PyBool).Value)
+}
+
+func TestComprehensionDoesNotShadowEnclosing(t *testing.T) {
+	vm := runCode(t, `
+x = 42
+result = [x for x in range(5)]
+after = x
+`)
+	assert.Equal(t, int64(42), vm.GetGlobal("after").(*runtime.PyInt).Value)
+}
+
+func TestGenexpVariableDoesNotLeak(t *testing.T) {
+	vm := runCode(t, `
+list(j for j in range(3))
+try:
+    j
+    leaked = True
+except NameError:
+    leaked = False
+`)
+	assert.False(t, vm.GetGlobal("leaked").(*runtime.PyBool).Value)
+}
+
+func TestDictComprehensionScoping(t *testing.T) {
+	vm := runCode(t, `
+d = {k: k * 2 for k in range(3)}
+try:
+    k
+    leaked = True
+except NameError:
+    leaked = False
+size = len(d)
+two = d[1]
+`)
+	assert.False(t, vm.GetGlobal("leaked").(*runtime.PyBool).Value)
+	assert.Equal(t, int64(3), vm.GetGlobal("size").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), vm.GetGlobal("two").(*runtime.PyInt).Value)
+}
+
+func TestSetComprehensionScoping(t *testing.T) {
+	vm := runCode(t, `
+s = {x % 3 for x in range(9)}
+try:
+    x
+    leaked = True
+except NameError:
+    leaked = False
+size = len(s)
+`)
+	assert.False(t, vm.GetGlobal("leaked").(*runtime.PyBool).Value)
+	assert.Equal(t, int64(3), vm.GetGlobal("size").(*runtime.PyInt).Value)
+}
+
+func TestNestedComprehensionInnerSeesOuterVariable(t *testing.T) {
+	vm := runCode(t, `
+result = [[x * y for y in range(3)] for x in range(2)]
+`)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	assert.Equal(t, 2, len(result.Items))
+	inner := result.Items[1].(*runtime.PyList)
+	assert.Equal(t, int64(0), inner.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(1), inner.Items[1].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), inner.Items[2].(*runtime.PyInt).Value)
+}
+
+func TestComprehensionMultipleForClauses(t *testing.T) {
+	vm := runCode(t, `
+m = [[1, 2], [3, 4]]
+flat = [y for row in m for y in row]
+`)
+	flat := vm.GetGlobal("flat").(*runtime.PyList)
+	assert.Equal(t, 4, len(flat.Items))
+	assert.Equal(t, int64(4), flat.Items[3].(*runtime.PyInt).Value)
+}
+
+func TestComprehensionSeesEnclosingFunctionLocal(t *testing.T) {
+	vm := runCode(t, `
+def f():
+    n = 10
+    return [i * n for i in range(3)]
+
+result = f()
+`)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	assert.Equal(t, int64(20), result.Items[2].(*runtime.PyInt).Value)
+}
+
+func TestLambdaInComprehensionLateBinding(t *testing.T) {
+	// Lambdas capture the comprehension variable, not its value, so all
+	// see the final value — same as CPython.
+	vm := runCode(t, `
+funcs = [lambda: i for i in range(3)]
+result = [f() for f in funcs]
+`)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	assert.Equal(t, int64(2), result.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), result.Items[1].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), result.Items[2].(*runtime.PyInt).Value)
+}
+
+func TestClassBodyComprehensionSeesClassVariableAsIterable(t *testing.T) {
+	vm := runCode(t, `
+class C:
+    vals = [1, 2, 3]
+    doubled = [v * 2 for v in vals]
+
+result = C.doubled
+`)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	assert.Equal(t, int64(6), result.Items[2].(*runtime.PyInt).Value)
+}
+
+func TestNameErrorIsCatchable(t *testing.T) {
+	vm := runCode(t, `
+try:
+    undefined_name
+    caught = False
+except NameError as e:
+    caught = True
+    msg = str(e)
+`)
+	assert.True(t, vm.GetGlobal("caught").(*runtime.PyBool).Value)
+	assert.Equal(t, "name 'undefined_name' is not defined", vm.GetGlobal("msg").(*runtime.PyString).Value)
+}
@@ -0,0 +1,281 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ATSOTECK/rage/pkg/rage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests exercise the documented thread-safety contract under the race
+// detector: separate States may be used from separate goroutines, Close may
+// race with an in-flight Run, and Go callbacks may spawn goroutines that
+// create rage values. Run with: go test -race ./test/
+
+// TestConcurrentStateChurn creates and destroys many states across goroutines,
+// each running code that touches the string intern pool and module registry.
+func TestConcurrentStateChurn(t *testing.T) {
+	const goroutines = 8
+	const iterations = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines*iterations)
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				state := rage.NewState()
+				_, err := state.Run(fmt.Sprintf(`
+import math
+words = ["alpha", "beta", "gamma", "delta"] * 10
+joined = "-".join(words)
+result = math.floor(%d.5) + len(joined)
+`, g*100+i))
+				if err != nil {
+					errs <- err
+				}
+				state.Close()
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent state churn failed: %v", err)
+	}
+}
+
+// TestConcurrentStatePoolCheckout simulates a fixed-size pool of states being
+// checked out, used, and returned by many workers — the embedding pattern used
+// by servers that amortize state creation cost.
+func TestConcurrentStatePoolCheckout(t *testing.T) {
+	const poolSize = 4
+	const workers = 16
+	const checkouts = 10
+
+	pool := make(chan *rage.State, poolSize)
+	for i := 0; i < poolSize; i++ {
+		pool <- rage.NewState()
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*checkouts)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < checkouts; i++ {
+				state := <-pool
+				state.SetGlobal("worker", rage.Int(int64(w)))
+				_, err := state.Run(`result = worker * 2 + len([x for x in range(50)])`)
+				if err != nil {
+					errs <- err
+				} else if n, ok := rage.AsInt(state.GetGlobal("result")); !ok || n != int64(w*2+50) {
+					errs <- fmt.Errorf("worker %d: unexpected result %v", w, state.GetGlobal("result"))
+				}
+				pool <- state
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("pool checkout failed: %v", err)
+	}
+
+	for i := 0; i < poolSize; i++ {
+		(<-pool).Close()
+	}
+}
+
+// TestConcurrentCancelDuringRun interrupts a long-running script from another
+// goroutine via context cancellation.
+func TestConcurrentCancelDuringRun(t *testing.T) {
+	state := rage.NewState()
+	defer state.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := state.RunWithContext(ctx, `
+i = 0
+while True:
+    i += 1
+`)
+	require.Error(t, err)
+
+	// The state must remain usable after cancellation.
+	v, err := state.Run(`x = 1 + 1`)
+	require.NoError(t, err)
+	_ = v
+	n, ok := rage.AsInt(state.GetGlobal("x"))
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), n)
+}
+
+// TestCloseDuringRun calls Close while another goroutine is inside Run.
+// The in-flight Run must complete (successfully or with an error) without
+// panicking, and the state must report closed afterwards.
+func TestCloseDuringRun(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		state := rage.NewState()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			// Long enough to overlap with Close, short enough to terminate.
+			_, _ = state.Run(`
+total = 0
+for i in range(200000):
+    total += i
+`)
+		}()
+
+		time.Sleep(time.Duration(i) * time.Millisecond)
+		state.Close()
+		<-done
+
+		// All operations after Close must fail cleanly rather than panic.
+		_, err := state.Run(`x = 1`)
+		require.Error(t, err)
+		assert.Nil(t, state.GetGlobal("total"))
+	}
+}
+
+// TestCloseIdempotentConcurrent calls Close from many goroutines at once.
+func TestCloseIdempotentConcurrent(t *testing.T) {
+	state := rage.NewState()
+	_, err := state.Run(`x = 42`)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			state.Close()
+		}()
+	}
+	wg.Wait()
+
+	_, err = state.Run(`x = 1`)
+	assert.Error(t, err)
+}
+
+// TestCallbackSpawnsGoroutines registers a Go callback that fans work out to
+// goroutines which create rage values (hitting the shared intern pool) and
+// even spin up their own states.
+func TestCallbackSpawnsGoroutines(t *testing.T) {
+	state := rage.NewState()
+	defer state.Close()
+
+	state.Register("fanout", func(s *rage.State, args ...rage.Value) rage.Value {
+		var wg sync.WaitGroup
+		results := make([]int64, 4)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				// Touch the shared intern pool from the spawned goroutine.
+				_ = rage.String(fmt.Sprintf("spawned-%d", i))
+				worker := rage.NewState()
+				defer worker.Close()
+				_, err := worker.Run(fmt.Sprintf(`result = %d * 10`, i))
+				if err == nil {
+					if n, ok := rage.AsInt(worker.GetGlobal("result")); ok {
+						results[i] = n
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		var total int64
+		for _, r := range results {
+			total += r
+		}
+		return rage.Int(total)
+	})
+
+	_, err := state.Run(`total = fanout()`)
+	require.NoError(t, err)
+	n, ok := rage.AsInt(state.GetGlobal("total"))
+	require.True(t, ok)
+	assert.Equal(t, int64(60), n) // 0 + 10 + 20 + 30
+}
+
+// TestConcurrentSharedImports has many states importing the same stdlib
+// modules at once, exercising the shared module registry and loaded-module
+// cache.
+func TestConcurrentSharedImports(t *testing.T) {
+	const goroutines = 8
+
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines)
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			state := rage.NewStateWithModules(
+				rage.WithModules(rage.ModuleMath, rage.ModuleJSON, rage.ModuleString),
+			)
+			defer state.Close()
+			_, err := state.Run(`
+import math
+import json
+data = json.dumps({"pi": math.pi})
+parsed = json.loads(data)
+ok = abs(parsed["pi"] - math.pi) < 1e-9
+`)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if b, isBool := rage.AsBool(state.GetGlobal("ok")); !isBool || !b {
+				errs <- fmt.Errorf("round-trip through json failed")
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent import failed: %v", err)
+	}
+}
+
+// TestConcurrentInternPool hammers the shared string intern pool with
+// overlapping short strings from many goroutines.
+func TestConcurrentInternPool(t *testing.T) {
+	const goroutines = 8
+	const iterations = 200
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				// Reuse a small key space so goroutines collide on entries.
+				_ = rage.String(fmt.Sprintf("key-%d", i%16))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Greater(t, rage.StringInternPoolSize(), 0)
+}
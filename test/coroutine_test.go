@@ -166,3 +166,321 @@ closed_ok = True
 	result := vm.GetGlobal("closed_ok")
 	assert.Equal(t, runtime.True, result)
 }
+
+// =============================================================================
+// async for / async with / async comprehensions
+// =============================================================================
+
+const asyncCounterSource = `
+import asyncio
+
+class Counter:
+    def __init__(self, n):
+        self.n = n
+        self.i = 0
+    def __aiter__(self):
+        return self
+    async def __anext__(self):
+        if self.i >= self.n:
+            raise StopAsyncIteration
+        self.i += 1
+        return self.i
+`
+
+func TestAsyncForCollectsValues(t *testing.T) {
+	source := asyncCounterSource + `
+async def main():
+    out = []
+    async for x in Counter(3):
+        out.append(x)
+    return out
+
+result = asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	require.Equal(t, 3, len(result.Items))
+	assert.Equal(t, int64(1), result.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(3), result.Items[2].(*runtime.PyInt).Value)
+}
+
+func TestAsyncForBreakContinueElse(t *testing.T) {
+	source := asyncCounterSource + `
+async def main():
+    total = 0
+    async for x in Counter(10):
+        if x == 2:
+            continue
+        if x > 4:
+            break
+        total += x
+    else_ran = False
+    async for x in Counter(2):
+        pass
+    else:
+        else_ran = True
+    return [total, else_ran]
+
+result = asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	assert.Equal(t, int64(8), result.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, runtime.True, result.Items[1])
+}
+
+func TestAsyncForPropagatesOtherExceptions(t *testing.T) {
+	// Only StopAsyncIteration ends the loop; anything else escapes
+	source := `
+import asyncio
+
+class Broken:
+    def __aiter__(self):
+        return self
+    async def __anext__(self):
+        raise ValueError("bad iterator")
+
+async def main():
+    try:
+        async for x in Broken():
+            pass
+    except ValueError as e:
+        return str(e)
+    return "not raised"
+
+result = asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyString)
+	assert.Equal(t, "bad iterator", result.Value)
+}
+
+func TestAsyncWithEnterExit(t *testing.T) {
+	source := `
+import asyncio
+
+log = []
+
+class Resource:
+    async def __aenter__(self):
+        log.append("enter")
+        return "res"
+    async def __aexit__(self, et, ev, tb):
+        log.append("exit")
+        return False
+
+async def main():
+    async with Resource() as r:
+        log.append(r)
+
+asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	log := vm.GetGlobal("log").(*runtime.PyList)
+	require.Equal(t, 3, len(log.Items))
+	assert.Equal(t, "enter", log.Items[0].(*runtime.PyString).Value)
+	assert.Equal(t, "res", log.Items[1].(*runtime.PyString).Value)
+	assert.Equal(t, "exit", log.Items[2].(*runtime.PyString).Value)
+}
+
+func TestAsyncWithSuppressesException(t *testing.T) {
+	// __aexit__ returning True suppresses the exception, like __exit__
+	source := `
+import asyncio
+
+class Suppress:
+    async def __aenter__(self):
+        return self
+    async def __aexit__(self, et, ev, tb):
+        return True
+
+async def main():
+    async with Suppress():
+        raise ValueError("boom")
+    return "suppressed"
+
+result = asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyString)
+	assert.Equal(t, "suppressed", result.Value)
+}
+
+func TestAsyncWithExitSeesException(t *testing.T) {
+	source := `
+import asyncio
+
+seen = []
+
+class Watcher:
+    async def __aenter__(self):
+        return self
+    async def __aexit__(self, et, ev, tb):
+        seen.append(et.__name__)
+        return False
+
+async def main():
+    try:
+        async with Watcher():
+            raise KeyError("k")
+    except KeyError:
+        seen.append("caught")
+
+asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	seen := vm.GetGlobal("seen").(*runtime.PyList)
+	require.Equal(t, 2, len(seen.Items))
+	assert.Equal(t, "KeyError", seen.Items[0].(*runtime.PyString).Value)
+	assert.Equal(t, "caught", seen.Items[1].(*runtime.PyString).Value)
+}
+
+func TestAsyncListComprehension(t *testing.T) {
+	source := asyncCounterSource + `
+async def main():
+    return [x * 10 async for x in Counter(3)]
+
+result = asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	require.Equal(t, 3, len(result.Items))
+	assert.Equal(t, int64(10), result.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(30), result.Items[2].(*runtime.PyInt).Value)
+}
+
+func TestAsyncDictSetComprehensions(t *testing.T) {
+	source := asyncCounterSource + `
+async def main():
+    d = {x: x * x async for x in Counter(4) if x % 2 == 0}
+    s = {x % 2 async for x in Counter(5)}
+    return [sorted(d.items()), sorted(s)]
+
+result = asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	items := result.Items[0].(*runtime.PyList)
+	require.Equal(t, 2, len(items.Items))
+	first := items.Items[0].(*runtime.PyTuple)
+	assert.Equal(t, int64(2), first.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(4), first.Items[1].(*runtime.PyInt).Value)
+	set := result.Items[1].(*runtime.PyList)
+	assert.Equal(t, 2, len(set.Items))
+}
+
+func TestAsyncGeneratorDrivesAsyncFor(t *testing.T) {
+	source := `
+import asyncio
+
+async def agen():
+    yield 1
+    yield 2
+    yield 3
+
+async def main():
+    out = []
+    async for x in agen():
+        out.append(x)
+    return out
+
+result = asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	require.Equal(t, 3, len(result.Items))
+	assert.Equal(t, int64(1), result.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(3), result.Items[2].(*runtime.PyInt).Value)
+}
+
+func TestAsyncGeneratorWithAwaitInBody(t *testing.T) {
+	source := `
+import asyncio
+
+async def double(n):
+    return n * 2
+
+async def agen():
+    for i in range(3):
+        yield await double(i)
+
+async def main():
+    return [x async for x in agen()]
+
+result = asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	require.Equal(t, 3, len(result.Items))
+	assert.Equal(t, int64(4), result.Items[2].(*runtime.PyInt).Value)
+}
+
+func TestAsyncGeneratorAnextProtocol(t *testing.T) {
+	source := `
+import asyncio
+
+async def agen():
+    yield "a"
+    yield "b"
+
+async def main():
+    g = agen()
+    first = await g.__anext__()
+    second = await g.asend(None)
+    exhausted = False
+    try:
+        await g.__anext__()
+    except StopAsyncIteration:
+        exhausted = True
+    return [first, second, exhausted]
+
+result = asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	assert.Equal(t, "a", result.Items[0].(*runtime.PyString).Value)
+	assert.Equal(t, "b", result.Items[1].(*runtime.PyString).Value)
+	assert.Equal(t, runtime.True, result.Items[2])
+}
+
+func TestAsyncGeneratorPropagatesBodyException(t *testing.T) {
+	source := `
+import asyncio
+
+async def boom():
+    yield 1
+    raise ValueError("from agen")
+
+async def main():
+    seen = []
+    caught = ""
+    try:
+        async for x in boom():
+            seen.append(x)
+    except ValueError as e:
+        caught = str(e)
+    return [seen, caught]
+
+result = asyncio.run(main())
+`
+	vm := runCodeWithStdlib(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	seen := result.Items[0].(*runtime.PyList)
+	require.Equal(t, 1, len(seen.Items))
+	assert.Equal(t, "from agen", result.Items[1].(*runtime.PyString).Value)
+}
+
+func TestAsyncGeneratorIsNotAwaitable(t *testing.T) {
+	source := `
+import asyncio
+
+async def agen():
+    yield 1
+
+async def main():
+    await agen()
+
+asyncio.run(main())
+`
+	runCodeExpectErrorWithStdlib(t, source, "object async_generator can't be used in 'await' expression")
+}
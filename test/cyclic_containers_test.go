@@ -0,0 +1,57 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// Cycle-Safe Container Comparison Tests
+// =============================================================================
+
+func TestSelfReferentialEquality(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+a = [1]
+a.append(a)
+same = a == a
+other = a == [1, 2]
+`)
+	assert.True(t, vm.GetGlobal("same").(*runtime.PyBool).Value)
+	assert.False(t, vm.GetGlobal("other").(*runtime.PyBool).Value)
+}
+
+func TestSelfReferentialOrdering(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+a = []
+a.append(a)
+lt = a < a
+gt = a > a
+`)
+	assert.False(t, vm.GetGlobal("lt").(*runtime.PyBool).Value)
+	assert.False(t, vm.GetGlobal("gt").(*runtime.PyBool).Value)
+}
+
+func TestTupleSharingCycleEquality(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+inner = [1]
+inner.append(inner)
+a = (inner, "x")
+b = (inner, "x")
+eq = a == b
+`)
+	assert.True(t, vm.GetGlobal("eq").(*runtime.PyBool).Value)
+}
+
+func TestMutualCycleOrderingRaisesRecursionError(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+x = []
+y = []
+x.append(y)
+x.append(1)
+y.append(x)
+y.append(2)
+x < y
+`, "maximum recursion depth")
+}
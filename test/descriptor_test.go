@@ -395,3 +395,256 @@ prop = MyClass.get_value
 	_, ok := prop.(*runtime.PyProperty)
 	assert.True(t, ok, "Expected property object, got %T", prop)
 }
+
+// =====================================
+// Descriptor protocol conformance
+// =====================================
+//
+// These tests pin the CPython precedence order for attribute lookup:
+// data descriptor > instance dict > non-data descriptor > class attribute.
+
+func TestDataDescriptorBeatsInstanceDict(t *testing.T) {
+	source := `
+class D:
+    def __get__(self, obj, owner):
+        return "data-get"
+    def __set__(self, obj, value):
+        pass
+
+class C:
+    attr = D()
+
+c = C()
+c.__dict__["attr"] = "instance"
+result = c.attr
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "data-get", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestInstanceDictBeatsNonDataDescriptor(t *testing.T) {
+	source := `
+class ND:
+    def __get__(self, obj, owner):
+        return "nondata-get"
+
+class C:
+    attr = ND()
+
+c = C()
+before = c.attr
+c.__dict__["attr"] = "instance"
+after = c.attr
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "nondata-get", vm.GetGlobal("before").(*runtime.PyString).Value)
+	assert.Equal(t, "instance", vm.GetGlobal("after").(*runtime.PyString).Value)
+}
+
+func TestSetOnlyDescriptorIsNotDataForGet(t *testing.T) {
+	// A descriptor with __set__ but no __get__: instance dict wins on read.
+	source := `
+class SetOnly:
+    def __set__(self, obj, value):
+        obj.__dict__["stored"] = value
+
+class C:
+    x = SetOnly()
+
+c = C()
+c.__dict__["x"] = "instance"
+result = c.x
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "instance", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestDataDescriptorSetAndDelete(t *testing.T) {
+	source := `
+class D:
+    def __get__(self, obj, owner):
+        return obj.__dict__.get("_x", "unset")
+    def __set__(self, obj, value):
+        obj.__dict__["_x"] = value * 2
+    def __delete__(self, obj):
+        obj.__dict__["deleted"] = True
+
+class C:
+    x = D()
+
+c = C()
+c.x = 21
+doubled = c.x
+del c.x
+deleted = c.__dict__.get("deleted", False)
+`
+	vm := runCode(t, source)
+	assert.Equal(t, int64(42), vm.GetGlobal("doubled").(*runtime.PyInt).Value)
+	assert.Equal(t, runtime.True, vm.GetGlobal("deleted"))
+}
+
+func TestDescriptorClassAccessPassesOwner(t *testing.T) {
+	// Access through the class calls __get__(None, owner).
+	source := `
+class D:
+    def __get__(self, obj, owner):
+        return (obj is None, owner.__name__)
+
+class C:
+    x = D()
+
+obj_is_none, owner_name = C.x
+`
+	vm := runCode(t, source)
+	assert.Equal(t, runtime.True, vm.GetGlobal("obj_is_none"))
+	assert.Equal(t, "C", vm.GetGlobal("owner_name").(*runtime.PyString).Value)
+}
+
+func TestDataDescriptorInheritedFromBase(t *testing.T) {
+	source := `
+class D:
+    def __get__(self, obj, owner):
+        return "from-base"
+    def __set__(self, obj, value):
+        pass
+
+class Base:
+    x = D()
+
+class Sub(Base):
+    pass
+
+s = Sub()
+s.__dict__["x"] = "instance"
+result = s.x
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "from-base", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestFunctionsAreNonDataDescriptors(t *testing.T) {
+	// Plain methods can be shadowed by the instance dict.
+	source := `
+class C:
+    def m(self):
+        return "method"
+
+c = C()
+c.__dict__["m"] = lambda: "shadowed"
+result = c.m()
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "shadowed", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestPropertyIsDataDescriptor(t *testing.T) {
+	// Properties define __set__ (raising AttributeError) so they always win
+	// over the instance dict.
+	source := `
+class C:
+    @property
+    def x(self):
+        return "prop"
+
+c = C()
+c.__dict__["x"] = "instance"
+result = c.x
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "prop", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestSetNameCalledAtClassCreation(t *testing.T) {
+	source := `
+class Field:
+    def __set_name__(self, owner, name):
+        self.owner_name = owner.__name__
+        self.name = name
+    def __get__(self, obj, owner):
+        return self.owner_name + "." + self.name
+
+class Model:
+    a = Field()
+    b = Field()
+
+m = Model()
+a = m.a
+b = m.b
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "Model.a", vm.GetGlobal("a").(*runtime.PyString).Value)
+	assert.Equal(t, "Model.b", vm.GetGlobal("b").(*runtime.PyString).Value)
+}
+
+func TestSetNameSeesOriginalOwnerInSubclass(t *testing.T) {
+	// __set_name__ runs once, when the defining class is created.
+	source := `
+class Field:
+    def __set_name__(self, owner, name):
+        self.info = owner.__name__ + "." + name
+    def __get__(self, obj, owner):
+        return self.info
+
+class Base:
+    f = Field()
+
+class Sub(Base):
+    pass
+
+result = Sub().f
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "Base.f", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestSetNameErrorWrappedInRuntimeError(t *testing.T) {
+	// CPython chains __set_name__ failures into a RuntimeError.
+	source := `
+class Bad:
+    def __set_name__(self, owner, name):
+        raise ValueError("nope")
+
+class C:
+    f = Bad()
+`
+	runCodeExpectError(t, source, "RuntimeError")
+}
+
+func TestSetNameOrmPattern(t *testing.T) {
+	// Typed fields storing values under their attribute name — the ORM-ish
+	// pattern this precedence order exists for.
+	source := `
+class Typed:
+    def __init__(self, kind):
+        self.kind = kind
+    def __set_name__(self, owner, name):
+        self.name = name
+    def __get__(self, obj, owner):
+        if obj is None:
+            return self
+        return obj.__dict__.get("_" + self.name)
+    def __set__(self, obj, value):
+        if not isinstance(value, self.kind):
+            raise TypeError("wrong type for " + self.name)
+        obj.__dict__["_" + self.name] = value
+
+class Person:
+    name = Typed(str)
+    age = Typed(int)
+
+p = Person()
+p.name = "Ada"
+p.age = 36
+name = p.name
+age = p.age
+try:
+    p.age = "old"
+    type_error = False
+except TypeError:
+    type_error = True
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "Ada", vm.GetGlobal("name").(*runtime.PyString).Value)
+	assert.Equal(t, int64(36), vm.GetGlobal("age").(*runtime.PyInt).Value)
+	assert.Equal(t, runtime.True, vm.GetGlobal("type_error"))
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =====================================
+// Dict merge operators (PEP 584)
+// =====================================
+
+func assertGlobalStr(t *testing.T, vm *runtime.VM, name, expected string) {
+	t.Helper()
+	s := vm.GetGlobal(name).(*runtime.PyString)
+	assert.Equal(t, expected, s.Value, "global %s", name)
+}
+
+func TestDictMergeOperator(t *testing.T) {
+	vm := runCode(t, `
+a = {"a": 1, "b": 2}
+b = {"b": 20, "c": 3}
+ab = repr(a | b)
+ba = repr(b | a)
+ra = repr(a)
+rb = repr(b)
+`)
+	// Left operand's key order wins; right operand's values win on conflict
+	assertGlobalStr(t, vm, "ab", "{'a': 1, 'b': 20, 'c': 3}")
+	assertGlobalStr(t, vm, "ba", "{'b': 2, 'c': 3, 'a': 1}")
+	// Operands are not mutated
+	assertGlobalStr(t, vm, "ra", "{'a': 1, 'b': 2}")
+	assertGlobalStr(t, vm, "rb", "{'b': 20, 'c': 3}")
+}
+
+func TestDictMergeOperatorRequiresDicts(t *testing.T) {
+	runCodeExpectError(t, `
+{"a": 1} | [("b", 2)]
+`, "unsupported operand type(s) for |: 'dict' and 'list'")
+
+	runCodeExpectError(t, `
+[("b", 2)] | {"a": 1}
+`, "unsupported operand type(s) for |: 'list' and 'dict'")
+}
+
+func TestDictInplaceMerge(t *testing.T) {
+	vm := runCode(t, `
+d = {"a": 1}
+alias = d
+d |= {"b": 2, "a": 10}
+r = repr(d)
+same = alias is d
+`)
+	assertGlobalStr(t, vm, "r", "{'a': 10, 'b': 2}")
+	// |= updates in place rather than rebinding to a new dict
+	assert.True(t, vm.GetGlobal("same").(*runtime.PyBool).Value)
+}
+
+func TestDictInplaceMergeAcceptsIterables(t *testing.T) {
+	vm := runCode(t, `
+d = {"a": 1}
+d |= [("b", 2), ("a", 9)]
+rd = repr(d)
+z = {"k": 1}
+z |= zip(["p", "q"], [7, 8])
+rz = repr(z)
+`)
+	assertGlobalStr(t, vm, "rd", "{'a': 9, 'b': 2}")
+	assertGlobalStr(t, vm, "rz", "{'k': 1, 'p': 7, 'q': 8}")
+}
+
+func TestDictComprehensionOrderAndLastKeyWins(t *testing.T) {
+	vm := runCode(t, `
+r = repr({k: k * 2 for k in ["c", "a", "b", "a"]})
+`)
+	assertGlobalStr(t, vm, "r", "{'c': 'cc', 'a': 'aa', 'b': 'bb'}")
+}
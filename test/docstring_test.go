@@ -0,0 +1,130 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctionDocstring(t *testing.T) {
+	source := `
+def greet(name):
+    """Greet someone by name."""
+    return "hi " + name
+
+doc = greet.__doc__
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "Greet someone by name.", vm.GetGlobal("doc").(*runtime.PyString).Value)
+}
+
+func TestFunctionWithoutDocstring(t *testing.T) {
+	source := `
+def f():
+    return 1
+
+doc = f.__doc__
+`
+	vm := runCode(t, source)
+	assert.Equal(t, runtime.None, vm.GetGlobal("doc"))
+}
+
+func TestMultilineDocstring(t *testing.T) {
+	source := `
+def f():
+    """First line.
+
+    More detail."""
+    pass
+
+has_detail = "More detail." in f.__doc__
+starts = f.__doc__.startswith("First line.")
+`
+	vm := runCode(t, source)
+	assert.Equal(t, runtime.True, vm.GetGlobal("has_detail"))
+	assert.Equal(t, runtime.True, vm.GetGlobal("starts"))
+}
+
+func TestClassDocstring(t *testing.T) {
+	source := `
+class Documented:
+    """A documented class."""
+
+class Bare:
+    pass
+
+doc = Documented.__doc__
+bare_doc = Bare.__doc__
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "A documented class.", vm.GetGlobal("doc").(*runtime.PyString).Value)
+	assert.Equal(t, runtime.None, vm.GetGlobal("bare_doc"))
+}
+
+func TestMethodDocstring(t *testing.T) {
+	source := `
+class C:
+    def m(self):
+        """Method doc."""
+        pass
+
+bound = C().m.__doc__
+unbound = C.m.__doc__
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "Method doc.", vm.GetGlobal("bound").(*runtime.PyString).Value)
+	assert.Equal(t, "Method doc.", vm.GetGlobal("unbound").(*runtime.PyString).Value)
+}
+
+func TestModuleDocstring(t *testing.T) {
+	source := `"""Module level docs."""
+doc = __doc__
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "Module level docs.", vm.GetGlobal("doc").(*runtime.PyString).Value)
+}
+
+func TestDocstringNotTreatedAsDocWhenNotFirst(t *testing.T) {
+	source := `
+def f():
+    x = 1
+    "not a docstring"
+    return x
+
+doc = f.__doc__
+`
+	vm := runCode(t, source)
+	assert.Equal(t, runtime.None, vm.GetGlobal("doc"))
+}
+
+func TestDocstringWritable(t *testing.T) {
+	source := `
+def f():
+    """Original."""
+    pass
+
+f.__doc__ = "Replaced."
+doc = f.__doc__
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "Replaced.", vm.GetGlobal("doc").(*runtime.PyString).Value)
+}
+
+func TestFunctoolsWrapsCopiesDocstring(t *testing.T) {
+	source := `
+import functools
+
+def original():
+    """Original docs."""
+    pass
+
+@functools.wraps(original)
+def wrapper():
+    pass
+
+doc = wrapper.__doc__
+`
+	vm := runCodeWithStdlib(t, source)
+	assert.Equal(t, "Original docs.", vm.GetGlobal("doc").(*runtime.PyString).Value)
+}
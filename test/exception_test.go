@@ -496,3 +496,96 @@ finally:
 	assert.True(t, vm.GetGlobal("outer_finally").(*runtime.PyBool).Value)
 	assert.True(t, vm.GetGlobal("inner_finally").(*runtime.PyBool).Value)
 }
+
+// =====================================
+// Exception chaining (raise ... from ...)
+// =====================================
+
+func TestRaiseFromSetsCause(t *testing.T) {
+	vm := runCode(t, `
+try:
+    try:
+        raise ValueError("original")
+    except ValueError as e:
+        raise RuntimeError("wrapper") from e
+except RuntimeError as e:
+    cause_type = type(e.__cause__).__name__
+    cause_msg = str(e.__cause__)
+    suppress = e.__suppress_context__
+`)
+	assert.Equal(t, "ValueError", vm.GetGlobal("cause_type").(*runtime.PyString).Value)
+	assert.Equal(t, "original", vm.GetGlobal("cause_msg").(*runtime.PyString).Value)
+	assert.True(t, vm.GetGlobal("suppress").(*runtime.PyBool).Value)
+}
+
+func TestRaiseFromNoneSuppressesContext(t *testing.T) {
+	vm := runCode(t, `
+try:
+    try:
+        raise ValueError("orig")
+    except ValueError:
+        raise RuntimeError("no chain") from None
+except RuntimeError as e:
+    cause_is_none = e.__cause__ is None
+    suppress = e.__suppress_context__
+`)
+	assert.True(t, vm.GetGlobal("cause_is_none").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("suppress").(*runtime.PyBool).Value)
+}
+
+func TestImplicitContextChaining(t *testing.T) {
+	vm := runCode(t, `
+try:
+    try:
+        raise KeyError("k")
+    except KeyError:
+        raise TypeError("t")
+except TypeError as e:
+    context_type = type(e.__context__).__name__
+    suppress = e.__suppress_context__
+`)
+	assert.Equal(t, "KeyError", vm.GetGlobal("context_type").(*runtime.PyString).Value)
+	assert.False(t, vm.GetGlobal("suppress").(*runtime.PyBool).Value)
+}
+
+func TestRaiseFromExceptionClass(t *testing.T) {
+	vm := runCode(t, `
+try:
+    raise RuntimeError("w") from ValueError
+except RuntimeError as e:
+    cause_type = type(e.__cause__).__name__
+`)
+	assert.Equal(t, "ValueError", vm.GetGlobal("cause_type").(*runtime.PyString).Value)
+}
+
+func TestTypeOfCaughtException(t *testing.T) {
+	vm := runCode(t, `
+try:
+    raise ValueError("x")
+except ValueError as e:
+    type_name = type(e).__name__
+    is_value_error = type(e) is ValueError
+`)
+	assert.Equal(t, "ValueError", vm.GetGlobal("type_name").(*runtime.PyString).Value)
+	assert.True(t, vm.GetGlobal("is_value_error").(*runtime.PyBool).Value)
+}
+
+func TestUncaughtChainedExceptionMentionsCause(t *testing.T) {
+	source := `
+try:
+    raise ValueError("root cause")
+except ValueError as e:
+    raise RuntimeError("wrapper") from e
+`
+	runCodeExpectError(t, source, "The above exception was the direct cause of the following exception")
+}
+
+func TestUncaughtExceptionMentionsContext(t *testing.T) {
+	source := `
+try:
+    raise ValueError("root cause")
+except ValueError:
+    raise RuntimeError("wrapper")
+`
+	runCodeExpectError(t, source, "During handling of the above exception, another exception occurred")
+}
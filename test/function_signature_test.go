@@ -0,0 +1,164 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests for positional-only (/) and keyword-only (*) parameter syntax and the
+// call-time enforcement of function signatures.
+
+func TestPositionalOnlyParams(t *testing.T) {
+	source := `
+def f(a, /, b, *, c):
+    return (a, b, c)
+
+result = f(1, 2, c=3)
+by_keyword = f(1, b=2, c=3)
+`
+	vm := runCode(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyTuple)
+	assert.Equal(t, int64(1), result.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), result.Items[1].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(3), result.Items[2].(*runtime.PyInt).Value)
+	byKeyword := vm.GetGlobal("by_keyword").(*runtime.PyTuple)
+	assert.Equal(t, int64(2), byKeyword.Items[1].(*runtime.PyInt).Value)
+}
+
+func TestPositionalOnlyRejectsKeyword(t *testing.T) {
+	source := `
+def f(a, /, b):
+    return a + b
+
+f(a=1, b=2)
+`
+	runCodeExpectError(t, source, "got some positional-only arguments passed as keyword arguments: 'a'")
+}
+
+func TestPositionalOnlyNameFallsIntoKwargs(t *testing.T) {
+	source := `
+def f(a, /, b, **kw):
+    return (a, b, kw)
+
+result = f(1, b=2, a=3)
+`
+	vm := runCode(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyTuple)
+	assert.Equal(t, int64(1), result.Items[0].(*runtime.PyInt).Value)
+	kw := result.Items[2].(*runtime.PyDict)
+	assert.Equal(t, 1, len(kw.Items))
+}
+
+func TestKeywordOnlyRejectsPositional(t *testing.T) {
+	source := `
+def f(a, *, b):
+    return a + b
+
+f(1, 2)
+`
+	runCodeExpectError(t, source, "takes 1 positional argument but 2 were given")
+}
+
+func TestKeywordOnlyMissing(t *testing.T) {
+	source := `
+def f(a, *, b, c=3):
+    return a + b + c
+
+f(1)
+`
+	runCodeExpectError(t, source, "missing 1 required keyword-only argument: 'b'")
+}
+
+func TestKeywordOnlyAfterVarArgs(t *testing.T) {
+	source := `
+def f(a, *args, b, c=10):
+    return (a, args, b, c)
+
+result = f(1, 2, 3, b=4)
+`
+	vm := runCode(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyTuple)
+	assert.Equal(t, int64(1), result.Items[0].(*runtime.PyInt).Value)
+	varargs := result.Items[1].(*runtime.PyTuple)
+	assert.Equal(t, 2, len(varargs.Items))
+	assert.Equal(t, int64(4), result.Items[2].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(10), result.Items[3].(*runtime.PyInt).Value)
+}
+
+func TestPositionalOnlyWithDefaults(t *testing.T) {
+	source := `
+def f(a, b=2, /, c=3, *, d, e=5):
+    return (a, b, c, d, e)
+
+result = f(1, d=4)
+`
+	vm := runCode(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyTuple)
+	assert.Equal(t, int64(1), result.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), result.Items[1].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(3), result.Items[2].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(4), result.Items[3].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(5), result.Items[4].(*runtime.PyInt).Value)
+}
+
+func TestCallMissingPositionalArg(t *testing.T) {
+	source := `
+def f(a, b):
+    return a + b
+
+f(1)
+`
+	runCodeExpectError(t, source, "f() missing 1 required positional argument: 'b'")
+}
+
+func TestCallTooManyPositionalArgs(t *testing.T) {
+	source := `
+def f(a, b):
+    return a + b
+
+f(1, 2, 3)
+`
+	runCodeExpectError(t, source, "f() takes 2 positional arguments but 3 were given")
+}
+
+func TestCallTooManyWithDefaults(t *testing.T) {
+	source := `
+def f(a, b=2):
+    return a + b
+
+f(1, 2, 3)
+`
+	runCodeExpectError(t, source, "f() takes from 1 to 2 positional arguments but 3 were given")
+}
+
+func TestCallUnexpectedKeyword(t *testing.T) {
+	source := `
+def f(a):
+    return a
+
+f(1, x=2)
+`
+	runCodeExpectError(t, source, "f() got an unexpected keyword argument 'x'")
+}
+
+func TestCallMultipleValuesForArg(t *testing.T) {
+	source := `
+def f(a, b):
+    return a + b
+
+f(1, a=2)
+`
+	runCodeExpectError(t, source, "f() got multiple values for argument 'a'")
+}
+
+func TestCallMissingMultipleArgs(t *testing.T) {
+	source := `
+def f(a, b, c):
+    return a
+
+f()
+`
+	runCodeExpectError(t, source, "f() missing 3 required positional arguments: 'a', 'b' and 'c'")
+}
@@ -0,0 +1,119 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameMathVec2Operators(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import gamemath
+a = gamemath.Vec2(1.0, 2.0)
+b = gamemath.Vec2(3.0, 4.0)
+added = repr(a + b)
+scaled = repr(a * 2)
+rscaled = repr(2 * a)
+halved = repr(b / 2)
+negated = repr(-a)
+dotted = a @ b
+same = a == gamemath.Vec2(1.0, 2.0)
+diff = a == b
+`)
+	assert.Equal(t, "Vec2(4.0, 6.0)", vm.GetGlobal("added").(*runtime.PyString).Value)
+	assert.Equal(t, "Vec2(2.0, 4.0)", vm.GetGlobal("scaled").(*runtime.PyString).Value)
+	assert.Equal(t, "Vec2(2.0, 4.0)", vm.GetGlobal("rscaled").(*runtime.PyString).Value)
+	assert.Equal(t, "Vec2(1.5, 2.0)", vm.GetGlobal("halved").(*runtime.PyString).Value)
+	assert.Equal(t, "Vec2(-1.0, -2.0)", vm.GetGlobal("negated").(*runtime.PyString).Value)
+	assert.Equal(t, 11.0, vm.GetGlobal("dotted").(*runtime.PyFloat).Value)
+	assert.Equal(t, true, vm.GetGlobal("same").(*runtime.PyBool).Value)
+	assert.Equal(t, false, vm.GetGlobal("diff").(*runtime.PyBool).Value)
+}
+
+func TestGameMathVec3Methods(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import gamemath
+v = gamemath.Vec3(3.0, 0.0, 4.0)
+l = v.length()
+unit = v.normalize()
+unit_len = unit.length()
+crossed = repr(gamemath.Vec3(1, 0, 0).cross(gamemath.Vec3(0, 1, 0)))
+halfway = repr(gamemath.Vec3(0, 0, 0).lerp(gamemath.Vec3(10, 20, 30), 0.5))
+x = v.x
+z = v.z
+`)
+	assert.Equal(t, 5.0, vm.GetGlobal("l").(*runtime.PyFloat).Value)
+	assert.InDelta(t, 1.0, vm.GetGlobal("unit_len").(*runtime.PyFloat).Value, 1e-12)
+	assert.Equal(t, "Vec3(0.0, 0.0, 1.0)", vm.GetGlobal("crossed").(*runtime.PyString).Value)
+	assert.Equal(t, "Vec3(5.0, 10.0, 15.0)", vm.GetGlobal("halfway").(*runtime.PyString).Value)
+	assert.Equal(t, 3.0, vm.GetGlobal("x").(*runtime.PyFloat).Value)
+	assert.Equal(t, 4.0, vm.GetGlobal("z").(*runtime.PyFloat).Value)
+}
+
+func TestGameMathMat3(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import gamemath
+ident = gamemath.Mat3()
+v = gamemath.Vec3(1.0, 2.0, 3.0)
+unchanged = ident * v == v
+moved = repr(gamemath.translation(10, 20) * gamemath.Vec2(1.0, 2.0))
+det = ident.determinant()
+double = gamemath.Mat3() * 2
+ddet = double.determinant()
+`)
+	assert.Equal(t, true, vm.GetGlobal("unchanged").(*runtime.PyBool).Value)
+	assert.Equal(t, "Vec2(11.0, 22.0)", vm.GetGlobal("moved").(*runtime.PyString).Value)
+	assert.Equal(t, 1.0, vm.GetGlobal("det").(*runtime.PyFloat).Value)
+	assert.Equal(t, 8.0, vm.GetGlobal("ddet").(*runtime.PyFloat).Value)
+}
+
+func TestGameMathRotation(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import gamemath
+import math
+r = gamemath.rotation(math.pi / 2)
+turned = r * gamemath.Vec2(1.0, 0.0)
+x = turned.x
+y = turned.y
+`)
+	assert.InDelta(t, 0.0, vm.GetGlobal("x").(*runtime.PyFloat).Value, 1e-12)
+	assert.InDelta(t, 1.0, vm.GetGlobal("y").(*runtime.PyFloat).Value, 1e-12)
+}
+
+func TestGameMathConversionHooks(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import gamemath
+
+class Pos:
+    def __init__(self, x, y, z):
+        self.x = x
+        self.y = y
+        self.z = z
+
+p = Pos(1.0, 2.0, 3.0)
+d = gamemath.dot(p, gamemath.Vec3(1.0, 1.0, 1.0))
+from_seq = repr(gamemath.Vec2((4.0, 5.0)))
+from_obj = repr(gamemath.Vec3(p))
+scalar_lerp = gamemath.lerp(0.0, 10.0, 0.25)
+`)
+	assert.Equal(t, 6.0, vm.GetGlobal("d").(*runtime.PyFloat).Value)
+	assert.Equal(t, "Vec2(4.0, 5.0)", vm.GetGlobal("from_seq").(*runtime.PyString).Value)
+	assert.Equal(t, "Vec3(1.0, 2.0, 3.0)", vm.GetGlobal("from_obj").(*runtime.PyString).Value)
+	assert.Equal(t, 2.5, vm.GetGlobal("scalar_lerp").(*runtime.PyFloat).Value)
+}
+
+func TestGameMathErrors(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import gamemath
+gamemath.Vec2(1.0, 2.0) / 0
+`, "ZeroDivisionError")
+	runCodeExpectErrorWithStdlib(t, `
+import gamemath
+gamemath.Vec3(0.0, 0.0, 0.0).normalize()
+`, "zero-length")
+	runCodeExpectErrorWithStdlib(t, `
+import gamemath
+gamemath.Vec2(1.0, 2.0) + "x"
+`, "unsupported operand type")
+}
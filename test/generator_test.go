@@ -657,3 +657,146 @@ result = list(gen())
 	require.Len(t, result.Items, 1)
 	assert.Equal(t, int64(-10), result.Items[0].(*runtime.PyInt).Value)
 }
+
+// =====================================
+// yield from delegation (PEP 380)
+// =====================================
+
+func TestYieldFromSendPassesThrough(t *testing.T) {
+	source := `
+def inner():
+    x = yield 1
+    yield x
+
+def outer():
+    yield from inner()
+
+g = outer()
+first = next(g)
+sent = g.send("hello")
+`
+	vm := runCode(t, source)
+	assert.Equal(t, int64(1), vm.GetGlobal("first").(*runtime.PyInt).Value)
+	assert.Equal(t, "hello", vm.GetGlobal("sent").(*runtime.PyString).Value)
+}
+
+func TestYieldFromReturnsGeneratorValue(t *testing.T) {
+	source := `
+def inner():
+    yield 1
+    return "inner-done"
+
+def outer():
+    result = yield from inner()
+    yield result
+
+g = outer()
+next(g)
+result = next(g)
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "inner-done", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestStopIterationValueAttribute(t *testing.T) {
+	source := `
+def gen():
+    return 42
+    yield
+
+g = gen()
+try:
+    next(g)
+except StopIteration as e:
+    value = e.value
+`
+	vm := runCode(t, source)
+	assert.Equal(t, int64(42), vm.GetGlobal("value").(*runtime.PyInt).Value)
+}
+
+func TestYieldFromThrowHandledByInner(t *testing.T) {
+	source := `
+def inner():
+    try:
+        yield 1
+    except ValueError:
+        yield "caught"
+
+def outer():
+    yield from inner()
+
+g = outer()
+first = next(g)
+caught = g.throw(ValueError)
+`
+	vm := runCode(t, source)
+	assert.Equal(t, int64(1), vm.GetGlobal("first").(*runtime.PyInt).Value)
+	assert.Equal(t, "caught", vm.GetGlobal("caught").(*runtime.PyString).Value)
+}
+
+func TestYieldFromThrowEscapesToOuter(t *testing.T) {
+	source := `
+def inner():
+    yield 1
+
+def outer():
+    try:
+        yield from inner()
+    except ValueError:
+        yield "outer caught"
+
+g = outer()
+next(g)
+result = g.throw(ValueError)
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "outer caught", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestYieldFromThrowFinishesInner(t *testing.T) {
+	source := `
+def inner():
+    try:
+        yield 1
+    except ValueError:
+        return "inner-finished"
+
+def outer():
+    result = yield from inner()
+    yield result
+
+g = outer()
+next(g)
+result = g.throw(ValueError)
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "inner-finished", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestYieldFromClosePropagates(t *testing.T) {
+	source := `
+closed = []
+
+def inner():
+    try:
+        yield 1
+    finally:
+        closed.append("inner")
+
+def outer():
+    try:
+        yield from inner()
+    finally:
+        closed.append("outer")
+
+g = outer()
+next(g)
+g.close()
+order = closed
+`
+	vm := runCode(t, source)
+	order := vm.GetGlobal("order").(*runtime.PyList)
+	require.Len(t, order.Items, 2)
+	assert.Equal(t, "inner", order.Items[0].(*runtime.PyString).Value)
+	assert.Equal(t, "outer", order.Items[1].(*runtime.PyString).Value)
+}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Gettext Module Tests
+// =============================================================================
+
+func TestGettextInstallAndLookup(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import gettext
+gettext.install({
+    "hello": "bonjour",
+    "%d apple": ["%d pomme", "%d pommes"],
+})
+_ = gettext.gettext
+hello = _("hello")
+untranslated = _("goodbye")
+one = gettext.ngettext("%d apple", "%d apples", 1)
+many = gettext.ngettext("%d apple", "%d apples", 3)
+fallback_many = gettext.ngettext("%d pear", "%d pears", 2)
+`)
+	assert.Equal(t, "bonjour", vm.GetGlobal("hello").(*runtime.PyString).Value)
+	assert.Equal(t, "goodbye", vm.GetGlobal("untranslated").(*runtime.PyString).Value)
+	assert.Equal(t, "%d pomme", vm.GetGlobal("one").(*runtime.PyString).Value)
+	assert.Equal(t, "%d pommes", vm.GetGlobal("many").(*runtime.PyString).Value)
+	assert.Equal(t, "%d pears", vm.GetGlobal("fallback_many").(*runtime.PyString).Value)
+}
+
+func TestGettextLoadCatalogFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "de.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"hello": "hallo",
+		"%d item": ["%d Sache", "%d Sachen"]
+	}`), 0o644))
+
+	vm := runCodeWithStdlib(t, fmt.Sprintf(`
+import gettext
+gettext.load(%q)
+hello = gettext.gettext("hello")
+two = gettext.ngettext("%%d item", "%%d items", 2)
+`, path))
+	assert.Equal(t, "hallo", vm.GetGlobal("hello").(*runtime.PyString).Value)
+	assert.Equal(t, "%d Sachen", vm.GetGlobal("two").(*runtime.PyString).Value)
+}
+
+func TestGettextErrors(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import gettext
+gettext.install("not a dict")
+`, "must be a dict")
+
+	runCodeExpectErrorWithStdlib(t, `
+import gettext
+gettext.load("/no/such/catalog.json")
+`, "FileNotFoundError")
+
+	runCodeExpectErrorWithStdlib(t, `
+import gettext
+gettext.install({"key": ["only-one"]})
+`, "[singular, plural]")
+}
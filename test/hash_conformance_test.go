@@ -0,0 +1,63 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// Hash Conformance Tests
+// =============================================================================
+
+func TestUserHashConsistentWithEq(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+class Point:
+    def __init__(self, x, y):
+        self.x = x
+        self.y = y
+
+    def __eq__(self, other):
+        return self.x == other.x and self.y == other.y
+
+    def __hash__(self):
+        return hash((self.x, self.y))
+
+d = {Point(1, 2): "a"}
+found = d[Point(1, 2)]
+same_hash = hash(Point(1, 2)) == hash(Point(1, 2))
+`)
+	assert.Equal(t, "a", vm.GetGlobal("found").(*runtime.PyString).Value)
+	assert.True(t, vm.GetGlobal("same_hash").(*runtime.PyBool).Value)
+}
+
+func TestUserHashHonoredInsideTuple(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+class Key:
+    def __init__(self, n):
+        self.n = n
+
+    def __eq__(self, other):
+        return self.n == other.n
+
+    def __hash__(self):
+        return hash(self.n)
+
+d = {(Key(1), "x"): True}
+hit = (Key(1), "x") in d
+`)
+	assert.True(t, vm.GetGlobal("hit").(*runtime.PyBool).Value)
+}
+
+func TestMixedTupleAndFrozensetKeys(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+d = {}
+d[(1, "a", 2.5)] = 1
+d[frozenset(["x", "y"])] = 2
+t_hit = d[(1.0, "a", 2.5)]
+f_hit = d[frozenset(["y", "x"])]
+`)
+	assert.Equal(t, int64(1), vm.GetGlobal("t_hit").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), vm.GetGlobal("f_hit").(*runtime.PyInt).Value)
+}
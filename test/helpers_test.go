@@ -102,6 +102,20 @@ func runCodeWithStdlib(t *testing.T, source string) *runtime.VM {
 	return vm
 }
 
+// runCodeExpectErrorWithStdlib initializes stdlib modules, then compiles and
+// executes source code, expecting an execution error containing expectedErrSubstr.
+func runCodeExpectErrorWithStdlib(t *testing.T, source string, expectedErrSubstr string) {
+	t.Helper()
+	runtime.ResetModules()
+	stdlib.InitAllModules()
+	vm := runtime.NewVM()
+	code, errs := compiler.CompileSource(source, "<test>")
+	require.Empty(t, errs, "Compilation errors: %v", errs)
+	_, err := vm.Execute(code)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), expectedErrSubstr)
+}
+
 // newStdlibVM initializes stdlib modules and returns a fresh VM.
 func newStdlibVM(t *testing.T) *runtime.VM {
 	t.Helper()
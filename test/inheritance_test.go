@@ -893,3 +893,105 @@ result = Child.class_method()
 	result := vm.GetGlobal("result")
 	assert.Equal(t, "Child:Base", result.(*runtime.PyString).Value)
 }
+
+// TestSuperZeroArgInNestedFunction tests zero-arg super() inside a function
+// defined within a method body
+func TestSuperZeroArgInNestedFunction(t *testing.T) {
+	source := `
+class A:
+    def greet(self):
+        return "A"
+
+class B(A):
+    def greet(self):
+        def inner():
+            return super().greet() + "B"
+        return inner()
+
+result = B().greet()
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "AB", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+// TestSuperZeroArgInComprehension tests zero-arg super() inside a list
+// comprehension in a method body
+func TestSuperZeroArgInComprehension(t *testing.T) {
+	source := `
+class A:
+    def greet(self):
+        return "A"
+
+class B(A):
+    def greet(self):
+        return [super().greet() for _ in range(3)]
+
+result = B().greet()
+`
+	vm := runCode(t, source)
+	result := vm.GetGlobal("result").(*runtime.PyList)
+	require.Len(t, result.Items, 3)
+	for _, item := range result.Items {
+		assert.Equal(t, "A", item.(*runtime.PyString).Value)
+	}
+}
+
+// TestSuperZeroArgInLambda tests zero-arg super() inside a lambda defined in
+// a method body
+func TestSuperZeroArgInLambda(t *testing.T) {
+	source := `
+class A:
+    def greet(self):
+        return "A"
+
+class B(A):
+    def greet(self):
+        g = lambda: super().greet() + "B"
+        return g()
+
+result = B().greet()
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "AB", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+// TestSuperZeroArgInDecoratedClassmethod tests zero-arg super() in a
+// classmethod created through a plain decorator
+func TestSuperZeroArgInDecoratedClassmethod(t *testing.T) {
+	source := `
+def as_classmethod(f):
+    return classmethod(f)
+
+class A:
+    @classmethod
+    def make(cls):
+        return "A"
+
+class B(A):
+    @as_classmethod
+    def make(cls):
+        return super().make() + "B"
+
+result = B.make()
+`
+	vm := runCode(t, source)
+	assert.Equal(t, "AB", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+// TestSuperZeroArgInGeneratorExpression documents that zero-arg super()
+// inside a generator expression cannot bind self (the generator runs in its
+// own frame), matching CPython behavior
+func TestSuperZeroArgInGeneratorExpression(t *testing.T) {
+	source := `
+class A:
+    def greet(self):
+        return "A"
+
+class B(A):
+    def greet(self):
+        return next(super().greet() for _ in range(1))
+
+B().greet()
+`
+	runCodeExpectError(t, source, "super()")
+}
@@ -0,0 +1,83 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// Augmented Assignment Dunder Tests
+// =============================================================================
+
+func TestAllInplaceDundersDispatch(t *testing.T) {
+	ops := []struct {
+		dunder string
+		op     string
+	}{
+		{"__iadd__", "+="},
+		{"__isub__", "-="},
+		{"__imul__", "*="},
+		{"__itruediv__", "/="},
+		{"__ifloordiv__", "//="},
+		{"__imod__", "%="},
+		{"__ipow__", "**="},
+		{"__iand__", "&="},
+		{"__ior__", "|="},
+		{"__ixor__", "^="},
+		{"__ilshift__", "<<="},
+		{"__irshift__", ">>="},
+	}
+	for _, tc := range ops {
+		t.Run(tc.dunder, func(t *testing.T) {
+			vm := runCodeWithStdlib(t, fmt.Sprintf(`
+class Box:
+    def %s(self, other):
+        self.last = other
+        return self
+
+b = Box()
+b %s 7
+got = b.last
+`, tc.dunder, tc.op))
+			assert.Equal(t, int64(7), vm.GetGlobal("got").(*runtime.PyInt).Value, tc.dunder)
+		})
+	}
+}
+
+func TestInplaceFallsBackToBinary(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+class Count:
+    def __init__(self, n):
+        self.n = n
+
+    def __add__(self, other):
+        return Count(self.n + other)
+
+c = Count(1)
+c += 4
+n = c.n
+`)
+	assert.Equal(t, int64(5), vm.GetGlobal("n").(*runtime.PyInt).Value)
+}
+
+func TestInplaceNotImplementedFallsBack(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+class Picky:
+    def __init__(self, n):
+        self.n = n
+
+    def __imul__(self, other):
+        return NotImplemented
+
+    def __mul__(self, other):
+        return Picky(self.n * other)
+
+p = Picky(3)
+p *= 4
+n = p.n
+`)
+	assert.Equal(t, int64(12), vm.GetGlobal("n").(*runtime.PyInt).Value)
+}
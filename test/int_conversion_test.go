@@ -0,0 +1,75 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Integer String Conversion Tests
+// =============================================================================
+
+func TestIntUnderscores(t *testing.T) {
+	vm := runCode(t, `
+a = int("1_000")
+b = int("-1_234_567")
+c = int("0x_1f", 0)
+d = int("1_0", 2)
+`)
+	assert.Equal(t, int64(1000), vm.GetGlobal("a").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(-1234567), vm.GetGlobal("b").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(31), vm.GetGlobal("c").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), vm.GetGlobal("d").(*runtime.PyInt).Value)
+}
+
+func TestIntUnderscorePlacementRejected(t *testing.T) {
+	for _, bad := range []string{
+		`int("1__0")`,
+		`int("_10")`,
+		`int("10_")`,
+		`int("-_10")`,
+	} {
+		runCodeExpectError(t, bad, "invalid literal for int()")
+	}
+}
+
+func TestIntBaseZeroAutoDetect(t *testing.T) {
+	vm := runCode(t, `
+hexa = int("0x1f", 0)
+octal = int("0o17", 0)
+binary = int("0b101", 0)
+decimal = int("42", 0)
+zero = int("0_0", 0)
+`)
+	assert.Equal(t, int64(31), vm.GetGlobal("hexa").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(15), vm.GetGlobal("octal").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(5), vm.GetGlobal("binary").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(42), vm.GetGlobal("decimal").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(0), vm.GetGlobal("zero").(*runtime.PyInt).Value)
+	runCodeExpectError(t, `int("0755", 0)`, "invalid literal for int() with base 0")
+}
+
+func TestIntMaxStrDigitsDefault(t *testing.T) {
+	runCodeExpectError(t, `int("9" * 5000)`, "Exceeds the limit (4300 digits)")
+}
+
+func TestIntMaxStrDigitsConfigurable(t *testing.T) {
+	source := `n = int("9" * 5000)`
+	code, errs := compiler.CompileSource(source, "<test>")
+	require.Empty(t, errs)
+
+	vm := runtime.NewVM()
+	vm.SetIntMaxStrDigits(100)
+	_, err := vm.Execute(code)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Exceeds the limit (100 digits)")
+
+	vm = runtime.NewVM()
+	vm.SetIntMaxStrDigits(0) // disabled
+	_, err = vm.Execute(code)
+	require.NoError(t, err)
+}
@@ -140,3 +140,45 @@ def test_slots_tuple():
     expect(obj.a + obj.b).to_be(3)
 
 test("slots with tuple", test_slots_tuple)
+
+# Test 10: Subclass without __slots__ regains __dict__
+def test_subclass_without_slots():
+    class Base:
+        __slots__ = ['x']
+
+    class Child(Base):
+        pass
+
+    c = Child()
+    c.x = 1
+    c.extra = 2
+    expect(c.x).to_be(1)
+    expect(c.extra).to_be(2)
+    expect(hasattr(c, '__dict__')).to_be(True)
+
+test("subclass without slots regains dict", test_subclass_without_slots)
+
+# Test 11: __dict__ in __slots__ allows arbitrary attributes
+def test_dict_in_slots():
+    class Flexible:
+        __slots__ = ('a', '__dict__')
+
+    f = Flexible()
+    f.a = 1
+    f.anything = 2
+    expect(f.a + f.anything).to_be(3)
+
+test("__dict__ in slots", test_dict_in_slots)
+
+# Test 12: Slot name conflicting with a class variable is an error
+def test_slot_class_var_conflict():
+    error = None
+    try:
+        class Conflict:
+            __slots__ = ('v',)
+            v = 3
+    except ValueError:
+        error = True
+    expect(error).to_be(True)
+
+test("slot conflicts with class variable", test_slot_class_var_conflict)
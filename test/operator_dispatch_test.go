@@ -0,0 +1,96 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// Binary Operator Dispatch Tests
+// =============================================================================
+
+func TestReflectedAddFallback(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+class Money:
+    def __init__(self, cents):
+        self.cents = cents
+
+    def __radd__(self, other):
+        return Money(other + self.cents)
+
+total = 100 + Money(42)
+cents = total.cents
+`)
+	assert.Equal(t, int64(142), vm.GetGlobal("cents").(*runtime.PyInt).Value)
+}
+
+func TestNotImplementedFallsBackToReflected(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+class Left:
+    def __add__(self, other):
+        return NotImplemented
+
+class Right:
+    def __radd__(self, other):
+        return "reflected"
+
+result = Left() + Right()
+`)
+	assert.Equal(t, "reflected", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestSubclassReflectedTakesPrecedence(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+class Base:
+    def __add__(self, other):
+        return "base"
+
+    def __radd__(self, other):
+        return "base reflected"
+
+class Sub(Base):
+    def __radd__(self, other):
+        return "sub reflected"
+
+result = Base() + Sub()
+`)
+	assert.Equal(t, "sub reflected", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestSameClassSkipsReflected(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+calls = []
+
+class Frac:
+    def __add__(self, other):
+        calls.append("add")
+        return NotImplemented
+
+    def __radd__(self, other):
+        calls.append("radd")
+        return NotImplemented
+
+try:
+    Frac() + Frac()
+except TypeError:
+    pass
+count = len(calls)
+first = calls[0]
+`)
+	assert.Equal(t, int64(1), vm.GetGlobal("count").(*runtime.PyInt).Value)
+	assert.Equal(t, "add", vm.GetGlobal("first").(*runtime.PyString).Value)
+}
+
+func TestAllNotImplementedRaisesTypeError(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+class A:
+    pass
+
+class B:
+    pass
+
+A() + B()
+`, "unsupported operand type(s) for +: 'A' and 'B'")
+}
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Filesystem Package Import Tests
+// =============================================================================
+
+// writeProject lays out a small on-disk project under a temp dir.
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+	}
+	return dir
+}
+
+func TestImportSiblingModuleFromDirectory(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"helpers.py": "def twice(n):\n    return n * 2\n",
+		"main.py":    "import helpers\nresult = helpers.twice(21)\n",
+	})
+
+	state := newRageState(t)
+	defer state.Close()
+	src, err := os.ReadFile(filepath.Join(dir, "main.py"))
+	require.NoError(t, err)
+	_, err = state.RunWithFilename(string(src), filepath.Join(dir, "main.py"))
+	require.NoError(t, err)
+
+	n, ok := state.GetGlobalInt("result")
+	require.True(t, ok)
+	assert.Equal(t, int64(42), n)
+}
+
+func TestImportPackageWithInit(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"proj/__init__.py": "def greet(who):\n    return 'hello ' + who\n",
+		"proj/sub.py":      "VALUE = 99\n",
+		"main.py":          "from proj import greet\nfrom proj.sub import VALUE\na = greet('world')\nb = VALUE\n",
+	})
+
+	state := newRageState(t)
+	defer state.Close()
+	src, err := os.ReadFile(filepath.Join(dir, "main.py"))
+	require.NoError(t, err)
+	_, err = state.RunWithFilename(string(src), filepath.Join(dir, "main.py"))
+	require.NoError(t, err)
+
+	s, ok := state.GetGlobalString("a")
+	require.True(t, ok)
+	assert.Equal(t, "hello world", s)
+	n, ok := state.GetGlobalInt("b")
+	require.True(t, ok)
+	assert.Equal(t, int64(99), n)
+}
+
+func TestImportDottedSubmoduleAttribute(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"proj/__init__.py": "",
+		"proj/inner.py":    "def ping():\n    return 'pong'\n",
+		"main.py":          "import proj.inner\nresult = proj.inner.ping()\n",
+	})
+
+	state := newRageState(t)
+	defer state.Close()
+	src, err := os.ReadFile(filepath.Join(dir, "main.py"))
+	require.NoError(t, err)
+	_, err = state.RunWithFilename(string(src), filepath.Join(dir, "main.py"))
+	require.NoError(t, err)
+
+	s, ok := state.GetGlobalString("result")
+	require.True(t, ok)
+	assert.Equal(t, "pong", s)
+}
@@ -0,0 +1,83 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// Pprint Module Tests
+// =============================================================================
+
+func TestPformatShortStaysOneLine(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import pprint
+s = pprint.pformat([1, 2, 3])
+`)
+	assert.Equal(t, "[1, 2, 3]", vm.GetGlobal("s").(*runtime.PyString).Value)
+}
+
+func TestPformatWrapsAtWidth(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import pprint
+s = pprint.pformat([100, 200, 300], width=10)
+`)
+	assert.Equal(t, "[100,\n 200,\n 300]", vm.GetGlobal("s").(*runtime.PyString).Value)
+}
+
+func TestPformatIndent(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import pprint
+s = pprint.pformat([100, 200], width=5, indent=3)
+`)
+	assert.Equal(t, "[100,\n   200]", vm.GetGlobal("s").(*runtime.PyString).Value)
+}
+
+func TestPformatSortDicts(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import pprint
+d = {"b": 2, "a": 1}
+sorted_out = pprint.pformat(d)
+raw_out = pprint.pformat(d, sort_dicts=False)
+`)
+	assert.Equal(t, "{'a': 1, 'b': 2}", vm.GetGlobal("sorted_out").(*runtime.PyString).Value)
+	assert.Equal(t, "{'b': 2, 'a': 1}", vm.GetGlobal("raw_out").(*runtime.PyString).Value)
+}
+
+func TestPformatCycle(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import pprint
+a = [1]
+a.append(a)
+s = pprint.pformat(a)
+`)
+	assert.Equal(t, "[1, [...]]", vm.GetGlobal("s").(*runtime.PyString).Value)
+}
+
+func TestReprSelfReferentialContainers(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+a = [1]
+a.append(a)
+list_repr = repr(a)
+
+d = {}
+d["self"] = d
+dict_repr = repr(d)
+`)
+	assert.Equal(t, "[1, [...]]", vm.GetGlobal("list_repr").(*runtime.PyString).Value)
+	assert.Equal(t, "{'self': {...}}", vm.GetGlobal("dict_repr").(*runtime.PyString).Value)
+}
+
+func TestPprintErrors(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import pprint
+pprint.pformat()
+`, "takes exactly 1 positional argument")
+
+	runCodeExpectErrorWithStdlib(t, `
+import pprint
+pprint.pformat([1], width="wide")
+`, "width must be an int")
+}
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Range Length, Indexing, and Slicing Tests
+// =============================================================================
+
+func TestRangeLenOfVariable(t *testing.T) {
+	vm := runCode(t, `
+r = range(0, 100, 3)
+n = len(r)
+empty = len(range(5, 5))
+down = len(range(10, 0, -3))
+`)
+	assert.Equal(t, int64(34), vm.GetGlobal("n").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(0), vm.GetGlobal("empty").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(4), vm.GetGlobal("down").(*runtime.PyInt).Value)
+}
+
+func TestRangeLenHugeWithoutMaterializing(t *testing.T) {
+	vm := runCode(t, `
+n = len(range(0, 1000000000000000000, 3))
+`)
+	assert.Equal(t, int64(333333333333333334), vm.GetGlobal("n").(*runtime.PyInt).Value)
+}
+
+func TestRangeIndexing(t *testing.T) {
+	vm := runCode(t, `
+r = range(0, 100, 3)
+a = r[5]
+b = r[-1]
+c = range(0, 1000000000000000000, 3)[333333333333333333]
+`)
+	assert.Equal(t, int64(15), vm.GetGlobal("a").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(99), vm.GetGlobal("b").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(999999999999999999), vm.GetGlobal("c").(*runtime.PyInt).Value)
+	runCodeExpectError(t, `range(0, 10, 3)[10]`, "range object index out of range")
+}
+
+func TestRangeSlicingReturnsRange(t *testing.T) {
+	vm := runCode(t, `
+r = range(0, 100, 3)
+mid = r[2:8]
+mid_type = type(mid).__name__
+mid_items = list(mid)
+every_other = list(r[::2])[:4]
+rev = r[::-1]
+rev_first = rev[0]
+rev_items = list(rev)[:3]
+clamped = list(range(10)[3:100])
+`)
+	assert.Equal(t, "range", vm.GetGlobal("mid_type").(*runtime.PyString).Value)
+	mid := vm.GetGlobal("mid_items").(*runtime.PyList)
+	require.Len(t, mid.Items, 6)
+	assert.Equal(t, int64(6), mid.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(21), mid.Items[5].(*runtime.PyInt).Value)
+	every := vm.GetGlobal("every_other").(*runtime.PyList)
+	assert.Equal(t, int64(12), every.Items[2].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(99), vm.GetGlobal("rev_first").(*runtime.PyInt).Value)
+	rev := vm.GetGlobal("rev_items").(*runtime.PyList)
+	assert.Equal(t, int64(96), rev.Items[1].(*runtime.PyInt).Value)
+	clamped := vm.GetGlobal("clamped").(*runtime.PyList)
+	require.Len(t, clamped.Items, 7)
+	assert.Equal(t, int64(9), clamped.Items[6].(*runtime.PyInt).Value)
+}
+
+func TestRangeIterationStaysLazy(t *testing.T) {
+	vm := runCode(t, `
+total = 0
+for i in range(1000000):
+    total += i
+first = None
+for x in range(0, 1000000000000000000, 7):
+    first = x
+    break
+`)
+	assert.Equal(t, int64(499999500000), vm.GetGlobal("total").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(0), vm.GetGlobal("first").(*runtime.PyInt).Value)
+}
+
+func TestRangeRejectsBigIntBounds(t *testing.T) {
+	runCodeExpectError(t, `range(123456789012345678901234567890)`,
+		"OverflowError: Python int too large to convert to C ssize_t")
+}
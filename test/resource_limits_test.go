@@ -3,6 +3,7 @@ package test
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ATSOTECK/rage/internal/compiler"
 	"github.com/ATSOTECK/rage/internal/runtime"
@@ -410,3 +411,55 @@ func TestResourceLimitsAllocatedBytes(t *testing.T) {
 	// Should have tracked some bytes
 	assert.Greater(t, state.AllocatedBytes(), int64(0))
 }
+
+// =============================================================================
+// Interrupt Checkpoints in Go-Native Loops
+//
+// Long-running builtins (sorting, regex replace loops) must hit cancellation
+// checkpoints so RunWithTimeout can interrupt them (see VM.CheckInterrupt).
+// =============================================================================
+
+func TestTimeoutInterruptsRegexReplaceLoop(t *testing.T) {
+	runtime.ResetModules()
+	stdlib.InitAllModules()
+	vm := runtime.NewVM()
+
+	// re.subn with an explicit count walks the string once per replacement,
+	// which is quadratic and never passes through the dispatch loop.
+	source := `
+import re
+re.subn("a", "b", "a" * 100000, 100000)
+`
+	code, errs := compiler.CompileSource(source, "<test>")
+	require.Empty(t, errs)
+
+	_, err := vm.ExecuteWithTimeout(200*time.Millisecond, code)
+	require.Error(t, err)
+	var timeoutErr *runtime.TimeoutError
+	assert.ErrorAs(t, err, &timeoutErr, "expected TimeoutError, got: %v", err)
+}
+
+func TestTimeoutInterruptsNativeSort(t *testing.T) {
+	vm := runtime.NewVM()
+	vm.SetCheckInterval(100)
+
+	// Sorting with an always-equal comparison result is valid but slow for a
+	// large list; the comparator checkpoint must allow interruption.
+	source := `
+items = list(range(2000000))
+items.sort(reverse=True)
+items.sort()
+items.sort(reverse=True)
+`
+	code, errs := compiler.CompileSource(source, "<test>")
+	require.Empty(t, errs)
+
+	start := time.Now()
+	_, err := vm.ExecuteWithTimeout(50*time.Millisecond, code)
+	if err == nil {
+		t.Skip("machine sorted too fast to observe the timeout")
+	}
+	var timeoutErr *runtime.TimeoutError
+	assert.ErrorAs(t, err, &timeoutErr, "expected TimeoutError, got: %v", err)
+	assert.Less(t, time.Since(start), 5*time.Second, "timeout should interrupt promptly")
+}
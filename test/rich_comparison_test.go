@@ -0,0 +1,114 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =====================================
+// Lexicographic comparison of lists and tuples
+// =====================================
+
+func assertGlobalBool(t *testing.T, vm *runtime.VM, name string, expected bool) {
+	t.Helper()
+	result := vm.GetGlobal(name).(*runtime.PyBool)
+	assert.Equal(t, expected, result.Value, "global %s", name)
+}
+
+func TestListTupleLexicographicComparison(t *testing.T) {
+	vm := runCode(t, `
+a = [1, 2] < [1, 3]
+b = (1, 2) <= (1, 2)
+c = [1, 2, 3] > [1, 2]
+d = (2,) >= (1, 9)
+e = [[1, 2], [3]] < [[1, 2], [4]]
+f = ((1, (2, 3)),) < ((1, (2, 4)),)
+g = [1, 1.5] < [1, 2]
+h = [True] < [2]
+`)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		assertGlobalBool(t, vm, name, true)
+	}
+}
+
+func TestListComparisonDispatchesElementDunders(t *testing.T) {
+	vm := runCode(t, `
+class Lt:
+    def __init__(self, n):
+        self.n = n
+    def __lt__(self, other):
+        return self.n < other.n
+
+class Gt:
+    def __init__(self, n):
+        self.n = n
+    def __gt__(self, other):
+        return self.n > other.n
+
+a = [Lt(1)] < [Lt(2)]
+b = [Lt(2)] < [Lt(1)]
+c = [Gt(1)] < [Gt(2)]
+d = (Lt(1), "x") < (Lt(2), "a")
+`)
+	assertGlobalBool(t, vm, "a", true)
+	assertGlobalBool(t, vm, "b", false)
+	// Only __gt__ defined: the reflected operator must be used
+	assertGlobalBool(t, vm, "c", true)
+	// First element decides, second is never compared
+	assertGlobalBool(t, vm, "d", true)
+}
+
+func TestListComparisonUnorderableElements(t *testing.T) {
+	runCodeExpectError(t, `
+[1] < [None]
+`, "'<' not supported between instances of 'int' and 'NoneType'")
+
+	runCodeExpectError(t, `
+class P:
+    pass
+[P()] < [P()]
+`, "'<' not supported between instances of 'P' and 'P'")
+}
+
+// =====================================
+// sort/sorted and min/max with custom orderings
+// =====================================
+
+func TestSortWithOnlyDunderLt(t *testing.T) {
+	vm := runCode(t, `
+class V:
+    def __init__(self, n):
+        self.n = n
+    def __lt__(self, other):
+        return self.n < other.n
+
+lst = [V(3), V(1), V(2)]
+lst.sort()
+first = lst[0].n
+by_key = sorted([V(3), V(1), V(2)], key=lambda v: v)[0].n
+largest = max([V(1), V(4), V(2)]).n
+smallest = min([V(1), V(4), V(2)]).n
+`)
+	assert.Equal(t, int64(1), vm.GetGlobal("first").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(1), vm.GetGlobal("by_key").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(4), vm.GetGlobal("largest").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(1), vm.GetGlobal("smallest").(*runtime.PyInt).Value)
+}
+
+func TestSortUnorderableTypesMessage(t *testing.T) {
+	runCodeExpectError(t, `
+sorted([1, "a"])
+`, "'<' not supported between instances of 'str' and 'int'")
+
+	runCodeExpectError(t, `
+[3, None].sort()
+`, "'<' not supported between instances of 'NoneType' and 'int'")
+
+	runCodeExpectError(t, `
+class P:
+    pass
+sorted([P(), P()])
+`, "'<' not supported between instances of 'P' and 'P'")
+}
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// round() / format() Dunder Parity Tests
+// =============================================================================
+
+func TestFStringConsultsFormatDunder(t *testing.T) {
+	vm := runCode(t, `
+class Money:
+    def __init__(self, cents):
+        self.cents = cents
+    def __format__(self, spec):
+        if spec == "":
+            return "$" + str(self.cents / 100)
+        return spec + ":" + str(self.cents)
+    def __str__(self):
+        return "Money(" + str(self.cents) + ")"
+    def __repr__(self):
+        return "<Money " + str(self.cents) + ">"
+
+m = Money(250)
+plain = f"{m}"
+spec = f"{m:usd}"
+builtin = format(m)
+as_str = f"{m!s}"
+as_repr = f"{m!r}"
+`)
+	assert.Equal(t, "$2.5", vm.GetGlobal("plain").(*runtime.PyString).Value)
+	assert.Equal(t, "usd:250", vm.GetGlobal("spec").(*runtime.PyString).Value)
+	assert.Equal(t, "$2.5", vm.GetGlobal("builtin").(*runtime.PyString).Value)
+	assert.Equal(t, "Money(250)", vm.GetGlobal("as_str").(*runtime.PyString).Value)
+	assert.Equal(t, "<Money 250>", vm.GetGlobal("as_repr").(*runtime.PyString).Value)
+}
+
+func TestFStringWithoutFormatDunder(t *testing.T) {
+	vm := runCode(t, `
+a = f"{42}"
+b = f"{'hi'}"
+c = f"{None}"
+d = f"{[1, 2]}"
+`)
+	assert.Equal(t, "42", vm.GetGlobal("a").(*runtime.PyString).Value)
+	assert.Equal(t, "hi", vm.GetGlobal("b").(*runtime.PyString).Value)
+	assert.Equal(t, "None", vm.GetGlobal("c").(*runtime.PyString).Value)
+	assert.Equal(t, "[1, 2]", vm.GetGlobal("d").(*runtime.PyString).Value)
+}
+
+func TestRoundDunder(t *testing.T) {
+	vm := runCode(t, `
+class Dec:
+    def __init__(self, v):
+        self.v = v
+    def __round__(self, ndigits=None):
+        if ndigits is None:
+            return int(self.v)
+        return Dec(round(self.v, ndigits))
+
+d = Dec(2.675)
+whole = round(d)
+two = round(d, 2).v
+`)
+	assert.Equal(t, int64(2), vm.GetGlobal("whole").(*runtime.PyInt).Value)
+	assert.Equal(t, 2.68, vm.GetGlobal("two").(*runtime.PyFloat).Value)
+}
+
+func TestRoundNdigitsNone(t *testing.T) {
+	vm := runCode(t, `
+a = round(2.5, None)
+b = round(3.5, None)
+`)
+	assert.Equal(t, int64(2), vm.GetGlobal("a").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(4), vm.GetGlobal("b").(*runtime.PyInt).Value)
+}
+
+func TestRoundNdigitsIndex(t *testing.T) {
+	vm := runCode(t, `
+class Two:
+    def __index__(self):
+        return 2
+
+n = round(3.14159, Two())
+`)
+	assert.Equal(t, 3.14, vm.GetGlobal("n").(*runtime.PyFloat).Value)
+}
+
+func TestRoundRejectsNonNumeric(t *testing.T) {
+	runCodeExpectError(t, `round("2.5")`, "doesn't define __round__ method")
+	runCodeExpectError(t, `round([1])`, "doesn't define __round__ method")
+}
@@ -0,0 +1,221 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =====================================
+// Slice assignment and deletion
+// =====================================
+
+func assertIntList(t *testing.T, vm *runtime.VM, name string, expected []int64) {
+	t.Helper()
+	result := vm.GetGlobal(name).(*runtime.PyList)
+	require.Equal(t, len(expected), len(result.Items))
+	for i, want := range expected {
+		assert.Equal(t, want, result.Items[i].(*runtime.PyInt).Value)
+	}
+}
+
+func TestSliceAssignmentContiguous(t *testing.T) {
+	vm := runCode(t, `
+a = [0, 1, 2, 3, 4, 5]
+a[2:4] = [9, 9, 9]
+b = [0, 1, 2]
+b[1:1] = [7, 8]
+c = [0, 1, 2, 3, 4]
+c[-4:-1] = [6]
+`)
+	assertIntList(t, vm, "a", []int64{0, 1, 9, 9, 9, 4, 5})
+	assertIntList(t, vm, "b", []int64{0, 7, 8, 1, 2})
+	assertIntList(t, vm, "c", []int64{0, 6, 4})
+}
+
+func TestSliceAssignmentExtended(t *testing.T) {
+	vm := runCode(t, `
+a = [0, 1, 2, 3, 4]
+a[::2] = [10, 20, 30]
+b = [0, 1, 2, 3, 4]
+b[::-1] = [10, 20, 30, 40, 50]
+c = [0, 1, 2, 3, 4, 5]
+c[1::2] = [7, 8, 9]
+`)
+	assertIntList(t, vm, "a", []int64{10, 1, 20, 3, 30})
+	assertIntList(t, vm, "b", []int64{50, 40, 30, 20, 10})
+	assertIntList(t, vm, "c", []int64{0, 7, 2, 8, 4, 9})
+}
+
+func TestSliceAssignmentExtendedSizeMismatch(t *testing.T) {
+	runCodeExpectError(t, `
+a = [0, 1, 2, 3, 4]
+a[::2] = [1, 2]
+`, "attempt to assign sequence of size 2 to extended slice of size 3")
+}
+
+func TestSliceDeletionWithStep(t *testing.T) {
+	vm := runCode(t, `
+a = [0, 1, 2, 3, 4, 5]
+del a[::2]
+b = [0, 1, 2, 3, 4, 5]
+del b[::-2]
+c = [0, 1, 2, 3, 4]
+del c[1:3]
+`)
+	assertIntList(t, vm, "a", []int64{1, 3, 5})
+	assertIntList(t, vm, "b", []int64{0, 2, 4})
+	assertIntList(t, vm, "c", []int64{0, 3, 4})
+}
+
+func TestSliceDispatchesToInstanceDunders(t *testing.T) {
+	vm := runCode(t, `
+class Box:
+    def __init__(self):
+        self.calls = []
+    def __setitem__(self, key, value):
+        self.calls.append((key.start, key.stop, key.step, value))
+    def __delitem__(self, key):
+        self.calls.append((key.start, key.stop, key.step))
+
+b = Box()
+b[1:3] = "x"
+del b[::2]
+count = len(b.calls)
+set_start = b.calls[0][0]
+del_start = b.calls[1][0]
+del_step = b.calls[1][2]
+`)
+	count := vm.GetGlobal("count").(*runtime.PyInt)
+	assert.Equal(t, int64(2), count.Value)
+	setStart := vm.GetGlobal("set_start").(*runtime.PyInt)
+	assert.Equal(t, int64(1), setStart.Value)
+	// Omitted slice components arrive as None
+	assert.IsType(t, &runtime.PyNone{}, vm.GetGlobal("del_start"))
+	delStep := vm.GetGlobal("del_step").(*runtime.PyInt)
+	assert.Equal(t, int64(2), delStep.Value)
+}
+
+func TestSliceObjectAttributes(t *testing.T) {
+	vm := runCode(t, `
+s = slice(1, 10, 2)
+start = s.start
+stop = s.stop
+step = s.step
+t = slice(None, 5)
+t_start = t.start
+t_step = t.step
+`)
+	assert.Equal(t, int64(1), vm.GetGlobal("start").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(10), vm.GetGlobal("stop").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), vm.GetGlobal("step").(*runtime.PyInt).Value)
+	assert.IsType(t, &runtime.PyNone{}, vm.GetGlobal("t_start"))
+	assert.IsType(t, &runtime.PyNone{}, vm.GetGlobal("t_step"))
+}
+
+// =====================================
+// bytearray
+// =====================================
+
+func TestByteArrayConstruction(t *testing.T) {
+	vm := runCode(t, `
+empty = bytearray()
+zeros = bytearray(3)
+from_bytes = bytearray(b"abc")
+from_list = bytearray([65, 66, 67])
+r = repr(from_bytes)
+`)
+	empty := vm.GetGlobal("empty").(*runtime.PyByteArray)
+	assert.Empty(t, empty.Value)
+	zeros := vm.GetGlobal("zeros").(*runtime.PyByteArray)
+	assert.Equal(t, []byte{0, 0, 0}, zeros.Value)
+	fromBytes := vm.GetGlobal("from_bytes").(*runtime.PyByteArray)
+	assert.Equal(t, []byte("abc"), fromBytes.Value)
+	fromList := vm.GetGlobal("from_list").(*runtime.PyByteArray)
+	assert.Equal(t, []byte("ABC"), fromList.Value)
+	assert.Equal(t, "bytearray(b'abc')", vm.GetGlobal("r").(*runtime.PyString).Value)
+}
+
+func TestByteArrayIndexing(t *testing.T) {
+	vm := runCode(t, `
+ba = bytearray(b"hello")
+first = ba[0]
+last = ba[-1]
+ba[0] = 72
+ba[-1] = 79
+del ba[1]
+n = len(ba)
+`)
+	assert.Equal(t, int64(104), vm.GetGlobal("first").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(111), vm.GetGlobal("last").(*runtime.PyInt).Value)
+	ba := vm.GetGlobal("ba").(*runtime.PyByteArray)
+	assert.Equal(t, []byte("HllO"), ba.Value)
+	assert.Equal(t, int64(4), vm.GetGlobal("n").(*runtime.PyInt).Value)
+}
+
+func TestByteArraySlicing(t *testing.T) {
+	vm := runCode(t, `
+ba = bytearray(b"abcdef")
+mid = ba[1:3]
+rev = ba[::-1]
+ba[1:3] = b"XY"
+after_set = bytes(ba)
+del ba[::2]
+after_del = bytes(ba)
+`)
+	mid := vm.GetGlobal("mid").(*runtime.PyByteArray)
+	assert.Equal(t, []byte("bc"), mid.Value)
+	rev := vm.GetGlobal("rev").(*runtime.PyByteArray)
+	assert.Equal(t, []byte("fedcba"), rev.Value)
+	assert.Equal(t, []byte("aXYdef"), vm.GetGlobal("after_set").(*runtime.PyBytes).Value)
+	assert.Equal(t, []byte("Xdf"), vm.GetGlobal("after_del").(*runtime.PyBytes).Value)
+}
+
+func TestByteArrayExtendedSliceAssignment(t *testing.T) {
+	vm := runCode(t, `
+ba = bytearray(b"abcdef")
+ba[::2] = b"XYZ"
+`)
+	ba := vm.GetGlobal("ba").(*runtime.PyByteArray)
+	assert.Equal(t, []byte("XbYdZf"), ba.Value)
+
+	runCodeExpectError(t, `
+ba = bytearray(b"abcdef")
+ba[::2] = b"XY"
+`, "attempt to assign bytes of size 2 to extended slice of size 3")
+}
+
+func TestByteArrayValueRange(t *testing.T) {
+	runCodeExpectError(t, `
+ba = bytearray(b"a")
+ba[0] = 300
+`, "byte must be in range(0, 256)")
+}
+
+func TestByteArrayEquality(t *testing.T) {
+	vm := runCode(t, `
+a = bytearray(b"abc") == bytearray(b"abc")
+b = bytearray(b"abc") == b"abc"
+c = b"abc" == bytearray(b"abc")
+d = bytearray(b"abc") == bytearray(b"abd")
+e = bytearray(b"ab") < bytearray(b"ac")
+`)
+	assert.True(t, vm.GetGlobal("a").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("b").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("c").(*runtime.PyBool).Value)
+	assert.False(t, vm.GetGlobal("d").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("e").(*runtime.PyBool).Value)
+}
+
+func TestByteArrayIteration(t *testing.T) {
+	vm := runCode(t, `
+total = 0
+for b in bytearray(b"abc"):
+    total += b
+as_list = list(bytearray(b"ab"))
+`)
+	assert.Equal(t, int64(294), vm.GetGlobal("total").(*runtime.PyInt).Value)
+	assertIntList(t, vm, "as_list", []int64{97, 98})
+}
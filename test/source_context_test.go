@@ -0,0 +1,72 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Exception Source Context Tests
+// =============================================================================
+
+// runExpectException compiles and runs source, requiring that it fails with
+// a *runtime.PyException so the traceback can be inspected.
+func runExpectException(t *testing.T, source string) *runtime.PyException {
+	t.Helper()
+	code, errs := compiler.CompileSource(source, "script.py")
+	require.Empty(t, errs)
+	vm := runtime.NewVM()
+	_, err := vm.Execute(code)
+	require.Error(t, err)
+	exc, ok := err.(*runtime.PyException)
+	require.True(t, ok, "expected *runtime.PyException, got %T: %v", err, err)
+	return exc
+}
+
+func TestTracebackCarriesColumnSpan(t *testing.T) {
+	exc := runExpectException(t, `
+a = 10
+b = 0
+c = a / b
+`)
+	require.NotEmpty(t, exc.Traceback)
+	tb := exc.Traceback[0]
+	assert.Equal(t, "script.py", tb.Filename)
+	assert.Equal(t, 4, tb.Line)
+	// The span covers "a / b" (cols 5-9)
+	assert.Equal(t, 5, tb.Col)
+	assert.Equal(t, 10, tb.EndCol)
+}
+
+func TestTracebackColumnsInsideFunction(t *testing.T) {
+	exc := runExpectException(t, `
+def lookup(d):
+    return d["missing"]
+
+lookup({})
+`)
+	require.NotEmpty(t, exc.Traceback)
+	tb := exc.Traceback[0]
+	assert.Equal(t, 3, tb.Line)
+	assert.Equal(t, "lookup", tb.Function)
+	// The span covers the subscript d["missing"] (cols 12-23)
+	assert.Equal(t, 12, tb.Col)
+	assert.Equal(t, 24, tb.EndCol)
+}
+
+func TestUncaughtGoErrorGetsTraceback(t *testing.T) {
+	exc := runExpectException(t, `
+x = 1
+y = x + undefined_thing
+`)
+	require.NotEmpty(t, exc.Traceback)
+	tb := exc.Traceback[0]
+	assert.Equal(t, 3, tb.Line)
+	// The span covers the undefined identifier
+	assert.Equal(t, 9, tb.Col)
+	assert.Equal(t, 24, tb.EndCol)
+}
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// String Method CPython Parity Tests
+// =============================================================================
+
+// The expected values below are CPython 3.12 outputs for the same
+// expressions, so these tests pin down Unicode behavior rather than the
+// ASCII approximations the methods used to take.
+
+func TestStrMethodParityMatrix(t *testing.T) {
+	cases := []struct {
+		expr string
+		want string
+	}{
+		// title: first cased character of each run is titlecased, the rest
+		// lowercased; apostrophes start a new run
+		{`"hello world".title()`, "Hello World"},
+		{`"they're bill's friends".title()`, "They'Re Bill'S Friends"},
+		{`"HELLO".title()`, "Hello"},
+		{`"αβγ δε".title()`, "Αβγ Δε"},
+		{`"ǆungla".title()`, "ǅungla"},
+
+		// swapcase over non-ASCII letters
+		{`"Hello".swapcase()`, "hELLO"},
+		{`"Àéî".swapcase()`, "àÉÎ"},
+
+		// casefold: full case folding, including expansions
+		{`"ß".casefold()`, "ss"},
+		{`"ẞ".casefold()`, "ss"},
+		{`"ﬁle".casefold()`, "file"},
+		{`"τέλοΣ".casefold()`, "τέλοσ"},
+		{`"ὈδυσσεύΣ".casefold().replace("ς", "σ")`, "ὀδυσσεύσ"},
+
+		// translate with dicts and arbitrary subscriptable tables
+		{`"abc".translate({ord("a"): "x", ord("b"): None})`, "xc"},
+		{`"abc".translate(str.maketrans("ab", "xy"))`, "xyc"},
+		{`"read this short text".translate(None if False else {ord("t"): "T"})`, "read This shorT TexT"},
+	}
+	for _, tc := range cases {
+		vm := runCodeWithStdlib(t, fmt.Sprintf("result = %s", tc.expr))
+		assert.Equal(t, tc.want, vm.GetGlobal("result").(*runtime.PyString).Value, tc.expr)
+	}
+}
+
+func TestStrPredicateParityMatrix(t *testing.T) {
+	cases := []struct {
+		expr string
+		want bool
+	}{
+		{`"héllo".isalpha()`, true},
+		{`"Москва".isalpha()`, true},
+		{`"abc123".isalpha()`, false},
+		{`"".isalpha()`, false},
+
+		{`"١٢٣".isdigit()`, true},
+		{`"12a".isdigit()`, false},
+
+		{`"αβ12".isalnum()`, true},
+		{`"Ⅻ".isalnum()`, true},
+		{`"a b".isalnum()`, false},
+
+		{`"  ".isspace()`, true},
+		{`"\x1c\x1d".isspace()`, true},
+		{`"x ".isspace()`, false},
+
+		{`"ΣΟΦΙΑ".isupper()`, true},
+		{`"Σοφία".isupper()`, false},
+		{`"σοφία".islower()`, true},
+		{`"ǅungla".islower()`, false},
+	}
+	for _, tc := range cases {
+		vm := runCodeWithStdlib(t, fmt.Sprintf("result = %s", tc.expr))
+		assert.Equal(t, tc.want, vm.GetGlobal("result").(*runtime.PyBool).Value, tc.expr)
+	}
+}
+
+func TestStrTranslateWithGetitemObject(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+class Rot13:
+    def __getitem__(self, cp):
+        c = chr(cp)
+        if "a" <= c <= "z":
+            return chr((cp - ord("a") + 13) % 26 + ord("a"))
+        raise LookupError(cp)
+
+result = "hello, world!".translate(Rot13())
+`)
+	assert.Equal(t, "uryyb, jbeyq!", vm.GetGlobal("result").(*runtime.PyString).Value)
+}
+
+func TestStrTranslateRejectsBadMappingValue(t *testing.T) {
+	runCodeExpectError(t, `"abc".translate({ord("a"): 1.5})`,
+		"character mapping must return integer, None or str")
+}
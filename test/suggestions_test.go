@@ -0,0 +1,86 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// Did-You-Mean Suggestion Tests
+// =============================================================================
+
+func TestNameErrorSuggestsBuiltin(t *testing.T) {
+	runCodeExpectError(t, `pritn("hi")`,
+		"name 'pritn' is not defined. Did you mean 'print'?")
+}
+
+func TestNameErrorSuggestsGlobal(t *testing.T) {
+	runCodeExpectError(t, `
+max_retries = 3
+x = max_retres + 1
+`, "name 'max_retres' is not defined. Did you mean 'max_retries'?")
+}
+
+func TestNameErrorWithoutCloseMatch(t *testing.T) {
+	vm := runCode(t, `
+try:
+    totally_unrelated
+except NameError as e:
+    msg = str(e)
+`)
+	assert.Equal(t, "name 'totally_unrelated' is not defined",
+		vm.GetGlobal("msg").(*runtime.PyString).Value)
+}
+
+func TestAttributeErrorSuggestsInstanceAttribute(t *testing.T) {
+	vm := runCode(t, `
+class Config:
+    def __init__(self):
+        self.timeout = 5
+    def validate(self):
+        return True
+
+c = Config()
+try:
+    c.timout
+except AttributeError as e:
+    attr_msg = str(e)
+try:
+    c.validat()
+except AttributeError as e:
+    method_msg = str(e)
+try:
+    c.zzz
+except AttributeError as e:
+    none_msg = str(e)
+`)
+	assert.Equal(t, "'Config' object has no attribute 'timout'. Did you mean 'timeout'?",
+		vm.GetGlobal("attr_msg").(*runtime.PyString).Value)
+	assert.Equal(t, "'Config' object has no attribute 'validat'. Did you mean 'validate'?",
+		vm.GetGlobal("method_msg").(*runtime.PyString).Value)
+	assert.Equal(t, "'Config' object has no attribute 'zzz'",
+		vm.GetGlobal("none_msg").(*runtime.PyString).Value)
+}
+
+func TestAttributeErrorSuggestsModuleAttribute(t *testing.T) {
+	runCodeExpectError(t, `
+import math
+math.sqr(2)
+`, "module 'math' has no attribute 'sqr'. Did you mean 'sqrt'?")
+}
+
+func TestCustomGetattrErrorPassesThrough(t *testing.T) {
+	vm := runCode(t, `
+class A:
+    def __getattr__(self, name):
+        raise AttributeError("custom: " + name)
+
+try:
+    A().foo
+except AttributeError as e:
+    msg = str(e)
+`)
+	assert.Equal(t, "custom: foo", vm.GetGlobal("msg").(*runtime.PyString).Value)
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// Template Module Tests
+// =============================================================================
+
+func TestTemplateVariablesAndFilters(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import template
+greeting = template.render("Hello {{ name | upper }}, you have {{ count }} messages.", name="ada", count=3)
+titled = template.render("{{ phrase | title }}", phrase="the iron keep")
+joined = template.render("{{ tags | join(\" / \") }}", tags=["a", "b", "c"])
+fallback = template.render("{{ missing | default(\"n/a\") }}")
+total = template.render("{{ items | length }}", items=[1, 2, 3, 4])
+`)
+	assert.Equal(t, "Hello ADA, you have 3 messages.", vm.GetGlobal("greeting").(*runtime.PyString).Value)
+	assert.Equal(t, "The Iron Keep", vm.GetGlobal("titled").(*runtime.PyString).Value)
+	assert.Equal(t, "a / b / c", vm.GetGlobal("joined").(*runtime.PyString).Value)
+	assert.Equal(t, "n/a", vm.GetGlobal("fallback").(*runtime.PyString).Value)
+	assert.Equal(t, "4", vm.GetGlobal("total").(*runtime.PyString).Value)
+}
+
+func TestTemplateConditionals(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import template
+tpl = "{% if hp <= 0 %}dead{% elif hp < 30 %}wounded{% else %}healthy{% endif %}"
+a = template.render(tpl, hp=0)
+b = template.render(tpl, hp=10)
+c = template.render(tpl, hp=90)
+negated = template.render("{% if not hidden %}visible{% endif %}", hidden=False)
+`)
+	assert.Equal(t, "dead", vm.GetGlobal("a").(*runtime.PyString).Value)
+	assert.Equal(t, "wounded", vm.GetGlobal("b").(*runtime.PyString).Value)
+	assert.Equal(t, "healthy", vm.GetGlobal("c").(*runtime.PyString).Value)
+	assert.Equal(t, "visible", vm.GetGlobal("negated").(*runtime.PyString).Value)
+}
+
+func TestTemplateLoops(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import template
+tpl = "{% for item in items %}{{ loop.index }}. {{ item }}{% if not loop.last %}, {% endif %}{% endfor %}"
+listing = template.render(tpl, items=["sword", "shield"])
+nested = template.render(
+    "{% for row in grid %}{% for cell in row %}{{ cell }}{% endfor %};{% endfor %}",
+    grid=[[1, 2], [3, 4]],
+)
+`)
+	assert.Equal(t, "1. sword, 2. shield", vm.GetGlobal("listing").(*runtime.PyString).Value)
+	assert.Equal(t, "12;34;", vm.GetGlobal("nested").(*runtime.PyString).Value)
+}
+
+func TestTemplateAttributeAndIndexAccess(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import template
+npc = {"name": "Borin", "stats": {"str": 17}}
+line = template.render("{{ npc.name }} has strength {{ npc.stats.str }}.", npc=npc)
+first = template.render("{{ party[0] }} leads", party=["Borin", "Mira"])
+comment = template.render("a{# hidden #}b")
+`)
+	assert.Equal(t, "Borin has strength 17.", vm.GetGlobal("line").(*runtime.PyString).Value)
+	assert.Equal(t, "Borin leads", vm.GetGlobal("first").(*runtime.PyString).Value)
+	assert.Equal(t, "ab", vm.GetGlobal("comment").(*runtime.PyString).Value)
+}
+
+func TestTemplatePositionalVarsDict(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import template
+out = template.render("{{ who }}", {"who": "world"})
+`)
+	assert.Equal(t, "world", vm.GetGlobal("out").(*runtime.PyString).Value)
+}
+
+func TestTemplateErrors(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import template
+template.render("{{ nope }}")
+`, "'nope' is undefined")
+
+	runCodeExpectErrorWithStdlib(t, `
+import template
+template.render("{% for x in xs %}no end")
+`, "endfor")
+
+	runCodeExpectErrorWithStdlib(t, `
+import template
+template.render("{{ x | frobnicate }}", x=1)
+`, "unknown filter")
+}
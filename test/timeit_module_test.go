@@ -0,0 +1,76 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// Timeit Module Tests
+// =============================================================================
+
+func TestTimeitTimesCallable(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import timeit
+
+calls = 0
+def work():
+    global calls
+    calls = calls + 1
+
+elapsed = timeit.timeit(work, number=50)
+positive = elapsed >= 0.0
+`)
+	assert.Equal(t, int64(50), vm.GetGlobal("calls").(*runtime.PyInt).Value)
+	assert.True(t, vm.GetGlobal("positive").(*runtime.PyBool).Value)
+}
+
+func TestTimeitRepeat(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import timeit
+
+def work():
+    return sum([1, 2, 3])
+
+runs = timeit.repeat(work, repeat=3, number=10)
+count = len(runs)
+all_nonneg = all(r >= 0.0 for r in runs)
+`)
+	assert.Equal(t, int64(3), vm.GetGlobal("count").(*runtime.PyInt).Value)
+	assert.True(t, vm.GetGlobal("all_nonneg").(*runtime.PyBool).Value)
+}
+
+func TestTimeitDefaultTimerMonotonic(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import timeit
+a = timeit.default_timer()
+b = timeit.default_timer()
+ordered = b >= a
+`)
+	assert.True(t, vm.GetGlobal("ordered").(*runtime.PyBool).Value)
+}
+
+func TestTimeitErrors(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import timeit
+timeit.timeit()
+`, "requires a callable")
+
+	runCodeExpectErrorWithStdlib(t, `
+import timeit
+timeit.timeit(lambda: 1, number="many")
+`, "must be an int")
+}
+
+func TestTimeitPropagatesCallableError(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import timeit
+
+def boom():
+    raise ValueError("inner failure")
+
+timeit.timeit(boom, number=2)
+`, "inner failure")
+}
@@ -0,0 +1,161 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Userdata Comparison and Hashing Metamethod Tests
+// =============================================================================
+
+type entityHandle struct {
+	id int64
+}
+
+// handleOf extracts the entityHandle from a stack slot, or nil if the value
+// there is not a Handle userdata.
+func handleOf(vm *runtime.VM, idx int) *entityHandle {
+	ud, ok := vm.Get(idx).(*runtime.PyUserData)
+	if !ok {
+		return nil
+	}
+	h, ok := ud.Value.(*entityHandle)
+	if !ok {
+		return nil
+	}
+	return h
+}
+
+// registerHandle registers an entity-handle userdata type with comparison and
+// hashing metamethods so handles can be dict keys and sorted.
+func registerHandle(vm *runtime.VM) {
+	vm.RegisterType("Handle",
+		// Constructor: Handle(id)
+		func(vm *runtime.VM) int {
+			id := vm.CheckInt(1)
+			vm.Push(vm.NewUserDataWithMeta(&entityHandle{id: id}, "Handle"))
+			return 1
+		},
+		map[string]runtime.GoFunction{
+			"id": func(vm *runtime.VM) int {
+				h := handleOf(vm, 1)
+				if h == nil {
+					vm.Push(runtime.None)
+					return 1
+				}
+				vm.Push(runtime.NewInt(h.id))
+				return 1
+			},
+			"__eq__": func(vm *runtime.VM) int {
+				a := handleOf(vm, 1)
+				b := handleOf(vm, 2)
+				if a == nil || b == nil {
+					vm.Push(runtime.NotImplemented)
+					return 1
+				}
+				vm.Push(runtime.NewBool(a.id == b.id))
+				return 1
+			},
+			"__lt__": func(vm *runtime.VM) int {
+				a := handleOf(vm, 1)
+				b := handleOf(vm, 2)
+				if a == nil || b == nil {
+					vm.Push(runtime.NotImplemented)
+					return 1
+				}
+				vm.Push(runtime.NewBool(a.id < b.id))
+				return 1
+			},
+			"__hash__": func(vm *runtime.VM) int {
+				h := handleOf(vm, 1)
+				if h == nil {
+					vm.Push(runtime.None)
+					return 1
+				}
+				vm.Push(runtime.NewInt(h.id))
+				return 1
+			},
+		},
+	)
+}
+
+func TestUserDataComparisonMetamethods(t *testing.T) {
+	vm := runtime.NewVM()
+	registerHandle(vm)
+
+	source := `
+a = Handle(7)
+b = Handle(7)
+c = Handle(3)
+eq = a == b
+ne = a != c
+lt = c < a
+gt = a > c
+ordered = [h.id() for h in sorted([a, c])]
+`
+	code, errs := compiler.CompileSource(source, "<test>")
+	require.Empty(t, errs)
+	_, err := vm.Execute(code)
+	require.NoError(t, err)
+
+	assert.True(t, vm.GetGlobal("eq").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("ne").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("lt").(*runtime.PyBool).Value)
+	assert.True(t, vm.GetGlobal("gt").(*runtime.PyBool).Value)
+	ordered := vm.GetGlobal("ordered").(*runtime.PyList)
+	require.Len(t, ordered.Items, 2)
+	assert.Equal(t, int64(3), ordered.Items[0].(*runtime.PyInt).Value)
+	assert.Equal(t, int64(7), ordered.Items[1].(*runtime.PyInt).Value)
+}
+
+func TestUserDataAsDictKey(t *testing.T) {
+	vm := runtime.NewVM()
+	registerHandle(vm)
+
+	source := `
+a = Handle(7)
+b = Handle(7)
+d = {}
+d[a] = "alpha"
+d[b] = "beta"
+n = len(d)
+val = d[a]
+`
+	code, errs := compiler.CompileSource(source, "<test>")
+	require.Empty(t, errs)
+	_, err := vm.Execute(code)
+	require.NoError(t, err)
+
+	// Equal handles hash alike, so b overwrote a's entry
+	assert.Equal(t, int64(1), vm.GetGlobal("n").(*runtime.PyInt).Value)
+	assert.Equal(t, "beta", vm.GetGlobal("val").(*runtime.PyString).Value)
+}
+
+func TestUserDataWithoutCompareMetamethods(t *testing.T) {
+	vm := runtime.NewVM()
+	a := runtime.NewUserData(1)
+	b := runtime.NewUserData(2)
+	vm.SetGlobal("a", a)
+	vm.SetGlobal("b", b)
+
+	// Without metamethods, equality falls back to identity
+	code, errs := compiler.CompileSource(`same = a == a
+diff = a == b`, "<test>")
+	require.Empty(t, errs)
+	_, err := vm.Execute(code)
+	require.NoError(t, err)
+	assert.True(t, vm.GetGlobal("same").(*runtime.PyBool).Value)
+	assert.False(t, vm.GetGlobal("diff").(*runtime.PyBool).Value)
+
+	// Ordering is a TypeError, as with other unorderable types
+	code, errs = compiler.CompileSource(`a < b`, "<test>")
+	require.Empty(t, errs)
+	_, err = vm.Execute(code)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported between instances")
+}
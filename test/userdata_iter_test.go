@@ -0,0 +1,121 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/compiler"
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Userdata Metamethod Tests (len/iteration in builtins)
+// =============================================================================
+
+// registerIntBag registers a userdata type holding int64s with __len__ and
+// __iter__ metamethods so it can participate in iterating builtins.
+func registerIntBag(vm *runtime.VM) {
+	vm.RegisterType("IntBag",
+		// Constructor: IntBag(*values)
+		func(vm *runtime.VM) int {
+			values := make([]int64, 0, vm.GetTop())
+			for i := 1; i <= vm.GetTop(); i++ {
+				values = append(values, vm.CheckInt(i))
+			}
+			ud := vm.NewUserDataWithMeta(&values, "IntBag")
+			vm.Push(ud)
+			return 1
+		},
+		map[string]runtime.GoFunction{
+			"__len__": func(vm *runtime.VM) int {
+				ud := vm.CheckUserData(1, "IntBag")
+				if ud == nil {
+					vm.Push(runtime.None)
+					return 1
+				}
+				values := ud.Value.(*[]int64)
+				vm.Push(runtime.NewInt(int64(len(*values))))
+				return 1
+			},
+			"__iter__": func(vm *runtime.VM) int {
+				ud := vm.CheckUserData(1, "IntBag")
+				if ud == nil {
+					vm.Push(runtime.None)
+					return 1
+				}
+				values := ud.Value.(*[]int64)
+				idx := 0
+				vm.Push(&runtime.PyGoIterator{
+					Name: "IntBag_iterator",
+					Next: func() (runtime.Value, bool, error) {
+						if idx >= len(*values) {
+							return nil, true, nil
+						}
+						v := (*values)[idx]
+						idx++
+						return runtime.NewInt(v), false, nil
+					},
+				})
+				return 1
+			},
+		},
+	)
+}
+
+func TestUserDataLen(t *testing.T) {
+	vm := runtime.NewVM()
+	registerIntBag(vm)
+
+	code, errs := compiler.CompileSource(`n = len(IntBag(3, 1, 2))`, "<test>")
+	require.Empty(t, errs)
+	_, err := vm.Execute(code)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), vm.GetGlobal("n").(*runtime.PyInt).Value)
+}
+
+func TestUserDataIteratingBuiltins(t *testing.T) {
+	vm := runtime.NewVM()
+	registerIntBag(vm)
+
+	source := `
+b = IntBag(3, 1, 2)
+total = sum(b)
+ordered = str(sorted(b))
+low = min(b)
+high = max(b)
+as_list = len(list(b))
+looped = 0
+for x in b:
+    looped += x
+`
+	code, errs := compiler.CompileSource(source, "<test>")
+	require.Empty(t, errs)
+	_, err := vm.Execute(code)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(6), vm.GetGlobal("total").(*runtime.PyInt).Value)
+	assert.Equal(t, "[1, 2, 3]", vm.GetGlobal("ordered").(*runtime.PyString).Value)
+	assert.Equal(t, int64(1), vm.GetGlobal("low").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(3), vm.GetGlobal("high").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(3), vm.GetGlobal("as_list").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(6), vm.GetGlobal("looped").(*runtime.PyInt).Value)
+}
+
+func TestUserDataWithoutMetamethods(t *testing.T) {
+	vm := runtime.NewVM()
+	vm.SetGlobal("opaque", runtime.NewUserData(struct{}{}))
+
+	code, errs := compiler.CompileSource(`len(opaque)`, "<test>")
+	require.Empty(t, errs)
+	_, err := vm.Execute(code)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no len()")
+
+	code, errs = compiler.CompileSource(`sum(opaque)`, "<test>")
+	require.Empty(t, errs)
+	_, err = vm.Execute(code)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not iterable")
+}
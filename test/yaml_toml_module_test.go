@@ -0,0 +1,191 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ATSOTECK/rage/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// YAML Module Tests
+// =============================================================================
+
+func TestYAMLSafeLoad(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import yaml
+doc = yaml.safe_load("""
+name: rage
+port: 8080
+ratio: 0.5
+debug: true
+tags:
+  - fast
+  - embeddable
+server:
+  host: localhost
+  retries: 3
+empty:
+""")
+name = doc["name"]
+port = doc["port"]
+ratio = doc["ratio"]
+debug = doc["debug"]
+tag_count = len(doc["tags"])
+first_tag = doc["tags"][0]
+host = doc["server"]["host"]
+empty_is_none = doc["empty"] is None
+`)
+	assert.Equal(t, "rage", vm.GetGlobal("name").(*runtime.PyString).Value)
+	assert.Equal(t, int64(8080), vm.GetGlobal("port").(*runtime.PyInt).Value)
+	assert.Equal(t, 0.5, vm.GetGlobal("ratio").(*runtime.PyFloat).Value)
+	assert.True(t, vm.GetGlobal("debug").(*runtime.PyBool).Value)
+	assert.Equal(t, int64(2), vm.GetGlobal("tag_count").(*runtime.PyInt).Value)
+	assert.Equal(t, "fast", vm.GetGlobal("first_tag").(*runtime.PyString).Value)
+	assert.Equal(t, "localhost", vm.GetGlobal("host").(*runtime.PyString).Value)
+	assert.True(t, vm.GetGlobal("empty_is_none").(*runtime.PyBool).Value)
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import yaml
+original = {"name": "svc", "replicas": 3, "labels": ["a", "b"]}
+text = yaml.safe_dump(original)
+parsed = yaml.safe_load(text)
+name = parsed["name"]
+replicas = parsed["replicas"]
+labels = repr(parsed["labels"])
+`)
+	assert.Equal(t, "svc", vm.GetGlobal("name").(*runtime.PyString).Value)
+	assert.Equal(t, int64(3), vm.GetGlobal("replicas").(*runtime.PyInt).Value)
+	assert.Equal(t, "['a', 'b']", vm.GetGlobal("labels").(*runtime.PyString).Value)
+}
+
+func TestYAMLSafeLoadError(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import yaml
+yaml.safe_load("key: [unclosed")
+`, "yaml.YAMLError")
+}
+
+func TestYAMLSafeDumpRejectsUnserializable(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import yaml
+yaml.safe_dump({"fn": len})
+`, "not serializable")
+}
+
+// =============================================================================
+// TOML Module Tests
+// =============================================================================
+
+func TestTOMLLoads(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import toml
+doc = toml.loads("""
+# top-level settings
+title = "example"
+port = 8_080
+pi = 3.14
+enabled = true
+released = 2024-06-01
+
+[server]
+host = "localhost"
+timeouts = [1, 2, 3]
+
+[server.limits]
+max_conns = 100
+
+[[workers]]
+name = "alpha"
+
+[[workers]]
+name = "beta"
+""")
+title = doc["title"]
+port = doc["port"]
+pi = doc["pi"]
+enabled = doc["enabled"]
+released = doc["released"]
+host = doc["server"]["host"]
+timeouts = repr(doc["server"]["timeouts"])
+max_conns = doc["server"]["limits"]["max_conns"]
+worker_count = len(doc["workers"])
+second_worker = doc["workers"][1]["name"]
+`)
+	assert.Equal(t, "example", vm.GetGlobal("title").(*runtime.PyString).Value)
+	assert.Equal(t, int64(8080), vm.GetGlobal("port").(*runtime.PyInt).Value)
+	assert.Equal(t, 3.14, vm.GetGlobal("pi").(*runtime.PyFloat).Value)
+	assert.True(t, vm.GetGlobal("enabled").(*runtime.PyBool).Value)
+	assert.Equal(t, "2024-06-01", vm.GetGlobal("released").(*runtime.PyString).Value)
+	assert.Equal(t, "localhost", vm.GetGlobal("host").(*runtime.PyString).Value)
+	assert.Equal(t, "[1, 2, 3]", vm.GetGlobal("timeouts").(*runtime.PyString).Value)
+	assert.Equal(t, int64(100), vm.GetGlobal("max_conns").(*runtime.PyInt).Value)
+	assert.Equal(t, int64(2), vm.GetGlobal("worker_count").(*runtime.PyInt).Value)
+	assert.Equal(t, "beta", vm.GetGlobal("second_worker").(*runtime.PyString).Value)
+}
+
+func TestTOMLLoadsStringsAndInlineTables(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import toml
+doc = toml.loads("""
+escaped = "line1\\nline2"
+literal = 'C:\\path\\to\\file'
+point = {x = 1, y = 2}
+"quoted key" = "works"
+a.b.c = "nested"
+""")
+escaped = doc["escaped"]
+literal = doc["literal"]
+x = doc["point"]["x"]
+quoted = doc["quoted key"]
+nested = doc["a"]["b"]["c"]
+`)
+	assert.Equal(t, "line1\nline2", vm.GetGlobal("escaped").(*runtime.PyString).Value)
+	assert.Equal(t, `C:\path\to\file`, vm.GetGlobal("literal").(*runtime.PyString).Value)
+	assert.Equal(t, int64(1), vm.GetGlobal("x").(*runtime.PyInt).Value)
+	assert.Equal(t, "works", vm.GetGlobal("quoted").(*runtime.PyString).Value)
+	assert.Equal(t, "nested", vm.GetGlobal("nested").(*runtime.PyString).Value)
+}
+
+func TestTOMLRoundTrip(t *testing.T) {
+	vm := runCodeWithStdlib(t, `
+import toml
+original = {
+    "title": "app",
+    "ratio": 2.5,
+    "server": {"host": "h", "ports": [80, 443]},
+    "workers": [{"name": "a"}, {"name": "b"}],
+}
+text = toml.dumps(original)
+parsed = toml.loads(text)
+title = parsed["title"]
+ratio = parsed["ratio"]
+ports = repr(parsed["server"]["ports"])
+workers = len(parsed["workers"])
+`)
+	assert.Equal(t, "app", vm.GetGlobal("title").(*runtime.PyString).Value)
+	assert.Equal(t, 2.5, vm.GetGlobal("ratio").(*runtime.PyFloat).Value)
+	assert.Equal(t, "[80, 443]", vm.GetGlobal("ports").(*runtime.PyString).Value)
+	assert.Equal(t, int64(2), vm.GetGlobal("workers").(*runtime.PyInt).Value)
+}
+
+func TestTOMLLoadsErrors(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import toml
+toml.loads("key = ")
+`, "toml.TOMLDecodeError")
+
+	runCodeExpectErrorWithStdlib(t, `
+import toml
+toml.loads("a = 1\na = 2")
+`, "duplicate key")
+}
+
+func TestTOMLDumpsRequiresDict(t *testing.T) {
+	runCodeExpectErrorWithStdlib(t, `
+import toml
+toml.dumps([1, 2])
+`, "must be a dict")
+}